@@ -6,8 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -156,6 +154,9 @@ type Block struct {
 	TxnsMap   map[string]bool `json:"-" msgpack:"-"`
 	mutexTxns sync.RWMutex    `json:"-" msgpack:"-"`
 
+	txnResults      map[string]*TxnResult `json:"-" msgpack:"-"`
+	txnResultsMutex sync.RWMutex          `json:"-" msgpack:"-"`
+
 	ClientState           util.MerklePatriciaTrieI `json:"-" msgpack:"-"`
 	stateStatus           int8
 	stateStatusMutex      sync.RWMutex `json:"-" msgpack:"-"`
@@ -384,8 +385,17 @@ func (b *Block) SetStateChangesCount(s util.MerklePatriciaTrieI) {
 }
 
 /*AddTransaction - add a transaction to the block */
-func (b *Block) AddTransaction(t *transaction.Transaction) {
+func (b *Block) AddTransaction(t *transaction.Transaction) error {
 	t.OutputHash = t.ComputeOutputHash()
+	t.ResultsHash = ""
+	if res, ok := b.GetTxnResult(t.Hash); ok && res.Hashed {
+		hash, err := res.Hash()
+		if err != nil {
+			return err
+		}
+		t.ResultsHash = hash
+	}
+	return nil
 }
 
 /*AddVerificationTicket - Add a verification ticket to a block if it's not already present */
@@ -452,38 +462,7 @@ func (b *Block) GetMerkleTree() *util.MerkleTree {
 }
 
 func (b *Block) getHashData() string {
-	mt := b.GetMerkleTree()
-	merkleRoot := mt.GetRoot()
-	rmt := b.GetReceiptsMerkleTree()
-	rMerkleRoot := rmt.GetRoot()
-
-	hashBuilder := strings.Builder{}
-	hashBuilder.WriteString(b.MinerID)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(b.PrevHash)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(common.TimeToString(b.CreationDate))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.FormatInt(b.Round, 10))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.FormatInt(b.GetRoundRandomSeed(), 10))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(strconv.Itoa(b.StateChangesCount))
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(merkleRoot)
-	hashBuilder.WriteString(":")
-	hashBuilder.WriteString(rMerkleRoot)
-
-	if b.MagicBlock != nil {
-		if b.MagicBlock.Hash == "" {
-			b.MagicBlock.Hash = b.MagicBlock.GetHash()
-		}
-
-		hashBuilder.WriteString(":")
-		hashBuilder.WriteString(b.MagicBlock.Hash)
-	}
-
-	return hashBuilder.String()
+	return b.header().getHashData()
 }
 
 /*ComputeHash - compute the hash of the block */
@@ -797,6 +776,17 @@ type Chainer interface {
 		waitC ...chan struct{}) ([]event.Event, error)
 	GetEventDb() *event.EventDb
 	GetStateCache() *statecache.StateCache
+	NewTxnExecutor(b *Block, bState util.MerklePatriciaTrieI,
+		blockStateCache *statecache.BlockCache) TxnExecutor
+}
+
+// TxnExecutor applies the transactions of a block to the block state in the
+// block order.
+type TxnExecutor interface {
+	// Speculate executes the transactions ahead of applying them, concurrently
+	Speculate(ctx context.Context, txns []*transaction.Transaction)
+	UpdateState(ctx context.Context, txn *transaction.Transaction,
+		waitC ...chan struct{}) ([]event.Event, error)
 }
 
 // CreateStateWithPreviousBlock creates block client state with previous block
@@ -913,16 +903,20 @@ func (b *Block) ComputeState(ctx context.Context, c Chainer, waitC ...chan struc
 		PrevHash: b.PrevHash,
 	})
 
-	beginStateRoot := bState.GetRoot()
-	b.Events = []event.Event{}
-	ts := time.Now()
 	for _, txn := range b.Txns {
 		if datastore.IsEmpty(txn.ClientID) {
 			if err := txn.ComputeClientID(); err != nil {
 				return err
 			}
 		}
+	}
 
+	beginStateRoot := bState.GetRoot()
+	b.Events = []event.Event{}
+	ts := time.Now()
+	executor := c.NewTxnExecutor(b, bState, blockStateCache)
+	executor.Speculate(ctx, b.Txns)
+	for _, txn := range b.Txns {
 		b.Events = append(b.Events, event.Event{
 			BlockNumber: b.Round,
 			TxHash:      txn.Hash,
@@ -942,7 +936,7 @@ func (b *Block) ComputeState(ctx context.Context, c Chainer, waitC ...chan struc
 			},
 		})
 
-		events, err := c.UpdateState(ctx, b, bState, txn, blockStateCache, waitC...)
+		events, err := executor.UpdateState(ctx, txn, waitC...)
 		switch err {
 		case context.Canceled:
 			b.SetStateStatus(StateCancelled)
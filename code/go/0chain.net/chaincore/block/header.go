@@ -0,0 +1,97 @@
+package block
+
+import (
+	"strconv"
+	"strings"
+
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+// Header is the part of a block needed to verify its hash and notarization
+// without the transactions. The client state hash is not part of the block hash.
+//
+// swagger:model BlockHeader
+type Header struct {
+	Hash                           string                `json:"hash"`
+	MinerID                        datastore.Key         `json:"miner_id"`
+	PrevHash                       string                `json:"prev_hash"`
+	CreationDate                   common.Timestamp      `json:"creation_date"`
+	Round                          int64                 `json:"round"`
+	RoundRandomSeed                int64                 `json:"round_random_seed"`
+	StateChangesCount              int                   `json:"state_changes_count"`
+	MerkleTreeRoot                 string                `json:"merkle_tree_root"`
+	ReceiptMerkleTreeRoot          string                `json:"receipt_merkle_tree_root"`
+	MagicBlockHash                 string                `json:"magic_block_hash,omitempty"`
+	LatestFinalizedMagicBlockHash  string                `json:"latest_finalized_magic_block_hash"`
+	LatestFinalizedMagicBlockRound int64                 `json:"latest_finalized_magic_block_round"`
+	ClientStateHash                util.Key              `json:"state_hash"`
+	VerificationTickets            []*VerificationTicket `json:"verification_tickets"`
+	// PrevVerificationTickets notarize the previous block
+	PrevVerificationTickets []*VerificationTicket `json:"prev_verification_tickets,omitempty"`
+}
+
+// GetHeader returns the header of the block.
+func (b *Block) GetHeader() *Header {
+	h := b.header()
+	h.VerificationTickets = b.GetVerificationTickets()
+	h.PrevVerificationTickets = b.PrevBlockVerificationTickets
+	return h
+}
+
+func (b *Block) header() *Header {
+	h := &Header{
+		Hash:                           b.Hash,
+		MinerID:                        b.MinerID,
+		PrevHash:                       b.PrevHash,
+		CreationDate:                   b.CreationDate,
+		Round:                          b.Round,
+		RoundRandomSeed:                b.GetRoundRandomSeed(),
+		StateChangesCount:              b.StateChangesCount,
+		MerkleTreeRoot:                 b.GetMerkleTree().GetRoot(),
+		ReceiptMerkleTreeRoot:          b.GetReceiptsMerkleTree().GetRoot(),
+		LatestFinalizedMagicBlockHash:  b.LatestFinalizedMagicBlockHash,
+		LatestFinalizedMagicBlockRound: b.LatestFinalizedMagicBlockRound,
+		ClientStateHash:                b.ClientStateHash,
+	}
+	if b.MagicBlock != nil {
+		if b.MagicBlock.Hash == "" {
+			b.MagicBlock.Hash = b.MagicBlock.GetHash()
+		}
+		h.MagicBlockHash = b.MagicBlock.Hash
+	}
+	return h
+}
+
+// ComputeHash computes the hash of the block from the header.
+func (h *Header) ComputeHash() string {
+	return encryption.Hash(h.getHashData())
+}
+
+func (h *Header) getHashData() string {
+	hashBuilder := strings.Builder{}
+	hashBuilder.WriteString(h.MinerID)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.PrevHash)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(common.TimeToString(h.CreationDate))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.FormatInt(h.Round, 10))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.FormatInt(h.RoundRandomSeed, 10))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(strconv.Itoa(h.StateChangesCount))
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.MerkleTreeRoot)
+	hashBuilder.WriteString(":")
+	hashBuilder.WriteString(h.ReceiptMerkleTreeRoot)
+
+	if h.MagicBlockHash != "" {
+		hashBuilder.WriteString(":")
+		hashBuilder.WriteString(h.MagicBlockHash)
+	}
+
+	return hashBuilder.String()
+}
@@ -0,0 +1,287 @@
+package block
+
+// Transaction receipts entity db stores the receipts of the finalized
+// transactions in the rocksdb, keyed by the transaction hash.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+const (
+	TxnReceiptMetaName = "txn_receipt"
+	TxnReceiptDBName   = "txnreceiptdb"
+)
+
+var txnReceiptEntityMetadata *datastore.EntityMetadataImpl
+
+// TxnResult is what the execution of a transaction did to the state, it's kept
+// with the block the transaction has been executed in until the receipts are
+// stored.
+type TxnResult struct {
+	// Events emitted by the transaction
+	Events []event.Event
+	// Transfers between clients, including the fee, the signed transfers and
+	// the transfers of the mints
+	Transfers []*state.Transfer
+	// Mints of the approved minters
+	Mints []*state.Mint
+	// Fee charged
+	Fee currency.Coin
+	// Hashed when the results hash is part of the receipt, from the round of
+	// the hermes hardfork
+	Hashed bool
+}
+
+// resultEvent is the part of an event the results hash covers, the sequence
+// numbers and the database fields are set once the block is finalized
+type resultEvent struct {
+	Type  event.EventType `json:"type"`
+	Tag   event.EventTag  `json:"tag"`
+	Index string          `json:"index"`
+	Data  interface{}     `json:"data"`
+}
+
+// Hash returns the hash of the result, the results hash of the transaction.
+// The result is hashed in its canonical JSON form, so the hash of a result
+// decoded from a receipt is the same.
+func (r *TxnResult) Hash() (string, error) {
+	events := make([]resultEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		events = append(events, resultEvent{Type: e.Type, Tag: e.Tag, Index: e.Index, Data: e.Data})
+	}
+	buf, err := json.Marshal(struct {
+		Events    []resultEvent     `json:"events"`
+		Transfers []*state.Transfer `json:"transfers"`
+		Mints     []*state.Mint     `json:"mints"`
+		Fee       currency.Coin     `json:"fee"`
+	}{events, r.Transfers, r.Mints, r.Fee})
+	if err != nil {
+		return "", err
+	}
+
+	// decode and encode again to sort the keys of the event data
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+	if buf, err = json.Marshal(v); err != nil {
+		return "", err
+	}
+	return encryption.Hash(buf), nil
+}
+
+// SetTxnResult sets the result of the execution of the transaction.
+func (b *Block) SetTxnResult(hash string, res *TxnResult) {
+	b.txnResultsMutex.Lock()
+	defer b.txnResultsMutex.Unlock()
+	if b.txnResults == nil {
+		b.txnResults = make(map[string]*TxnResult, len(b.Txns))
+	}
+	b.txnResults[hash] = res
+}
+
+// GetTxnResult returns the result of the execution of the transaction, if the
+// block state has been computed locally.
+func (b *Block) GetTxnResult(hash string) (*TxnResult, bool) {
+	b.txnResultsMutex.RLock()
+	defer b.txnResultsMutex.RUnlock()
+	res, ok := b.txnResults[hash]
+	return res, ok
+}
+
+// ClearTxnResults drops the results of the transactions, once the results
+// hashes are set or verified and the receipts are stored.
+func (b *Block) ClearTxnResults() {
+	b.txnResultsMutex.Lock()
+	defer b.txnResultsMutex.Unlock()
+	b.txnResults = nil
+}
+
+// VerifyTxnResults verifies the results hashes of the transactions against
+// the results of their local execution. The transactions executed before the
+// hermes hardfork have no results hash.
+func (b *Block) VerifyTxnResults() error {
+	for _, txn := range b.Txns {
+		res, ok := b.GetTxnResult(txn.Hash)
+		if !ok {
+			return fmt.Errorf("no result of the transaction %s", txn.Hash)
+		}
+		if !res.Hashed {
+			if txn.ResultsHash != "" {
+				return fmt.Errorf("unexpected results hash of the transaction %s", txn.Hash)
+			}
+			continue
+		}
+		hash, err := res.Hash()
+		if err != nil {
+			return err
+		}
+		if hash != txn.ResultsHash {
+			return fmt.Errorf("results hash mismatch of the transaction %s: %s, computed %s",
+				txn.Hash, txn.ResultsHash, hash)
+		}
+	}
+	return nil
+}
+
+// Receipt is the receipt of a finalized transaction. The receipt merkle path
+// proves the output hash and the results hash against the receipt merkle tree
+// root of the block, the results hash being the hash of the events, the
+// transfers, the mints and the fee of the receipt. The receipts of the
+// transactions executed before the hermes hardfork have no results hash, the
+// path proves the output hash only.
+//
+// swagger:model Receipt
+type Receipt struct {
+	datastore.NOIDField
+	Hash                  string            `json:"hash"`
+	BlockHash             string            `json:"block_hash"`
+	Round                 int64             `json:"round"`
+	Status                int               `json:"status"`
+	Output                string            `json:"output"`
+	OutputHash            string            `json:"output_hash"`
+	ResultsHash           string            `json:"results_hash"`
+	Fee                   currency.Coin     `json:"fee"`
+	Events                []event.Event     `json:"events"`
+	Transfers             []*state.Transfer `json:"transfers"`
+	Mints                 []*state.Mint     `json:"mints"`
+	ReceiptMerkleTreeRoot string            `json:"receipt_merkle_tree_root"`
+	ReceiptMerkleTreePath *util.MTPath      `json:"receipt_merkle_tree_path"`
+}
+
+// GetReceipts returns the receipts of the transactions of the block, the
+// transactions not executed locally have no receipt.
+func (b *Block) GetReceipts() []*Receipt {
+	var (
+		rmt      = b.GetReceiptsMerkleTree()
+		root     = rmt.GetRoot()
+		receipts = make([]*Receipt, 0, len(b.Txns))
+	)
+	for _, txn := range b.Txns {
+		res, ok := b.GetTxnResult(txn.Hash)
+		if !ok {
+			continue
+		}
+		r := TxnReceiptProvider().(*Receipt)
+		r.Hash = txn.Hash
+		r.BlockHash = b.Hash
+		r.Round = b.Round
+		r.Status = txn.Status
+		r.Output = txn.TransactionOutput
+		r.OutputHash = txn.OutputHash
+		r.ResultsHash = txn.ResultsHash
+		r.Fee = res.Fee
+		r.Events = res.Events
+		r.Transfers = res.Transfers
+		r.Mints = res.Mints
+		r.ReceiptMerkleTreeRoot = root
+		r.ReceiptMerkleTreePath = rmt.GetPath(transaction.NewTransactionReceipt(txn))
+		receipts = append(receipts, r)
+	}
+	return receipts
+}
+
+// VerifyMerklePath verifies the output and the results of the receipt against
+// the receipt merkle tree root.
+func (r *Receipt) VerifyMerklePath() bool {
+	if r.ReceiptMerkleTreePath == nil {
+		return false
+	}
+	if r.ResultsHash != "" {
+		res := &TxnResult{Events: r.Events, Transfers: r.Transfers, Mints: r.Mints, Fee: r.Fee}
+		hash, err := res.Hash()
+		if err != nil || hash != r.ResultsHash {
+			return false
+		}
+	}
+	return util.VerifyMerklePath(transaction.ReceiptHash(r.OutputHash, r.ResultsHash),
+		r.ReceiptMerkleTreePath, r.ReceiptMerkleTreeRoot)
+}
+
+// SetupTxnReceiptEntity - setup the transaction receipt entity
+func SetupTxnReceiptEntity(store datastore.Store) {
+	txnReceiptEntityMetadata = datastore.MetadataProvider()
+	txnReceiptEntityMetadata.Name = TxnReceiptMetaName
+	txnReceiptEntityMetadata.DB = TxnReceiptDBName
+	txnReceiptEntityMetadata.Provider = TxnReceiptProvider
+	txnReceiptEntityMetadata.Store = store
+	txnReceiptEntityMetadata.IDColumnName = "hash"
+	datastore.RegisterEntityMetadata(TxnReceiptMetaName, txnReceiptEntityMetadata)
+}
+
+// SetupTxnReceiptDB - sets up the transaction receipts database
+func SetupTxnReceiptDB(workdir string) {
+	datadir := filepath.Join(workdir, "data/rocksdb/txnreceipts")
+	db, err := ememorystore.CreateDB(datadir)
+	if err != nil {
+		panic(err)
+	}
+	ememorystore.AddPool(TxnReceiptDBName, db)
+}
+
+func TxnReceiptProvider() datastore.Entity {
+	return &Receipt{}
+}
+
+// GetEntityMetadata returns the txnReceiptEntityMetadata
+func (r *Receipt) GetEntityMetadata() datastore.EntityMetadata {
+	return txnReceiptEntityMetadata
+}
+
+// GetKey returns the key of the entity
+func (r *Receipt) GetKey() datastore.Key {
+	return datastore.ToKey(r.Hash)
+}
+
+// SetKey sets the key of the entity
+func (r *Receipt) SetKey(key datastore.Key) {
+	r.Hash = datastore.ToString(key)
+}
+
+// Read reads the receipt from the store
+func (r *Receipt) Read(ctx context.Context, key datastore.Key) error {
+	return r.GetEntityMetadata().GetStore().Read(ctx, key, r)
+}
+
+// Write writes the receipt to the store
+func (r *Receipt) Write(ctx context.Context) error {
+	return r.GetEntityMetadata().GetStore().Write(ctx, r)
+}
+
+// Delete deletes the receipt from the store
+func (r *Receipt) Delete(ctx context.Context) error {
+	return r.GetEntityMetadata().GetStore().Delete(ctx, r)
+}
+
+func (r *Receipt) Encode() []byte {
+	buff, _ := json.Marshal(r)
+	return buff
+}
+
+func (r *Receipt) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+// UnmarshalJSON decodes the receipt keeping the numbers of the event data as
+// they are, for the results hash to be computed again
+func (r *Receipt) UnmarshalJSON(input []byte) error {
+	type receipt Receipt
+	dec := json.NewDecoder(bytes.NewReader(input))
+	dec.UseNumber()
+	return dec.Decode((*receipt)(r))
+}
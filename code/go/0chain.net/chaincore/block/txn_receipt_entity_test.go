@@ -0,0 +1,112 @@
+package block
+
+import (
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlock_GetReceipts(t *testing.T) {
+	b := NewBlock("", 1)
+	b.Hash = encryption.Hash("block")
+	for i := 0; i < 5; i++ {
+		txn := &transaction.Transaction{
+			Status:            transaction.TxnSuccess,
+			TransactionOutput: "output " + strconv.Itoa(i),
+		}
+		txn.Hash = encryption.Hash(strconv.Itoa(i))
+		b.SetTxnResult(txn.Hash, &TxnResult{Hashed: true})
+		b.Txns = append(b.Txns, txn)
+	}
+
+	transfer := state.NewTransfer("from", "to", 10)
+	mint := state.NewMint("minter", "to", 20)
+	b.SetTxnResult(b.Txns[2].Hash, &TxnResult{
+		Events: []event.Event{{
+			Type:  event.TypeStats,
+			Tag:   event.TagMintReward,
+			Index: "to",
+			Data:  event.RewardMint{Amount: 400000000000000001, ClientID: "to", BlockNumber: 1},
+		}},
+		Transfers: []*state.Transfer{transfer, state.NewTransfer("minter", "to", 20)},
+		Mints:     []*state.Mint{mint},
+		Fee:       5,
+		Hashed:    true,
+	})
+	for _, txn := range b.Txns {
+		require.NoError(t, b.AddTransaction(txn))
+	}
+	require.NoError(t, b.VerifyTxnResults())
+
+	receipts := b.GetReceipts()
+	require.Len(t, receipts, len(b.Txns))
+	root := b.GetReceiptsMerkleTree().GetRoot()
+	for i, r := range receipts {
+		require.Equal(t, b.Txns[i].Hash, r.GetKey())
+		require.Equal(t, b.Hash, r.BlockHash)
+		require.Equal(t, root, r.ReceiptMerkleTreeRoot)
+		require.True(t, r.VerifyMerklePath())
+	}
+	require.Equal(t, []*state.Mint{mint}, receipts[2].Mints)
+	require.EqualValues(t, 5, receipts[2].Fee)
+	require.Empty(t, receipts[1].Transfers)
+
+	// a tampered output doesn't verify against the block root
+	r := receipts[3]
+	r.OutputHash = encryption.Hash("tampered")
+	require.False(t, r.VerifyMerklePath())
+
+	// the decoded events, transfers and mints are covered by the proof
+	var decoded Receipt
+	require.NoError(t, decoded.Decode(receipts[2].Encode()))
+	require.Equal(t, receipts[2].Hash, decoded.Hash)
+	require.True(t, decoded.VerifyMerklePath())
+
+	decoded.Transfers[0].Amount++
+	require.False(t, decoded.VerifyMerklePath())
+	decoded.Transfers[0].Amount--
+	decoded.Events[0].Index = "other"
+	require.False(t, decoded.VerifyMerklePath())
+
+	// a results hash not matching the local execution doesn't verify
+	b.Txns[1].ResultsHash = b.Txns[2].ResultsHash
+	require.Error(t, b.VerifyTxnResults())
+
+	b.ClearTxnResults()
+	require.Empty(t, b.GetReceipts())
+}
+
+func TestBlock_GetReceiptsBeforeHardFork(t *testing.T) {
+	b := NewBlock("", 1)
+	b.Hash = encryption.Hash("block")
+	for i := 0; i < 3; i++ {
+		txn := &transaction.Transaction{
+			Status:            transaction.TxnSuccess,
+			TransactionOutput: "output " + strconv.Itoa(i),
+		}
+		txn.Hash = encryption.Hash(strconv.Itoa(i))
+		b.SetTxnResult(txn.Hash, &TxnResult{Transfers: []*state.Transfer{state.NewTransfer("from", "to", 10)}})
+		b.Txns = append(b.Txns, txn)
+	}
+	for _, txn := range b.Txns {
+		require.NoError(t, b.AddTransaction(txn))
+		require.Empty(t, txn.ResultsHash)
+		require.Equal(t, txn.OutputHash, transaction.NewTransactionReceipt(txn).GetHash())
+	}
+	require.NoError(t, b.VerifyTxnResults())
+
+	// the receipts prove the output only
+	for _, r := range b.GetReceipts() {
+		require.Empty(t, r.ResultsHash)
+		require.True(t, r.VerifyMerklePath())
+	}
+
+	// a results hash of a node past the hardfork doesn't verify
+	b.Txns[1].ResultsHash = encryption.Hash("results")
+	require.Error(t, b.VerifyTxnResults())
+}
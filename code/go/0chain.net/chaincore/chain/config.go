@@ -395,6 +395,15 @@ func (c *ConfigImpl) BlockFinalizationTimeout() time.Duration {
 	return t
 }
 
+// ParallelExecutionWorkers returns the number of transactions of a block
+// executed ahead concurrently, 0 executes them one at a time.
+func (c *ConfigImpl) ParallelExecutionWorkers() int {
+	c.guard.RLock()
+	w := c.conf.ParallelExecutionWorkers
+	c.guard.RUnlock()
+	return w
+}
+
 // ConfigData - chain Configuration
 type ConfigData struct {
 	version               int64         `json:"-"` //version of config to track updates
@@ -442,6 +451,7 @@ type ConfigData struct {
 
 	ReuseTransactions        bool          `json:"reuse_txns"`                 // indicates if transactions from unrelated blocks can be reused
 	BlockFinalizationTimeout time.Duration `json:"block_finalization_timeout"` // time after which the block finalization will timeout
+	ParallelExecutionWorkers int           `json:"parallel_execution_workers"` // transactions executed ahead concurrently, 0 disables it
 
 	ClientSignatureScheme string `json:"client_signature_scheme"` // indicates which signature scheme is being used
 
@@ -570,6 +580,7 @@ func (c *ConfigImpl) FromViper() error {
 	}
 	conf.ReuseTransactions = viper.GetBool("server_chain.block.reuse_txns")
 	conf.BlockFinalizationTimeout = viper.GetDuration("server_chain.block.finalization.timeout")
+	conf.ParallelExecutionWorkers = viper.GetInt("server_chain.block.parallel_execution.workers")
 
 	conf.MinActiveSharders = viper.GetInt("server_chain.block.sharding.min_active_sharders")
 	conf.MinActiveReplicators = viper.GetInt("server_chain.block.sharding.min_active_replicators")
@@ -604,6 +615,21 @@ func (c *ConfigImpl) FromViper() error {
 	conf.DbsEvents.KafkaPassword = viper.GetString("server_chain.kafka.password")
 	conf.DbsEvents.KafkaWriteTimeout = viper.GetDuration("kafka.write_timeout")
 	conf.DbsEvents.KafkaTriggerRound = viper.GetInt64("kafka.trigger_round")
+	conf.DbsEvents.EventSink = config2.EventSinkConfig{
+		Type:              viper.GetString("event_sink.type"),
+		Topic:             viper.GetString("event_sink.topic"),
+		TriggerRound:      viper.GetInt64("event_sink.trigger_round"),
+		PublishTimeout:    viper.GetDuration("event_sink.publish_timeout"),
+		NatsURL:           viper.GetString("event_sink.nats.url"),
+		NatsUsername:      viper.GetString("event_sink.nats.username"),
+		NatsPassword:      viper.GetString("event_sink.nats.password"),
+		FilePath:          viper.GetString("event_sink.file.path"),
+		WebhookURL:        viper.GetString("event_sink.webhook.url"),
+		WebhookSecret:     viper.GetString("event_sink.webhook.secret"),
+		WebhookTimeout:    viper.GetDuration("event_sink.webhook.timeout"),
+		WebhookMaxRetries: viper.GetInt("event_sink.webhook.max_retries"),
+		WebhookRetryDelay: viper.GetDuration("event_sink.webhook.retry_delay"),
+	}
 	conf.DbsSettings.Debug = viper.GetBool("server_chain.dbs.settings.debug")
 	conf.DbsSettings.AggregatePeriod = viper.GetInt64("server_chain.dbs.settings.aggregate_period")
 	conf.DbsSettings.PartitionChangePeriod = viper.GetInt64("server_chain.dbs.settings.partition_change_period")
@@ -611,6 +637,7 @@ func (c *ConfigImpl) FromViper() error {
 	conf.DbsSettings.PermanentPartitionChangePeriod = viper.GetInt64("server_chain.dbs.settings.permanent_partition_change_period")
 	conf.DbsSettings.PermanentPartitionKeepCount = viper.GetInt64("server_chain.dbs.settings.permanent_partition_keep_count")
 	conf.DbsSettings.PageLimit = viper.GetInt64("server_chain.dbs.settings.page_limit")
+	conf.DbsSettings.HistoryPeriod = viper.GetInt64("server_chain.dbs.settings.history_period")
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/govsc"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
 	"0chain.net/smartcontract/zcnsc"
@@ -192,6 +193,8 @@ type Chain struct {
 
 	EventDb    *event.EventDb
 	eventMutex *sync.RWMutex
+	// events of the genesis block state, to replay them on an events reindex
+	genesisEvents []event.Event
 	// LFB tickets channels
 	getLFBTicket          chan *LFBTicket          // check out (any time)
 	updateLFBTicket       chan *LFBTicket          // receive
@@ -290,7 +293,10 @@ func (c *Chain) SetupEventDatabase() error {
 }
 
 func (c *Chain) getBlockEvents(round int64) (int64, []event.Event, error) {
-	meta := datastore.GetEntityMetadata("last_block_events")
+	meta := datastore.GetEntityMetadata(block.BlockEventsMetaName)
+	if meta == nil {
+		return 0, nil, errors.New("block events store not set up")
+	}
 	blockEvents := meta.Instance().(*block.BlockEvents)
 	key := strconv.FormatInt(round%int64(block.EventsRingSize), 10)
 
@@ -720,6 +726,14 @@ func (c *Chain) setupInitialState(initStates *state.InitStates, gb *block.Block)
 		panic(err)
 	}
 
+	err = govsc.InitConfig(stateCtx)
+	if err != nil {
+		logging.Logger.Error("chain.stateDB govsc InitConfig failed", zap.Error(err))
+		panic(err)
+	}
+
+	c.genesisEvents = stateCtx.GetEvents()
+
 	gbInitedKey := encryption.RawHash("genesis block state init")
 	_, err = c.stateDB.GetNode(gbInitedKey)
 	switch err {
@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"0chain.net/chaincore/block"
@@ -70,6 +71,9 @@ func minerHandlersMap(c Chainer) map[string]func(http.ResponseWriter, *http.Requ
 			transactionEntityMetadata,
 		),
 	))
+	m["/v1/transaction/put/batch"] = common.WithCORS(common.UserRateLimit(
+		common.ToJSONResponse(PutTransactionsBatchHandler),
+	))
 	m[GetBlockV1Pattern] = common.UserRateLimit(common.ToJSONResponse(GetBlockHandler))
 	return m
 }
@@ -113,11 +117,19 @@ func handlersMap(c Chainer) map[string]func(http.ResponseWriter, *http.Request)
 		"/_diagnostics/round_info": common.UserRateLimit(
 			RoundInfoHandler(c),
 		),
+		"/metrics": common.UserRateLimit(
+			MetricsHandler,
+		),
 		"/v1/estimate_txn_fee": common.WithCORS(common.UserRateLimit(
 			common.ToJSONResponse(
 				SuggestedFeeHandler,
 			),
 		)),
+		"/v1/transaction/simulate": common.WithCORS(common.UserRateLimit(
+			common.ToJSONResponse(
+				SimulateTransactionHandler,
+			),
+		)),
 		"/v1/fees_table": common.WithCORS(common.UserRateLimit(
 			common.ToJSONResponse(
 				FeesTableHandler,
@@ -217,6 +229,8 @@ func GetBlockResponse(b *block.Block, contentParts []string) (interface{}, error
 			data["block"] = b
 		case "header":
 			data["header"] = b.GetSummary()
+		case "notarized_header":
+			data["notarized_header"] = b.GetHeader()
 		case "merkle_tree":
 			data["merkle_tree"] = b.GetMerkleTree().GetTree()
 		}
@@ -1491,6 +1505,15 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 		}
 	}
 
+	if err := transaction.AddToPool(ctx, txn, nonce); err != nil {
+		logging.Logger.Error("put transaction - rejected by the txn pool",
+			zap.String("txn", txn.Hash),
+			zap.String("client_id", txn.ClientID),
+			zap.Int64("nonce", txn.Nonce),
+			zap.Error(err))
+		return nil, err
+	}
+
 	txnRsp, err := transaction.PutTransaction(ctx, txn)
 	if err != nil {
 		logging.Logger.Error("failed to save transaction",
@@ -1502,6 +1525,133 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 	return txnRsp, nil
 }
 
+const (
+	// MaxTxnBatchSize is the maximum number of transactions of a batch put request.
+	MaxTxnBatchSize = 1000
+	// MaxTxnBatchBytes is the maximum size of the body of a batch put request.
+	MaxTxnBatchBytes = 32 * 1024 * 1024
+)
+
+// TxnBatchResult is the outcome of a transaction of a batch put request.
+//
+// swagger:model TxnBatchResult
+type TxnBatchResult struct {
+	// Index of the transaction in the request
+	Index    int    `json:"index"`
+	Hash     string `json:"hash,omitempty"`
+	Accepted bool   `json:"accepted"`
+	// Error is the reason the transaction has been rejected
+	Error string `json:"error,omitempty"`
+}
+
+// TxnBatchResponse is the response of a batch put request.
+//
+// swagger:model TxnBatchResponse
+type TxnBatchResponse struct {
+	Accepted int              `json:"accepted"`
+	Rejected int              `json:"rejected"`
+	Results  []TxnBatchResult `json:"results"`
+}
+
+// swagger:route POST /v1/transaction/put/batch miner PutTransactionsBatch
+// Put a batch of transactions.
+// Put an array of signed transactions to the transaction pool. The transactions are verified in parallel
+// and every one of them is accepted or rejected on its own, the same way as by /v1/transaction/put.
+// A batch cannot have more than 1000 transactions.
+//
+// Consumes:
+//    - application/json
+//
+// responses:
+//
+//	200: TxnBatchResponse
+//	400:
+func PutTransactionsBatchHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-type"), "application/json") {
+		return nil, common.NewErrBadRequest("Header Content-type=application/json not found")
+	}
+
+	var raw []json.RawMessage
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, MaxTxnBatchBytes)).Decode(&raw); err != nil {
+		return nil, common.NewErrBadRequest("invalid transactions batch: " + err.Error())
+	}
+	if len(raw) == 0 {
+		return nil, common.NewErrBadRequest("empty transactions batch")
+	}
+	if len(raw) > MaxTxnBatchSize {
+		return nil, common.NewErrBadRequest(fmt.Sprintf("too many transactions in the batch, max %d", MaxTxnBatchSize))
+	}
+
+	put := memorystore.WithConnectionEntityJSONHandler(PutTransaction, datastore.GetEntityMetadata("txn"))
+	return putTransactionsBatch(ctx, raw, put), nil
+}
+
+func putTransactionsBatch(ctx context.Context, raw []json.RawMessage,
+	put datastore.JSONEntityReqResponderF) *TxnBatchResponse {
+	transactionEntityMetadata := datastore.GetEntityMetadata("txn")
+
+	var (
+		results = make([]TxnBatchResult, len(raw))
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+	)
+	workers := runtime.NumCPU()
+	if workers > len(raw) {
+		workers = len(raw)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = putBatchTransaction(ctx, idx, raw[idx], transactionEntityMetadata, put)
+			}
+		}()
+	}
+	for idx := range raw {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	rsp := &TxnBatchResponse{Results: results}
+	for _, res := range results {
+		if res.Accepted {
+			rsp.Accepted++
+		} else {
+			rsp.Rejected++
+		}
+	}
+	return rsp
+}
+
+func putBatchTransaction(ctx context.Context, idx int, data json.RawMessage,
+	entityMetadata datastore.EntityMetadata, put datastore.JSONEntityReqResponderF) TxnBatchResult {
+	res := TxnBatchResult{Index: idx}
+	txn, ok := entityMetadata.Instance().(*transaction.Transaction)
+	if !ok {
+		res.Error = "invalid transaction entity"
+		return res
+	}
+	if err := json.Unmarshal(data, txn); err != nil {
+		res.Error = "error decoding json: " + err.Error()
+		return res
+	}
+	res.Hash = txn.Hash
+	if err := txn.ComputeProperties(); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	ctx = datastore.WithAsyncChannel(ctx, transaction.TransactionEntityChannel)
+	if _, err := put(ctx, txn); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Accepted = true
+	return res
+}
+
 // RoundInfoHandler collects and writes information about current round
 func RoundInfoHandler(c Chainer) common.ReqRespHandlerf {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -2071,9 +2221,47 @@ func SetupSharderHandlers(c Chainer) {
 	setupHandlers(handlersMap(c))
 }
 
+// swagger:route POST /v1/transaction/simulate miner sharder SimulateTransaction
+// Simulate a transaction.
+// Executes the transaction provided in the body of the request against the state of the LFB (latest finalized block)
+// without submitting it, and returns the output, the events, the transfers and mints, and the state keys it would touch.
+// Nothing is persisted. The signature is not verified, a transaction without a nonce gets the next nonce of the client.
+//
+// Consumes:
+// - application/json
+//
+// responses:
+//   200: TxnSimulation
+//   400:
+//   500:
+func SimulateTransactionHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	defer r.Body.Close()
+	var txn transaction.Transaction
+	if err := json.NewDecoder(r.Body).Decode(&txn); err != nil {
+		return nil, common.NewErrBadRequest("invalid transaction: " + err.Error())
+	}
+	if err := txn.ComputeProperties(); err != nil {
+		return nil, common.NewErrBadRequest(err.Error())
+	}
+
+	c := GetServerChain()
+	if c.TxnMaxPayload() > 0 && len(txn.TransactionData) > c.TxnMaxPayload() {
+		return nil, common.NewError("txn_exceed_max_payload",
+			fmt.Sprintf("transaction payload exceeds the max payload (%d)", c.TxnMaxPayload()))
+	}
+
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return nil, errors.New("LFB not ready yet")
+	}
+
+	return c.SimulateTransaction(ctx, lfb.Clone(), &txn)
+}
+
 // swagger:route GET /v1/estimate_txn_fee miner sharder GetTxnFees
 // Estimate transaction fees
 // Returns an on-chain calculation of the fee based on the provided txn data (in SAS which is the indivisible unit of ZCN coin, 1 ZCN = 10^10 SAS). Txn data is provided in the body of the request.
+// When the base fee is enabled, the fee is the burned base fee of the txn and base_fee is the current base fee per unit of cost, anything paid above the fee is a tip to the generator and the sharders.
 //
 // Consumes:
 // - application/json
@@ -2112,14 +2300,21 @@ func SuggestedFeeHandler(ctx context.Context, r *http.Request) (interface{}, err
 		return nil, err
 	}
 
+	baseFee, err := c.GetBaseFee(lfb)
+	if err != nil {
+		logging.Logger.Error("failed to get the base fee", zap.Error(err))
+		return nil, err
+	}
+
 	return map[string]uint64{
-		"fee": uint64(fee),
+		"fee":      uint64(fee),
+		"base_fee": uint64(baseFee),
 	}, nil
 }
 
 // swagger:route GET /v1/fees_table miner sharder GetTxnFeesTable
 // Get transaction fees table
-// Returns the transaction fees table based on the latest finalized block.
+// Returns the transaction fees table based on the latest finalized block. When the base fee is enabled, the fees are the burned base fees, the current base fee per unit of cost is the base_fee of /v1/estimate_txn_fee.
 //
 // responses:
 //   200: FeesTableResponse
@@ -0,0 +1,77 @@
+package chain
+
+import (
+	"net/http"
+	"strconv"
+
+	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/memorystore"
+	"0chain.net/core/metric"
+	"github.com/0chain/common/core/logging"
+	metrics "github.com/rcrowley/go-metrics"
+	"go.uber.org/zap"
+)
+
+// EventsRoundGauge is the round of the last block with the events committed to the event db
+var EventsRoundGauge = metrics.GetOrRegisterGauge("event_db_round", nil)
+
+/*MetricsHandler - all the registered metrics and the chain progress in the prometheus text format */
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	c := GetServerChain()
+	self := node.Self.Underlying()
+
+	pw := metric.NewPrometheusWriter(metric.Labels{
+		"node":  self.GetKey(),
+		"round": strconv.FormatInt(c.GetCurrentRound(), 10),
+	})
+	pw.AddRegistry(metrics.DefaultRegistry)
+	c.addChainGauges(pw, self)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := pw.WriteTo(w); err != nil {
+		logging.Logger.Error("write metrics", zap.Error(err))
+	}
+}
+
+func (c *Chain) addChainGauges(pw *metric.PrometheusWriter, self *node.Node) {
+	pw.AddGauge("node_info", "Node type and name", 1, metric.Labels{
+		"type":       self.GetNodeTypeName(),
+		"short_name": self.GetPseudoName(),
+	})
+	pw.AddGauge("current_round", "Current round", float64(c.GetCurrentRound()), nil)
+
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb != nil {
+		pw.AddGauge("lfb_round", "Round of the latest finalized block", float64(lfb.Round), nil)
+	}
+	if lfmb := c.GetLatestFinalizedMagicBlock(common.GetRootContext()); lfmb != nil {
+		pw.AddGauge("lfmb_round", "Round of the latest finalized magic block", float64(lfmb.Round), nil)
+	}
+
+	if c.GetEventDb() != nil && lfb != nil {
+		eventsRound := EventsRoundGauge.Value()
+		pw.AddGauge("event_db_lag", "Rounds finalized and not yet committed to the event db",
+			float64(lfb.Round-eventsRound), nil)
+	}
+
+	if self.Type == node.NodeTypeMiner {
+		pw.AddGauge("txn_pool_size", "Transactions in the pool", float64(txnPoolSize()), nil)
+	}
+}
+
+func txnPoolSize() int64 {
+	txn, ok := transaction.Provider().(*transaction.Transaction)
+	if !ok {
+		return 0
+	}
+	transactionEntityMetadata := txn.GetEntityMetadata()
+	mstore, ok := transactionEntityMetadata.GetStore().(*memorystore.Store)
+	if !ok {
+		return 0
+	}
+	cctx := memorystore.WithEntityConnection(common.GetRootContext(), transactionEntityMetadata)
+	defer memorystore.Close(cctx)
+	return mstore.GetCollectionSize(cctx, transactionEntityMetadata, txn.GetCollectionName())
+}
@@ -0,0 +1,488 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+var (
+	// parallelTxnsReused counts the transactions applied from their speculative execution
+	parallelTxnsReused = metrics.GetOrRegisterCounter("parallel_txns_reused", nil)
+	// parallelTxnsReexecuted counts the transactions executed again as their
+	// speculative execution conflicted with the transactions before them
+	parallelTxnsReexecuted = metrics.GetOrRegisterCounter("parallel_txns_reexecuted", nil)
+)
+
+// stateOp is a change of the state made by a speculative execution, replayed
+// to apply the transaction without executing it again.
+type stateOp struct {
+	path  util.Path
+	value []byte // nil deletes the value
+}
+
+// txnAccess records the state a transaction reads and writes, by MPT path and
+// by state cache key.
+type txnAccess struct {
+	// speculative executions keep their changes to be replayed, they can't
+	// change anything out of the transaction state
+	speculative bool
+
+	mutex      sync.Mutex
+	reads      map[string]struct{} // MPT paths
+	cacheReads map[string]struct{} // block state cache keys
+	writes     map[string]struct{} // MPT paths
+	keys       map[string]struct{} // state context keys inserted or deleted
+	ops        []stateOp
+	// global is set when the transaction iterated the state, it depends on all of it
+	global bool
+	// unsafe is set when the transaction changed something out of its state
+	unsafe bool
+}
+
+func newTxnAccess(speculative bool) *txnAccess {
+	acc := &txnAccess{speculative: speculative}
+	acc.reset()
+	return acc
+}
+
+// reset drops what has been recorded, the state of a transaction is reset when
+// a smart contract fails with a chargeable error
+func (acc *txnAccess) reset() {
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+	acc.reads = make(map[string]struct{})
+	acc.cacheReads = make(map[string]struct{})
+	acc.writes = make(map[string]struct{})
+	acc.keys = make(map[string]struct{})
+	acc.ops = nil
+	acc.global = false
+	acc.unsafe = false
+}
+
+func (acc *txnAccess) wrapState(s util.MerklePatriciaTrieI) util.MerklePatriciaTrieI {
+	return &accessState{MerklePatriciaTrieI: s, acc: acc}
+}
+
+func (acc *txnAccess) wrap(sctx bcstate.StateContextI) bcstate.StateContextI {
+	return &accessStateContext{StateContextI: sctx, acc: acc}
+}
+
+func (acc *txnAccess) read(path util.Path) {
+	acc.mutex.Lock()
+	acc.reads[string(path)] = struct{}{}
+	acc.mutex.Unlock()
+}
+
+func (acc *txnAccess) readCache(key string) {
+	acc.mutex.Lock()
+	acc.cacheReads[key] = struct{}{}
+	acc.mutex.Unlock()
+}
+
+func (acc *txnAccess) write(path util.Path, value []byte) {
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+	acc.writes[string(path)] = struct{}{}
+	if acc.speculative {
+		acc.ops = append(acc.ops, stateOp{path: path, value: value})
+	}
+}
+
+func (acc *txnAccess) key(key datastore.Key) {
+	acc.mutex.Lock()
+	acc.keys[key] = struct{}{}
+	acc.mutex.Unlock()
+}
+
+func (acc *txnAccess) setGlobal() {
+	acc.mutex.Lock()
+	acc.global = true
+	acc.mutex.Unlock()
+}
+
+func (acc *txnAccess) setUnsafe() {
+	acc.mutex.Lock()
+	acc.unsafe = true
+	acc.mutex.Unlock()
+}
+
+// accessState is the transaction state recording the paths accessed
+type accessState struct {
+	util.MerklePatriciaTrieI
+	acc *txnAccess
+}
+
+func (s *accessState) GetNodeValue(path util.Path, v util.MPTSerializable) error {
+	s.acc.read(path)
+	return s.MerklePatriciaTrieI.GetNodeValue(path, v)
+}
+
+func (s *accessState) GetNodeValueRaw(path util.Path) ([]byte, error) {
+	s.acc.read(path)
+	return s.MerklePatriciaTrieI.GetNodeValueRaw(path)
+}
+
+func (s *accessState) Insert(path util.Path, v util.MPTSerializable) (util.Key, error) {
+	// the value is replayed as inserted, the MPT doesn't keep it
+	var value []byte
+	if v != nil && s.acc.speculative {
+		var err error
+		if value, err = v.MarshalMsg(nil); err != nil {
+			return nil, err
+		}
+	}
+	if v == nil || (s.acc.speculative && len(value) == 0) {
+		// inserting nothing deletes the value, if there is one
+		s.acc.read(path)
+		value = nil
+	}
+
+	k, err := s.MerklePatriciaTrieI.Insert(path, v)
+	if err != nil {
+		s.acc.read(path)
+		return k, err
+	}
+	s.acc.write(path, value)
+	return k, nil
+}
+
+func (s *accessState) Delete(path util.Path) (util.Key, error) {
+	// deleting a missing value fails
+	s.acc.read(path)
+	k, err := s.MerklePatriciaTrieI.Delete(path)
+	if err != nil {
+		return k, err
+	}
+	s.acc.write(path, nil)
+	return k, nil
+}
+
+func (s *accessState) Iterate(ctx context.Context, handler util.MPTIteratorHandler, visitNodeTypes byte) error {
+	s.acc.setGlobal()
+	return s.MerklePatriciaTrieI.Iterate(ctx, handler, visitNodeTypes)
+}
+
+func (s *accessState) IterateFrom(ctx context.Context, from util.Key, handler util.MPTIteratorHandler, visitNodeTypes byte) error {
+	s.acc.setGlobal()
+	return s.MerklePatriciaTrieI.IterateFrom(ctx, from, handler, visitNodeTypes)
+}
+
+// accessStateContext is the state context recording the state keys a
+// transaction changes. A speculative execution can't change the magic block.
+type accessStateContext struct {
+	bcstate.StateContextI
+	acc *txnAccess
+}
+
+func (sc *accessStateContext) InsertTrieNode(key datastore.Key, v util.MPTSerializable) (datastore.Key, error) {
+	sc.acc.key(key)
+	return sc.StateContextI.InsertTrieNode(key, v)
+}
+
+func (sc *accessStateContext) DeleteTrieNode(key datastore.Key) (datastore.Key, error) {
+	sc.acc.key(key)
+	return sc.StateContextI.DeleteTrieNode(key)
+}
+
+func (sc *accessStateContext) SetMagicBlock(mb *block.MagicBlock) {
+	if sc.acc.speculative {
+		sc.acc.setUnsafe()
+		return
+	}
+	sc.StateContextI.SetMagicBlock(mb)
+}
+
+// speculativeCache reads the block state cache recording the keys read. The
+// transaction caches on top of it are never committed.
+type speculativeCache struct {
+	*statecache.BlockCache
+	acc *txnAccess
+}
+
+func (sc *speculativeCache) Get(key string) (statecache.Value, bool) {
+	sc.acc.readCache(key)
+	return sc.BlockCache.Get(key)
+}
+
+// txnSpeculation is the outcome of a transaction executed ahead of its turn
+// against the block state as it was when the speculation started.
+type txnSpeculation struct {
+	txn  *transaction.Transaction // the copy of the transaction executed
+	acc  *txnAccess
+	sctx bcstate.StateContextI
+	err  error
+	// epoch is the number of transactions applied before the speculation
+	epoch int
+}
+
+// txnExecutor applies the transactions of a block in order. When parallel
+// execution is enabled the transactions are executed ahead concurrently, and
+// a speculative execution is applied as is when none of the state it read has
+// been changed by the transactions applied since, otherwise the transaction
+// is executed again. The resulting state is the same as the serial execution.
+type txnExecutor struct {
+	c               *Chain
+	b               *block.Block
+	bState          util.MerklePatriciaTrieI
+	blockStateCache *statecache.BlockCache
+	workers         int
+
+	speculations map[string]*txnSpeculation
+	applied      int            // number of the transactions applied
+	writes       map[string]int // MPT path -> number of the transactions applied when last written
+	keys         map[string]int // state cache key -> number of the transactions applied when last written
+}
+
+// NewTxnExecutor returns the executor applying the transactions to the block
+// state, executing them concurrently when parallel execution is enabled.
+func (c *Chain) NewTxnExecutor(b *block.Block, bState util.MerklePatriciaTrieI,
+	blockStateCache *statecache.BlockCache) block.TxnExecutor {
+	return &txnExecutor{
+		c:               c,
+		b:               b,
+		bState:          bState,
+		blockStateCache: blockStateCache,
+		workers:         c.ChainConfig.ParallelExecutionWorkers(),
+		speculations:    make(map[string]*txnSpeculation),
+		writes:          make(map[string]int),
+		keys:            make(map[string]int),
+	}
+}
+
+// Speculate executes the transactions concurrently against the current block
+// state, the outcomes are used by UpdateState when still valid.
+//
+// The smart contracts are executed concurrently with their own state context
+// each. The package level state of the smart contracts is shared: the
+// contract map, the settings and cost tables, the phase functions of the
+// miner smart contract and the execution stats maps are only written when
+// the contracts are set up, before any block is generated, and read after.
+// The execution timers and histograms are go-metrics ones, safe for
+// concurrent use, and the locks of the miner smart contract are mutexes.
+// A smart contract adding package level state written on execution has to
+// guard it the same way.
+func (e *txnExecutor) Speculate(ctx context.Context, txns []*transaction.Transaction) {
+	if e.workers <= 0 || len(txns) < 2 {
+		return
+	}
+
+	e.c.stateMutex.Lock()
+	defer e.c.stateMutex.Unlock()
+
+	if checkBlockState(e.b, e.bState) != nil {
+		return
+	}
+
+	var (
+		specs   = make([]*txnSpeculation, len(txns))
+		indexes = make(chan int)
+		workers = e.workers
+		wg      sync.WaitGroup
+	)
+	if workers > len(txns) {
+		workers = len(txns)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				specs[i] = e.speculate(ctx, txns[i])
+			}
+		}()
+	}
+	for i := range txns {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, txn := range txns {
+		e.speculations[txn.Hash] = specs[i]
+	}
+}
+
+func (e *txnExecutor) speculate(ctx context.Context, txn *transaction.Transaction) *txnSpeculation {
+	spec := &txnSpeculation{
+		txn:   txn.Clone(),
+		acc:   newTxnAccess(true),
+		epoch: e.applied,
+	}
+	cache := &speculativeCache{BlockCache: e.blockStateCache, acc: spec.acc}
+	newTxnState := func() bcstate.StateContextI {
+		spec.acc.reset()
+		clientState := spec.acc.wrapState(CreateTxnMPT(e.bState, statecache.NewTransactionCache(cache)))
+		return spec.acc.wrap(e.c.NewStateContext(e.b, clientState, spec.txn, nil))
+	}
+	spec.sctx, spec.err = e.c.executeTxn(ctx, newTxnState(), newTxnState)
+	return spec
+}
+
+// UpdateState applies the transaction to the block state, from its speculative
+// execution when it's still valid.
+func (e *txnExecutor) UpdateState(ctx context.Context, txn *transaction.Transaction,
+	waitC ...chan struct{}) ([]event.Event, error) {
+	if e.workers <= 0 {
+		return e.c.UpdateState(ctx, e.b, e.bState, txn, e.blockStateCache, waitC...)
+	}
+
+	e.c.stateMutex.Lock()
+	defer e.c.stateMutex.Unlock()
+
+	spec, ok := e.speculations[txn.Hash]
+	if ok {
+		delete(e.speculations, txn.Hash)
+		if e.valid(spec) {
+			events, err := e.applySpeculation(txn, spec)
+			if err == nil {
+				parallelTxnsReused.Inc(1)
+				return events, nil
+			}
+		}
+		parallelTxnsReexecuted.Inc(1)
+	}
+
+	acc := newTxnAccess(false)
+	events, err := e.c.applyTxn(ctx, e.b, e.bState, txn, e.blockStateCache, acc, waitC...)
+	if err != nil {
+		return nil, err
+	}
+	e.commit(acc)
+	return events, nil
+}
+
+// valid checks if the state the speculation read is unchanged since it started
+func (e *txnExecutor) valid(spec *txnSpeculation) bool {
+	if spec.err != nil || spec.acc.unsafe {
+		return false
+	}
+	if spec.epoch == e.applied {
+		return true
+	}
+	if spec.acc.global {
+		return false
+	}
+	for path := range spec.acc.reads {
+		if n, ok := e.writes[path]; ok && n > spec.epoch {
+			return false
+		}
+	}
+	for path := range spec.acc.writes {
+		if n, ok := e.writes[path]; ok && n > spec.epoch {
+			return false
+		}
+	}
+	for key := range spec.acc.cacheReads {
+		if n, ok := e.keys[key]; ok && n > spec.epoch {
+			return false
+		}
+	}
+	return true
+}
+
+// applySpeculation replays the state changes of the speculation on the block
+// state, then pays the fee and processes the transfers and the nonce as applyTxn does.
+func (e *txnExecutor) applySpeculation(txn *transaction.Transaction, spec *txnSpeculation) ([]event.Event, error) {
+	if err := checkBlockState(e.b, e.bState); err != nil {
+		return nil, err
+	}
+
+	var (
+		acc           = newTxnAccess(false)
+		txnStateCache = statecache.NewTransactionCache(e.blockStateCache)
+		clientState   = acc.wrapState(CreateTxnMPT(e.bState, txnStateCache))
+	)
+	for _, op := range spec.acc.ops {
+		var err error
+		if op.value == nil {
+			_, err = clientState.Delete(op.path)
+		} else {
+			_, err = clientState.Insert(op.path, &util.SecureSerializableValue{Buffer: op.value})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sctx := acc.wrap(e.c.NewStateContext(e.b, clientState, txn, nil))
+	if err := carryOver(sctx, spec.sctx); err != nil {
+		return nil, err
+	}
+
+	status, output := txn.Status, txn.TransactionOutput
+	txn.Status, txn.TransactionOutput = spec.txn.Status, spec.txn.TransactionOutput
+	events, err := e.c.finalizeTxn(e.bState, clientState, sctx)
+	if err != nil {
+		txn.Status, txn.TransactionOutput = status, output
+		return nil, err
+	}
+
+	// the values the speculation changed are not in the transaction cache,
+	// the block cache can't keep the previous ones
+	for key := range spec.acc.keys {
+		txnStateCache.Remove(key)
+		acc.keys[key] = struct{}{}
+	}
+	txnStateCache.Commit()
+	e.commit(acc)
+	return events, nil
+}
+
+// carryOver adds the transfers, the mints and the events of the speculative
+// execution to the state context the transaction is applied with. The
+// transfer of a mint is added with the mint, in its place.
+func carryOver(sctx, spec bcstate.StateContextI) error {
+	mints := spec.GetMints()
+	for _, t := range spec.GetTransfers() {
+		if len(mints) > 0 && isMintTransfer(t, mints[0]) {
+			if err := sctx.AddMint(mints[0]); err != nil {
+				return err
+			}
+			mints = mints[1:]
+			continue
+		}
+		if err := sctx.AddTransfer(t); err != nil {
+			return err
+		}
+	}
+	if len(mints) > 0 {
+		return errors.New("speculation mints without their transfers")
+	}
+	for _, st := range spec.GetSignedTransfers() {
+		sctx.AddSignedTransfer(st)
+	}
+	for _, ev := range spec.GetEvents() {
+		ev := ev
+		sctx.EmitEventWithVersion(ev.Version, ev.Type, ev.Tag, ev.Index, ev.Data,
+			func(events []event.Event, _ event.Event) []event.Event {
+				return append(events, ev)
+			})
+	}
+	return nil
+}
+
+func isMintTransfer(t *state.Transfer, m *state.Mint) bool {
+	return t.ClientID == m.Minter && t.ToClientID == m.ToClientID && t.Amount == m.Amount
+}
+
+// commit records the state changed by the applied transaction
+func (e *txnExecutor) commit(acc *txnAccess) {
+	e.applied++
+	for path := range acc.writes {
+		e.writes[path] = e.applied
+	}
+	for key := range acc.keys {
+		e.keys[key] = e.applied
+	}
+}
@@ -0,0 +1,233 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnExecutor(t *testing.T) {
+	ch := NewChainFromConfig()
+	ch.stateCache = statecache.NewStateCache()
+
+	client := func(i int) string { return encryption.Hash("client" + strconv.Itoa(i)) }
+
+	clientState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	for i := 0; i < 10; i++ {
+		s := &state.State{Balance: 100}
+		s.SetRound(1)
+		require.NoError(t, s.SetTxnHash(encryption.Hash("mint")))
+		_, err := clientState.Insert(util.Path(client(i)), s)
+		require.NoError(t, err)
+	}
+
+	lfb := block.NewBlock("", 1)
+	lfb.Hash = encryption.Hash("lfb")
+	lfb.ClientState = clientState
+	lfb.ClientStateHash = clientState.GetRoot()
+
+	newTxns := func() []*transaction.Transaction {
+		send := func(from, to int, nonce, value int64) *transaction.Transaction {
+			txn := &transaction.Transaction{
+				ClientID:        client(from),
+				ToClientID:      client(to),
+				TransactionType: transaction.TxnTypeSend,
+				Value:           currency.Coin(value),
+				Nonce:           nonce,
+			}
+			txn.Hash = encryption.Hash(txn.ClientID + txn.ToClientID + strconv.FormatInt(nonce, 10))
+			return txn
+		}
+		return []*transaction.Transaction{
+			// independent of each other
+			send(0, 10, 1, 10),
+			send(1, 11, 1, 10),
+			send(2, 12, 1, 10),
+			// the next nonce of a client
+			send(0, 10, 2, 20),
+			// the transfer to the receiver is not part of the speculation
+			send(3, 2, 1, 10),
+			// only has the balance to send after the transaction before
+			send(2, 13, 2, 95),
+			// fails on its own
+			send(4, 14, 1, 1000),
+			send(5, 15, 1, 10),
+		}
+	}
+
+	apply := func(workers int) (util.Key, []*transaction.Transaction) {
+		b := block.NewBlock("", 2)
+		b.PrevBlock = lfb
+		b.PrevHash = lfb.Hash
+		bState := block.CreateStateWithPreviousBlock(lfb, ch.GetStateDB(), b.Round)
+		blockStateCache := statecache.NewBlockCache(ch.GetStateCache(), statecache.Block{
+			Round:    b.Round,
+			PrevHash: b.PrevHash,
+		})
+
+		e := ch.NewTxnExecutor(b, bState, blockStateCache).(*txnExecutor)
+		e.workers = workers
+
+		txns := newTxns()
+		e.Speculate(context.Background(), txns)
+		for _, txn := range txns {
+			_, err := e.UpdateState(context.Background(), txn)
+			if err != nil {
+				txn.Status = transaction.TxnFail
+			}
+		}
+		return bState.GetRoot(), txns
+	}
+
+	serialRoot, serialTxns := apply(0)
+
+	reused, reexecuted := parallelTxnsReused.Count(), parallelTxnsReexecuted.Count()
+	root, txns := apply(4)
+	require.Equal(t, util.ToHex(serialRoot), util.ToHex(root))
+	for i, txn := range txns {
+		require.Equal(t, serialTxns[i].Status, txn.Status, "txn %d", i)
+	}
+	require.Equal(t, transaction.TxnSuccess, txns[5].Status)
+	require.Equal(t, transaction.TxnFail, txns[6].Status)
+
+	// the next nonce, the sender funded by the transaction before and the
+	// failed transaction are executed again
+	require.EqualValues(t, 5, parallelTxnsReused.Count()-reused)
+	require.EqualValues(t, 3, parallelTxnsReexecuted.Count()-reexecuted)
+}
+
+// mintContract mints the minted input to the caller and transfers the spent
+// one to the receiver
+type mintContract struct {
+	sci.SmartContractInterface
+}
+
+func (mc *mintContract) Execute(t *transaction.Transaction, _ string, input []byte, balances bcstate.StateContextI) (string, error) {
+	var in struct {
+		Minted currency.Coin `json:"minted"`
+		Spent  currency.Coin `json:"spent"`
+		To     string        `json:"to"`
+	}
+	if err := json.Unmarshal(input, &in); err != nil {
+		return "", err
+	}
+	if in.Spent > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(t.ClientID, in.To, in.Spent)); err != nil {
+			return "", err
+		}
+	}
+	if err := balances.AddMint(state.NewMint(t.ToClientID, t.ClientID, in.Minted)); err != nil {
+		return "", err
+	}
+	return "minted", nil
+}
+
+func (mc *mintContract) GetExecutionStats() map[string]interface{} {
+	return nil
+}
+
+func TestTxnExecutorMints(t *testing.T) {
+	ch := NewChainFromConfig()
+	ch.stateCache = statecache.NewStateCache()
+
+	minter, err := bcstate.GetMinter(bcstate.MinterZcn)
+	require.NoError(t, err)
+	smartcontract.ContractMap[minter] = &mintContract{}
+	defer delete(smartcontract.ContractMap, minter)
+
+	client := func(i int) string { return encryption.Hash("client" + strconv.Itoa(i)) }
+
+	clientState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	for _, id := range []string{client(0), client(1), client(2), minter} {
+		s := &state.State{Balance: 1000}
+		s.SetRound(1)
+		require.NoError(t, s.SetTxnHash(encryption.Hash("mint")))
+		_, err := clientState.Insert(util.Path(id), s)
+		require.NoError(t, err)
+	}
+	// the results hashes are part of the receipts
+	hf := bcstate.NewHardFork("hermes", 0)
+	_, err = clientState.Insert(util.Path(encryption.Hash(hf.GetKey())), hf)
+	require.NoError(t, err)
+
+	lfb := block.NewBlock("", 1)
+	lfb.Hash = encryption.Hash("lfb")
+	lfb.ClientState = clientState
+	lfb.ClientStateHash = clientState.GetRoot()
+
+	newTxns := func() []*transaction.Transaction {
+		mint := func(from int, nonce int64, input string) *transaction.Transaction {
+			txn := &transaction.Transaction{
+				ClientID:        client(from),
+				ToClientID:      minter,
+				TransactionType: transaction.TxnTypeSmartContract,
+				SmartContractData: &transaction.SmartContractData{
+					FunctionName: "mint",
+					InputData:    json.RawMessage(input),
+				},
+				Nonce: nonce,
+			}
+			txn.Hash = encryption.Hash(txn.ClientID + input + strconv.FormatInt(nonce, 10))
+			return txn
+		}
+		return []*transaction.Transaction{
+			mint(0, 1, `{"minted":10}`),
+			// a transfer before the mint
+			mint(1, 1, `{"minted":20,"spent":5,"to":"`+client(2)+`"}`),
+			mint(2, 1, `{"minted":5}`),
+			// the next nonce, executed again
+			mint(0, 2, `{"minted":30}`),
+		}
+	}
+
+	apply := func(workers int) *block.Block {
+		b := block.NewBlock("", 2)
+		b.PrevBlock = lfb
+		b.PrevHash = lfb.Hash
+		bState := block.CreateStateWithPreviousBlock(lfb, ch.GetStateDB(), b.Round)
+		blockStateCache := statecache.NewBlockCache(ch.GetStateCache(), statecache.Block{
+			Round:    b.Round,
+			PrevHash: b.PrevHash,
+		})
+
+		e := ch.NewTxnExecutor(b, bState, blockStateCache).(*txnExecutor)
+		e.workers = workers
+
+		txns := newTxns()
+		e.Speculate(context.Background(), txns)
+		for _, txn := range txns {
+			_, err := e.UpdateState(context.Background(), txn)
+			require.NoError(t, err)
+			require.NoError(t, b.AddTransaction(txn))
+		}
+		b.Txns = txns
+		return b
+	}
+
+	serial := apply(0)
+	reused := parallelTxnsReused.Count()
+	parallel := apply(4)
+	require.NotZero(t, parallelTxnsReused.Count()-reused)
+
+	for i, txn := range parallel.Txns {
+		require.NotEmpty(t, txn.ResultsHash, "txn %d", i)
+		require.Equal(t, serial.Txns[i].ResultsHash, txn.ResultsHash, "txn %d", i)
+
+		res, ok := parallel.GetTxnResult(txn.Hash)
+		require.True(t, ok)
+		require.Len(t, res.Mints, 1, "txn %d", i)
+	}
+}
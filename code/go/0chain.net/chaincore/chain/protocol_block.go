@@ -291,6 +291,12 @@ func (c *Chain) MergeVerificationTickets(b *block.Block, vts []*block.Verificati
 }
 
 func (c *Chain) finalizeBlock(ctx context.Context, fb *block.Block, bsh BlockStateHandler) (err error) {
+	ctx, span := StartBlockSpan(ctx, "finalize_block", fb)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	logging.Logger.Info("finalize block", zap.Int64("round", fb.Round), zap.Int64("current_round", c.GetCurrentRound()),
 		zap.Int64("lf_round", c.GetLatestFinalizedBlock().Round), zap.String("hash", fb.Hash),
 		zap.Int("round_rank", fb.RoundRank), zap.Int8("state", fb.GetBlockState()))
@@ -418,6 +424,7 @@ func (c *Chain) finalizeBlock(ctx context.Context, fb *block.Block, bsh BlockSta
 			if eventTx == nil {
 				// Already committed
 				c.GetEventDb().AddToEventsCounter(uint64(eventsCount))
+				EventsRoundGauge.Update(fb.Round)
 			}
 
 			EventsComputationTimer.Update(time.Since(ts).Microseconds())
@@ -477,6 +484,7 @@ func (c *Chain) finalizeBlock(ctx context.Context, fb *block.Block, bsh BlockSta
 						zap.Error(cerr))
 				} else {
 					c.GetEventDb().AddToEventsCounter(uint64(eventsCount))
+					EventsRoundGauge.Update(fb.Round)
 					logging.Logger.Debug("finalize block - commit events",
 						zap.Int64("round", fb.Round),
 						zap.String("block", fb.Hash))
@@ -514,6 +522,7 @@ func (c *Chain) finalizeBlock(ctx context.Context, fb *block.Block, bsh BlockSta
 			panic(err)
 		}
 		c.GetEventDb().AddToEventsCounter(uint64(eventsCount))
+		EventsRoundGauge.Update(fb.Round)
 		logging.Logger.Debug("finalize block - commit events",
 			zap.Int64("round", fb.Round),
 			zap.String("block", fb.Hash))
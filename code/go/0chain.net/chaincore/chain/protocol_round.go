@@ -41,6 +41,9 @@ var StartToFinalizeTimer metrics.Timer
 var StartToFinalizeTxnTimer metrics.Timer
 var StartToFinalizeTxnTypeTimer map[string]metrics.Timer
 
+// guards StartToFinalizeTxnTypeTimer, transactions can be executed concurrently
+var startToFinalizeTxnTypeTimerMutex sync.Mutex
+
 // FinalizationLagMetric - a metric that tracks how much is the lag between current round and finalization round
 var FinalizationLagMetric metrics.Histogram
 
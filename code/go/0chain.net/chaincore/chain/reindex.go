@@ -0,0 +1,108 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// ErrBlockEventsUnavailable is returned when the events of a block can
+// neither be computed, the state of the previous block being pruned, nor
+// found in the events stored by the sharder.
+var ErrBlockEventsUnavailable = errors.New("block events unavailable")
+
+// ReplayBlockEvents returns the events of the finalized block, re-executed on
+// the state of the previous block. The events of the genesis block are the
+// ones of its initial state. When the state has been pruned the events stored
+// for the round by the sharder are used instead. Nothing is persisted to the
+// state db and the block is left untouched.
+func (c *Chain) ReplayBlockEvents(ctx context.Context, b, prev *block.Block) ([]event.Event, error) {
+	if b.Round == 0 {
+		if len(c.genesisEvents) == 0 {
+			return nil, fmt.Errorf("%w: genesis block", ErrBlockEventsUnavailable)
+		}
+		return append([]event.Event(nil), c.genesisEvents...), nil
+	}
+
+	events, err := c.computeBlockEvents(ctx, b, prev)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		logging.Logger.Debug("replay block events - compute state failed, use the stored events",
+			zap.Int64("round", b.Round),
+			zap.String("block", b.Hash),
+			zap.Error(err))
+
+		round, stored, serr := c.getBlockEvents(b.Round)
+		if serr != nil || round != b.Round {
+			return nil, fmt.Errorf("%w: round %d: %v", ErrBlockEventsUnavailable, b.Round, err)
+		}
+		events = stored
+	}
+
+	if !hasBlockFinalizeEvent(events) {
+		events = append(events, block.CreateFinalizeBlockEvent(b))
+	}
+	return events, nil
+}
+
+// computeBlockEvents computes the state of a copy of the block, the block may
+// be shared with the chain.
+func (c *Chain) computeBlockEvents(ctx context.Context, b, prev *block.Block) ([]event.Event, error) {
+	if prev == nil || prev.Hash != b.PrevHash {
+		return nil, block.ErrPreviousBlockUnavailable
+	}
+
+	// the state of the previous block is loaded from the state db instead of
+	// chaining the computed states in memory
+	pb := block.NewBlock(prev.ChainID, prev.Round)
+	pb.Hash = prev.Hash
+	pb.ClientStateHash = prev.ClientStateHash
+	if err := pb.InitStateDB(c.GetStateDB()); err != nil {
+		return nil, err
+	}
+
+	cb := b.Clone()
+	cb.PrevBlock = pb
+	cb.ClientState = nil
+	cb.SetStateStatus(block.StatePending)
+	if err := c.ComputeState(ctx, cb); err != nil {
+		return nil, err
+	}
+	return cb.Events, nil
+}
+
+// ReprocessBlockEvents processes the events of the finalized block into the
+// event db and commits them, the options are passed to ProcessEvents.
+func (c *Chain) ReprocessBlockEvents(ctx context.Context, b *block.Block, events []event.Event,
+	opts ...event.ProcessEventsOptionsFunc) error {
+	edb := c.GetEventDb()
+	if edb == nil {
+		return errors.New("event db is disabled")
+	}
+
+	_, count, err := edb.ProcessEvents(
+		ctx,
+		events,
+		b.Round,
+		b.Hash,
+		len(b.Txns),
+		func(event.BlockEvents) error { return nil },
+		append(opts, event.CommitNow())...)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("events of round %d not committed", b.Round)
+	}
+
+	edb.AddToEventsCounter(uint64(count))
+	return nil
+}
@@ -0,0 +1,157 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/ememorystore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayBlockEvents(t *testing.T) {
+	ch := NewChainFromConfig()
+	ch.stateCache = statecache.NewStateCache()
+	ch.stateDB = util.NewMemoryNodeDB()
+
+	var (
+		from = encryption.Hash("from")
+		to   = encryption.Hash("to")
+	)
+
+	clientState := util.NewMerklePatriciaTrie(ch.stateDB, 1, nil, statecache.NewEmpty())
+	fs := &state.State{Balance: 100}
+	fs.SetRound(1)
+	require.NoError(t, fs.SetTxnHash(encryption.Hash("mint")))
+	_, err := clientState.Insert(util.Path(from), fs)
+	require.NoError(t, err)
+	require.NoError(t, clientState.SaveChanges(context.Background(), ch.stateDB, false))
+
+	prev := block.NewBlock("", 1)
+	prev.Hash = encryption.Hash("prev")
+	prev.ClientStateHash = clientState.GetRoot()
+
+	txn := &transaction.Transaction{
+		ClientID:        from,
+		ToClientID:      to,
+		TransactionType: transaction.TxnTypeSend,
+		Value:           currency.Coin(30),
+		Nonce:           1,
+	}
+	txn.Hash = encryption.Hash(txn.ClientID + txn.ToClientID)
+
+	b := block.NewBlock("", 2)
+	b.Hash = encryption.Hash("block")
+	b.PrevHash = prev.Hash
+	b.Txns = []*transaction.Transaction{txn}
+
+	// the state hash of the block, computed on a copy
+	require.NoError(t, prev.InitStateDB(ch.stateDB))
+	cb := b.Clone()
+	bState := block.CreateStateWithPreviousBlock(prev, ch.GetStateDB(), cb.Round)
+	blockStateCache := statecache.NewBlockCache(ch.GetStateCache(), statecache.Block{
+		Round:    cb.Round,
+		PrevHash: cb.PrevHash,
+	})
+	_, err = ch.NewTxnExecutor(cb, bState, blockStateCache).UpdateState(context.Background(), cb.Txns[0])
+	require.NoError(t, err)
+	b.ClientStateHash = bState.GetRoot()
+
+	events, err := ch.ReplayBlockEvents(context.Background(), b, prev)
+	require.NoError(t, err)
+	tags := make([]event.EventTag, 0, len(events))
+	for _, e := range events {
+		tags = append(tags, e.Tag)
+	}
+	require.Contains(t, tags, event.TagAddTransactions)
+	require.Equal(t, event.TagFinalizeBlock, tags[len(tags)-1])
+
+	// the block is left untouched and nothing is persisted
+	require.Nil(t, b.ClientState)
+	require.Nil(t, b.PrevBlock)
+	require.Empty(t, b.Events)
+	require.EqualValues(t, block.StatePending, b.GetStateStatus())
+	require.Empty(t, txn.TransactionOutput)
+	_, err = ch.stateDB.GetNode(b.ClientStateHash)
+	require.ErrorIs(t, err, util.ErrNodeNotFound)
+
+	// the state of the previous block has been pruned
+	pruned := block.NewBlock("", 1)
+	pruned.Hash = prev.Hash
+	pruned.ClientStateHash = util.Key(encryption.RawHash("pruned"))
+	_, err = ch.ReplayBlockEvents(context.Background(), b, pruned)
+	require.ErrorIs(t, err, ErrBlockEventsUnavailable)
+
+	// not the previous block
+	_, err = ch.ReplayBlockEvents(context.Background(), b, b)
+	require.ErrorIs(t, err, ErrBlockEventsUnavailable)
+}
+
+func TestReplayStoredBlockEvents(t *testing.T) {
+	ch := NewChainFromConfig()
+	ch.stateCache = statecache.NewStateCache()
+	ch.stateDB = util.NewMemoryNodeDB()
+
+	db, err := ememorystore.CreateDB(t.TempDir())
+	require.NoError(t, err)
+	ememorystore.AddPool(block.BlockEventsDBName, db)
+	block.SetupBlockEventEntity(ememorystore.GetStorageProvider())
+
+	// the state of the previous block has been pruned
+	prev := block.NewBlock("", 41)
+	prev.Hash = encryption.Hash("prev")
+	prev.ClientStateHash = util.Key(encryption.RawHash("pruned"))
+	b := block.NewBlock("", 42)
+	b.Hash = encryption.Hash("block")
+	b.PrevHash = prev.Hash
+
+	_, err = ch.ReplayBlockEvents(context.Background(), b, prev)
+	require.ErrorIs(t, err, ErrBlockEventsUnavailable)
+
+	stored := []event.Event{{Type: event.TypeStats, Tag: event.TagAddOrOverwriteUser, Index: "user"}}
+	ed, err := json.Marshal(stored)
+	require.NoError(t, err)
+	require.NoError(t, ch.storeBlockEvents(&block.BlockEvents{
+		Key:    strconv.FormatInt(b.Round%int64(block.EventsRingSize), 10),
+		Round:  b.Round,
+		Events: ed,
+	}))
+
+	events, err := ch.ReplayBlockEvents(context.Background(), b, prev)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "user", events[0].Index)
+	require.Equal(t, event.TagFinalizeBlock, events[1].Tag)
+
+	// the stored events of another round of the ring are not used
+	b.Round += int64(block.EventsRingSize)
+	_, err = ch.ReplayBlockEvents(context.Background(), b, prev)
+	require.ErrorIs(t, err, ErrBlockEventsUnavailable)
+}
+
+func TestReplayGenesisBlockEvents(t *testing.T) {
+	ch := NewChainFromConfig()
+	gb := block.NewBlock("", 0)
+
+	_, err := ch.ReplayBlockEvents(context.Background(), gb, nil)
+	require.ErrorIs(t, err, ErrBlockEventsUnavailable)
+
+	ch.genesisEvents = []event.Event{{Type: event.TypeStats, Tag: event.TagAddOrOverwriteUser, Index: "user"}}
+	events, err := ch.ReplayBlockEvents(context.Background(), gb, nil)
+	require.NoError(t, err)
+	require.Equal(t, ch.genesisEvents, events)
+
+	// the genesis events are replayed as they are, the returned ones can be
+	// processed without changing them
+	events[0].Index = "other"
+	require.Equal(t, "user", ch.genesisEvents[0].Index)
+}
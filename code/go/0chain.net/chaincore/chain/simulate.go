@@ -0,0 +1,201 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+)
+
+// stateRecorder records the state keys a transaction reads, writes and deletes,
+// and keeps the latest state context of the transaction.
+type stateRecorder struct {
+	mutex   sync.Mutex
+	reads   map[string]struct{}
+	writes  map[string]struct{}
+	deletes map[string]struct{}
+	sctx    bcstate.StateContextI
+}
+
+func newStateRecorder() *stateRecorder {
+	return &stateRecorder{
+		reads:   make(map[string]struct{}),
+		writes:  make(map[string]struct{}),
+		deletes: make(map[string]struct{}),
+	}
+}
+
+// wrapState keeps the state as is, the accesses are recorded by the state context
+func (r *stateRecorder) wrapState(s util.MerklePatriciaTrieI) util.MerklePatriciaTrieI {
+	return s
+}
+
+func (r *stateRecorder) wrap(sctx bcstate.StateContextI) bcstate.StateContextI {
+	rsctx := &recordingStateContext{StateContextI: sctx, rec: r}
+	r.mutex.Lock()
+	r.sctx = rsctx
+	r.mutex.Unlock()
+	return rsctx
+}
+
+func (r *stateRecorder) record(keys map[string]struct{}, key string) {
+	r.mutex.Lock()
+	keys[key] = struct{}{}
+	r.mutex.Unlock()
+}
+
+func sortedKeys(keys map[string]struct{}) []string {
+	res := make([]string, 0, len(keys))
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// recordingStateContext is the state context of a simulated transaction,
+// every state access goes through the recorder.
+type recordingStateContext struct {
+	bcstate.StateContextI
+	rec *stateRecorder
+}
+
+func (sc *recordingStateContext) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	sc.rec.record(sc.rec.reads, key)
+	return sc.StateContextI.GetTrieNode(key, v)
+}
+
+func (sc *recordingStateContext) InsertTrieNode(key datastore.Key, v util.MPTSerializable) (datastore.Key, error) {
+	sc.rec.record(sc.rec.writes, key)
+	return sc.StateContextI.InsertTrieNode(key, v)
+}
+
+func (sc *recordingStateContext) DeleteTrieNode(key datastore.Key) (datastore.Key, error) {
+	sc.rec.record(sc.rec.deletes, key)
+	return sc.StateContextI.DeleteTrieNode(key)
+}
+
+func (sc *recordingStateContext) GetClientState(clientID datastore.Key) (*state.State, error) {
+	sc.rec.record(sc.rec.reads, clientID)
+	return sc.StateContextI.GetClientState(clientID)
+}
+
+func (sc *recordingStateContext) SetClientState(clientID datastore.Key, s *state.State) (util.Key, error) {
+	sc.rec.record(sc.rec.writes, clientID)
+	return sc.StateContextI.SetClientState(clientID, s)
+}
+
+func (sc *recordingStateContext) GetClientBalance(clientID datastore.Key) (currency.Coin, error) {
+	sc.rec.record(sc.rec.reads, clientID)
+	return sc.StateContextI.GetClientBalance(clientID)
+}
+
+// TxnSimulationState lists the state keys accessed by a simulated transaction,
+// the clients' states are listed by client id.
+type TxnSimulationState struct {
+	Reads   []string `json:"reads"`
+	Writes  []string `json:"writes"`
+	Deletes []string `json:"deletes"`
+}
+
+// TxnSimulation is the outcome of a transaction executed against the latest
+// finalized state without being persisted.
+//
+// swagger:model TxnSimulation
+type TxnSimulation struct {
+	// Round of the latest finalized block the transaction has been executed on
+	Round  int64  `json:"round"`
+	Hash   string `json:"hash"`
+	Status int    `json:"status"`
+	Output string `json:"output,omitempty"`
+	// Error is the reason the transaction would be rejected or fail
+	Error           string                  `json:"error,omitempty"`
+	Events          []event.Event           `json:"events"`
+	Transfers       []*state.Transfer       `json:"transfers"`
+	SignedTransfers []*state.SignedTransfer `json:"signed_transfers"`
+	// Mints of the approved minters, their transfers are part of the transfers
+	Mints []*state.Mint      `json:"mints"`
+	State TxnSimulationState `json:"state"`
+}
+
+// SimulateTransaction executes the transaction against a throwaway copy of the
+// state of the given finalized block, through the same path as the block
+// state computation. Nothing is persisted, the state changes are dropped along
+// with the copy. A transaction without a nonce gets the next nonce of the client.
+func (c *Chain) SimulateTransaction(ctx context.Context, lfb *block.Block,
+	txn *transaction.Transaction) (*TxnSimulation, error) {
+	if lfb.ClientState == nil {
+		return nil, errors.New("latest finalized block state not available")
+	}
+
+	if txn.Nonce == 0 {
+		s, err := GetStateById(lfb.ClientState, txn.ClientID)
+		if bcstate.ErrInvalidState(err) {
+			return nil, common.NewErrInternal("state not ready")
+		}
+		txn.Nonce = 1
+		if s != nil {
+			txn.Nonce = s.Nonce + 1
+		}
+	}
+	if txn.CreationDate == 0 {
+		txn.CreationDate = common.Now()
+	}
+
+	b := block.NewBlock(lfb.ChainID, lfb.Round+1)
+	b.PrevBlock = lfb
+	b.PrevHash = lfb.Hash
+	b.CreationDate = txn.CreationDate
+	b.MinerID = lfb.MinerID
+	b.RoundRandomSeed = lfb.RoundRandomSeed
+	b.MagicBlock = lfb.MagicBlock
+	b.LatestFinalizedMagicBlockHash = lfb.LatestFinalizedMagicBlockHash
+	b.LatestFinalizedMagicBlockRound = lfb.LatestFinalizedMagicBlockRound
+
+	var (
+		bState          = block.CreateStateWithPreviousBlock(lfb, c.GetStateDB(), b.Round)
+		blockStateCache = statecache.NewBlockCache(c.GetStateCache(), statecache.Block{
+			Round:    b.Round,
+			PrevHash: b.PrevHash,
+		})
+		rec = newStateRecorder()
+	)
+
+	res := &TxnSimulation{Round: lfb.Round, Hash: txn.Hash}
+	events, err := c.applyTxn(ctx, b, bState, txn, blockStateCache, rec)
+	if err != nil {
+		if bcstate.ErrInvalidState(err) {
+			return nil, common.NewErrInternal("state not ready")
+		}
+		res.Status = transaction.TxnFail
+		res.Error = err.Error()
+	} else {
+		res.Status = txn.Status
+		res.Output = txn.TransactionOutput
+		res.Events = events
+		if txn.Status == transaction.TxnError {
+			res.Error = txn.TransactionOutput
+		}
+
+		res.SignedTransfers = rec.sctx.GetSignedTransfers()
+		res.Transfers = rec.sctx.GetTransfers()
+		res.Mints = rec.sctx.GetMints()
+	}
+	res.State = TxnSimulationState{
+		Reads:   sortedKeys(rec.reads),
+		Writes:  sortedKeys(rec.writes),
+		Deletes: sortedKeys(rec.deletes),
+	}
+	return res, nil
+}
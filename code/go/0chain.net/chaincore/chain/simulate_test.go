@@ -0,0 +1,69 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateTransaction(t *testing.T) {
+	ch := NewChainFromConfig()
+	ch.stateCache = statecache.NewStateCache()
+
+	var (
+		from = encryption.Hash("from")
+		to   = encryption.Hash("to")
+	)
+
+	clientState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	fs := &state.State{Balance: 100}
+	fs.SetRound(1)
+	require.NoError(t, fs.SetTxnHash(encryption.Hash("mint")))
+	_, err := clientState.Insert(util.Path(from), fs)
+	require.NoError(t, err)
+
+	lfb := block.NewBlock("", 1)
+	lfb.Hash = encryption.Hash("lfb")
+	lfb.ClientState = clientState
+	lfb.ClientStateHash = clientState.GetRoot()
+
+	newTxn := func(value int64) *transaction.Transaction {
+		txn := &transaction.Transaction{
+			ClientID:        from,
+			ToClientID:      to,
+			TransactionType: transaction.TxnTypeSend,
+			Value:           currency.Coin(value),
+		}
+		txn.Hash = encryption.Hash(txn.ClientID + txn.ToClientID)
+		return txn
+	}
+
+	res, err := ch.SimulateTransaction(context.Background(), lfb, newTxn(30))
+	require.NoError(t, err)
+	require.Empty(t, res.Error)
+	require.Equal(t, transaction.TxnSuccess, res.Status)
+	require.Contains(t, res.Transfers, state.NewTransfer(from, to, 30))
+	require.Empty(t, res.Mints)
+	require.Contains(t, res.State.Writes, from)
+	require.Contains(t, res.State.Writes, to)
+
+	// nothing is persisted
+	require.Equal(t, lfb.ClientStateHash, lfb.ClientState.GetRoot())
+	s, err := GetStateById(lfb.ClientState, from)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, s.Balance)
+	require.EqualValues(t, 0, s.Nonce)
+
+	res, err = ch.SimulateTransaction(context.Background(), lfb, newTxn(300))
+	require.NoError(t, err)
+	require.Equal(t, transaction.TxnFail, res.Status)
+	require.Equal(t, "insufficient balance to send", res.Error)
+}
@@ -39,8 +39,8 @@ var EventsComputationTimer metrics.Histogram
 
 func init() {
 	SmartContractExecutionTimer = metrics.GetOrRegisterTimer("sc_execute_timer", nil)
-	StateComputationTimer = metrics.NewHistogram(metrics.NewUniformSample(1024))
-	EventsComputationTimer = metrics.NewHistogram(metrics.NewUniformSample(1024))
+	StateComputationTimer = metrics.GetOrRegisterHistogram("state_computation_time", nil, metrics.NewUniformSample(1024))
+	EventsComputationTimer = metrics.GetOrRegisterHistogram("events_computation_time", nil, metrics.NewUniformSample(1024))
 }
 
 var ErrWrongNonce = common.NewError("wrong_nonce", "nonce of sender is not valid")
@@ -135,6 +135,9 @@ func (c *Chain) ExecuteSmartContract(
 		resultC = make(chan result, 1)
 	)
 
+	ctx, span := startSmartContractSpan(ctx, txn)
+	defer span.End()
+
 	if node.Self.Type == node.NodeTypeSharder {
 		// give more times for sharders to compute state, as sharders are required to be run
 		// as full node, so each block should not be executed failed due to timeout
@@ -152,6 +155,7 @@ func (c *Chain) ExecuteSmartContract(
 		return "", transaction.ErrSmartContractContext
 	case r := <-resultC:
 		SmartContractExecutionTimer.Update(time.Since(ts))
+		span.SetError(r.err)
 		if len(balances.GetMissingNodeKeys()) > 0 {
 			if r.err == nil || !cstate.ErrInvalidState(r.err) {
 				logging.Logger.Error("execute smart contract - find missing nodes, not return from calling",
@@ -317,6 +321,15 @@ func (c *Chain) EstimateTransactionCostFee(ctx context.Context,
 
 	maxFee := c.ChainConfig.MaxTxnFee()
 
+	baseFee, err := c.GetBaseFee(b)
+	if err != nil {
+		return cost, 0, err
+	}
+	if baseFee > 0 {
+		fee, err := minersc.TxnBaseFee(baseFee, cost, c.ChainConfig.MaxTxnFee())
+		return cost, fee, err
+	}
+
 	zcn := float64(cost) / float64(c.ChainConfig.TxnCostFeeCoeff())
 	parseZCN, err := currency.ParseZCN(zcn)
 	if err != nil {
@@ -353,10 +366,26 @@ func (c *Chain) GetTransactionCostFeeTable(ctx context.Context,
 		}
 	}
 
+	baseFee, err := minersc.GetBaseFee(sctx)
+	if err != nil {
+		logging.Logger.Error("fees table - can't get base fee", zap.Error(err))
+	}
+
 	fees := make(map[string]map[string]int64)
 	for sc, t := range table {
 		fees[sc] = make(map[string]int64, len(t))
 		for f, cost := range t {
+			if baseFee > 0 {
+				fee, err := minersc.TxnBaseFee(baseFee, cost, c.ChainConfig.MaxTxnFee())
+				if err != nil {
+					logging.Logger.Error("fees table - can't get base fee of the function",
+						zap.String("sc", sc), zap.String("function", f), zap.Error(err))
+					continue
+				}
+				fees[sc][f] = int64(fee)
+				continue
+			}
+
 			zcn := float64(cost) / float64(c.ChainConfig.TxnCostFeeCoeff())
 			parseZCN, err := currency.ParseZCN(zcn)
 			if err != nil {
@@ -376,6 +405,21 @@ func (c *Chain) GetTransactionCostFeeTable(ctx context.Context,
 	return fees
 }
 
+// GetBaseFee returns the base fee per unit of cost at the state of the block,
+// 0 if the base fee is disabled
+func (c *Chain) GetBaseFee(b *block.Block) (currency.Coin, error) {
+	return minersc.GetBaseFee(c.GetBlockStateContext(b, nil))
+}
+
+// GetBlockStateContext returns a state context to query the state of the block,
+// changes are never saved.
+func (c *Chain) GetBlockStateContext(b *block.Block, eventDb *event.EventDb) *bcstate.StateContext {
+	qbc := statecache.NewQueryBlockCache(c.GetStateCache(), b.Hash)
+	tbc := statecache.NewTransactionCache(qbc)
+	clientState := CreateTxnMPT(b.ClientState, tbc) // begin transaction
+	return c.NewStateContext(b, clientState, &transaction.Transaction{}, eventDb)
+}
+
 // NewStateContext creation helper.
 func (c *Chain) NewStateContext(
 	b *block.Block,
@@ -400,25 +444,47 @@ func (c *Chain) updateState(ctx context.Context,
 	bState util.MerklePatriciaTrieI,
 	txn *transaction.Transaction,
 	blockStateCache *statecache.BlockCache,
-	waitC ...chan struct{}) (es []event.Event, err error) {
-	// check if the block's ClientState has root value
-	_, err = bState.GetNodeDB().GetNode(bState.GetRoot())
-	if err != nil {
-		return nil, common.NewErrorf("update_state_failed",
-			"block state root is incorrect, err: %v, block hash: %v, state hash: %v, root: %v, round: %d",
-			err, b.Hash, util.ToHex(b.ClientStateHash), util.ToHex(bState.GetRoot()), b.Round)
-	}
+	waitC ...chan struct{}) ([]event.Event, error) {
+	return c.applyTxn(ctx, b, bState, txn, blockStateCache, nil, waitC...)
+}
 
-	if txn.Value > config.MaxTokenSupply {
-		return nil, errors.New("invalid transaction value, exceeds max token supply")
+// txnRecorder records the state accessed by a transaction applied with applyTxn.
+type txnRecorder interface {
+	wrapState(s util.MerklePatriciaTrieI) util.MerklePatriciaTrieI
+	wrap(sctx bcstate.StateContextI) bcstate.StateContextI
+}
+
+// applyTxn executes the transaction against the block state, the recorder,
+// when given, records the state the transaction accesses and its transfers.
+func (c *Chain) applyTxn(ctx context.Context,
+	b *block.Block,
+	bState util.MerklePatriciaTrieI,
+	txn *transaction.Transaction,
+	blockStateCache *statecache.BlockCache,
+	rec txnRecorder,
+	waitC ...chan struct{}) (es []event.Event, err error) {
+	if err = checkBlockState(b, bState); err != nil {
+		return nil, err
 	}
 
 	var (
-		txnStateCache = statecache.NewTransactionCache(blockStateCache)
-		clientState   = CreateTxnMPT(bState, txnStateCache) // begin transaction
-		sctx          = c.NewStateContext(b, clientState, txn, nil)
-		startRoot     = sctx.GetState().GetRoot()
+		txnStateCache *statecache.TransactionCache
+		clientState   util.MerklePatriciaTrieI
+		sctx          bcstate.StateContextI
 	)
+	newTxnState := func() bcstate.StateContextI {
+		txnStateCache = statecache.NewTransactionCache(blockStateCache)
+		clientState = CreateTxnMPT(bState, txnStateCache) // begin transaction
+		if rec != nil {
+			clientState = rec.wrapState(clientState)
+		}
+		sctx = c.NewStateContext(b, clientState, txn, nil)
+		if rec != nil {
+			sctx = rec.wrap(sctx)
+		}
+		return sctx
+	}
+	newTxnState()
 
 	defer func() {
 		if err == nil {
@@ -431,13 +497,47 @@ func (c *Chain) updateState(ctx context.Context,
 		}
 	}()
 
-	if err = c.validateNonce(sctx, txn.ClientID, txn.Nonce); err != nil {
+	if sctx, err = c.executeTxn(ctx, sctx, newTxnState); err != nil {
 		return nil, err
 	}
 
+	return c.finalizeTxn(bState, clientState, sctx)
+}
+
+// checkBlockState checks if the block's ClientState has root value
+func checkBlockState(b *block.Block, bState util.MerklePatriciaTrieI) error {
+	_, err := bState.GetNodeDB().GetNode(bState.GetRoot())
+	if err != nil {
+		return common.NewErrorf("update_state_failed",
+			"block state root is incorrect, err: %v, block hash: %v, state hash: %v, root: %v, round: %d",
+			err, b.Hash, util.ToHex(b.ClientStateHash), util.ToHex(bState.GetRoot()), b.Round)
+	}
+	return nil
+}
+
+// executeTxn runs the transaction itself, the send or the smart contract, on
+// the transaction state. A smart contract failing with a chargeable error gets
+// its changes dropped by a new transaction state, which is returned.
+func (c *Chain) executeTxn(ctx context.Context, sctx bcstate.StateContextI,
+	newTxnState func() bcstate.StateContextI) (bcstate.StateContextI, error) {
+	var (
+		b   = sctx.GetBlock()
+		txn = sctx.GetTransaction()
+	)
+
+	if txn.Value > config.MaxTokenSupply {
+		return sctx, errors.New("invalid transaction value, exceeds max token supply")
+	}
+
+	startRoot := sctx.GetState().GetRoot()
+
+	if err := c.validateNonce(sctx, txn.ClientID, txn.Nonce); err != nil {
+		return sctx, err
+	}
+
 	// checks if the client has enough funds to pay for transaction before heavy computations are executed
-	if err = sctx.Validate(); err != nil {
-		return nil, err
+	if err := sctx.Validate(); err != nil {
+		return sctx, err
 	}
 
 	switch txn.TransactionType {
@@ -456,7 +556,7 @@ func (c *Chain) updateState(ctx context.Context,
 				zap.Duration("time_spent", time.Since(t)),
 				zap.Any("txn", txn))
 			//return original error, to handle upwards
-			return nil, err
+			return sctx, err
 		case context.Canceled:
 			logging.Logger.Debug("Error executing the SC, internal error",
 				zap.Error(err),
@@ -467,7 +567,7 @@ func (c *Chain) updateState(ctx context.Context,
 				zap.Duration("time_spent", time.Since(t)),
 				zap.Any("txn", txn))
 			//return original error, to handle upwards
-			return nil, err
+			return sctx, err
 		default:
 			if err != nil {
 				if bcstate.ErrInvalidState(err) {
@@ -479,7 +579,7 @@ func (c *Chain) updateState(ctx context.Context,
 						zap.String("prev block", b.PrevBlock.Hash),
 						zap.Duration("time_spent", time.Since(t)),
 						zap.Any("txn", txn))
-					return nil, err
+					return sctx, err
 				}
 
 				logging.Logger.Debug("Error executing the SC, chargeable error",
@@ -492,9 +592,7 @@ func (c *Chain) updateState(ctx context.Context,
 					zap.Any("txn", txn))
 
 				//refresh client state context, so all changes made by broken smart contract are rejected, it will be used to add fee
-				txnStateCache = statecache.NewTransactionCache(blockStateCache)
-				clientState = CreateTxnMPT(bState, txnStateCache) // begin transaction
-				sctx = c.NewStateContext(b, clientState, txn, nil)
+				sctx = newTxnState()
 				// records chargeable error event
 				sctx.EmitError(err)
 
@@ -503,11 +601,13 @@ func (c *Chain) updateState(ctx context.Context,
 			}
 		}
 		txn.TransactionOutput = output
+		startToFinalizeTxnTypeTimerMutex.Lock()
 		if _, ok := StartToFinalizeTxnTypeTimer[txn.FunctionName]; !ok {
 			StartToFinalizeTxnTypeTimer[txn.FunctionName] = metrics.GetOrRegisterTimer(txn.FunctionName, nil)
 		}
 		StartToFinalizeTxnTypeTimer[txn.FunctionName].Update(time.Since(t))
-		mptCacheHits, mptCacheMiss := txnStateCache.Stats()
+		startToFinalizeTxnTypeTimerMutex.Unlock()
+		mptCacheHits, mptCacheMiss := sctx.Cache().Stats()
 		logging.Logger.Info("SC executed",
 			zap.String("client id", txn.ClientID),
 			zap.String("block", b.Hash),
@@ -528,11 +628,11 @@ func (c *Chain) updateState(ctx context.Context,
 		// check src balance
 		balance, err := sctx.GetClientBalance(txn.ClientID)
 		if err != nil {
-			return nil, err
+			return sctx, err
 		}
 
 		if balance < txn.Fee+txn.Value {
-			return nil, errors.New("insufficient balance to send")
+			return sctx, errors.New("insufficient balance to send")
 		}
 
 		err = sctx.AddTransfer(state.NewTransfer(txn.ClientID, txn.ToClientID, txn.Value))
@@ -543,15 +643,27 @@ func (c *Chain) updateState(ctx context.Context,
 				zap.String("minersc_address", minersc.ADDRESS),
 				zap.Any("state_balance", txn.Fee),
 				zap.Any("current_root", sctx.GetState().GetRoot()))
-			return nil, err
+			return sctx, err
 		}
 	default:
 		logging.Logger.Error("Invalid transaction type", zap.Int("txn type", txn.TransactionType))
-		return nil, fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
+		return sctx, fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
 	}
 
+	return sctx, nil
+}
+
+// finalizeTxn pays the fee, processes the transfers and the nonce of the
+// executed transaction and merges its state into the block state.
+func (c *Chain) finalizeTxn(bState, clientState util.MerklePatriciaTrieI,
+	sctx bcstate.StateContextI) ([]event.Event, error) {
+	var (
+		b   = sctx.GetBlock()
+		txn = sctx.GetTransaction()
+	)
+
 	if c.ChainConfig.IsFeeEnabled() {
-		err = sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.Fee))
+		err := sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.Fee))
 		if err != nil {
 			logging.Logger.Error("Failed to add transfer",
 				zap.Int("txn type", txn.TransactionType),
@@ -611,6 +723,11 @@ func (c *Chain) updateState(ctx context.Context,
 		c.emitUserEvent(sctx, e)
 	}
 
+	res, err := c.txnResult(sctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
 	// commit transaction
 	if err = bState.MergeMPTChanges(clientState); err != nil {
 		if state.DebugTxn() {
@@ -628,9 +745,34 @@ func (c *Chain) updateState(ctx context.Context,
 		txn.Status = transaction.TxnSuccess
 	}
 
+	b.SetTxnResult(txn.Hash, res)
 	return sctx.GetEvents(), nil
 }
 
+// txnResult collects the outcome of the executed transaction for its receipt,
+// the results hash is part of the receipt from the hermes hardfork.
+func (c *Chain) txnResult(sctx bcstate.StateContextI, txn *transaction.Transaction) (*block.TxnResult, error) {
+	res := &block.TxnResult{
+		Events:    sctx.GetEvents(),
+		Transfers: sctx.GetTransfers(),
+		Mints:     sctx.GetMints(),
+	}
+	if err := bcstate.WithActivation(sctx, "hermes", func() error { return nil }, func() error {
+		res.Hashed = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if c.ChainConfig.IsFeeEnabled() {
+		res.Fee = txn.Fee
+	}
+	for _, st := range sctx.GetSignedTransfers() {
+		t := st.Transfer
+		res.Transfers = append(res.Transfers, &t)
+	}
+	return res, nil
+}
+
 func sumOfFromToBalance(sctx bcstate.StateContextI, from, to string) (currency.Coin, error) {
 	ofb, err := sctx.GetClientBalance(from)
 	if err != nil && err != util.ErrValueNotPresent {
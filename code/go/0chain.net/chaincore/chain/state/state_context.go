@@ -53,6 +53,16 @@ func GetMinter(minter ApprovedMinter) (string, error) {
 	return approvedMinters[minter], nil
 }
 
+// IsApprovedMinter reports whether the client is one of the approved minters.
+func IsApprovedMinter(clientID string) bool {
+	for _, id := range approvedMinters {
+		if id == clientID {
+			return true
+		}
+	}
+	return false
+}
+
 /*
 * The state context is available to the smart contract logic.
 * The smart contract logic can use
@@ -102,7 +112,9 @@ type StateContextI interface {
 	DeleteTrieNode(key datastore.Key) (datastore.Key, error)
 	AddTransfer(t *state.Transfer) error
 	AddSignedTransfer(st *state.SignedTransfer)
+	AddMint(m *state.Mint) error
 	GetTransfers() []*state.Transfer // cannot use in smart contracts or REST endpoints
+	GetMints() []*state.Mint         // cannot use in smart contracts or REST endpoints
 	GetSignedTransfers() []*state.SignedTransfer
 	Validate() error
 	GetSignatureScheme() encryption.SignatureScheme
@@ -122,6 +134,7 @@ type StateContext struct {
 	txn             *transaction.Transaction
 	transfers       []*state.Transfer
 	signedTransfers []*state.SignedTransfer
+	mints           []*state.Mint
 	events          []event.Event
 	// clientStates is the cache for storing client states, usually for storing txn.From and txn.To
 	clientStates                  map[string]*state.State
@@ -219,6 +232,22 @@ func (sc *StateContext) AddSignedTransfer(st *state.SignedTransfer) {
 	sc.signedTransfers = append(sc.signedTransfers, st)
 }
 
+// AddMint - add the mint of an approved minter, the minted tokens are
+// transferred from the minter to the client
+func (sc *StateContext) AddMint(m *state.Mint) error {
+	if !IsApprovedMinter(m.Minter) {
+		return state.ErrInvalidMint
+	}
+	if err := sc.AddTransfer(state.NewTransfer(m.Minter, m.ToClientID, m.Amount)); err != nil {
+		return err
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.mints = append(sc.mints, m)
+	return nil
+}
+
 // GetTransfers - get all the transfers
 func (sc *StateContext) GetTransfers() []*state.Transfer {
 	return sc.transfers
@@ -229,6 +258,11 @@ func (sc *StateContext) GetSignedTransfers() []*state.SignedTransfer {
 	return sc.signedTransfers
 }
 
+// GetMints - get all the mints
+func (sc *StateContext) GetMints() []*state.Mint {
+	return sc.mints
+}
+
 func (sc *StateContext) EmitEvent(eventType event.EventType, tag event.EventTag, index string, data interface{}, appenders ...Appender) {
 	sc.EmitEventWithVersion(event.Version1, eventType, tag, index, data, appenders...)
 }
@@ -14,17 +14,17 @@ import (
 
 	"0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/smartcontract"
-	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/govsc"
+	"0chain.net/smartcontract/graphqlapi"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
 	"0chain.net/smartcontract/zcnsc"
 	"github.com/0chain/common/core/logging"
-	"github.com/0chain/common/core/statecache"
 	"github.com/0chain/common/core/util"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/tinylib/msgp/msgp"
@@ -50,11 +50,12 @@ func SetupScRestApiHandlers() {
 	SetupSwagger()
 	if c.EventDb != nil {
 		faucetsc.SetupRestHandler(restHandler)
+		govsc.SetupRestHandler(restHandler)
 		minersc.SetupRestHandler(restHandler)
 		storagesc.SetupRestHandler(restHandler)
 		vestingsc.SetupRestHandler(restHandler)
 		zcnsc.SetupRestHandler(restHandler)
-
+		graphqlapi.SetupRestHandler(restHandler)
 	} else {
 		logging.Logger.Warn("cannot find event database, REST API will not be supported on this sharder")
 	}
@@ -64,6 +65,7 @@ func SetupScRestApiHandlers() {
 func SetupSharderStateHandlers() {
 	c := GetServerChain()
 	http.HandleFunc("/v1/client/get/balance", common.WithCORS(common.UserRateLimit(common.ToJSONResponse(c.GetBalanceHandler))))
+	http.HandleFunc("/v1/state/proof", common.WithCORS(common.UserRateLimit(common.ToJSONResponse(c.GetStateProofHandler))))
 	http.HandleFunc("/v1/current-round", common.WithCORS(common.UserRateLimit(common.ToJSONResponse(c.GetCurrentRoundHandler))))
 	http.HandleFunc("/v1/scstats/", common.WithCORS(common.UserRateLimit(c.GetSCStats)))
 	http.HandleFunc("/v1/screst/", common.WithCORS(common.UserRateLimit(c.HandleSCRest)))
@@ -94,10 +96,7 @@ func (c *Chain) GetStateContextI() state.StateContextI {
 		logging.Logger.Error("empty latest finalized block or state")
 		return nil
 	}
-	qbc := statecache.NewQueryBlockCache(c.GetStateCache(), lfb.Hash)
-	tbc := statecache.NewTransactionCache(qbc)
-	clientState := CreateTxnMPT(lfb.ClientState, tbc) // begin transaction
-	return c.NewStateContext(lfb, clientState, &transaction.Transaction{}, c.GetEventDb())
+	return c.GetBlockStateContext(lfb, c.GetEventDb())
 }
 
 func (c *Chain) HandleSCRest(w http.ResponseWriter, r *http.Request) {
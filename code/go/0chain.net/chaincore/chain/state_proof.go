@@ -0,0 +1,157 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// StateProofResponse is the value stored in the state of a finalized block along
+// with the proof of it against the state hash of the block.
+//
+// swagger:model StateProofResponse
+type StateProofResponse struct {
+	Round     int64  `json:"round"`
+	BlockHash string `json:"block_hash"`
+	// StateHash is the hex encoded client state hash of the block
+	StateHash string `json:"state_hash"`
+	// VerificationTickets notarizing the block hash. The state hash is not part
+	// of the block hash, it should be checked against other sharders.
+	VerificationTickets []*block.VerificationTicket `json:"verification_tickets"`
+	// Value is the JSON decoded value, empty when there is no value for the key
+	Value interface{}  `json:"value,omitempty"`
+	Proof *state.Proof `json:"proof"`
+}
+
+// GetStateProofHandler - get a value of the state with its merkle patricia trie proof
+// swagger:route GET /v1/state/proof sharder GetStateProof
+// Get state proof.
+// Retrieves a value from the state of a finalized block, either the state of a client
+// or a smart contract state key, with the trie nodes needed to verify it against the
+// state hash of the block. A missing value is proven the same way, with no value.
+//
+// parameters:
+//    +name: client_id
+//      in: query
+//      required: false
+//      type: string
+//      description: Client ID, to get the client state (balance and nonce)
+//    +name: sc_address
+//      in: query
+//      required: false
+//      type: string
+//      description: Smart contract address, along with key to get a smart contract state value
+//    +name: key
+//      in: query
+//      required: false
+//      type: string
+//      description: Smart contract state key, without the smart contract address
+//    +name: block
+//      in: query
+//      required: false
+//      type: string
+//      description: Block hash, the latest finalized block by default
+//
+// responses:
+//   200: StateProofResponse
+//   400:
+func (c *Chain) GetStateProofHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	var (
+		clientID  = r.FormValue("client_id")
+		scAddress = r.FormValue("sc_address")
+		key       = r.FormValue("key")
+		path      util.Path
+		decode    func([]byte) (interface{}, error)
+	)
+	switch {
+	case clientID != "" && (scAddress != "" || key != ""):
+		return nil, common.InvalidRequest("either client_id or sc_address and key is required, not both")
+	case clientID != "":
+		path = util.Path(clientID)
+		decode = decodeClientStateValue
+	case scAddress != "" && key != "":
+		path = util.Path(encryption.Hash(scAddress + key))
+		decode = decodeSCStateValue
+	default:
+		return nil, common.InvalidRequest("client_id or sc_address and key is required")
+	}
+
+	var b *block.Block
+	if hash := r.FormValue("block"); hash != "" {
+		var err error
+		if b, err = c.GetBlock(ctx, hash); err != nil {
+			return nil, err
+		}
+		if !b.IsBlockFinalised() {
+			return nil, common.InvalidRequest("block is not finalized")
+		}
+	} else {
+		b = c.GetLatestFinalizedBlock()
+	}
+	if b == nil || b.ClientState == nil {
+		return nil, common.NewError("get_state_proof", "block state doesn't exist")
+	}
+
+	rsp, err := c.GetStateProof(b, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(rsp.Proof.Value) > 0 {
+		if rsp.Value, err = decode(rsp.Proof.Value); err != nil {
+			return nil, common.NewErrorf("decode error", "decode state value failed: %v", err)
+		}
+	}
+	return rsp, nil
+}
+
+// GetStateProof returns the value at the path of the state of the block with its proof.
+func (c *Chain) GetStateProof(b *block.Block, path util.Path) (*StateProofResponse, error) {
+	c.stateMutex.RLock()
+	proof, err := state.GetProof(b.ClientState, path)
+	c.stateMutex.RUnlock()
+	if err != nil {
+		if err == state.ErrProofInvalidPath {
+			return nil, common.InvalidRequest(err.Error())
+		}
+		return nil, common.NewError("get_state_proof", err.Error())
+	}
+
+	return &StateProofResponse{
+		Round:               b.Round,
+		BlockHash:           b.Hash,
+		StateHash:           util.ToHex(b.ClientStateHash),
+		VerificationTickets: b.GetVerificationTickets(),
+		Proof:               proof,
+	}, nil
+}
+
+func decodeClientStateValue(d []byte) (interface{}, error) {
+	s := &state.State{}
+	if err := s.Decode(d); err != nil {
+		return nil, err
+	}
+	if err := s.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func decodeSCStateValue(d []byte) (interface{}, error) {
+	buf := &bytes.Buffer{}
+	if _, err := msgp.UnmarshalAsJSON(buf, d); err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.NewDecoder(buf).Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,60 @@
+package chain
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStateProof(t *testing.T) {
+	ch := NewChainFromConfig()
+	clientID := encryption.Hash("client")
+
+	clientState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	s := &state.State{Balance: 100, Nonce: 3}
+	s.SetRound(1)
+	require.NoError(t, s.SetTxnHash(encryption.Hash("txn")))
+	_, err := clientState.Insert(util.Path(clientID), s)
+	require.NoError(t, err)
+
+	b := block.NewBlock("", 1)
+	b.Hash = encryption.Hash("block")
+	b.ClientState = clientState
+	b.ClientStateHash = clientState.GetRoot()
+
+	rsp, err := ch.GetStateProof(b, util.Path(clientID))
+	require.NoError(t, err)
+	require.Equal(t, b.Hash, rsp.BlockHash)
+	require.Equal(t, util.ToHex(b.ClientStateHash), rsp.StateHash)
+
+	value, err := rsp.Proof.Verify(b.ClientStateHash)
+	require.NoError(t, err)
+	got := &state.State{}
+	_, err = got.UnmarshalMsg(value)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, got.Balance)
+	require.EqualValues(t, 3, got.Nonce)
+
+	v, err := decodeClientStateValue(value)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, v.(*state.State).Balance)
+
+	rsp, err = ch.GetStateProof(b, util.Path("xyz"))
+	require.Nil(t, rsp)
+	require.Contains(t, err.Error(), "invalid_request")
+}
+
+func TestGetStateProofHandlerParams(t *testing.T) {
+	ch := NewChainFromConfig()
+	for _, q := range []string{"", "?sc_address=abc", "?client_id=abc&key=def"} {
+		_, err := ch.GetStateProofHandler(context.Background(), httptest.NewRequest("GET", "/v1/state/proof"+q, nil))
+		require.Error(t, err, q)
+	}
+}
@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/trace"
+	"0chain.net/core/viper"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// SetupTracing starts the tracing of the node spans when enabled by the configuration
+func SetupTracing(workdir string) {
+	if !viper.GetBool("trace.enabled") {
+		return
+	}
+
+	self := node.Self.Underlying()
+	cfg := trace.Config{
+		Resource: trace.Resource{
+			ServiceName: "0chain-" + strings.ToLower(self.GetNodeTypeName()),
+			NodeID:      self.GetKey(),
+		},
+		SampleRatio:  viper.GetFloat64("trace.sample_ratio"),
+		Exporter:     viper.GetString("trace.exporter"),
+		File:         filepath.Join(workdir, viper.GetString("trace.file")),
+		OTLPEndpoint: viper.GetString("trace.otlp_endpoint"),
+	}
+	if err := trace.Setup(cfg); err != nil {
+		logging.Logger.Panic("setup tracing", zap.Error(err))
+	}
+	logging.Logger.Info("tracing enabled",
+		zap.String("exporter", cfg.Exporter),
+		zap.Float64("sample_ratio", cfg.SampleRatio))
+}
+
+// StartBlockSpan starts a span of a block lifecycle stage in the trace of the block round
+func StartBlockSpan(ctx context.Context, name string, b *block.Block) (context.Context, *trace.Span) {
+	attrs := []trace.Attribute{
+		trace.Int64("round", b.Round),
+		trace.String("miner", b.MinerID),
+	}
+	if b.Hash != "" {
+		attrs = append(attrs, trace.String("block", b.Hash))
+	}
+	return trace.Start(trace.WithRound(ctx, b.Round), name, trace.WithAttributes(attrs...))
+}
+
+// startSmartContractSpan starts the span of a smart contract execution
+func startSmartContractSpan(ctx context.Context, txn *transaction.Transaction) (context.Context, *trace.Span) {
+	if !trace.Enabled() {
+		return ctx, nil
+	}
+
+	attrs := []trace.Attribute{
+		trace.String("txn", txn.Hash),
+		trace.String("smart_contract", txn.ToClientID),
+	}
+	var scData sci.SmartContractTransactionData
+	if err := json.Unmarshal([]byte(txn.TransactionData), &scData); err == nil {
+		attrs = append(attrs, trace.String("function", scData.FunctionName))
+	}
+	return trace.Start(ctx, "execute_smart_contract", trace.WithAttributes(attrs...))
+}
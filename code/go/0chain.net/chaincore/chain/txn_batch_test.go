@@ -0,0 +1,92 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/core/memorystore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutTransactionsBatch(t *testing.T) {
+	common.SetupRootContext(node.GetNodeContext())
+	transaction.SetupEntity(memorystore.GetStorageProvider())
+
+	scheme := encryption.NewBLS0ChainScheme()
+	require.NoError(t, scheme.GenerateKeys())
+
+	var raw []json.RawMessage
+	for nonce := 1; nonce <= 3; nonce++ {
+		raw = append(raw, json.RawMessage(fmt.Sprintf(
+			`{"hash":"h%d","public_key":%q,"transaction_nonce":%d}`, nonce, scheme.GetPublicKey(), nonce)))
+	}
+	raw = append(raw,
+		json.RawMessage(`{"hash":"h4"}`),            // no public key
+		json.RawMessage(`{"hash":"h5","nonce":"x"`), // invalid json
+	)
+
+	put := func(ctx context.Context, entity datastore.Entity) (interface{}, error) {
+		txn := entity.(*transaction.Transaction)
+		if txn.Nonce == 2 {
+			return nil, errors.New("invalid transaction nonce")
+		}
+		return txn, nil
+	}
+
+	rsp := putTransactionsBatch(context.Background(), raw, put)
+	require.Equal(t, 2, rsp.Accepted)
+	require.Equal(t, 3, rsp.Rejected)
+	require.Len(t, rsp.Results, len(raw))
+
+	for i, res := range rsp.Results {
+		require.Equal(t, i, res.Index)
+	}
+	require.True(t, rsp.Results[0].Accepted)
+	require.Equal(t, "invalid transaction nonce", rsp.Results[1].Error)
+	require.True(t, rsp.Results[2].Accepted)
+	require.Equal(t, "h4", rsp.Results[3].Hash)
+	require.Equal(t, transaction.ErrTxnMissingPublicKey.Error(), rsp.Results[3].Error)
+	require.Contains(t, rsp.Results[4].Error, "error decoding json")
+}
+
+func TestPutTransactionsBatchHandlerLimits(t *testing.T) {
+	tt := []struct {
+		name string
+		body string
+		err  string
+	}{
+		{name: "not an array", body: `{}`, err: "invalid transactions batch"},
+		{name: "empty", body: `[]`, err: "empty transactions batch"},
+		{
+			name: "too many",
+			body: "[" + strings.TrimSuffix(strings.Repeat("{},", MaxTxnBatchSize+1), ",") + "]",
+			err:  "too many transactions in the batch",
+		},
+		{
+			name: "too large",
+			body: `["` + strings.Repeat("a", MaxTxnBatchBytes) + `"]`,
+			err:  "request body too large",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/transaction/put/batch", strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", "application/json")
+			_, err := PutTransactionsBatchHandler(context.Background(), r)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
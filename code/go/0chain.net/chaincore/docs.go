@@ -37,7 +37,8 @@ type BlockFeeStatsResponse struct {
 
 // swagger:model
 type TxnFeeResponse struct {
-	Fee string `json:"fee"`
+	Fee     string `json:"fee"`
+	BaseFee string `json:"base_fee"`
 }
 
 // swagger:model FeesTableResponse
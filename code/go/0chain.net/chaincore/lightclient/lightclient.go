@@ -0,0 +1,222 @@
+// Package lightclient follows the finalized blocks of the chain without
+// trusting any single sharder. Starting from a trusted magic block it verifies
+// the notarization of the block headers with the miners' keys, follows the
+// magic block transitions and verifies the state proofs against the state
+// hash of the verified blocks.
+//
+// The client state hash is not part of the block hash the miners sign, so the
+// state hash of a block is only accepted once enough sharders agree on it.
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"0chain.net/chaincore/block"
+)
+
+const (
+	// DefaultThresholdByCount is the default percentage of the miners that
+	// notarize a block, matching server_chain.block.consensus.threshold_by_count.
+	DefaultThresholdByCount = 66
+	// DefaultViewChangeOffset is the default number of rounds a magic block
+	// takes to be used after its starting round, matching chain.ViewChangeOffset.
+	DefaultViewChangeOffset = 4
+	// DefaultTimeout of the requests to the sharders.
+	DefaultTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNoTickets is returned when a block has no verification tickets
+	ErrNoTickets = errors.New("no verification tickets")
+	// ErrDuplicateTicket is returned when a verifier signed a block more than once
+	ErrDuplicateTicket = errors.New("duplicate verification ticket")
+	// ErrUnknownVerifier is returned when a ticket is not signed by a miner of the magic block
+	ErrUnknownVerifier = errors.New("verifier is not a miner of the magic block")
+	// ErrInvalidSignature is returned when a ticket signature doesn't verify
+	ErrInvalidSignature = errors.New("invalid verification ticket signature")
+	// ErrNotNotarized is returned when the tickets don't reach the notarization threshold
+	ErrNotNotarized = errors.New("block not notarized")
+	// ErrHashMismatch is returned when a block doesn't hash to its hash
+	ErrHashMismatch = errors.New("block hash mismatch")
+	// ErrInvalidMagicBlock is returned when a magic block doesn't follow the latest known one
+	ErrInvalidMagicBlock = errors.New("invalid magic block")
+	// ErrNoQuorum is returned when not enough sharders agree on a state hash
+	ErrNoQuorum = errors.New("not enough sharders agree on the state hash")
+)
+
+// Config of the light client.
+type Config struct {
+	// Sharders are the base URLs of the sharders to query
+	Sharders []string
+	// ThresholdByCount is the percentage of the miners notarizing a block
+	ThresholdByCount int
+	// ViewChangeOffset is the number of rounds a magic block takes to be used
+	ViewChangeOffset int64
+	// StateQuorum is the number of sharders that have to agree on the state
+	// hash of a block, a majority of the sharders by default
+	StateQuorum int
+	// Timeout of the requests to the sharders
+	Timeout time.Duration
+}
+
+// Client is a light client of the chain.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mutex       sync.RWMutex
+	magicBlocks []*block.MagicBlock // ordered by the magic block number
+	latest      *block.Header
+}
+
+// New creates a light client trusting the given magic block.
+func New(cfg Config, trusted *block.MagicBlock) (*Client, error) {
+	if trusted == nil || trusted.Miners == nil || trusted.Miners.Size() == 0 {
+		return nil, fmt.Errorf("%w: no miners in the trusted magic block", ErrInvalidMagicBlock)
+	}
+	if trusted.Hash == "" {
+		trusted.Hash = trusted.GetHash()
+	}
+	if cfg.ThresholdByCount <= 0 {
+		cfg.ThresholdByCount = DefaultThresholdByCount
+	}
+	if cfg.ViewChangeOffset <= 0 {
+		cfg.ViewChangeOffset = DefaultViewChangeOffset
+	}
+	if cfg.StateQuorum <= 0 {
+		cfg.StateQuorum = len(cfg.Sharders)/2 + 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Client{
+		cfg:         cfg,
+		httpClient:  &http.Client{Timeout: cfg.Timeout},
+		magicBlocks: []*block.MagicBlock{trusted},
+	}, nil
+}
+
+// LatestMagicBlock returns the latest verified magic block.
+func (c *Client) LatestMagicBlock() *block.MagicBlock {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.magicBlocks[len(c.magicBlocks)-1]
+}
+
+// Latest returns the latest verified finalized block header, nil before the
+// first sync.
+func (c *Client) Latest() *block.Header {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.latest
+}
+
+// MagicBlock returns the magic block the miners of the round are taken from.
+func (c *Client) MagicBlock(round int64) *block.MagicBlock {
+	if round > c.cfg.ViewChangeOffset {
+		round -= c.cfg.ViewChangeOffset
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	i := sort.Search(len(c.magicBlocks), func(i int) bool {
+		return c.magicBlocks[i].StartingRound > round
+	})
+	if i == 0 {
+		return c.magicBlocks[0]
+	}
+	return c.magicBlocks[i-1]
+}
+
+// VerifyNotarization verifies the tickets notarize the block hash of the round.
+func (c *Client) VerifyNotarization(hash string, round int64, tickets []*block.VerificationTicket) error {
+	if len(tickets) == 0 {
+		return ErrNoTickets
+	}
+
+	var (
+		mb        = c.MagicBlock(round)
+		threshold = int(math.Ceil(float64(mb.Miners.Size()) * float64(c.cfg.ThresholdByCount) / 100))
+		verifiers = make(map[string]struct{}, len(tickets))
+	)
+	for _, vt := range tickets {
+		if vt == nil {
+			return ErrNoTickets
+		}
+		if _, ok := verifiers[vt.VerifierID]; ok {
+			return ErrDuplicateTicket
+		}
+		verifiers[vt.VerifierID] = struct{}{}
+
+		miner := mb.Miners.GetNode(vt.VerifierID)
+		if miner == nil {
+			return ErrUnknownVerifier
+		}
+		if ok, err := miner.Verify(vt.Signature, hash); err != nil || !ok {
+			return ErrInvalidSignature
+		}
+	}
+
+	if len(verifiers) < threshold {
+		return fmt.Errorf("%w: %d of %d tickets", ErrNotNotarized, len(verifiers), threshold)
+	}
+	return nil
+}
+
+// VerifyHeader verifies the header hashes to its hash and the tickets notarize it.
+func (c *Client) VerifyHeader(h *block.Header, tickets []*block.VerificationTicket) error {
+	if h.ComputeHash() != h.Hash {
+		return ErrHashMismatch
+	}
+	return c.VerifyNotarization(h.Hash, h.Round, tickets)
+}
+
+// AddMagicBlock verifies the block carrying the next magic block and adds it
+// to the known magic blocks.
+func (c *Client) AddMagicBlock(b *block.Block) error {
+	mb := b.MagicBlock
+	if mb == nil {
+		return fmt.Errorf("%w: block has no magic block", ErrInvalidMagicBlock)
+	}
+	if mb.Hash == "" || mb.Hash != mb.GetHash() {
+		return fmt.Errorf("%w: magic block hash mismatch", ErrInvalidMagicBlock)
+	}
+
+	latest := c.LatestMagicBlock()
+	if mb.MagicBlockNumber != latest.MagicBlockNumber+1 ||
+		mb.PreviousMagicBlockHash != latest.Hash ||
+		mb.StartingRound <= latest.StartingRound {
+		return fmt.Errorf("%w: magic block %d doesn't follow %d", ErrInvalidMagicBlock,
+			mb.MagicBlockNumber, latest.MagicBlockNumber)
+	}
+	if mb.Miners == nil || mb.Miners.Size() == 0 {
+		return fmt.Errorf("%w: no miners", ErrInvalidMagicBlock)
+	}
+
+	if err := c.VerifyHeader(b.GetHeader(), b.GetVerificationTickets()); err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.magicBlocks[len(c.magicBlocks)-1] != latest {
+		return fmt.Errorf("%w: magic block %d already added", ErrInvalidMagicBlock, mb.MagicBlockNumber)
+	}
+	c.magicBlocks = append(c.magicBlocks, mb)
+	return nil
+}
+
+// setLatest sets the latest verified header if it's newer than the current one.
+func (c *Client) setLatest(h *block.Header) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.latest == nil || h.Round > c.latest.Round {
+		c.latest = h
+	}
+}
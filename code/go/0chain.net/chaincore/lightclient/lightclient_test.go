@@ -0,0 +1,235 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+type testMiners struct {
+	pool    *node.Pool
+	schemes map[string]encryption.SignatureScheme
+}
+
+func newTestMiners(t *testing.T, n int) *testMiners {
+	tm := &testMiners{
+		pool:    node.NewPool(node.NodeTypeMiner),
+		schemes: make(map[string]encryption.SignatureScheme),
+	}
+	for i := 0; i < n; i++ {
+		scheme := encryption.NewBLS0ChainScheme()
+		require.NoError(t, scheme.GenerateKeys())
+		nd := node.Provider()
+		nd.Type = node.NodeTypeMiner
+		nd.PublicKey = scheme.GetPublicKey()
+		require.NoError(t, tm.pool.AddNode(nd))
+		tm.schemes[nd.ID] = scheme
+	}
+	return tm
+}
+
+func (tm *testMiners) sign(t *testing.T, hash string, n int) []*block.VerificationTicket {
+	var tickets []*block.VerificationTicket
+	for id, scheme := range tm.schemes {
+		if len(tickets) == n {
+			break
+		}
+		sig, err := scheme.Sign(hash)
+		require.NoError(t, err)
+		tickets = append(tickets, &block.VerificationTicket{VerifierID: id, Signature: sig})
+	}
+	return tickets
+}
+
+func newTestMagicBlock(miners *testMiners, number, startingRound int64, prevHash string) *block.MagicBlock {
+	mb := block.NewMagicBlock()
+	mb.MagicBlockNumber = number
+	mb.StartingRound = startingRound
+	mb.PreviousMagicBlockHash = prevHash
+	mb.Miners = miners.pool
+	mb.Sharders = node.NewPool(node.NodeTypeSharder)
+	mb.Hash = mb.GetHash()
+	return mb
+}
+
+func newTestBlock(round int64, prevHash string, mb *block.MagicBlock) *block.Block {
+	b := &block.Block{}
+	b.Round = round
+	b.PrevHash = prevHash
+	b.MinerID = encryption.Hash("miner")
+	b.CreationDate = common.Timestamp(round)
+	b.MagicBlock = mb
+	b.HashBlock()
+	return b
+}
+
+type testSharder struct {
+	magicBlocks map[int64]*block.Block
+	blocks      map[int64]*block.Block
+	lfb         int64
+	state       util.MerklePatriciaTrieI
+	stateHash   string
+}
+
+func (ts *testSharder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var rsp interface{}
+	switch r.URL.Path {
+	case magicBlockURL:
+		n, _ := strconv.ParseInt(r.FormValue("magic_block_number"), 10, 64)
+		if b, ok := ts.magicBlocks[n]; ok {
+			rsp = b
+		}
+	case latestFinalizedURL:
+		rsp = &block.BlockSummary{Round: ts.lfb, Hash: ts.blocks[ts.lfb].Hash}
+	case blockURL:
+		round, _ := strconv.ParseInt(r.FormValue("round"), 10, 64)
+		if b, ok := ts.blocks[round]; ok {
+			rsp = map[string]interface{}{notarizedHeaderContent: b.GetHeader()}
+		}
+	case stateProofURL:
+		p, err := state.GetProof(ts.state, util.Path(r.FormValue("client_id")))
+		if err == nil {
+			rsp = &stateProofResponse{
+				BlockHash: r.FormValue("block"),
+				StateHash: ts.stateHash,
+				Proof:     p,
+			}
+		}
+	}
+	if rsp == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(rsp)
+}
+
+func newTestState(t *testing.T, clientID string, balance currency.Coin) util.MerklePatriciaTrieI {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	s := &state.State{Balance: balance}
+	s.SetRound(1)
+	require.NoError(t, s.SetTxnHash(encryption.Hash("txn")))
+	_, err := mpt.Insert(util.Path(clientID), s)
+	require.NoError(t, err)
+	return mpt
+}
+
+func TestVerifyNotarization(t *testing.T) {
+	miners := newTestMiners(t, 3)
+	others := newTestMiners(t, 1)
+	c, err := New(Config{}, newTestMagicBlock(miners, 1, 0, ""))
+	require.NoError(t, err)
+
+	hash := encryption.Hash("block")
+	require.NoError(t, c.VerifyNotarization(hash, 1, miners.sign(t, hash, 3)))
+
+	require.Equal(t, ErrNoTickets, c.VerifyNotarization(hash, 1, nil))
+	require.True(t, errors.Is(c.VerifyNotarization(hash, 1, miners.sign(t, hash, 1)), ErrNotNotarized))
+
+	tickets := miners.sign(t, hash, 2)
+	require.Equal(t, ErrDuplicateTicket, c.VerifyNotarization(hash, 1, append(tickets, tickets[0])))
+	require.Equal(t, ErrUnknownVerifier, c.VerifyNotarization(hash, 1, append(tickets, others.sign(t, hash, 1)...)))
+	require.Equal(t, ErrInvalidSignature, c.VerifyNotarization(encryption.Hash("other"), 1, tickets))
+}
+
+func TestSync(t *testing.T) {
+	var (
+		genesisMiners = newTestMiners(t, 3)
+		nextMiners    = newTestMiners(t, 4)
+		genesis       = newTestMagicBlock(genesisMiners, 1, 0, "")
+		clientID      = encryption.Hash("client")
+		clientState   = newTestState(t, clientID, 100)
+	)
+
+	// the view change block carries the next magic block, notarized by the genesis miners
+	mb2 := newTestMagicBlock(nextMiners, 2, 20, genesis.Hash)
+	vcb := newTestBlock(10, encryption.Hash("prev"), mb2)
+	vcb.VerificationTickets = genesisMiners.sign(t, vcb.Hash, 2)
+
+	// the latest finalized block is notarized by the next magic block miners,
+	// its tickets only kept by the next block
+	lfb := newTestBlock(30, encryption.Hash("prev"), nil)
+	lfb.ClientStateHash = clientState.GetRoot()
+	next := newTestBlock(31, lfb.Hash, nil)
+	next.PrevBlockVerificationTickets = nextMiners.sign(t, lfb.Hash, 3)
+
+	newSharder := func(stateHash string) *httptest.Server {
+		return httptest.NewServer(&testSharder{
+			magicBlocks: map[int64]*block.Block{2: vcb},
+			blocks:      map[int64]*block.Block{30: lfb, 31: next},
+			lfb:         30,
+			state:       clientState,
+			stateHash:   stateHash,
+		})
+	}
+	good1 := newSharder(util.ToHex(clientState.GetRoot()))
+	defer good1.Close()
+	good2 := newSharder(util.ToHex(clientState.GetRoot()))
+	defer good2.Close()
+	// reports a state it made up along with a valid proof of it
+	fakeState := newTestState(t, clientID, 1000000)
+	bad := httptest.NewServer(&testSharder{
+		blocks: map[int64]*block.Block{30: lfb, 31: next},
+		lfb:    30,
+		state:  fakeState, stateHash: util.ToHex(fakeState.GetRoot()),
+	})
+	defer bad.Close()
+
+	c, err := New(Config{Sharders: []string{bad.URL, good1.URL, good2.URL}}, genesis)
+	require.NoError(t, err)
+
+	h, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, lfb.Hash, h.Hash)
+	require.Equal(t, int64(2), c.LatestMagicBlock().MagicBlockNumber)
+	require.Equal(t, int64(2), c.MagicBlock(30).MagicBlockNumber)
+	require.Equal(t, int64(1), c.MagicBlock(22).MagicBlockNumber)
+
+	s, err := c.GetClientState(context.Background(), clientID)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, s.Balance)
+
+	// the lying sharder and a single honest one don't make a quorum of 3
+	c, err = New(Config{Sharders: []string{bad.URL, good1.URL}, StateQuorum: 2}, genesis)
+	require.NoError(t, err)
+	_, err = c.GetClientState(context.Background(), clientID)
+	require.Equal(t, ErrNoQuorum, err)
+}
+
+func TestAddMagicBlock(t *testing.T) {
+	miners := newTestMiners(t, 3)
+	genesis := newTestMagicBlock(miners, 1, 0, "")
+	c, err := New(Config{}, genesis)
+	require.NoError(t, err)
+
+	// skips a magic block number
+	b := newTestBlock(10, "", newTestMagicBlock(miners, 3, 20, genesis.Hash))
+	b.VerificationTickets = miners.sign(t, b.Hash, 3)
+	require.True(t, errors.Is(c.AddMagicBlock(b), ErrInvalidMagicBlock))
+
+	// tampered miners
+	mb := newTestMagicBlock(miners, 2, 20, genesis.Hash)
+	b = newTestBlock(10, "", mb)
+	b.VerificationTickets = miners.sign(t, b.Hash, 3)
+	mb.Miners = newTestMiners(t, 3).pool
+	require.True(t, errors.Is(c.AddMagicBlock(b), ErrInvalidMagicBlock))
+
+	mb = newTestMagicBlock(miners, 2, 20, genesis.Hash)
+	b = newTestBlock(10, "", mb)
+	b.VerificationTickets = miners.sign(t, b.Hash, 3)
+	require.NoError(t, c.AddMagicBlock(b))
+	require.Equal(t, mb, c.LatestMagicBlock())
+}
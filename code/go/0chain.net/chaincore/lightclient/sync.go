@@ -0,0 +1,318 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
+
+const (
+	blockURL               = "/v1/block/get"
+	magicBlockURL          = "/v1/block/magic/get"
+	latestFinalizedURL     = "/v1/block/get/latest_finalized"
+	stateProofURL          = "/v1/state/proof"
+	notarizedHeaderContent = "notarized_header"
+)
+
+// errNotFound is returned when a sharder doesn't have what's requested
+var errNotFound = errors.New("not found")
+
+// Sync follows the magic blocks up to the latest one and verifies the latest
+// finalized block header reported by the sharders.
+func (c *Client) Sync(ctx context.Context) (*block.Header, error) {
+	if err := c.SyncMagicBlocks(ctx); err != nil {
+		return nil, err
+	}
+
+	// a sharder could report a round that doesn't exist, start from the
+	// highest round and go down until one can be verified
+	var rounds []int64
+	for _, sharder := range c.cfg.Sharders {
+		var bs block.BlockSummary
+		if err := c.getJSON(ctx, sharder, latestFinalizedURL, nil, &bs); err != nil {
+			continue
+		}
+		if latest := c.Latest(); latest != nil && bs.Round <= latest.Round {
+			continue
+		}
+		rounds = append(rounds, bs.Round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] > rounds[j] })
+
+	for i, round := range rounds {
+		if i > 0 && round == rounds[i-1] {
+			continue
+		}
+		h, err := c.GetHeader(ctx, round)
+		if err != nil {
+			continue
+		}
+		c.setLatest(h)
+		return h, nil
+	}
+
+	if latest := c.Latest(); latest != nil {
+		return latest, nil
+	}
+	return nil, errors.New("no verifiable latest finalized block")
+}
+
+// SyncMagicBlocks follows the magic blocks after the latest known one, as long
+// as a sharder has the next one and it verifies.
+func (c *Client) SyncMagicBlocks(ctx context.Context) error {
+	for {
+		number := c.LatestMagicBlock().MagicBlockNumber + 1
+
+		var added bool
+		for _, sharder := range c.cfg.Sharders {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			b, err := c.fetchMagicBlock(ctx, sharder, number)
+			if err != nil {
+				continue
+			}
+			if err := c.AddMagicBlock(b); err != nil {
+				if !errors.Is(err, ErrNotNotarized) && !errors.Is(err, ErrNoTickets) {
+					continue
+				}
+				// the block the magic block is in may not have kept its tickets,
+				// they are in the next block as the previous block tickets
+				next, nerr := c.fetchHeader(ctx, sharder, b.Round+1)
+				if nerr != nil || next.PrevHash != b.Hash {
+					continue
+				}
+				b.MergeVerificationTickets(next.PrevVerificationTickets)
+				if err := c.AddMagicBlock(b); err != nil {
+					continue
+				}
+			}
+			added = true
+			break
+		}
+
+		if !added {
+			return nil
+		}
+	}
+}
+
+// GetHeader returns the verified header of the finalized block of the round.
+func (c *Client) GetHeader(ctx context.Context, round int64) (*block.Header, error) {
+	err := fmt.Errorf("no sharder has a verifiable header of round %d", round)
+	for _, sharder := range c.cfg.Sharders {
+		h, ferr := c.fetchHeader(ctx, sharder, round)
+		if ferr != nil {
+			continue
+		}
+		if h.Round != round {
+			continue
+		}
+
+		verr := c.VerifyHeader(h, h.VerificationTickets)
+		if errors.Is(verr, ErrNotNotarized) || errors.Is(verr, ErrNoTickets) {
+			next, nerr := c.fetchHeader(ctx, sharder, round+1)
+			if nerr == nil && next.PrevHash == h.Hash {
+				h.VerificationTickets = mergeTickets(h.VerificationTickets, next.PrevVerificationTickets)
+				verr = c.VerifyHeader(h, h.VerificationTickets)
+			}
+		}
+		if verr != nil {
+			err = fmt.Errorf("header of round %d from %s: %w", round, sharder, verr)
+			continue
+		}
+		return h, nil
+	}
+	return nil, err
+}
+
+// stateProofResponse is the response of the sharders' state proof endpoint
+type stateProofResponse struct {
+	Round     int64        `json:"round"`
+	BlockHash string       `json:"block_hash"`
+	StateHash string       `json:"state_hash"`
+	Proof     *state.Proof `json:"proof"`
+}
+
+// GetState returns the value stored at the state path of the latest verified
+// block, nil if there is none. The proofs are verified against the state hash
+// at least StateQuorum sharders agree on.
+func (c *Client) GetState(ctx context.Context, path util.Path, query url.Values) ([]byte, *block.Header, error) {
+	h := c.Latest()
+	if h == nil {
+		var err error
+		if h, err = c.Sync(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	q := url.Values{"block": {h.Hash}}
+	for k, v := range query {
+		q[k] = v
+	}
+
+	type vote struct {
+		count int
+		value []byte
+	}
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		votes  = make(map[string]*vote)
+		result *vote
+	)
+	for _, sharder := range c.cfg.Sharders {
+		wg.Add(1)
+		go func(sharder string) {
+			defer wg.Done()
+			var rsp stateProofResponse
+			if err := c.getJSON(ctx, sharder, stateProofURL, q, &rsp); err != nil {
+				return
+			}
+			if rsp.BlockHash != h.Hash || rsp.Proof == nil || rsp.Proof.Path != string(path) {
+				return
+			}
+			root, err := hex.DecodeString(rsp.StateHash)
+			if err != nil {
+				return
+			}
+			value, err := rsp.Proof.Verify(root)
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			v, ok := votes[rsp.StateHash]
+			if !ok {
+				v = &vote{value: value}
+				votes[rsp.StateHash] = v
+			}
+			v.count++
+			if v.count >= c.cfg.StateQuorum {
+				result = v
+			}
+		}(sharder)
+	}
+	wg.Wait()
+
+	if result == nil {
+		return nil, h, ErrNoQuorum
+	}
+	return result.value, h, nil
+}
+
+// GetClientState returns the verified state of the client, the balance and
+// the nonce. A client with no state gets an empty state and util.ErrValueNotPresent.
+func (c *Client) GetClientState(ctx context.Context, clientID string) (*state.State, error) {
+	value, _, err := c.GetState(ctx, util.Path(clientID), url.Values{"client_id": {clientID}})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &state.State{}
+	if value == nil {
+		return s, util.ErrValueNotPresent
+	}
+	if err := s.Decode(value); err != nil {
+		return nil, err
+	}
+	return s, s.ComputeProperties()
+}
+
+// GetSCState decodes the verified smart contract state value of the key into v.
+// A missing value is reported with util.ErrValueNotPresent.
+func (c *Client) GetSCState(ctx context.Context, scAddress, key string, v util.MPTSerializable) error {
+	path := util.Path(encryption.Hash(scAddress + key))
+	value, _, err := c.GetState(ctx, path, url.Values{"sc_address": {scAddress}, "key": {key}})
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return util.ErrValueNotPresent
+	}
+	_, err = v.UnmarshalMsg(value)
+	return err
+}
+
+func (c *Client) fetchHeader(ctx context.Context, sharder string, round int64) (*block.Header, error) {
+	var rsp struct {
+		Header *block.Header `json:"notarized_header"`
+	}
+	q := url.Values{
+		"round":   {strconv.FormatInt(round, 10)},
+		"content": {notarizedHeaderContent},
+	}
+	if err := c.getJSON(ctx, sharder, blockURL, q, &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Header == nil {
+		return nil, errNotFound
+	}
+	return rsp.Header, nil
+}
+
+func (c *Client) fetchMagicBlock(ctx context.Context, sharder string, number int64) (*block.Block, error) {
+	b := &block.Block{}
+	q := url.Values{"magic_block_number": {strconv.FormatInt(number, 10)}}
+	if err := c.getJSON(ctx, sharder, magicBlockURL, q, b); err != nil {
+		return nil, err
+	}
+	if b.MagicBlock == nil || b.MagicBlock.MagicBlockNumber != number {
+		return nil, errNotFound
+	}
+	return b, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, sharder, path string, query url.Values, v interface{}) error {
+	u := strings.TrimSuffix(sharder, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func mergeTickets(a, b []*block.VerificationTicket) []*block.VerificationTicket {
+	seen := make(map[string]struct{}, len(a))
+	res := make([]*block.VerificationTicket, 0, len(a)+len(b))
+	for _, vts := range [][]*block.VerificationTicket{a, b} {
+		for _, vt := range vts {
+			if vt == nil {
+				continue
+			}
+			if _, ok := seen[vt.VerifierID]; ok {
+				continue
+			}
+			seen[vt.VerifierID] = struct{}{}
+			res = append(res, vt)
+		}
+	}
+	return res
+}
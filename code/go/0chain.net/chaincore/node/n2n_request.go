@@ -13,6 +13,7 @@ import (
 
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
+	"0chain.net/core/trace"
 	"github.com/0chain/common/core/logging"
 	"go.uber.org/zap"
 )
@@ -196,6 +197,14 @@ func SetRequestHeaders(req *http.Request, options *SendOptions, entityMetadata d
 func RequestEntityHandler(uri string, options *SendOptions, entityMetadata datastore.EntityMetadata) EntityRequestor {
 	return func(params *url.Values, handler datastore.JSONEntityReqResponderF) SendHandler {
 		return func(ctx context.Context, provider *Node) bool {
+			ctx, span := trace.Start(ctx, "n2n.request",
+				trace.WithKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					trace.String("uri", uri),
+					trace.String("peer", provider.GetKey()),
+				))
+			defer span.End()
+
 			entityMeta := entityMetadata
 			timer := provider.GetTimer(uri)
 			timeout := 500 * time.Millisecond
@@ -229,6 +238,7 @@ func RequestEntityHandler(uri string, options *SendOptions, entityMetadata datas
 			}
 
 			SetRequestHeaders(req, options, entityMeta)
+			trace.Inject(ctx, req.Header)
 			// Keep the number of messages to a node bounded
 
 			var (
@@ -296,6 +306,7 @@ func RequestEntityHandler(uri string, options *SendOptions, entityMetadata datas
 					return false
 				}
 			default:
+				span.SetError(err)
 				ue, ok := err.(*url.Error)
 				if ok && ue.Unwrap() != context.Canceled {
 					// requests could be canceled when the miner has received a response
@@ -405,10 +416,17 @@ func ToN2NSendEntityHandler(handler common.JSONResponderF) common.ReqRespHandler
 			return
 		}
 		sender.AddReceived(1)
-		ctx := context.TODO()
+		ctx, span := trace.Start(trace.Extract(context.TODO(), r.Header), "n2n.respond",
+			trace.WithKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				trace.String("uri", r.URL.Path),
+				trace.String("peer", nodeID),
+			))
+		defer span.End()
 		ts := time.Now()
 		data, err := handler(ctx, r)
 		if err != nil {
+			span.SetError(err)
 			common.Respond(w, r, nil, err)
 			logging.N2n.Error("message received", zap.String("from", sender.GetPseudoName()),
 				zap.String("to", Self.Underlying().GetPseudoName()), zap.String("handler", r.RequestURI), zap.Error(err))
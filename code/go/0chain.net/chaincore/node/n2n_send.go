@@ -13,6 +13,7 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"0chain.net/core/trace"
 	"github.com/0chain/common/core/logging"
 	metrics "github.com/rcrowley/go-metrics"
 	"go.uber.org/zap"
@@ -271,6 +272,15 @@ func SendEntityHandler(uri string, options *SendOptions) EntitySendHandler {
 		}
 
 		return func(ctx context.Context, receiver *Node) bool {
+			ctx, span := trace.Start(ctx, "n2n.send",
+				trace.WithKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					trace.String("uri", uri),
+					trace.String("peer", receiver.GetKey()),
+					trace.String("entity", entity.GetEntityMetadata().GetName()),
+				))
+			defer span.End()
+
 			timer := receiver.GetTimer(uri)
 			addr := receiver.GetN2NURLBase() + uri
 			var buffer *bytes.Buffer
@@ -296,6 +306,7 @@ func SendEntityHandler(uri string, options *SendOptions) EntitySendHandler {
 
 			req.Header.Set("Content-Type", "application/json; charset=utf-8")
 			SetSendHeaders(req, entity, options)
+			trace.Inject(ctx, req.Header)
 
 			setSignHeader(req)
 			// Keep the number of messages to a node bounded
@@ -333,6 +344,7 @@ func SendEntityHandler(uri string, options *SendOptions) EntitySendHandler {
 			switch err {
 			case nil:
 			default:
+				span.SetError(err)
 				ue, ok := err.(*url.Error)
 				if ok && ue.Unwrap() != context.Canceled {
 					receiver.AddSendErrors(1)
@@ -353,6 +365,7 @@ func SendEntityHandler(uri string, options *SendOptions) EntitySendHandler {
 				sizer := receiver.GetSizeMetric(uri)
 				sizer.Update(int64(len(data)))
 			}
+			span.SetAttributes(trace.Int64("status_code", int64(resp.StatusCode)))
 			if !(resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent) {
 				logging.N2n.Error("sending", zap.String("from", selfNode.GetPseudoName()), zap.String("to", receiver.GetPseudoName()), zap.String("handler", uri), zap.Duration("duration", time.Since(ts)), zap.String("entity", entity.GetEntityMetadata().GetName()), zap.String("id", entity.GetKey()), zap.Int("status_code", resp.StatusCode))
 				return false
@@ -533,7 +546,7 @@ func ToN2NReceiveEntityHandler(handler datastore.JSONEntityReqResponderF, option
 			}
 			// TODO:
 			root, _ := context.WithTimeout(common.GetRootContext(), 5*time.Second) //nolint:govet
-			ctx := WithSenderValidateFunc(root, senderValidateFunc)
+			ctx := WithSenderValidateFunc(trace.Extract(root, r.Header), senderValidateFunc)
 			initialNodeID := r.Header.Get(HeaderInitialNodeID)
 			if initialNodeID != "" {
 				initSender := GetNode(initialNodeID)
@@ -566,9 +579,19 @@ func ToN2NReceiveEntityHandler(handler datastore.JSONEntityReqResponderF, option
 				return
 			}
 
+			ctx, span := trace.Start(ctx, "n2n.receive",
+				trace.WithKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					trace.String("uri", r.URL.Path),
+					trace.String("peer", nodeID),
+					trace.String("entity", entityName),
+					trace.String("id", entityID),
+				))
 			start := time.Now()
 			_, err = handler(ctx, entity)
 			duration := time.Since(start)
+			span.SetError(err)
+			span.End()
 			if err != nil {
 				logging.N2n.Error("message received", zap.String("from", sender.GetPseudoName()),
 					zap.String("to", Self.Underlying().GetPseudoName()), zap.String("handler", r.RequestURI), zap.Duration("duration", duration), zap.String("entity", entityName), zap.String("id", entity.GetKey()), zap.Error(err))
@@ -73,8 +73,32 @@ func ExecuteSmartContract(txn *transaction.Transaction, balances c_state.StateCo
 	return "", common.NewError("invalid_smart_contract_address", "Invalid Smart Contract address")
 }
 
+// CostFunction returns the address and the name of the smart contract function
+// a transaction calling the function is charged for. From the hermes hardfork
+// it is the function executed by the called one when it forwards the call.
+func CostFunction(address string, scData sci.SmartContractTransactionData,
+	balances c_state.StateContextI) (string, string, error) {
+	f, ok := getSmartContract(address).(sci.CallForwarder)
+	if !ok {
+		return address, scData.FunctionName, nil
+	}
+
+	fAddress, fName := address, scData.FunctionName
+	err := c_state.WithActivation(balances, "hermes", func() error { return nil }, func() error {
+		if a, name, ok := f.ForwardedCall(scData.FunctionName, scData.InputData); ok {
+			fAddress, fName = a, name
+		}
+		return nil
+	})
+	return fAddress, fName, err
+}
+
 func EstimateTransactionCost(t *transaction.Transaction, scData sci.SmartContractTransactionData, balances c_state.StateContextI) (int, error) {
-	contractObj := getSmartContract(t.ToClientID)
+	address, functionName, err := CostFunction(t.ToClientID, scData, balances)
+	if err != nil {
+		return math.MaxInt, err
+	}
+	contractObj := getSmartContract(address)
 	if contractObj == nil {
 		return 0, errors.New("estimate transaction cost - invalid to client id")
 	}
@@ -83,7 +107,7 @@ func EstimateTransactionCost(t *transaction.Transaction, scData sci.SmartContrac
 	if err != nil {
 		return math.MaxInt, err
 	}
-	cost, ok := table[strings.ToLower(scData.FunctionName)]
+	cost, ok := table[strings.ToLower(functionName)]
 	if !ok {
 		//TODO figure out what to do with such transactions, do not return err now for backward compatibility
 		//return math.MaxInt, errors.New("no cost found for function")
@@ -4,6 +4,10 @@ import (
 	"0chain.net/core/common"
 )
 
+// GovernanceAddress is the address of the governance smart contract. It's the
+// client of the settings updates of the proposals passed by the stakeholders.
+const GovernanceAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1"
+
 func AuthorizeWithOwner(funcName string, hasAccess func() bool) error {
 	if !hasAccess() {
 		return common.NewError(funcName,
@@ -12,6 +16,14 @@ func AuthorizeWithOwner(funcName string, hasAccess func() bool) error {
 	return nil
 }
 
+// AuthorizeWithOwnerOrGovernance authorizes the owner and the governance
+// smart contract applying a passed proposal
+func AuthorizeWithOwnerOrGovernance(funcName, ownerID, clientID string) error {
+	return AuthorizeWithOwner(funcName, func() bool {
+		return clientID == ownerID || clientID == GovernanceAddress
+	})
+}
+
 func AuthorizeWithDelegate(funcName string, hasAccess func() bool) error {
 	if !hasAccess() {
 		return common.NewError(funcName,
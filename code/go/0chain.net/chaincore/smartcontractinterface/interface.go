@@ -40,6 +40,15 @@ type SmartContractInterface interface {
 	GetCostTable(balances c_state.StateContextI) (map[string]int, error)
 }
 
+// CallForwarder is a smart contract whose functions can execute a function of
+// another smart contract, the transactions are charged the cost of the
+// executed function.
+type CallForwarder interface {
+	// ForwardedCall returns the address and the name of the smart contract
+	// function executed by the function with the input, if any
+	ForwardedCall(functionName string, input []byte) (string, string, bool)
+}
+
 /*
 BCContextI interface for smart contracts to access blockchain.
 These functions should not modify blockchain states in anyway.
@@ -11,6 +11,15 @@ import (
 
 var ErrInvalidBurn = common.NewError("invalid_burn", "invalid burner")
 
+// BurnAddress is the client the burned tokens are transferred to. No key
+// hashes to it, so its balance can't be spent.
+const BurnAddress = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// NewBurnTransfer - create a transfer burning the tokens of the client
+func NewBurnTransfer(fromClientID datastore.Key, zcn currency.Coin) *Transfer {
+	return NewTransfer(fromClientID, BurnAddress, zcn)
+}
+
 type Burn struct {
 	Burner datastore.Key `json:"burner"`
 	Amount currency.Coin `json:"amount"`
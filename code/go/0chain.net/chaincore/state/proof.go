@@ -0,0 +1,174 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0chain/common/core/util"
+)
+
+var (
+	// ErrProofRootMismatch is returned when the proof doesn't start at the expected state root
+	ErrProofRootMismatch = errors.New("proof root mismatch")
+	// ErrProofNodeMismatch is returned when a proof node doesn't hash to the key referenced by its parent
+	ErrProofNodeMismatch = errors.New("proof node hash mismatch")
+	// ErrProofInvalidPath is returned when the path is not a hex string
+	ErrProofInvalidPath = errors.New("invalid proof path")
+	// ErrProofInvalidNode is returned when a proof node can't be decoded
+	ErrProofInvalidNode = errors.New("invalid proof node")
+	// ErrProofIncomplete is returned when the proof nodes end before the path is resolved
+	ErrProofIncomplete = errors.New("incomplete proof")
+	// ErrProofExtraNodes is returned when the proof has nodes past the end of the path
+	ErrProofExtraNodes = errors.New("proof has extra nodes")
+	// ErrProofValueMismatch is returned when the proof value is not the one stored at the path
+	ErrProofValueMismatch = errors.New("proof value mismatch")
+)
+
+// Proof is a merkle patricia trie proof of the value stored at a path. The nodes
+// are the encoded trie nodes from the root down to the node holding the value,
+// or down to the node where the path diverges, which proves there is no value.
+//
+// swagger:model StateProof
+type Proof struct {
+	// Root is the hex encoded state root the proof is for
+	Root string `json:"root"`
+	// Path in the trie, the client id for the clients' states or the hash of the
+	// smart contract state key
+	Path string `json:"path"`
+	// Value is the msgpack encoded value, empty when there is no value at the path
+	Value []byte   `json:"value,omitempty"`
+	Nodes [][]byte `json:"nodes"`
+}
+
+// GetProof builds the proof of the value stored at the path of the trie.
+func GetProof(mpt util.MerklePatriciaTrieI, path util.Path) (*Proof, error) {
+	var (
+		ndb  = mpt.GetNodeDB()
+		key  = mpt.GetRoot()
+		rest = path
+		p    = &Proof{Root: util.ToHex(key), Path: string(path)}
+	)
+	if !isHexPath(path) {
+		return nil, ErrProofInvalidPath
+	}
+	for {
+		node, err := ndb.GetNode(key)
+		if err != nil {
+			return nil, err
+		}
+		p.Nodes = append(p.Nodes, node.Encode())
+
+		var next util.Key
+		next, rest = nextProofNode(node, rest)
+		if next == nil {
+			if rest != nil {
+				p.Value = valueBytes(node)
+			}
+			return p, nil
+		}
+		key = next
+	}
+}
+
+// Verify verifies the proof against the state root and returns the value
+// stored at the path, nil if the proof shows there is no value at the path.
+func (p *Proof) Verify(root util.Key) ([]byte, error) {
+	if p.Root != util.ToHex(root) {
+		return nil, ErrProofRootMismatch
+	}
+	if !isHexPath(util.Path(p.Path)) {
+		return nil, ErrProofInvalidPath
+	}
+
+	var (
+		key  = root
+		rest = util.Path(p.Path)
+	)
+	for i, enc := range p.Nodes {
+		node, err := decodeProofNode(enc)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(node.GetHashBytes(), key) {
+			return nil, ErrProofNodeMismatch
+		}
+
+		var next util.Key
+		next, rest = nextProofNode(node, rest)
+		if next != nil {
+			key = next
+			continue
+		}
+		if i != len(p.Nodes)-1 {
+			return nil, ErrProofExtraNodes
+		}
+
+		var value []byte
+		if rest != nil {
+			value = valueBytes(node)
+		}
+		if !bytes.Equal(value, p.Value) {
+			return nil, ErrProofValueMismatch
+		}
+		return value, nil
+	}
+	return nil, ErrProofIncomplete
+}
+
+// nextProofNode returns the key of the child node to follow and the rest of
+// the path. With no child to follow, a non nil rest means the node holds the
+// value of the path, a nil one means there is no value at the path.
+func nextProofNode(node util.Node, path util.Path) (util.Key, util.Path) {
+	switch n := node.(type) {
+	case *util.LeafNode:
+		if bytes.Equal(n.Path, path) {
+			return nil, util.Path{}
+		}
+	case *util.FullNode:
+		if len(path) == 0 {
+			return nil, util.Path{}
+		}
+		if child := n.GetChild(path[0]); child != nil {
+			return child, path[1:]
+		}
+	case *util.ExtensionNode:
+		if len(n.Path) > 0 && bytes.HasPrefix(path, n.Path) {
+			return n.NodeKey, path[len(n.Path):]
+		}
+	}
+	return nil, nil
+}
+
+func isHexPath(path util.Path) bool {
+	for _, c := range path {
+		if bytes.IndexByte(util.PathElements, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func valueBytes(node util.Node) []byte {
+	switch n := node.(type) {
+	case *util.LeafNode:
+		return n.GetValueBytes()
+	case *util.FullNode:
+		return n.GetValueBytes()
+	}
+	return nil
+}
+
+func decodeProofNode(enc []byte) (node util.Node, err error) {
+	if len(enc) == 0 {
+		return nil, ErrProofInvalidNode
+	}
+	switch enc[0] & util.NodeTypesAll {
+	case util.NodeTypeLeafNode, util.NodeTypeFullNode, util.NodeTypeExtensionNode:
+	default:
+		return nil, ErrProofInvalidNode
+	}
+	if node, err = util.CreateNode(bytes.NewReader(enc)); err != nil {
+		return nil, ErrProofInvalidNode
+	}
+	return node, nil
+}
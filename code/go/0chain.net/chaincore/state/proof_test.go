@@ -0,0 +1,106 @@
+package state
+
+import (
+	"strconv"
+	"testing"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/statecache"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newProofTestState(t *testing.T, n int) util.MerklePatriciaTrieI {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil, statecache.NewEmpty())
+	for i := 0; i < n; i++ {
+		s := &State{Balance: currency.Coin(i + 1)}
+		s.SetRound(1)
+		require.NoError(t, s.SetTxnHash(encryption.Hash("txn")))
+		_, err := mpt.Insert(util.Path(encryption.Hash(strconv.Itoa(i))), s)
+		require.NoError(t, err)
+	}
+	return mpt
+}
+
+func TestProof(t *testing.T) {
+	mpt := newProofTestState(t, 100)
+	root := mpt.GetRoot()
+
+	for i := 0; i < 100; i += 7 {
+		path := util.Path(encryption.Hash(strconv.Itoa(i)))
+		p, err := GetProof(mpt, path)
+		require.NoError(t, err)
+
+		value, err := p.Verify(root)
+		require.NoError(t, err)
+
+		s := &State{}
+		_, err = s.UnmarshalMsg(value)
+		require.NoError(t, err)
+		require.EqualValues(t, i+1, s.Balance)
+	}
+
+	// missing value
+	p, err := GetProof(mpt, util.Path(encryption.Hash("missing")))
+	require.NoError(t, err)
+	require.Empty(t, p.Value)
+	value, err := p.Verify(root)
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	_, err = GetProof(mpt, util.Path("not hex"))
+	require.Equal(t, ErrProofInvalidPath, err)
+}
+
+func TestProofTampered(t *testing.T) {
+	mpt := newProofTestState(t, 50)
+	root := mpt.GetRoot()
+	path := util.Path(encryption.Hash("3"))
+
+	newProof := func() *Proof {
+		p, err := GetProof(mpt, path)
+		require.NoError(t, err)
+		return p
+	}
+
+	other := newProofTestState(t, 10)
+	_, err := newProof().Verify(other.GetRoot())
+	require.Equal(t, ErrProofRootMismatch, err)
+
+	p := newProof()
+	s := &State{Balance: 1000}
+	s.SetRound(1)
+	require.NoError(t, s.SetTxnHash(encryption.Hash("txn")))
+	p.Value, err = s.MarshalMsg(nil)
+	require.NoError(t, err)
+	_, err = p.Verify(root)
+	require.Equal(t, ErrProofValueMismatch, err)
+
+	// claim there is no value
+	p = newProof()
+	p.Value = nil
+	_, err = p.Verify(root)
+	require.Equal(t, ErrProofValueMismatch, err)
+
+	p = newProof()
+	last := p.Nodes[len(p.Nodes)-1]
+	last[len(last)-1]++
+	_, err = p.Verify(root)
+	require.Equal(t, ErrProofNodeMismatch, err)
+
+	p = newProof()
+	p.Nodes = p.Nodes[:len(p.Nodes)-1]
+	_, err = p.Verify(root)
+	require.Equal(t, ErrProofIncomplete, err)
+
+	p = newProof()
+	p.Nodes = append(p.Nodes, p.Nodes[0])
+	_, err = p.Verify(root)
+	require.Equal(t, ErrProofExtraNodes, err)
+
+	p = newProof()
+	p.Nodes[0] = []byte{0xff}
+	_, err = p.Verify(root)
+	require.Equal(t, ErrProofInvalidNode, err)
+}
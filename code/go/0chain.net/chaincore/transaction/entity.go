@@ -133,6 +133,10 @@ type Transaction struct {
 	// required: true
 	OutputHash        string `json:"txn_output_hash" msgpack:"oh"`
 
+	// ResultsHash - the hash of the events, transfers, mints and fee of the
+	// transaction execution, part of the receipt merkle tree with the output
+	ResultsHash       string `json:"txn_results_hash,omitempty" msgpack:"rh,omitempty"`
+
 	// Status - the status of the transaction
 	//
 	// required: true
@@ -520,6 +524,7 @@ func (t *Transaction) Clone() *Transaction {
 		TransactionType:   t.TransactionType,
 		TransactionOutput: t.TransactionOutput,
 		OutputHash:        t.OutputHash,
+		ResultsHash:       t.ResultsHash,
 		Status:            t.Status,
 	}
 
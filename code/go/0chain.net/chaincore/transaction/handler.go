@@ -43,6 +43,7 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 	err := entity.GetEntityMetadata().GetStore().Write(ctx, txn)
 	if err != nil {
 		logging.Logger.Error("put transaction", zap.Error(err), zap.String("txn", txn.Hash), zap.String("txn_obj", datastore.ToJSON(txn).String()))
+		RemoveFromTxnPool([]datastore.Entity{txn})
 		return nil, err
 	}
 
@@ -70,6 +71,10 @@ func PutTransactionWithoutVerifySig(ctx context.Context, entity datastore.Entity
 		return nil, common.NewError("put transaction error", fmt.Sprintf("client %v doesn't exist, please register", txn.ClientID))
 	}
 
+	if err := AddToPool(ctx, txn, -1); err != nil {
+		return nil, err
+	}
+
 	if datastore.DoAsync(ctx, txn) {
 		IncTransactionCount()
 		return txn, nil
@@ -77,6 +82,7 @@ func PutTransactionWithoutVerifySig(ctx context.Context, entity datastore.Entity
 	err = entity.GetEntityMetadata().GetStore().Write(ctx, txn)
 	if err != nil {
 		logging.Logger.Error("put transaction", zap.Error(err), zap.String("txn", txn.Hash), zap.String("txn_obj", datastore.ToJSON(txn).String()))
+		RemoveFromTxnPool([]datastore.Entity{txn})
 		return nil, err
 	}
 
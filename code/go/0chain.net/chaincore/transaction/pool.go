@@ -0,0 +1,540 @@
+package transaction
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"0chain.net/chaincore/client"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/memorystore"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultPoolMaxSize      = 100000
+	DefaultPoolMaxPerSender = 100
+	DefaultPoolPriceBump    = 10 // percent
+)
+
+var (
+	ErrPoolNonceTooLow        = errors.New("txn pool: nonce too low")
+	ErrPoolReplaceUnderpriced = errors.New("txn pool: replacement transaction underpriced")
+	ErrPoolSenderLimit        = errors.New("txn pool: too many transactions of the client")
+	ErrPoolFull               = errors.New("txn pool: pool is full and the transaction fee is too low")
+)
+
+// PoolConfig configures the transactions pool.
+type PoolConfig struct {
+	// MaxSize is the maximum number of transactions in the pool.
+	MaxSize int
+	// MaxPerSender is the maximum number of transactions of a client in the pool.
+	MaxPerSender int
+	// PriceBump is the minimum fee increase, in percent, of a transaction
+	// replacing the one of the same client and nonce.
+	PriceBump int
+}
+
+// senderQueue holds the pooled transactions of a client by nonce.
+type senderQueue struct {
+	// nonce is the latest nonce of the client known from the finalized state,
+	// known is false until it's been set.
+	nonce int64
+	known bool
+	txns  map[int64]*Transaction
+}
+
+// start returns the nonce the executable transactions of the client start from.
+func (sq *senderQueue) start() int64 {
+	if sq.known {
+		return sq.nonce + 1
+	}
+	start := int64(-1)
+	for n := range sq.txns {
+		if start < 0 || n < start {
+			start = n
+		}
+	}
+	return start
+}
+
+// executable returns the transactions of the client with consecutive nonces
+// that can be included in a block.
+func (sq *senderQueue) executable() []*Transaction {
+	var txns []*Transaction
+	for n := sq.start(); ; n++ {
+		txn, ok := sq.txns[n]
+		if !ok {
+			return txns
+		}
+		txns = append(txns, txn)
+	}
+}
+
+func (sq *senderQueue) maxNonce() int64 {
+	var max int64
+	for n := range sq.txns {
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// Pool is the miner's in memory index of the transactions pool. The pooled
+// transactions are kept in the redis collection as well, the pool orders them
+// by client and nonce so that only executable transactions are offered to the
+// block generation, and limits what's admitted when it's full.
+type Pool struct {
+	mutex   sync.RWMutex
+	config  PoolConfig
+	senders map[string]*senderQueue
+	byHash  map[string]*Transaction
+}
+
+var txnPool *Pool
+
+// SetupPool enables the transactions pool.
+func SetupPool(config PoolConfig) {
+	txnPool = NewPool(config)
+}
+
+// GetPool returns the transactions pool, nil when it's not enabled.
+func GetPool() *Pool {
+	return txnPool
+}
+
+func NewPool(config PoolConfig) *Pool {
+	if config.MaxSize <= 0 {
+		config.MaxSize = DefaultPoolMaxSize
+	}
+	if config.MaxPerSender <= 0 {
+		config.MaxPerSender = DefaultPoolMaxPerSender
+	}
+	if config.PriceBump < 0 {
+		config.PriceBump = DefaultPoolPriceBump
+	}
+	return &Pool{
+		config:  config,
+		senders: make(map[string]*senderQueue),
+		byHash:  make(map[string]*Transaction),
+	}
+}
+
+// Add admits the transaction to the pool, nonce is the latest nonce of the client
+// in the finalized state or negative when it's not known. The returned
+// transactions have been removed from the pool, either replaced by the
+// transaction or evicted to make room for it, they have to be removed from the
+// store as well.
+func (p *Pool) Add(txn *Transaction, nonce int64) ([]*Transaction, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.byHash[txn.Hash]; ok {
+		return nil, nil
+	}
+
+	var removed []*Transaction
+	if nonce >= 0 {
+		removed = p.setNonce(txn.ClientID, nonce)
+	}
+
+	sq, ok := p.senders[txn.ClientID]
+	if !ok {
+		sq = &senderQueue{txns: make(map[int64]*Transaction)}
+		if nonce >= 0 {
+			sq.nonce, sq.known = nonce, true
+		}
+	}
+	if sq.known && txn.Nonce <= sq.nonce {
+		return removed, ErrPoolNonceTooLow
+	}
+
+	if existing, ok := sq.txns[txn.Nonce]; ok {
+		// replace by fee
+		minFee := existing.Fee + existing.Fee*currency.Coin(p.config.PriceBump)/100
+		if txn.Fee <= existing.Fee || txn.Fee < minFee {
+			return removed, ErrPoolReplaceUnderpriced
+		}
+		p.remove(existing)
+		removed = append(removed, existing)
+	} else {
+		if len(sq.txns) >= p.config.MaxPerSender {
+			// a lower nonce takes the place of the highest one
+			maxNonce := sq.maxNonce()
+			if txn.Nonce > maxNonce {
+				return removed, ErrPoolSenderLimit
+			}
+			evicted := sq.txns[maxNonce]
+			p.remove(evicted)
+			removed = append(removed, evicted)
+		}
+
+		if len(p.byHash) >= p.config.MaxSize {
+			evicted, executable := p.cheapest(txn.ClientID)
+			if evicted == nil || (executable && evicted.Fee >= txn.Fee) {
+				return removed, ErrPoolFull
+			}
+			p.remove(evicted)
+			removed = append(removed, evicted)
+		}
+	}
+
+	sq.txns[txn.Nonce] = txn
+	p.senders[txn.ClientID] = sq
+	p.byHash[txn.Hash] = txn
+	return removed, nil
+}
+
+// cheapest returns the eviction candidate, the lowest fee transaction among
+// the last ones of the clients' queues, so that the queues stay without gaps.
+// Non executable transactions are evicted first, regardless of the fee.
+func (p *Pool) cheapest(except string) (*Transaction, bool) {
+	var (
+		candidate           *Transaction
+		candidateExecutable bool
+	)
+	for clientID, sq := range p.senders {
+		if clientID == except || len(sq.txns) == 0 {
+			continue
+		}
+		last := sq.txns[sq.maxNonce()]
+		executable := len(sq.executable()) == len(sq.txns)
+		switch {
+		case candidate == nil,
+			candidateExecutable && !executable,
+			candidateExecutable == executable && last.Fee < candidate.Fee:
+			candidate = last
+			candidateExecutable = executable
+		}
+	}
+	return candidate, candidateExecutable
+}
+
+func (p *Pool) remove(txn *Transaction) {
+	delete(p.byHash, txn.Hash)
+	sq, ok := p.senders[txn.ClientID]
+	if !ok {
+		return
+	}
+	if cur, ok := sq.txns[txn.Nonce]; ok && cur.Hash == txn.Hash {
+		delete(sq.txns, txn.Nonce)
+	}
+	if len(sq.txns) == 0 {
+		delete(p.senders, txn.ClientID)
+	}
+}
+
+// Remove removes the transactions from the pool.
+func (p *Pool) Remove(txns []datastore.Entity) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, e := range txns {
+		txn, ok := e.(*Transaction)
+		if !ok {
+			continue
+		}
+		if pooled, ok := p.byHash[txn.Hash]; ok {
+			p.remove(pooled)
+		}
+	}
+}
+
+func (p *Pool) setNonce(clientID string, nonce int64) []*Transaction {
+	sq, ok := p.senders[clientID]
+	if !ok || (sq.known && sq.nonce >= nonce) {
+		return nil
+	}
+	sq.nonce = nonce
+	sq.known = true
+	var removed []*Transaction
+	for n, txn := range sq.txns {
+		if n <= nonce {
+			removed = append(removed, txn)
+		}
+	}
+	for _, txn := range removed {
+		p.remove(txn)
+	}
+	return removed
+}
+
+// Finalized removes the transactions of a finalized block along with the pooled
+// transactions of the same clients with lower nonces.
+func (p *Pool) Finalized(txns []datastore.Entity) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	nonces := make(map[string]int64)
+	for _, e := range txns {
+		txn, ok := e.(*Transaction)
+		if !ok {
+			continue
+		}
+		clientID := txn.ClientID
+		if pooled, ok := p.byHash[txn.Hash]; ok {
+			clientID = pooled.ClientID
+			p.remove(pooled)
+		}
+		if clientID == "" && txn.PublicKey != "" {
+			// the client id is cleared in the generated blocks
+			id, err := client.GetIDFromPublicKey(txn.PublicKey)
+			if err != nil {
+				continue
+			}
+			clientID = id
+		}
+		if clientID == "" {
+			continue
+		}
+		if nonce, ok := nonces[clientID]; !ok || txn.Nonce > nonce {
+			nonces[clientID] = txn.Nonce
+		}
+	}
+	for clientID, nonce := range nonces {
+		p.setNonce(clientID, nonce)
+	}
+}
+
+// Size returns the number of transactions in the pool.
+func (p *Pool) Size() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return len(p.byHash)
+}
+
+// Get returns a copy of the pooled transaction.
+func (p *Pool) Get(hash string) (*Transaction, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	txn, ok := p.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+	return txn.Clone(), true
+}
+
+// txnHeap orders the next executable transaction of every client by fee.
+type txnHeap []*Transaction
+
+func (h txnHeap) Len() int { return len(h) }
+func (h txnHeap) Less(i, j int) bool {
+	if h[i].Fee == h[j].Fee {
+		return h[i].CreationDate < h[j].CreationDate
+	}
+	return h[i].Fee > h[j].Fee
+}
+func (h txnHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *txnHeap) Push(x interface{}) { *h = append(*h, x.(*Transaction)) }
+func (h *txnHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// Iterate calls the handler with the executable transactions, in nonce order
+// for every client and the highest fee first among the clients, until the
+// handler returns false or an error. The handler gets copies of the pooled
+// transactions.
+func (p *Pool) Iterate(ctx context.Context, handler datastore.CollectionIteratorHandler) error {
+	p.mutex.RLock()
+	queues := make(map[string][]*Transaction, len(p.senders))
+	heads := make(txnHeap, 0, len(p.senders))
+	for clientID, sq := range p.senders {
+		txns := sq.executable()
+		if len(txns) == 0 {
+			continue
+		}
+		for i := range txns {
+			txns[i] = txns[i].Clone()
+		}
+		heads = append(heads, txns[0])
+		queues[clientID] = txns[1:]
+	}
+	p.mutex.RUnlock()
+
+	heap.Init(&heads)
+	for heads.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		txn := heap.Pop(&heads).(*Transaction)
+		clientID := txn.ClientID
+		next, err := handler(ctx, txn)
+		if err != nil {
+			return err
+		}
+		if !next {
+			return nil
+		}
+		if q := queues[clientID]; len(q) > 0 {
+			heap.Push(&heads, q[0])
+			queues[clientID] = q[1:]
+		}
+	}
+	return nil
+}
+
+// PoolStats is the summary of the transactions pool.
+type PoolStats struct {
+	Size         int `json:"size"`
+	Executable   int `json:"executable"`
+	Queued       int `json:"queued"`
+	Clients      int `json:"clients"`
+	MaxSize      int `json:"max_size"`
+	MaxPerSender int `json:"max_per_sender"`
+	PriceBump    int `json:"price_bump"`
+}
+
+func (p *Pool) Stats() PoolStats {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	stats := PoolStats{
+		Size:         len(p.byHash),
+		Clients:      len(p.senders),
+		MaxSize:      p.config.MaxSize,
+		MaxPerSender: p.config.MaxPerSender,
+		PriceBump:    p.config.PriceBump,
+	}
+	for _, sq := range p.senders {
+		stats.Executable += len(sq.executable())
+	}
+	stats.Queued = stats.Size - stats.Executable
+	return stats
+}
+
+// PoolTxn is a pooled transaction as listed by the pool inspection.
+type PoolTxn struct {
+	Hash         string           `json:"hash"`
+	Nonce        int64            `json:"nonce"`
+	Fee          currency.Coin    `json:"fee"`
+	CreationDate common.Timestamp `json:"creation_date"`
+}
+
+// PoolClientTxns lists the pooled transactions of a client.
+type PoolClientTxns struct {
+	ClientID string `json:"client_id"`
+	// Nonce is the latest nonce of the client known to the pool.
+	Nonce      int64     `json:"nonce"`
+	Executable []PoolTxn `json:"executable"`
+	Queued     []PoolTxn `json:"queued"`
+}
+
+// ClientTxns returns the pooled transactions of the client.
+func (p *Pool) ClientTxns(clientID string) PoolClientTxns {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	res := PoolClientTxns{ClientID: clientID}
+	sq, ok := p.senders[clientID]
+	if !ok {
+		return res
+	}
+	res.Nonce = sq.nonce
+
+	executable := make(map[string]struct{})
+	for _, txn := range sq.executable() {
+		executable[txn.Hash] = struct{}{}
+		res.Executable = append(res.Executable, toPoolTxn(txn))
+	}
+	for _, txn := range sq.txns {
+		if _, ok := executable[txn.Hash]; !ok {
+			res.Queued = append(res.Queued, toPoolTxn(txn))
+		}
+	}
+	sort.Slice(res.Queued, func(i, j int) bool { return res.Queued[i].Nonce < res.Queued[j].Nonce })
+	return res
+}
+
+func toPoolTxn(txn *Transaction) PoolTxn {
+	return PoolTxn{
+		Hash:         txn.Hash,
+		Nonce:        txn.Nonce,
+		Fee:          txn.Fee,
+		CreationDate: txn.CreationDate,
+	}
+}
+
+// AddToPool admits the transaction to the pool when it's enabled, the
+// transactions it replaces or evicts are removed from the redis collection.
+// The nonce is the latest nonce of the client or negative when not known.
+func AddToPool(ctx context.Context, txn *Transaction, nonce int64) error {
+	if txnPool == nil {
+		return nil
+	}
+	removed, err := txnPool.Add(txn, nonce)
+	if len(removed) > 0 {
+		entities := make([]datastore.Entity, 0, len(removed))
+		hashes := make([]string, 0, len(removed))
+		for _, t := range removed {
+			entities = append(entities, t)
+			hashes = append(hashes, t.Hash)
+		}
+		logging.Logger.Debug("txn pool - remove replaced or evicted txns",
+			zap.String("txn", txn.Hash),
+			zap.Strings("removed", hashes))
+
+		cctx := memorystore.WithEntityConnection(ctx, transactionEntityMetadata)
+		defer memorystore.Close(cctx)
+		if derr := transactionEntityMetadata.GetStore().MultiDelete(cctx, transactionEntityMetadata, entities); derr != nil {
+			logging.Logger.Error("txn pool - remove txns failed", zap.Error(derr))
+		}
+	}
+	return err
+}
+
+// RemoveFromTxnPool removes the transactions from the pool when it's enabled.
+func RemoveFromTxnPool(txns []datastore.Entity) {
+	if txnPool != nil {
+		txnPool.Remove(txns)
+	}
+}
+
+// LoadPool fills the pool with the transactions of the redis collection, the
+// transactions evicted by the pool are removed from the collection.
+func LoadPool(ctx context.Context) error {
+	if txnPool == nil {
+		return nil
+	}
+	cctx := memorystore.WithEntityConnection(ctx, transactionEntityMetadata)
+	defer memorystore.Close(cctx)
+
+	txn := transactionEntityMetadata.Instance().(*Transaction)
+	collectionName := txn.GetCollectionName()
+
+	var removed []datastore.Entity
+	err := transactionEntityMetadata.GetStore().IterateCollection(cctx, transactionEntityMetadata, collectionName,
+		func(ctx context.Context, qe datastore.CollectionEntity) (bool, error) {
+			txn, ok := qe.(*Transaction)
+			if !ok {
+				return true, nil
+			}
+			evicted, err := txnPool.Add(txn, -1)
+			if err != nil {
+				removed = append(removed, txn)
+				return true, nil
+			}
+			for _, t := range evicted {
+				removed = append(removed, t)
+			}
+			return true, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	logging.Logger.Info("txn pool - loaded",
+		zap.Int("size", txnPool.Size()),
+		zap.Int("removed", len(removed)))
+	if len(removed) > 0 {
+		return transactionEntityMetadata.GetStore().MultiDelete(cctx, transactionEntityMetadata, removed)
+	}
+	return nil
+}
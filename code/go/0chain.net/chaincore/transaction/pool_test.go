@@ -0,0 +1,142 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func newPoolTxn(clientID string, nonce int64, fee currency.Coin) *Transaction {
+	txn := &Transaction{
+		ClientID:     clientID,
+		Nonce:        nonce,
+		Fee:          fee,
+		CreationDate: common.Timestamp(nonce),
+	}
+	txn.Hash = fmt.Sprintf("%s:%d:%d", clientID, nonce, fee)
+	return txn
+}
+
+func TestPoolReplaceByFee(t *testing.T) {
+	p := NewPool(PoolConfig{PriceBump: 10})
+
+	old := newPoolTxn("a", 1, 100)
+	_, err := p.Add(old, 0)
+	require.NoError(t, err)
+
+	// below the price bump
+	_, err = p.Add(newPoolTxn("a", 1, 105), 0)
+	require.Equal(t, ErrPoolReplaceUnderpriced, err)
+
+	removed, err := p.Add(newPoolTxn("a", 1, 110), 0)
+	require.NoError(t, err)
+	require.Equal(t, []*Transaction{old}, removed)
+	require.Equal(t, 1, p.Size())
+
+	_, err = p.Add(newPoolTxn("a", 0, 1000), 0)
+	require.Equal(t, ErrPoolNonceTooLow, err)
+}
+
+func TestPoolSenderLimit(t *testing.T) {
+	p := NewPool(PoolConfig{MaxPerSender: 2})
+
+	for n := int64(2); n <= 3; n++ {
+		_, err := p.Add(newPoolTxn("a", n, 1), 0)
+		require.NoError(t, err)
+	}
+	_, err := p.Add(newPoolTxn("a", 4, 1), 0)
+	require.Equal(t, ErrPoolSenderLimit, err)
+
+	// a lower nonce evicts the highest one
+	removed, err := p.Add(newPoolTxn("a", 1, 1), 0)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Equal(t, int64(3), removed[0].Nonce)
+
+	stats := p.Stats()
+	require.Equal(t, 2, stats.Size)
+	require.Equal(t, 2, stats.Executable)
+}
+
+func TestPoolEviction(t *testing.T) {
+	p := NewPool(PoolConfig{MaxSize: 3})
+
+	_, err := p.Add(newPoolTxn("a", 1, 5), 0)
+	require.NoError(t, err)
+	_, err = p.Add(newPoolTxn("b", 1, 10), 0)
+	require.NoError(t, err)
+	// not executable, nonce 1 is missing
+	gapped := newPoolTxn("c", 2, 20)
+	_, err = p.Add(gapped, 0)
+	require.NoError(t, err)
+
+	// the non executable transaction goes first even though it pays more
+	removed, err := p.Add(newPoolTxn("d", 1, 7), 0)
+	require.NoError(t, err)
+	require.Equal(t, []*Transaction{gapped}, removed)
+
+	// then the cheapest one
+	removed, err = p.Add(newPoolTxn("e", 1, 6), 0)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Equal(t, "a", removed[0].ClientID)
+
+	_, err = p.Add(newPoolTxn("f", 1, 6), 0)
+	require.Equal(t, ErrPoolFull, err)
+	require.Equal(t, 3, p.Size())
+}
+
+func TestPoolIterate(t *testing.T) {
+	p := NewPool(PoolConfig{})
+
+	for _, txn := range []*Transaction{
+		newPoolTxn("a", 1, 1),
+		newPoolTxn("a", 2, 50),
+		newPoolTxn("b", 1, 10),
+		newPoolTxn("b", 3, 100), // nonce 2 is missing
+		newPoolTxn("c", 5, 5),   // nonce not known, starts the queue
+	} {
+		_, err := p.Add(txn, -1)
+		require.NoError(t, err)
+	}
+	_, err := p.Add(newPoolTxn("b", 0, 1), 0)
+	require.Equal(t, ErrPoolNonceTooLow, err)
+
+	var hashes []string
+	err = p.Iterate(context.Background(), func(ctx context.Context, qe datastore.CollectionEntity) (bool, error) {
+		hashes = append(hashes, qe.GetKey())
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b:1:10", "c:5:5", "a:1:1", "a:2:50"}, hashes)
+
+	stats := p.Stats()
+	require.Equal(t, 4, stats.Executable)
+	require.Equal(t, 1, stats.Queued)
+}
+
+func TestPoolFinalized(t *testing.T) {
+	p := NewPool(PoolConfig{})
+
+	for n := int64(1); n <= 4; n++ {
+		_, err := p.Add(newPoolTxn("a", n, 1), 0)
+		require.NoError(t, err)
+	}
+
+	// the generated blocks have the client id cleared
+	finalized := newPoolTxn("a", 2, 1)
+	finalized.ClientID = ""
+	p.Finalized([]datastore.Entity{finalized})
+
+	res := p.ClientTxns("a")
+	require.Equal(t, int64(2), res.Nonce)
+	require.Len(t, res.Executable, 2)
+	require.Equal(t, int64(3), res.Executable[0].Nonce)
+	require.Empty(t, res.Queued)
+	require.Equal(t, 2, p.Size())
+}
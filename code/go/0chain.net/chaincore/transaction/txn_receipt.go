@@ -1,6 +1,9 @@
 package transaction
 
-import "github.com/0chain/common/core/util"
+import (
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+)
 
 //TxnReceipt - a transaction receipt is a processed transaction that contains the output
 type TxnReceipt struct {
@@ -9,15 +12,25 @@ type TxnReceipt struct {
 
 //GetHash - implement interface
 func (rh *TxnReceipt) GetHash() string {
-	return rh.Transaction.OutputHash
+	return ReceiptHash(rh.Transaction.OutputHash, rh.Transaction.ResultsHash)
 }
 
 /*GetHashBytes - implement Hashable interface */
 func (rh *TxnReceipt) GetHashBytes() []byte {
-	return util.HashStringToBytes(rh.Transaction.OutputHash)
+	return util.HashStringToBytes(rh.GetHash())
 }
 
 //NewTransactionReceipt - create a new transaction receipt
 func NewTransactionReceipt(t *Transaction) *TxnReceipt {
 	return &TxnReceipt{Transaction: t}
 }
+
+// ReceiptHash - the hash of the receipt of the transaction output and results,
+// the output hash alone for the transactions without results hash, the ones
+// executed before the hermes hardfork
+func ReceiptHash(outputHash, resultsHash string) string {
+	if resultsHash == "" {
+		return outputHash
+	}
+	return encryption.Hash(outputHash + resultsHash)
+}
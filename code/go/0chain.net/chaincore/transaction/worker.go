@@ -72,6 +72,7 @@ func CleanupWorker(ctx context.Context) {
 					zap.Int("invalid_count", len(invalidTxns)),
 					zap.Strings("txns", invalidTxnHashes),
 					zap.Int64("collection_size", mstore.GetCollectionSize(cctx, transactionEntityMetadata, collectionName)))
+				RemoveFromTxnPool(invalidTxns)
 				err = transactionEntityMetadata.GetStore().MultiDelete(cctx, transactionEntityMetadata, invalidTxns)
 				if err != nil {
 					logging.Logger.Error("Error in MultiDelete", zap.Error(err))
@@ -88,6 +89,7 @@ func CleanupWorker(ctx context.Context) {
 					zap.String("collection", collectionName),
 					zap.Int("missing_count", len(invalidHashes)),
 					zap.Strings("txns", txnHashes))
+				RemoveFromTxnPool(invalidHashes)
 				err = transactionEntityMetadata.GetStore().MultiDeleteFromCollection(cctx, transactionEntityMetadata, invalidHashes)
 				if err != nil {
 					logging.Logger.Error("Error in MultiDeleteFromCollection", zap.Error(err))
@@ -108,6 +110,9 @@ func RemoveFromPool(ctx context.Context, txns []datastore.Entity) {
 	collectionName := txn.GetCollectionName()
 
 	logging.Logger.Debug("cleaning past transactions")
+	if txnPool != nil {
+		txnPool.Finalized(txns)
+	}
 	clientMaxNonce := make(map[string]int64)
 	for _, e := range txns {
 		blockTx, ok := e.(*Transaction)
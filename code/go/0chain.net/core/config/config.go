@@ -22,6 +22,9 @@ var (
 // SetupDefaultConfig - setup the default config options that can be overridden via the config file
 func SetupDefaultConfig() {
 	viper.SetDefault("logging.level", "info")
+	viper.SetDefault("trace.sample_ratio", 1.0)
+	viper.SetDefault("trace.exporter", "file")
+	viper.SetDefault("trace.file", "log/trace.json")
 	viper.SetDefault("network.relay_time", 200)
 	viper.SetDefault("network.timeout.small_message", 500)
 	viper.SetDefault("network.timeout.large_message", 1000)
@@ -91,6 +94,7 @@ func SetupDefaultConfig() {
 	viper.SetDefault(GlobalSettingName[DbsAggregateDebug], false)
 	viper.SetDefault(GlobalSettingName[DbsAggregatePeriod], 10)
 	viper.SetDefault(GlobalSettingName[DbsAggregatePageLimit], 50)
+	viper.SetDefault(GlobalSettingName[DbsHistoryPeriod], 1000)
 
 	viper.SetDefault("kafka.host", "localhost:9092")
 	viper.SetDefault("kafka.topic", "events")
@@ -192,6 +196,7 @@ type ChainConfig interface {
 	TxnCostFeeCoeff() int
 	TxnFutureNonce() int
 	BlockFinalizationTimeout() time.Duration
+	ParallelExecutionWorkers() int
 }
 
 type DbAccess struct {
@@ -214,6 +219,63 @@ type DbAccess struct {
 	KafkaPassword       string
 	KafkaWriteTimeout   time.Duration
 	KafkaTriggerRound   int64
+	// EventSink is the sink the events are published to, kafka is used when it's
+	// enabled and no other sink is configured.
+	EventSink EventSinkConfig
+}
+
+const (
+	EventSinkKafka   = "kafka"
+	EventSinkNats    = "nats"
+	EventSinkFile    = "file"
+	EventSinkWebhook = "webhook"
+)
+
+type EventSinkConfig struct {
+	Type string
+	// Topic and TriggerRound default to the kafka ones when not set.
+	Topic          string
+	TriggerRound   int64
+	PublishTimeout time.Duration
+
+	NatsURL      string
+	NatsUsername string
+	NatsPassword string
+
+	FilePath string
+
+	WebhookURL        string
+	WebhookSecret     string
+	WebhookTimeout    time.Duration
+	WebhookMaxRetries int
+	WebhookRetryDelay time.Duration
+}
+
+// SinkType returns the type of the configured event sink, empty when the events are not published.
+func (c DbAccess) SinkType() string {
+	if c.EventSink.Type != "" {
+		return c.EventSink.Type
+	}
+	if c.KafkaEnabled {
+		return EventSinkKafka
+	}
+	return ""
+}
+
+// SinkTopic returns the topic the events are published to.
+func (c DbAccess) SinkTopic() string {
+	if c.EventSink.Topic != "" {
+		return c.EventSink.Topic
+	}
+	return c.KafkaTopic
+}
+
+// SinkTriggerRound returns the round from which the events are published.
+func (c DbAccess) SinkTriggerRound() int64 {
+	if c.EventSink.TriggerRound > 0 || c.SinkType() != EventSinkKafka {
+		return c.EventSink.TriggerRound
+	}
+	return c.KafkaTriggerRound
 }
 
 type DbSettings struct {
@@ -224,6 +286,7 @@ type DbSettings struct {
 	PermanentPartitionChangePeriod int64 `json:"permanent_partition_change_period"`
 	PermanentPartitionKeepCount    int64 `json:"permanent_partition_keep_count"`
 	PageLimit                      int64 `json:"page_limit"`
+	HistoryPeriod                  int64 `json:"history_period"`
 }
 
 func (s *DbSettings) Update(updates map[string]string) error {
@@ -262,6 +325,13 @@ func (s *DbSettings) Update(updates map[string]string) error {
 		}
 		s.PageLimit = iValue.(int64)
 	}
+	if value, found := updates[DbsHistoryPeriod.String()]; found {
+		iValue, err := StringToInterface(value, Int64)
+		if err != nil {
+			return err
+		}
+		s.HistoryPeriod = iValue.(int64)
+	}
 	return nil
 }
 
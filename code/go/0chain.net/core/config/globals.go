@@ -49,6 +49,11 @@ const (
 	TransactionExempt
 	TransactionCostFeeCoeff
 	TransactionFutureNonce
+	TransactionBaseFeeEnabled
+	TransactionBaseFeeInitial
+	TransactionBaseFeeMin
+	TransactionBaseFeeMaxChangeDenominator
+	TransactionBaseFeeTargetUtilization
 
 	ClientSignatureScheme
 	ClientDiscover // todo from chain
@@ -87,6 +92,7 @@ const (
 	DbsPermanentPartitionChangePeriod
 	DbsPermanentPartitionKeepCount
 	DbsAggregatePageLimit
+	DbsHistoryPeriod
 
 	HealthCheckDeepScanEnabled          // todo restart worker
 	HealthCheckDeepScanBatchSize        // todo restart worker
@@ -187,6 +193,11 @@ func initGlobalSettingNames() {
 	GlobalSettingName[TransactionExempt] = "server_chain.transaction.exempt"
 	GlobalSettingName[TransactionCostFeeCoeff] = "server_chain.transaction.cost_fee_coeff"
 	GlobalSettingName[TransactionFutureNonce] = "server_chain.transaction.future_nonce"
+	GlobalSettingName[TransactionBaseFeeEnabled] = "server_chain.transaction.base_fee.enabled"
+	GlobalSettingName[TransactionBaseFeeInitial] = "server_chain.transaction.base_fee.initial"
+	GlobalSettingName[TransactionBaseFeeMin] = "server_chain.transaction.base_fee.min"
+	GlobalSettingName[TransactionBaseFeeMaxChangeDenominator] = "server_chain.transaction.base_fee.max_change_denominator"
+	GlobalSettingName[TransactionBaseFeeTargetUtilization] = "server_chain.transaction.base_fee.target_utilization"
 
 	GlobalSettingName[ClientSignatureScheme] = "server_chain.client.signature_scheme"
 	GlobalSettingName[ClientDiscover] = "server_chain.client.discover"
@@ -226,6 +237,7 @@ func initGlobalSettingNames() {
 	GlobalSettingName[DbsPermanentPartitionKeepCount] = "server_chain.dbs.settings.rolling_partition_keep_count"
 	GlobalSettingName[DbsAggregatePageLimit] = "server_chain.dbs.settings.page_limit" +
 		""
+	GlobalSettingName[DbsHistoryPeriod] = "server_chain.dbs.settings.history_period"
 	GlobalSettingName[HealthCheckDeepScanEnabled] = "server_chain.health_check.deep_scan.enabled"
 	GlobalSettingName[HealthCheckDeepScanBatchSize] = "server_chain.health_check.deep_scan.batch_size"
 	GlobalSettingName[HealthCheckDeepScanWindow] = "server_chain.health_check.deep_scan.window"
@@ -316,6 +328,12 @@ func initGlobalSettings() {
 		GlobalSettingName[TransactionCostFeeCoeff]:   {Int, true},
 		GlobalSettingName[TransactionFutureNonce]:    {Int, true},
 
+		GlobalSettingName[TransactionBaseFeeEnabled]:              {Boolean, true},
+		GlobalSettingName[TransactionBaseFeeInitial]:              {Float64, true},
+		GlobalSettingName[TransactionBaseFeeMin]:                  {Float64, true},
+		GlobalSettingName[TransactionBaseFeeMaxChangeDenominator]: {Int, true},
+		GlobalSettingName[TransactionBaseFeeTargetUtilization]:    {Float64, true},
+
 		GlobalSettingName[ClientSignatureScheme]: {String, true},
 		GlobalSettingName[ClientDiscover]:        {Boolean, false},
 
@@ -353,6 +371,7 @@ func initGlobalSettings() {
 		GlobalSettingName[DbsPermanentPartitionChangePeriod]: {Int64, true},
 		GlobalSettingName[DbsPermanentPartitionKeepCount]:    {Int64, true},
 		GlobalSettingName[DbsAggregatePageLimit]:             {Int64, true},
+		GlobalSettingName[DbsHistoryPeriod]:                  {Int64, true},
 
 		GlobalSettingName[HealthCheckDeepScanEnabled]:          {Boolean, false},
 		GlobalSettingName[HealthCheckDeepScanBatchSize]:        {Int64, false},
@@ -1,6 +1,7 @@
 package encryption
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/herumi/bls-go-binary/bls"
@@ -72,6 +73,51 @@ func BLS0GenerateThresholdKeyShares(t, n int, originalKey SignatureScheme) ([]Th
 	return shares, nil
 }
 
+// BLS0VerifyThresholdKeyShares verifies that the public keys of the ids are
+// T-of-N shares of the public key, all of them being on the polynomial of the
+// public key.
+func BLS0VerifyThresholdKeyShares(t int, publicKey string, ids, publicKeys []string) error {
+	if t < 1 || len(ids) != len(publicKeys) || len(ids) < t {
+		return errors.New("invalid number of key shares")
+	}
+
+	key := NewBLS0ChainScheme()
+	if err := key.SetPublicKey(publicKey); err != nil {
+		return err
+	}
+
+	var (
+		blsIDs  = make([]bls.ID, len(ids))
+		blsPubs = make([]bls.PublicKey, len(ids))
+	)
+	for i := range ids {
+		share := NewBLS0ChainThresholdScheme()
+		if err := share.SetPublicKey(publicKeys[i]); err != nil {
+			return err
+		}
+		if err := share.SetID(ids[i]); err != nil {
+			return err
+		}
+		blsIDs[i] = share.id
+		blsPubs[i] = *share.pubKey
+	}
+
+	// the first t-1 shares and the public key define the polynomial, each of
+	// the other shares recovers the public key with them
+	recovers := func(j int) bool {
+		idVec := append(append([]bls.ID{}, blsIDs[:t-1]...), blsIDs[j])
+		pubVec := append(append([]bls.PublicKey{}, blsPubs[:t-1]...), blsPubs[j])
+		var pk bls.PublicKey
+		return pk.Recover(pubVec, idVec) == nil && pk.IsEqual(key.pubKey)
+	}
+	for j := t - 1; j < len(ids); j++ {
+		if !recovers(j) {
+			return fmt.Errorf("public key of id %s is not a share of the public key", ids[j])
+		}
+	}
+	return nil
+}
+
 //NewBLS0ChainReconstruction - create a new instance
 func NewBLS0ChainReconstruction(t, n int) *BLS0ChainReconstruction {
 	return &BLS0ChainReconstruction{
@@ -165,6 +165,54 @@ func TestBLS0ChainThresholdScheme_GetID(t *testing.T) {
 	}
 }
 
+func TestVerifyThresholdKeyShares(t *testing.T) {
+	T := 3
+	N := 5
+	scheme := "bls0chain"
+
+	shares := func() (string, []string, []string) {
+		groupKey := GetSignatureScheme(scheme)
+		if err := groupKey.GenerateKeys(); err != nil {
+			t.Fatal(err)
+		}
+		tss, err := GenerateThresholdKeyShares(scheme, T, N, groupKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids, keys []string
+		for _, share := range tss {
+			ids = append(ids, share.GetID())
+			keys = append(keys, share.GetPublicKey())
+		}
+		return groupKey.GetPublicKey(), ids, keys
+	}
+
+	publicKey, ids, keys := shares()
+	if err := VerifyThresholdKeyShares(scheme, T, publicKey, ids, keys); err != nil {
+		t.Fatal(err)
+	}
+
+	// shares of another key
+	otherKey, _, otherKeys := shares()
+	if err := VerifyThresholdKeyShares(scheme, T, otherKey, ids, keys); err == nil {
+		t.Error("shares of another key verified")
+	}
+
+	// one of the shares is not on the polynomial of the key
+	mixed := append([]string{}, keys...)
+	mixed[N-1] = otherKeys[N-1]
+	if err := VerifyThresholdKeyShares(scheme, T, publicKey, ids, mixed); err == nil {
+		t.Error("foreign share verified")
+	}
+
+	// the ids don't match the shares
+	swapped := append([]string{}, ids...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	if err := VerifyThresholdKeyShares(scheme, T, publicKey, swapped, keys); err == nil {
+		t.Error("shares with swapped ids verified")
+	}
+}
+
 func TestBLS0GenerateThresholdKeyShares(t *testing.T) {
 	t.Parallel()
 
@@ -131,6 +131,17 @@ func GenerateThresholdKeyShares(sigScheme string, t, n int, originalKey Signatur
 	}
 }
 
+// VerifyThresholdKeyShares - verify that the public keys of the ids are T-of-N
+// shares of the public key
+func VerifyThresholdKeyShares(sigScheme string, t int, publicKey string, ids, publicKeys []string) error {
+	switch sigScheme {
+	case SignatureSchemeBls0chain:
+		return BLS0VerifyThresholdKeyShares(t, publicKey, ids, publicKeys)
+	default:
+		return fmt.Errorf("key shares not supported by signature scheme: %v", sigScheme)
+	}
+}
+
 // IsValidReconstructSignatureScheme - whether a signature reconstruction scheme exists
 func IsValidReconstructSignatureScheme(sigScheme string) bool {
 	switch sigScheme {
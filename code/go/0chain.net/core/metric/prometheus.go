@@ -0,0 +1,269 @@
+package metric
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// PrometheusNamespace prefixes the name of every exported metric
+const PrometheusNamespace = "zchain"
+
+// the prometheus metric types
+const (
+	prometheusGauge   = "gauge"
+	prometheusCounter = "counter"
+	prometheusSummary = "summary"
+)
+
+var (
+	prometheusQuantiles = []float64{0.5, 0.9, 0.95, 0.99}
+
+	// sc:<sc address>:func:<function name>
+	scFuncMetricRe = regexp.MustCompile(`^sc:([^:]+):func:(.+)$`)
+	// <node id>.<uri>.time and <node id>.<uri>.size of the n2n calls
+	n2nMetricRe = regexp.MustCompile(`^([0-9a-f]{64})\.(.+)\.(time|size)$`)
+
+	invalidNameCharsRe = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+)
+
+// Labels of a prometheus sample
+type Labels map[string]string
+
+type prometheusSample struct {
+	suffix string
+	labels string
+	value  float64
+}
+
+type prometheusFamily struct {
+	name    string
+	help    string
+	typ     string
+	samples []prometheusSample
+}
+
+// PrometheusWriter collects go-metrics registries and gauges and writes them
+// in the prometheus text exposition format.
+type PrometheusWriter struct {
+	constLabels Labels
+	families    map[string]*prometheusFamily
+	// sources maps the exported names to the names of the metrics they are made of
+	sources map[string]string
+}
+
+// NewPrometheusWriter - create a writer, the constant labels are added to every sample
+func NewPrometheusWriter(constLabels Labels) *PrometheusWriter {
+	return &PrometheusWriter{
+		constLabels: constLabels,
+		families:    make(map[string]*prometheusFamily),
+		sources:     make(map[string]string),
+	}
+}
+
+// AddGauge adds a sample of a gauge
+func (pw *PrometheusWriter) AddGauge(name, help string, value float64, labels Labels) {
+	pw.family(pw.uniqueName(name, name), help, prometheusGauge).add("", pw.formatLabels(labels), value)
+}
+
+// AddRegistry adds all the metrics of the registry. The smart contract function
+// and the n2n metrics are exported as a single family labeled with the smart
+// contract and function or the peer node and uri. The metrics are added sorted
+// by name, so the suffixes of the colliding names are stable across scrapes.
+func (pw *PrometheusWriter) AddRegistry(r metrics.Registry) {
+	all := make(map[string]interface{})
+	r.Each(func(name string, i interface{}) {
+		all[name] = i
+	})
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		base, help, labels := prometheusName(name)
+		if labels == nil {
+			base = pw.uniqueName(base, name)
+		}
+		switch m := all[name].(type) {
+		case metrics.Counter:
+			pw.family(base+"_total", help, prometheusCounter).
+				add("", pw.formatLabels(labels), float64(m.Count()))
+		case metrics.Gauge:
+			pw.family(base, help, prometheusGauge).
+				add("", pw.formatLabels(labels), float64(m.Value()))
+		case metrics.GaugeFloat64:
+			pw.family(base, help, prometheusGauge).
+				add("", pw.formatLabels(labels), m.Value())
+		case metrics.Meter:
+			pw.family(base+"_total", help, prometheusCounter).
+				add("", pw.formatLabels(labels), float64(m.Count()))
+		case metrics.Timer:
+			s := m.Snapshot()
+			// the timers are measured in nanoseconds
+			pw.addSummary(pw.family(base+"_seconds", help, prometheusSummary), labels,
+				s.Percentiles(prometheusQuantiles), float64(s.Sum())/float64(time.Second), s.Count(),
+				1/float64(time.Second))
+		case metrics.Histogram:
+			s := m.Snapshot()
+			pw.addSummary(pw.family(base, help, prometheusSummary), labels,
+				s.Percentiles(prometheusQuantiles), float64(s.Sum()), s.Count(), 1)
+		}
+	}
+}
+
+// uniqueName returns the exported name of the source metric. The names
+// sanitized to the same one, as a.b and a_b, get a numbered suffix.
+func (pw *PrometheusWriter) uniqueName(base, source string) string {
+	name := base
+	for i := 2; ; i++ {
+		s, ok := pw.sources[name]
+		if !ok {
+			pw.sources[name] = source
+			return name
+		}
+		if s == source {
+			return name
+		}
+		name = base + "_" + strconv.Itoa(i)
+	}
+}
+
+func (pw *PrometheusWriter) addSummary(f *prometheusFamily, labels Labels,
+	percentiles []float64, sum float64, count int64, scale float64) {
+
+	for i, q := range prometheusQuantiles {
+		ql := make(Labels, len(labels)+1)
+		for k, v := range labels {
+			ql[k] = v
+		}
+		ql["quantile"] = strconv.FormatFloat(q, 'g', -1, 64)
+		f.add("", pw.formatLabels(ql), percentiles[i]*scale)
+	}
+	ls := pw.formatLabels(labels)
+	f.add("_sum", ls, sum)
+	f.add("_count", ls, float64(count))
+}
+
+// family returns the family of the name, a family of another type gets the
+// type appended to the name.
+func (pw *PrometheusWriter) family(name, help, typ string) *prometheusFamily {
+	name = PrometheusNamespace + "_" + name
+	f, ok := pw.families[name]
+	if ok && f.typ != typ {
+		return pw.family(strings.TrimPrefix(name, PrometheusNamespace+"_")+"_"+typ, help, typ)
+	}
+	if !ok {
+		f = &prometheusFamily{name: name, help: help, typ: typ}
+		pw.families[name] = f
+	}
+	return f
+}
+
+func (f *prometheusFamily) add(suffix, labels string, value float64) {
+	f.samples = append(f.samples, prometheusSample{suffix: suffix, labels: labels, value: value})
+}
+
+func (pw *PrometheusWriter) formatLabels(labels Labels) string {
+	all := make(Labels, len(pw.constLabels)+len(labels))
+	for k, v := range pw.constLabels {
+		all[k] = v
+	}
+	for k, v := range labels {
+		all[k] = v
+	}
+	if len(all) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(all[k]))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// WriteTo writes the families sorted by name
+func (pw *PrometheusWriter) WriteTo(w io.Writer) (int64, error) {
+	names := make([]string, 0, len(pw.families))
+	for name := range pw.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, name := range names {
+		f := pw.families[name]
+		bw.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		bw.WriteString("# TYPE " + f.name + " " + f.typ + "\n")
+		for _, s := range f.samples {
+			bw.WriteString(f.name + s.suffix + s.labels + " " +
+				strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// prometheusName maps a go-metrics name to a prometheus name and labels
+func prometheusName(name string) (string, string, Labels) {
+	if m := scFuncMetricRe.FindStringSubmatch(name); m != nil {
+		return "smart_contract_function", "Smart contract function executions",
+			Labels{"smart_contract": m[1], "function": m[2]}
+	}
+	if m := n2nMetricRe.FindStringSubmatch(name); m != nil {
+		return "n2n_" + m[3], "Node to node requests " + m[3],
+			Labels{"peer": m[1], "uri": m[2]}
+	}
+	return sanitizeName(name), name, nil
+}
+
+func sanitizeName(name string) string {
+	return strings.Trim(invalidNameCharsRe.ReplaceAllString(name, "_"), "_")
+}
+
+var (
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
+
+func escapeHelp(v string) string {
+	return helpReplacer.Replace(v)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
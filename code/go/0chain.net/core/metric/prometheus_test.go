@@ -0,0 +1,97 @@
+package metric
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusWriter(t *testing.T) {
+	t.Parallel()
+
+	const (
+		scAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9"
+		peer      = "31810bd1258ae95955fb40c7ef72498a556d3587121376d9059119d280f34929"
+	)
+
+	r := metrics.NewRegistry()
+	metrics.GetOrRegisterTimer("sc:"+scAddress+":func:add_miner", r).Update(2 * time.Second)
+	metrics.GetOrRegisterCounter("sc:"+scAddress+":func:update_globals", r).Inc(3)
+	metrics.GetOrRegisterTimer(peer+"./v1/_m2m/block/verify.time", r).Update(time.Second)
+	metrics.GetOrRegisterGauge("event db-round", r).Update(7)
+	metrics.GetOrRegisterHistogram("bs_histogram", r, metrics.NewUniformSample(16)).Update(5)
+
+	pw := NewPrometheusWriter(Labels{"node": "n0"})
+	pw.AddRegistry(r)
+	pw.AddGauge("lfb_round", "Round of the latest finalized block", 10, nil)
+
+	var sb strings.Builder
+	n, err := pw.WriteTo(&sb)
+	require.NoError(t, err)
+	require.EqualValues(t, sb.Len(), n)
+
+	out := sb.String()
+	for _, line := range []string{
+		"# TYPE zchain_smart_contract_function_seconds summary",
+		`zchain_smart_contract_function_seconds{function="add_miner",node="n0",quantile="0.5",smart_contract="` + scAddress + `"} 2`,
+		`zchain_smart_contract_function_seconds_sum{function="add_miner",node="n0",smart_contract="` + scAddress + `"} 2`,
+		`zchain_smart_contract_function_seconds_count{function="add_miner",node="n0",smart_contract="` + scAddress + `"} 1`,
+		"# TYPE zchain_smart_contract_function_total counter",
+		`zchain_smart_contract_function_total{function="update_globals",node="n0",smart_contract="` + scAddress + `"} 3`,
+		`zchain_n2n_time_seconds_count{node="n0",peer="` + peer + `",uri="/v1/_m2m/block/verify"} 1`,
+		"# TYPE zchain_event_db_round gauge",
+		`zchain_event_db_round{node="n0"} 7`,
+		`zchain_bs_histogram_sum{node="n0"} 5`,
+		`# HELP zchain_lfb_round Round of the latest finalized block`,
+		`zchain_lfb_round{node="n0"} 10`,
+	} {
+		require.Contains(t, out, line+"\n")
+	}
+
+	// families are sorted by name
+	require.Less(t, strings.Index(out, "zchain_bs_histogram"), strings.Index(out, "zchain_lfb_round"))
+}
+
+func TestPrometheusWriterFamilyTypes(t *testing.T) {
+	t.Parallel()
+
+	pw := NewPrometheusWriter(nil)
+	pw.AddGauge("round", "a gauge", 1, Labels{"v": `a"b`})
+
+	r := metrics.NewRegistry()
+	metrics.GetOrRegisterHistogram("round", r, metrics.NewUniformSample(16)).Update(1)
+	pw.AddRegistry(r)
+
+	var sb strings.Builder
+	_, err := pw.WriteTo(&sb)
+	require.NoError(t, err)
+
+	out := sb.String()
+	require.Contains(t, out, "# TYPE zchain_round gauge\n")
+	require.Contains(t, out, `zchain_round{v="a\"b"} 1`+"\n")
+	require.Contains(t, out, "# TYPE zchain_round_summary summary\n")
+}
+
+func TestPrometheusWriterNameCollisions(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	metrics.GetOrRegisterGauge("a_b", r).Update(1)
+	metrics.GetOrRegisterGauge("a.b", r).Update(2)
+	metrics.GetOrRegisterGauge("a-b", r).Update(3)
+
+	pw := NewPrometheusWriter(nil)
+	pw.AddRegistry(r)
+
+	var sb strings.Builder
+	_, err := pw.WriteTo(&sb)
+	require.NoError(t, err)
+
+	// the names are suffixed in the order of the source names
+	require.Equal(t, "# HELP zchain_a_b a-b\n# TYPE zchain_a_b gauge\nzchain_a_b 3\n"+
+		"# HELP zchain_a_b_2 a.b\n# TYPE zchain_a_b_2 gauge\nzchain_a_b_2 2\n"+
+		"# HELP zchain_a_b_3 a_b\n# TYPE zchain_a_b_3 gauge\nzchain_a_b_3 1\n", sb.String())
+}
@@ -0,0 +1,207 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanData is an ended span
+type SpanData struct {
+	Name         string      `json:"name"`
+	Kind         SpanKind    `json:"kind"`
+	TraceID      string      `json:"trace_id"`
+	SpanID       string      `json:"span_id"`
+	ParentSpanID string      `json:"parent_span_id,omitempty"`
+	Start        time.Time   `json:"start"`
+	End          time.Time   `json:"end"`
+	Attributes   []Attribute `json:"attributes,omitempty"`
+	Error        string      `json:"error,omitempty"`
+}
+
+// Resource describes the node recording the spans
+type Resource struct {
+	ServiceName string `json:"service_name"`
+	NodeID      string `json:"node_id"`
+}
+
+// Exporter sends batches of ended spans
+type Exporter interface {
+	Export(ctx context.Context, res Resource, spans []*SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// FileExporter appends the spans to a file, one JSON object per line
+type FileExporter struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFileExporter opens the file for appending, creating it and the directory as needed
+func NewFileExporter(path string) (*FileExporter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{file: f}, nil
+}
+
+type fileSpan struct {
+	Resource
+	*SpanData
+}
+
+// Export writes the spans
+func (fe *FileExporter) Export(_ context.Context, res Resource, spans []*SpanData) error {
+	fe.mutex.Lock()
+	defer fe.mutex.Unlock()
+
+	w := bufio.NewWriter(fe.file)
+	enc := json.NewEncoder(w)
+	for _, sd := range spans {
+		if err := enc.Encode(fileSpan{Resource: res, SpanData: sd}); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Shutdown closes the file
+func (fe *FileExporter) Shutdown(context.Context) error {
+	fe.mutex.Lock()
+	defer fe.mutex.Unlock()
+	return fe.file.Close()
+}
+
+// OTLPExporter posts the spans to the /v1/traces endpoint of an OTLP/HTTP
+// collector in the OTLP JSON encoding.
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewOTLPExporter - the endpoint is the base URL of the collector, for example http://localhost:4318
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	return &OTLPExporter{
+		url:    strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type otlpKeyValue struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func otlpAttribute(key, value string) otlpKeyValue {
+	kv := otlpKeyValue{Key: key}
+	kv.Value.StringValue = value
+	return kv
+}
+
+func (oe *OTLPExporter) request(res Resource, spans []*SpanData) *otlpRequest {
+	ss := otlpScopeSpans{Spans: make([]otlpSpan, 0, len(spans))}
+	ss.Scope.Name = "0chain.net"
+	for _, sd := range spans {
+		s := otlpSpan{
+			TraceID:           sd.TraceID,
+			SpanID:            sd.SpanID,
+			ParentSpanID:      sd.ParentSpanID,
+			Name:              sd.Name,
+			Kind:              sd.Kind,
+			StartTimeUnixNano: strconv.FormatInt(sd.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(sd.End.UnixNano(), 10),
+		}
+		for _, a := range sd.Attributes {
+			s.Attributes = append(s.Attributes, otlpAttribute(a.Key, a.Value))
+		}
+		if sd.Error != "" {
+			s.Status = otlpStatus{Code: 2, Message: sd.Error}
+		}
+		ss.Spans = append(ss.Spans, s)
+	}
+
+	rs := otlpResourceSpans{ScopeSpans: []otlpScopeSpans{ss}}
+	rs.Resource.Attributes = []otlpKeyValue{
+		otlpAttribute("service.name", res.ServiceName),
+		otlpAttribute("service.instance.id", res.NodeID),
+	}
+	return &otlpRequest{ResourceSpans: []otlpResourceSpans{rs}}
+}
+
+// Export posts the spans
+func (oe *OTLPExporter) Export(ctx context.Context, res Resource, spans []*SpanData) error {
+	body, err := json.Marshal(oe.request(res, spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oe.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := oe.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp export: %s", resp.Status)
+	}
+	return nil
+}
+
+// Shutdown - nothing to release
+func (oe *OTLPExporter) Shutdown(context.Context) error {
+	return nil
+}
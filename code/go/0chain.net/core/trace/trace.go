@@ -0,0 +1,252 @@
+// Package trace records spans of the block lifecycle and the node to node
+// messages. The trace context is propagated between the nodes with the W3C
+// traceparent header and the spans are exported to an OTLP collector or to a
+// local file.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// IsValid - a trace id is not all zeros
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID identifies a span of a trace
+type SpanID [8]byte
+
+// IsValid - a span id is not all zeros
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext is the part of a span propagated to the children and the other nodes
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid - the span context has a trace
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid()
+}
+
+// SpanKind is the role of the span in a node to node call
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = iota + 1
+	SpanKindServer
+	SpanKindClient
+)
+
+// Attribute is a key value annotation of a span
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// String attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 attribute
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: strconv.FormatInt(value, 10)}
+}
+
+// Span is a timed operation of a trace. A nil span, returned when the tracing
+// is disabled, ignores all the calls.
+type Span struct {
+	mutex  sync.Mutex
+	name   string
+	kind   SpanKind
+	sc     SpanContext
+	parent SpanID
+	start  time.Time
+	attrs  []Attribute
+	err    string
+	ended  bool
+}
+
+// SpanContext of the span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttributes adds the attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.attrs = append(s.attrs, attrs...)
+	s.mutex.Unlock()
+}
+
+// SetError marks the span failed, a nil error is ignored
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.err = err.Error()
+	s.mutex.Unlock()
+}
+
+// End the span and export it if sampled, only the first call has effect
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	end := time.Now()
+
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	attrs := make([]Attribute, len(s.attrs))
+	copy(attrs, s.attrs)
+	sd := &SpanData{
+		Name:       s.name,
+		Kind:       s.kind,
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Start:      s.start,
+		End:        end,
+		Attributes: attrs,
+		Error:      s.err,
+	}
+	if s.parent.IsValid() {
+		sd.ParentSpanID = s.parent.String()
+	}
+	s.mutex.Unlock()
+
+	if t := getTracer(); t != nil && s.sc.Sampled {
+		t.export(sd)
+	}
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a context with the span context as the parent of new spans
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the current span of the context
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// StartOption configures a new span
+type StartOption func(*Span)
+
+// WithKind sets the kind of the span, internal by default
+func WithKind(kind SpanKind) StartOption {
+	return func(s *Span) {
+		s.kind = kind
+	}
+}
+
+// WithAttributes sets the attributes of the span
+func WithAttributes(attrs ...Attribute) StartOption {
+	return func(s *Span) {
+		s.attrs = append(s.attrs, attrs...)
+	}
+}
+
+// Start a span as a child of the span of the context, or a new trace. The
+// returned context carries the span. Returns a nil span when tracing is disabled.
+func Start(ctx context.Context, name string, opts ...StartOption) (context.Context, *Span) {
+	t := getTracer()
+	if t == nil {
+		return ctx, nil
+	}
+
+	s := &Span{
+		name:  name,
+		kind:  SpanKindInternal,
+		start: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	parent := SpanContextFromContext(ctx)
+	if parent.IsValid() {
+		s.sc.TraceID = parent.TraceID
+		s.sc.Sampled = parent.Sampled
+		s.parent = parent.SpanID
+	} else {
+		s.sc.TraceID = newTraceID()
+		s.sc.Sampled = t.sample(s.sc.TraceID)
+	}
+	s.sc.SpanID = newSpanID()
+
+	return ContextWithSpanContext(ctx, s.sc), s
+}
+
+// WithRound returns a context that starts the spans of the round in a trace
+// derived from the round number, unless the context already has a span.
+// All the nodes record the spans of the same round in the same trace without
+// exchanging the trace context.
+func WithRound(ctx context.Context, round int64) context.Context {
+	t := getTracer()
+	if t == nil || SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	traceID := RoundTraceID(round)
+	return ContextWithSpanContext(ctx, SpanContext{
+		TraceID: traceID,
+		Sampled: t.sample(traceID),
+	})
+}
+
+// RoundTraceID is the trace id of the spans of the round
+func RoundTraceID(round int64) TraceID {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(round))
+	h := sha256.Sum256(append([]byte("round:"), b[:]...))
+
+	var id TraceID
+	copy(id[:], h[:])
+	return id
+}
+
+func newTraceID() (id TraceID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,153 @@
+package trace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memExporter struct {
+	mutex sync.Mutex
+	spans []*SpanData
+}
+
+func (me *memExporter) Export(_ context.Context, _ Resource, spans []*SpanData) error {
+	me.mutex.Lock()
+	defer me.mutex.Unlock()
+	me.spans = append(me.spans, spans...)
+	return nil
+}
+
+func (me *memExporter) Shutdown(context.Context) error { return nil }
+
+func TestTraceparent(t *testing.T) {
+	const v = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(v)
+	require.NoError(t, err)
+	require.True(t, sc.Sampled)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID.String())
+	require.Equal(t, "00f067aa0ba902b7", sc.SpanID.String())
+	require.Equal(t, v, sc.Traceparent())
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	} {
+		_, err := ParseTraceparent(invalid)
+		require.Error(t, err, invalid)
+	}
+
+	// a future version may have more fields
+	_, err = ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra")
+	require.NoError(t, err)
+}
+
+func TestSpans(t *testing.T) {
+	// disabled, nil spans ignore the calls
+	ctx, span := Start(context.Background(), "disabled")
+	require.Nil(t, span)
+	span.SetError(errors.New("ignored"))
+	span.End()
+	require.False(t, SpanContextFromContext(ctx).IsValid())
+
+	me := &memExporter{}
+	SetupWithExporter(Config{SampleRatio: 1}, me)
+
+	ctx, round := Start(WithRound(context.Background(), 10), "round")
+	require.Equal(t, RoundTraceID(10), round.SpanContext().TraceID)
+
+	// propagated to another node in the headers
+	h := http.Header{}
+	Inject(ctx, h)
+	remote := Extract(context.Background(), h)
+	_, child := Start(remote, "child", WithKind(SpanKindServer), WithAttributes(String("uri", "/v1/_m2m/block/verify")))
+	child.SetError(errors.New("failed"))
+	child.End()
+	round.End()
+	round.End()
+
+	Shutdown(context.Background())
+	require.Len(t, me.spans, 2)
+	require.Equal(t, "child", me.spans[0].Name)
+	require.Equal(t, RoundTraceID(10).String(), me.spans[0].TraceID)
+	require.Equal(t, me.spans[1].SpanID, me.spans[0].ParentSpanID)
+	require.Equal(t, SpanKindServer, me.spans[0].Kind)
+	require.Equal(t, "failed", me.spans[0].Error)
+	require.Equal(t, []Attribute{String("uri", "/v1/_m2m/block/verify")}, me.spans[0].Attributes)
+	require.Empty(t, me.spans[1].ParentSpanID)
+
+	// not sampled
+	me = &memExporter{}
+	SetupWithExporter(Config{SampleRatio: 0}, me)
+	ctx, span = Start(context.Background(), "not sampled")
+	require.True(t, SpanContextFromContext(ctx).IsValid())
+	span.End()
+	Shutdown(context.Background())
+	require.Empty(t, me.spans)
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log", "trace.json")
+	fe, err := NewFileExporter(path)
+	require.NoError(t, err)
+
+	res := Resource{ServiceName: "0chain-miner", NodeID: "n0"}
+	require.NoError(t, fe.Export(context.Background(), res, []*SpanData{
+		{Name: "generate_block", TraceID: RoundTraceID(1).String()},
+		{Name: "verify_block", TraceID: RoundTraceID(1).String()},
+	}))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var v map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &v))
+		require.Equal(t, "n0", v["node_id"])
+		names = append(names, v["name"].(string))
+	}
+	require.Equal(t, []string{"generate_block", "verify_block"}, names)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var req otlpRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &req))
+	}))
+	defer srv.Close()
+
+	oe := NewOTLPExporter(srv.URL + "/")
+	err := oe.Export(context.Background(), Resource{ServiceName: "0chain-sharder", NodeID: "n0"}, []*SpanData{
+		{Name: "store_transactions", Kind: SpanKindInternal, TraceID: RoundTraceID(1).String(), Error: "failed"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, req.ResourceSpans, 1)
+	require.Equal(t, "0chain-sharder", req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	require.Equal(t, "store_transactions", spans[0].Name)
+	require.Equal(t, 2, spans[0].Status.Code)
+}
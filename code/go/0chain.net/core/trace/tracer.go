@@ -0,0 +1,201 @@
+package trace
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// the exporters of the configuration
+const (
+	ExporterFile = "file"
+	ExporterOTLP = "otlp"
+)
+
+const (
+	defaultBatchSize     = 512
+	defaultQueueSize     = 8192
+	defaultFlushInterval = 5 * time.Second
+)
+
+// Config of the tracing
+type Config struct {
+	Resource
+	// SampleRatio of the traces recorded, in [0; 1]
+	SampleRatio float64
+	// Exporter is file or otlp
+	Exporter     string
+	File         string
+	OTLPEndpoint string
+	// BatchSize is the number of spans exported at once
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+type tracer struct {
+	res       Resource
+	exporter  Exporter
+	threshold uint64
+	batchSize int
+	interval  time.Duration
+	spans     chan *SpanData
+	stop      chan struct{}
+	done      chan struct{}
+	dropped   atomic.Int64
+}
+
+var current atomic.Pointer[tracer]
+
+func getTracer() *tracer {
+	return current.Load()
+}
+
+// Enabled - spans are recorded
+func Enabled() bool {
+	return getTracer() != nil
+}
+
+// Setup starts the tracing with the exporter of the configuration
+func Setup(cfg Config) error {
+	var (
+		exporter Exporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterFile:
+		exporter, err = NewFileExporter(cfg.File)
+		if err != nil {
+			return err
+		}
+	case ExporterOTLP:
+		exporter = NewOTLPExporter(cfg.OTLPEndpoint)
+	default:
+		return fmt.Errorf("unknown trace exporter: %q", cfg.Exporter)
+	}
+	SetupWithExporter(cfg, exporter)
+	return nil
+}
+
+// SetupWithExporter starts the tracing with the exporter, replacing the previous one
+func SetupWithExporter(cfg Config, exporter Exporter) {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+
+	t := &tracer{
+		res:       cfg.Resource,
+		exporter:  exporter,
+		threshold: sampleThreshold(cfg.SampleRatio),
+		batchSize: cfg.BatchSize,
+		interval:  cfg.FlushInterval,
+		spans:     make(chan *SpanData, defaultQueueSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go t.run()
+
+	if prev := current.Swap(t); prev != nil {
+		prev.shutdown(context.Background())
+	}
+}
+
+// Shutdown exports the pending spans and stops the tracing
+func Shutdown(ctx context.Context) {
+	if t := current.Swap(nil); t != nil {
+		t.shutdown(ctx)
+	}
+}
+
+func sampleThreshold(ratio float64) uint64 {
+	switch {
+	case ratio >= 1:
+		return 1 << 63
+	case ratio <= 0:
+		return 0
+	}
+	return uint64(ratio * (1 << 63))
+}
+
+// sample decides by the trace id, every node takes the same decision for a trace
+func (t *tracer) sample(id TraceID) bool {
+	return binary.BigEndian.Uint64(id[8:])>>1 < t.threshold
+}
+
+func (t *tracer) export(sd *SpanData) {
+	select {
+	case t.spans <- sd:
+	default:
+		// never block the caller on a slow exporter
+		t.dropped.Add(1)
+	}
+}
+
+func (t *tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	batch := make([]*SpanData, 0, t.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := t.exporter.Export(ctx, t.res, batch); err != nil {
+			logging.Logger.Warn("trace - export spans failed",
+				zap.Int("spans", len(batch)),
+				zap.Error(err))
+		}
+		if dropped := t.dropped.Swap(0); dropped > 0 {
+			logging.Logger.Warn("trace - spans dropped", zap.Int64("spans", dropped))
+		}
+		batch = make([]*SpanData, 0, t.batchSize)
+	}
+
+	add := func(sd *SpanData) {
+		batch = append(batch, sd)
+		if len(batch) >= t.batchSize {
+			flush()
+		}
+	}
+
+	for {
+		select {
+		case sd := <-t.spans:
+			add(sd)
+		case <-ticker.C:
+			flush()
+		case <-t.stop:
+			for {
+				select {
+				case sd := <-t.spans:
+					add(sd)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (t *tracer) shutdown(ctx context.Context) {
+	close(t.stop)
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+	}
+	if err := t.exporter.Shutdown(ctx); err != nil {
+		logging.Logger.Warn("trace - shutdown exporter failed", zap.Error(err))
+	}
+}
@@ -257,6 +257,7 @@ func (mc *Chain) deleteTxns(txns []datastore.Entity) error {
 		txnHashes[i] = txn.(*transaction.Transaction).Hash
 	}
 	logging.Logger.Debug("delete txns", zap.Any("txns", txnHashes))
+	transaction.RemoveFromTxnPool(txns)
 	return transactionMetadataProvider.GetStore().MultiDelete(ctx, transactionMetadataProvider, txns)
 }
 
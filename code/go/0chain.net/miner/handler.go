@@ -11,6 +11,7 @@ import (
 	"0chain.net/chaincore/chain"
 	"0chain.net/chaincore/diagnostics"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/config"
 )
@@ -29,6 +30,55 @@ func SetupHandlers() {
 	http.HandleFunc("/_txn_stats", common.WithCORS(
 		common.UserRateLimit(TxnStatsWriter),
 	))
+	http.HandleFunc("/v1/transaction/pool/stats", common.WithCORS(
+		common.UserRateLimit(common.ToJSONResponse(TxnPoolStatsHandler)),
+	))
+	http.HandleFunc("/v1/transaction/pool/client", common.WithCORS(
+		common.UserRateLimit(common.ToJSONResponse(TxnPoolClientHandler)),
+	))
+}
+
+// swagger:route GET /v1/transaction/pool/stats miner GetTxnPoolStats
+// Get transactions pool stats.
+// Retrieves the size of the transactions pool, the number of executable transactions and clients. No parameters needed.
+//
+// responses:
+//  200: PoolStats
+//  400:
+
+func TxnPoolStatsHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	pool := transaction.GetPool()
+	if pool == nil {
+		return nil, common.NewError("txn_pool_disabled", "transactions pool is not enabled")
+	}
+	return pool.Stats(), nil
+}
+
+// swagger:route GET /v1/transaction/pool/client miner GetTxnPoolClient
+// Get pooled transactions of a client.
+// Retrieves the executable and queued transactions of the client and its latest nonce known to the pool.
+//
+// parameters:
+//    +name: id
+//     description: client ID
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: PoolClientTxns
+//  400:
+
+func TxnPoolClientHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	pool := transaction.GetPool()
+	if pool == nil {
+		return nil, common.NewError("txn_pool_disabled", "transactions pool is not enabled")
+	}
+	clientID := r.FormValue("id")
+	if clientID == "" {
+		return nil, common.NewError("invalid_parameters", "missing client id")
+	}
+	return pool.ClientTxns(clientID), nil
 }
 
 // swagger:route GET /v1/chain/get/stats miner GetChainStats
@@ -83,6 +83,17 @@ func main() {
 	common.SetupRootContext(node.GetNodeContext())
 	ctx := common.GetRootContext()
 	initEntities(workdir, redisHost, redisPort, redisTxnsHost, redisTxnsPort)
+	if viper.GetBool("server_chain.transaction.pool.enabled") {
+		viper.SetDefault("server_chain.transaction.pool.price_bump", transaction.DefaultPoolPriceBump)
+		transaction.SetupPool(transaction.PoolConfig{
+			MaxSize:      viper.GetInt("server_chain.transaction.pool.max_size"),
+			MaxPerSender: viper.GetInt("server_chain.transaction.pool.max_per_sender"),
+			PriceBump:    viper.GetInt("server_chain.transaction.pool.price_bump"),
+		})
+		if err := transaction.LoadPool(ctx); err != nil {
+			logging.Logger.Error("load transactions pool failed", zap.Error(err))
+		}
+	}
 	serverChain := chain.NewChainFromConfig()
 
 	signatureScheme := serverChain.GetSignatureScheme()
@@ -212,6 +223,7 @@ func main() {
 	logging.Logger.Info("Self identity", zap.Int("set_index", node.Self.Underlying().SetIndex), zap.String("id", node.Self.Underlying().GetKey()))
 
 	registerInConductor(node.Self.Underlying().GetKey())
+	chain.SetupTracing(workdir)
 
 	var server *http.Server
 	var profServer *http.Server
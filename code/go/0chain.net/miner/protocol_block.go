@@ -20,10 +20,13 @@ import (
 	"0chain.net/chaincore/chain"
 	"0chain.net/chaincore/client"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/smartcontract"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"0chain.net/core/trace"
+	"0chain.net/smartcontract/govsc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/storagesc"
 	"github.com/0chain/common/core/logging"
@@ -61,10 +64,10 @@ func (mc *Chain) processTxn(ctx context.Context,
 	b *block.Block,
 	bState util.MerklePatriciaTrieI,
 	clients map[string]*client.Client,
-	blockStateCache *statecache.BlockCache,
+	executor block.TxnExecutor,
 ) error {
 	clients[txn.ClientID] = nil
-	events, err := mc.UpdateState(ctx, b, bState, txn, blockStateCache)
+	events, err := executor.UpdateState(ctx, txn)
 	if err != nil {
 		logging.Logger.Error("processTxn", zap.String("txn", txn.Hash),
 			zap.String("txn_object", datastore.ToJSON(txn).String()),
@@ -73,8 +76,7 @@ func (mc *Chain) processTxn(ctx context.Context,
 	}
 	b.Events = append(b.Events, events...)
 	b.Txns = append(b.Txns, txn)
-	b.AddTransaction(txn)
-	return nil
+	return b.AddTransaction(txn)
 }
 
 func (mc *Chain) createFeeTxn(b *block.Block) (*transaction.Transaction, error) {
@@ -125,6 +127,21 @@ func (mc *Chain) storageScCommitSettingChangesTx(b *block.Block) (*transaction.T
 	return scTxn, nil
 }
 
+func (mc *Chain) createApplyProposalsTxn(b *block.Block) (*transaction.Transaction, error) {
+	apTxn := transaction.Provider().(*transaction.Transaction)
+	apTxn.ClientID = node.Self.ID
+	apTxn.PublicKey = node.Self.PublicKey
+	apTxn.ToClientID = govsc.ADDRESS
+	apTxn.CreationDate = b.CreationDate
+	apTxn.TransactionType = transaction.TxnTypeSmartContract
+	apTxn.TransactionData = fmt.Sprintf(`{"name":"apply_proposals","input":{"round":%v}}`, b.Round)
+	apTxn.Fee = 0
+	if err := apTxn.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	return apTxn, nil
+}
+
 func (mc *Chain) createBlockRewardTxn(b *block.Block) (*transaction.Transaction, error) {
 	brTxn := transaction.Provider().(*transaction.Transaction)
 	brTxn.ClientID = node.Self.ID
@@ -191,6 +208,13 @@ func (mc *Chain) UpdatePendingBlock(ctx context.Context, b *block.Block, txns []
 }
 
 func (mc *Chain) verifySmartContracts(ctx context.Context, b *block.Block) error {
+	// the results are only needed to verify the results hashes
+	defer b.ClearTxnResults()
+	if err := b.VerifyTxnResults(); err != nil {
+		logging.Logger.Error("Transaction results verification failed", zap.Error(err))
+		return common.NewError("txn_results_verification_failed", err.Error())
+	}
+
 	for _, txn := range b.Txns {
 		if txn.TransactionType == transaction.TxnTypeSmartContract {
 			err := txn.VerifyOutputHash(ctx)
@@ -314,6 +338,11 @@ func (mc *Chain) VerifyBlockMagicBlock(ctx context.Context, b *block.Block) (
 // VerifyBlock - given a set of transaction ids within a block, validate the block.
 func (mc *Chain) VerifyBlock(ctx context.Context, b *block.Block) (
 	bvt *block.BlockVerificationTicket, err error) {
+	ctx, span := chain.StartBlockSpan(ctx, "verify_block", b)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
 	//ctx = common.GetRootContext()
 
 	var start = time.Now()
@@ -662,6 +691,7 @@ func (mc *Chain) updateFinalizedBlock(ctx context.Context, b *block.Block) {
 			zap.String("block", b.Hash),
 			zap.Error(err))
 	}
+	b.ClearTxnResults()
 
 	go mc.SendFinalizedBlock(context.Background(), b)
 	fr := mc.GetRound(b.Round)
@@ -717,7 +747,7 @@ type txnProcessorHandler func(context.Context,
 	*statecache.BlockCache,
 	chan struct{}) (bool, error)
 
-func txnProcessorHandlerFunc(mc *Chain, b *block.Block) txnProcessorHandler {
+func txnProcessorHandlerFunc(mc *Chain, b *block.Block, executor block.TxnExecutor) txnProcessorHandler {
 	return func(ctx context.Context,
 		bState util.MerklePatriciaTrieI,
 		txn *transaction.Transaction,
@@ -787,7 +817,7 @@ func txnProcessorHandlerFunc(mc *Chain, b *block.Block) txnProcessorHandler {
 				zap.String("txn_object", datastore.ToJSON(txn).String()))
 		}
 
-		events, err := mc.UpdateState(ctx, b, bState, txn, blockStateCache, waitC)
+		events, err := executor.UpdateState(ctx, txn, waitC)
 		if err != nil {
 			if debugTxn {
 				logging.Logger.Error("generate block (debug transaction) update state",
@@ -812,7 +842,9 @@ func txnProcessorHandlerFunc(mc *Chain, b *block.Block) txnProcessorHandler {
 			logging.Logger.Info("generate block (debug transaction) success in processing Txn hash: " + txn.Hash + " blockHash? = " + b.Hash)
 		}
 		tii.eTxns = append(tii.eTxns, txn)
-		b.AddTransaction(txn)
+		if err := b.AddTransaction(txn); err != nil {
+			return false, err
+		}
 		tii.byteSize += int64(len(txn.TransactionData)) + int64(len(txn.TransactionOutput))
 		if txn.PublicKey == "" {
 			tii.clients[txn.ClientID] = nil
@@ -910,6 +942,60 @@ func newTxnIterInfo(blockSize int32) *TxnIterInfo {
 	}
 }
 
+// iterateSpeculatively collects the transactions fitting in a block to have
+// them executed ahead concurrently, then hands them to the handler in order.
+// The collection is bounded by the limits of the handler: the number of
+// transactions of the block, the block cost from the given one on and the
+// byte size of the data and the output. The output is only known once a
+// transaction is executed, so a few more transactions may be speculated than
+// included, the handler still enforces the limits.
+func (mc *Chain) iterateSpeculatively(ctx context.Context,
+	lfb *block.Block,
+	limit, cost int,
+	executor block.TxnExecutor,
+	iterate func(datastore.CollectionIteratorHandler) error,
+	handler datastore.CollectionIteratorHandler) error {
+	var (
+		txns     []*transaction.Transaction
+		ces      []datastore.CollectionEntity
+		byteSize int64
+	)
+	err := iterate(func(ctx context.Context, ce datastore.CollectionEntity) (bool, error) {
+		ces = append(ces, ce)
+		txn, ok := ce.(*transaction.Transaction)
+		if !ok {
+			return true, nil
+		}
+
+		// the transactions failing the estimation or not fitting the block
+		// cost are left to the handler which skips them
+		txnCost, err := mc.EstimateTransactionCost(ctx, lfb, txn)
+		if err != nil || cost+txnCost >= mc.ChainConfig.MaxBlockCost() {
+			return true, nil
+		}
+
+		txns = append(txns, txn)
+		cost += txnCost
+		byteSize += int64(len(txn.TransactionData)) + int64(len(txn.TransactionOutput))
+		return len(txns) < limit && byteSize < mc.MaxByteSize(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	executor.Speculate(ctx, txns)
+	for _, ce := range ces {
+		ok, err := handler(ctx, ce)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+	}
+	return nil
+}
+
 // txns iterate handler, the function will return bool and error to indicate
 // whether the iteration should continue or not, or error if any to stop the iteration
 func txnIterHandlerFunc(
@@ -1038,6 +1124,14 @@ func txnIterHandlerFunc(
 func (mc *Chain) generateBlock(ctx context.Context, b *block.Block,
 	bsh chain.BlockStateHandler, waitOver bool, waitC chan struct{}) (err error) {
 
+	ctx, span := chain.StartBlockSpan(ctx, "generate_block", b)
+	defer func() {
+		// the hash is known once the block is generated
+		span.SetAttributes(trace.String("block", b.Hash), trace.Int64("txns", int64(len(b.Txns))))
+		span.SetError(err)
+		span.End()
+	}()
+
 	lfb := mc.GetLatestFinalizedBlock()
 	if lfb.ClientState == nil {
 		logging.Logger.Error("generate block - chain is not ready yet",
@@ -1050,9 +1144,10 @@ func (mc *Chain) generateBlock(ctx context.Context, b *block.Block,
 
 	var (
 		iterInfo        = newTxnIterInfo(int32(cap(b.Txns)))
-		txnProcessor    = txnProcessorHandlerFunc(mc, b)
 		blockState      = block.CreateStateWithPreviousBlock(b.PrevBlock, mc.GetStateDB(), b.Round)
 		blockStateCache = statecache.NewBlockCache(mc.GetStateCache(), statecache.Block{Round: b.Round, Hash: b.Hash, PrevHash: b.PrevHash})
+		executor        = mc.NewTxnExecutor(b, blockState, blockStateCache)
+		txnProcessor    = txnProcessorHandlerFunc(mc, b, executor)
 		beginState      = blockState.GetRoot()
 		txnIterHandler  = txnIterHandlerFunc(mc, b, lfb, blockState, txnProcessor, iterInfo, blockStateCache, waitC)
 	)
@@ -1115,7 +1210,18 @@ func (mc *Chain) generateBlock(ctx context.Context, b *block.Block,
 	txn := transactionEntityMetadata.Instance().(*transaction.Transaction)
 	collectionName := txn.GetCollectionName()
 	logging.Logger.Info("generate block starting iteration", zap.Int64("round", b.Round), zap.String("prev_block", b.PrevHash), zap.String("prev_state_hash", util.ToHex(b.PrevBlock.ClientStateHash)))
-	err = transactionEntityMetadata.GetStore().IterateCollection(cctx, transactionEntityMetadata, collectionName, txnIterHandler)
+	iterate := func(handler datastore.CollectionIteratorHandler) error {
+		if pool := transaction.GetPool(); pool != nil {
+			// only the executable transactions, the highest fee first
+			return pool.Iterate(cctx, handler)
+		}
+		return transactionEntityMetadata.GetStore().IterateCollection(cctx, transactionEntityMetadata, collectionName, handler)
+	}
+	if mc.ChainConfig.ParallelExecutionWorkers() > 0 {
+		err = mc.iterateSpeculatively(cctx, lfb, cap(b.Txns), iterInfo.cost, executor, iterate, txnIterHandler)
+	} else {
+		err = iterate(txnIterHandler)
+	}
 	if cstate.ErrInvalidState(err) {
 		logging.Logger.Error("generate block - process txn failed",
 			zap.Error(err),
@@ -1229,7 +1335,7 @@ l:
 			panic(err)
 		}
 
-		err = mc.processTxn(ctx, biTxn, b, blockState, iterInfo.clients, blockStateCache)
+		err = mc.processTxn(ctx, biTxn, b, blockState, iterInfo.clients, executor)
 		if err != nil {
 			logging.Logger.Warn("generate block - process build-in txn failed",
 				zap.String("txn", txn.Hash),
@@ -1310,6 +1416,8 @@ l:
 	if err = mc.hashAndSignGeneratedBlock(ctx, b); err != nil {
 		return err
 	}
+	// the results hashes are set, the miners don't store the receipts
+	b.ClearTxnResults()
 
 	b.SetBlockState(block.StateGenerated)
 	b.SetStateStatus(block.StateSuccessful)
@@ -1378,6 +1486,14 @@ func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block) ([]*trans
 			return nil, 0, err
 		}
 		txns = append(txns, cscTxn)
+
+		if smartcontract.GetSmartContract(govsc.ADDRESS) != nil {
+			apTxn, err := mc.createApplyProposalsTxn(b)
+			if err != nil {
+				return nil, 0, err
+			}
+			txns = append(txns, apTxn)
+		}
 	}
 
 	var cost int
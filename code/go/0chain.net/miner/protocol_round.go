@@ -1097,6 +1097,9 @@ func (mc *Chain) checkBlockNotarization(ctx context.Context, r *Round, b *block.
 		return true
 	}
 
+	ctx, span := chain.StartBlockSpan(ctx, "notarize_block", b)
+	defer span.End()
+
 	seed := b.GetRoundRandomSeed()
 	if seed == 0 {
 		logging.Logger.Error("checkBlockNotarization -- block random seed is 0", zap.Int64("round", b.Round))
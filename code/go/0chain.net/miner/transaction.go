@@ -7,6 +7,7 @@ const (
 	commitSettingsChangesTxnName = "commit_settings_changes"
 	blobberBlockRewardsTxnName   = "blobber_block_rewards"
 	generateChallengeTxnName     = "generate_challenge"
+	applyProposalsTxnName        = "apply_proposals"
 )
 
 var gBuildInTxnsMap = map[string]struct{}{
@@ -14,6 +15,7 @@ var gBuildInTxnsMap = map[string]struct{}{
 	commitSettingsChangesTxnName: {},
 	blobberBlockRewardsTxnName:   {},
 	generateChallengeTxnName:     {},
+	applyProposalsTxnName:        {},
 }
 
 // isBuildInTxn checks if the txn is build-in txn.
@@ -0,0 +1,120 @@
+package sharder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/storagesc"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+// auditUsersBatchSize is the number of users read from the event db at once
+const auditUsersBatchSize = 100
+
+// AuditEventDb compares the event db with the MPT of the finalized block of
+// the round, the latest finalized round if 0, and reports the rows which
+// differ from the objects they are derived from. With repair the rows are
+// overwritten with the values of the MPT.
+//
+// The event db holds the latest values, an audit of a round before the last
+// round processed by the event db reports the rows changed since.
+func (sc *Chain) AuditEventDb(ctx context.Context, round int64, repair bool) (*event.AuditReport, error) {
+	edb := sc.GetEventDb()
+	if edb == nil {
+		return nil, errors.New("event db is disabled")
+	}
+
+	lfb := sc.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return nil, errors.New("latest finalized block not available")
+	}
+	if round <= 0 {
+		round = lfb.Round
+	}
+	if round > lfb.Round {
+		return nil, fmt.Errorf("round %d is not finalized, latest finalized round %d", round, lfb.Round)
+	}
+	if round < lfb.Round {
+		logging.Logger.Warn("audit event db - round before the latest finalized round, rows changed since are reported",
+			zap.Int64("round", round),
+			zap.Int64("lfb_round", lfb.Round))
+	}
+
+	b, err := sc.getFinalizedBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("can't get block of round %d: %v", round, err)
+	}
+	if b.ClientState == nil {
+		if err := b.InitStateDB(sc.GetStateDB()); err != nil {
+			return nil, fmt.Errorf("can't load state of round %d: %v", round, err)
+		}
+	}
+
+	var (
+		report   = event.NewAuditReport(round, b.Hash)
+		balances = sc.GetBlockStateContext(b, nil)
+	)
+	if err := storagesc.AuditEventDb(ctx, edb, balances, report); err != nil {
+		return nil, err
+	}
+	if err := auditUsers(ctx, edb, balances, report); err != nil {
+		return nil, fmt.Errorf("audit users: %v", err)
+	}
+
+	for _, m := range report.Mismatches {
+		logging.Logger.Warn("audit event db - mismatch", zap.Stringer("mismatch", m))
+	}
+	logging.Logger.Info("audit event db - done",
+		zap.Int64("round", round),
+		zap.String("block", b.Hash),
+		zap.Any("checked", report.Checked),
+		zap.Int("mismatches", len(report.Mismatches)),
+		zap.Int("repairs", report.Repairs.Len()))
+
+	if repair && report.Repairs.Len() > 0 {
+		if err := edb.RepairAudit(ctx, &report.Repairs); err != nil {
+			return report, fmt.Errorf("repair event db: %v", err)
+		}
+		logging.Logger.Info("audit event db - repaired", zap.Int("rows", report.Repairs.Len()))
+	}
+	return report, nil
+}
+
+func auditUsers(ctx context.Context, edb *event.EventDb,
+	balances state.StateContextI, report *event.AuditReport) error {
+
+	var last string
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		users, err := edb.GetAuditUsers(last, auditUsersBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range users {
+			s, err := balances.GetClientState(users[i].UserID)
+			if err != nil && err != util.ErrValueNotPresent {
+				return err
+			}
+			// a client without state has no balance
+			if err := report.CompareUser(&users[i], &event.User{
+				UserID:  users[i].UserID,
+				Balance: s.Balance,
+				Nonce:   s.Nonce,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(users) < auditUsersBatchSize {
+			return nil
+		}
+		last = users[len(users)-1].UserID
+	}
+}
@@ -1,7 +1,10 @@
 package blockdb
 
 import (
+	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -105,3 +108,54 @@ func TestDBWrite(t *testing.T) {
 	err = db.Close()
 	require.NoError(t, err)
 }
+
+func TestDBReopen(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "blockdb")
+	db, err := NewBlockDB(file, 4, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Create())
+
+	students := []*Student{
+		{Name: "Bitcoin - the first cryptocurrency", ID: "2009"},
+		{Name: "Linux - the most popular open source operating system", ID: "1991"},
+	}
+	for _, s := range students {
+		require.NoError(t, db.WriteData(s))
+	}
+	require.NoError(t, db.Close())
+
+	// append a partially written record
+	f, err := os.OpenFile(file+"."+FileExtData, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{100, 0, 0, 0, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	db, err = NewBlockDB(file, 4, true)
+	require.NoError(t, err)
+	var found []Key
+	err = db.Reopen(context.Background(), func(_ context.Context, record Record) error {
+		found = append(found, record.GetKey())
+		return nil
+	}, &StudentProvider{})
+	require.NoError(t, err)
+	require.Equal(t, []Key{"2009", "1991"}, found)
+
+	s := &Student{Name: "Apache - the first open source web server", ID: "1995"}
+	require.NoError(t, db.WriteData(s))
+	require.NoError(t, db.Save())
+
+	db, err = NewBlockDB(file, 4, true)
+	require.NoError(t, err)
+	require.NoError(t, db.Open())
+	defer db.Close()
+	for _, s := range append(students, s) {
+		var s2 Student
+		require.NoError(t, db.Read(s.GetKey(), &s2))
+		require.Equal(t, s.Name, s2.Name)
+	}
+
+	var s2 Student
+	require.Equal(t, ErrKeyNotFound, db.Read("1990", &s2))
+	require.Equal(t, ErrKeyNotFound, db.Read("2010", &s2))
+}
@@ -57,6 +57,11 @@ func (bdb *BlockDB) SetIndex(index Index) {
 	bdb.index = index
 }
 
+// GetKeys - get the keys of the records, the database has to be open
+func (bdb *BlockDB) GetKeys() []Key {
+	return bdb.index.GetKeys()
+}
+
 // Create - create the database
 func (bdb *BlockDB) Create() error {
 	dir := filepath.Dir(bdb.file)
@@ -90,6 +95,70 @@ func (bdb *BlockDB) Open() error {
 	return err
 }
 
+// OpenHeader - open only the header of an existing database, records can then be read with
+// ReadFrom when the data file isn't available locally
+func (bdb *BlockDB) OpenHeader() error {
+	f, err := os.OpenFile(bdb.getHeaderFileName(), os.O_RDONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if bdb.index == nil {
+		bdb.SetIndex(newFixedKeyArrayIndex(bdb.keyLength))
+	}
+	return bdb.readHeader(f)
+}
+
+/*
+Reopen - open an existing database for appending more records. The index is rebuilt by
+scanning the data file so that records written after the last Save are not lost, the
+handler is invoked for every record found. A partially written trailing record (for
+example after an unclean shutdown) is truncated.
+*/
+func (bdb *BlockDB) Reopen(ctx context.Context, handler DBIteratorHandler, rp RecordProvider) error {
+	dir := filepath.Dir(bdb.file)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	bdb.SetIndex(newMapIndex())
+	var err error
+	bdb.dataFile, err = os.OpenFile(bdb.getDataFileName(), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	var (
+		offset   int64
+		dataFile = bufio.NewReader(bdb.dataFile)
+	)
+	for {
+		record := rp.NewRecord()
+		n, err := bdb.readCounted(dataFile, record)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			if err := bdb.dataFile.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := bdb.index.SetOffset(record.GetKey(), offset); err != nil {
+			return err
+		}
+		if handler != nil {
+			if err := handler(ctx, record); err != nil {
+				return err
+			}
+		}
+		offset += n
+	}
+	return nil
+}
+
 // Read - read an individual record
 func (bdb *BlockDB) Read(key Key, record Record) error {
 	offset, err := bdb.index.GetOffset(key)
@@ -104,29 +173,54 @@ func (bdb *BlockDB) Read(key Key, record Record) error {
 	return bdb.read(dataFile, record)
 }
 
+// ReadFrom - read an individual record from the data file opened at the record's offset by the given function
+func (bdb *BlockDB) ReadFrom(key Key, record Record, open func(offset int64) (io.ReadCloser, error)) error {
+	offset, err := bdb.index.GetOffset(key)
+	if err != nil {
+		return err
+	}
+	dataFile, err := open(offset)
+	if err != nil {
+		return err
+	}
+	defer dataFile.Close()
+	return bdb.read(bufio.NewReader(dataFile), record)
+}
+
 func (bdb *BlockDB) read(dataFile io.Reader, record Record) error {
+	_, err := bdb.readCounted(dataFile, record)
+	return err
+}
+
+// readCounted - read a record and return the number of bytes it occupies in the data file
+func (bdb *BlockDB) readCounted(dataFile io.Reader, record Record) (int64, error) {
 	var dlen int32
 	err := binary.Read(dataFile, binary.LittleEndian, &dlen)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	data := make([]byte, dlen)
 	n, err := io.ReadFull(dataFile, data)
 	if err != nil {
-		return err
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
 	}
 	if int32(n) != dlen {
-		return fmt.Errorf("read data length doesnot match expected data length dlen=%v n=%v", dlen, n)
+		return 0, fmt.Errorf("read data length doesnot match expected data length dlen=%v n=%v", dlen, n)
 	}
 	if bdb.compress {
 		data, err = compDe.Decompress(data)
 		if err != nil {
-			return err
+			return 0, err
 		}
 	}
 	buffer := bytes.NewBuffer(data)
-	err = record.Decode(buffer)
-	return err
+	if err = record.Decode(buffer); err != nil {
+		return 0, err
+	}
+	return int64(4 + n), nil
 }
 
 // ReadAll - read all the records
@@ -148,9 +242,9 @@ func (bdb *BlockDB) ReadAll(rp RecordProvider) ([]Record, error) {
 	return records, nil
 }
 
-// WriteData - write the data
+// WriteData - write the data, records are always appended to the end of the data file
 func (bdb *BlockDB) WriteData(record Record) error {
-	offset, err := bdb.dataFile.Seek(0, 1)
+	offset, err := bdb.dataFile.Seek(0, io.SeekEnd)
 	if err != nil {
 		return err
 	}
@@ -226,7 +320,7 @@ func (bdb *BlockDB) Delete() error {
 }
 
 func (bdb *BlockDB) saveHeader() error {
-	headerFile, err := os.OpenFile(bdb.getHeaderFileName(), os.O_RDWR|os.O_CREATE, 0644)
+	headerFile, err := os.OpenFile(bdb.getHeaderFileName(), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
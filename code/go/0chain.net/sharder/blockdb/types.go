@@ -48,6 +48,8 @@ type DBHeader interface {
 type Database interface {
 	Create() error
 	Open() error
+	OpenHeader() error
+	Reopen(ctx context.Context, handler DBIteratorHandler, rp RecordProvider) error
 	Save() error
 	Close() error
 	Delete() error
@@ -56,6 +58,7 @@ type Database interface {
 
 	ReadAll(rp RecordProvider) ([]Record, error)
 	Read(key Key, record Record) error
+	ReadFrom(key Key, record Record, open func(offset int64) (io.ReadCloser, error)) error
 	WriteData(record Record) error
 	Iterate(ctx context.Context, handler DBIteratorHandler, rp RecordProvider) error
 }
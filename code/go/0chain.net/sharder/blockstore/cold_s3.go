@@ -0,0 +1,95 @@
+package blockstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3ColdStoreConfig configures a cold store with an S3 compatible API.
+type S3ColdStoreConfig struct {
+	// Endpoint overrides the AWS endpoint, e.g. for a MinIO deployment.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is prepended to the keys of all the objects.
+	Prefix string
+	// AccessKey and SecretKey are optional, the default AWS credentials chain is used without them.
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket in the path instead of the host, most S3 compatible stores require it.
+	PathStyle bool
+}
+
+// S3ColdStore is a ColdStore backed by an S3 compatible object store, it holds
+// the cold tier of the segment store.
+type S3ColdStore struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+var _ ColdStore = (*S3ColdStore)(nil)
+
+func NewS3ColdStore(conf S3ColdStoreConfig) (*S3ColdStore, error) {
+	if conf.Bucket == "" {
+		return nil, errors.New("s3 cold store: missing bucket")
+	}
+
+	opts := []func(*config.LoadOptions) error{config.WithRegion(conf.Region)}
+	if conf.AccessKey != "" {
+		opts = append(opts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(conf.AccessKey, conf.SecretKey, "")))
+	}
+	c, err := config.LoadDefaultConfig(context.TODO(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(c, func(o *s3.Options) {
+		if conf.Endpoint != "" {
+			o.BaseEndpoint = aws.String(conf.Endpoint)
+		}
+		o.UsePathStyle = conf.PathStyle
+	})
+	return &S3ColdStore{client: client, bucket: conf.Bucket, prefix: conf.Prefix}, nil
+}
+
+func (sc *S3ColdStore) Put(ctx context.Context, key string, r io.ReadSeeker, size int64) error {
+	_, err := sc.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(sc.bucket),
+		Key:           aws.String(sc.prefix + key),
+		Body:          r,
+		ContentLength: size,
+	})
+	return err
+}
+
+func (sc *S3ColdStore) Get(ctx context.Context, key string, offset, size int64) (io.ReadCloser, error) {
+	in := &s3.GetObjectInput{
+		Bucket: aws.String(sc.bucket),
+		Key:    aws.String(sc.prefix + key),
+	}
+	switch {
+	case size > 0:
+		in.Range = aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+size-1))
+	case offset > 0:
+		in.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	}
+	out, err := sc.client.GetObject(ctx, in)
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, ErrColdObjectNotFound
+		}
+		return nil, err
+	}
+	return out.Body, nil
+}
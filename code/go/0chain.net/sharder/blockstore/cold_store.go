@@ -0,0 +1,293 @@
+package blockstore
+
+// The cold tier keeps the segments of old rounds out of the local disk.
+// Sealed segments whose rounds are older than the configured number of rounds
+// get uploaded to the cold store and their data file is removed locally. The
+// small header file (the hash and round indexes) is kept locally so that a block
+// is read from the cold store with two small ranged reads, its length and then
+// its record.
+//
+// The cold tier only works behind the segment store, it's configured in the
+// segment section of the storage config and rejected for the other stores.
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"0chain.net/core/viper"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultColdCheckInterval is how often segments are checked for moving to the cold tier.
+	DefaultColdCheckInterval = 10 * time.Minute
+
+	// recordLengthSize is the size of the length prefixing the records of a data file.
+	recordLengthSize = 4
+)
+
+// ErrColdObjectNotFound is returned by a ColdStore when the object doesn't exist.
+var ErrColdObjectNotFound = errors.New("cold store: object not found")
+
+// ColdStore is an object store holding the segments moved to the cold tier.
+type ColdStore interface {
+	// Put uploads the object of the given size read from r.
+	Put(ctx context.Context, key string, r io.ReadSeeker, size int64) error
+	// Get returns size bytes of the object starting at the given offset, the
+	// rest of the object if size is 0.
+	Get(ctx context.Context, key string, offset, size int64) (io.ReadCloser, error)
+}
+
+// FSColdStore is a ColdStore keeping the objects in a directory, for example a
+// mounted network drive.
+type FSColdStore struct {
+	path string
+}
+
+var _ ColdStore = (*FSColdStore)(nil)
+
+func NewFSColdStore(path string) (*FSColdStore, error) {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, err
+	}
+	return &FSColdStore{path: path}, nil
+}
+
+func (fc *FSColdStore) Put(_ context.Context, key string, r io.ReadSeeker, _ int64) error {
+	tmp := filepath.Join(fc.path, key+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(fc.path, key))
+}
+
+func (fc *FSColdStore) Get(_ context.Context, key string, offset, size int64) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(fc.path, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrColdObjectNotFound
+		}
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if size > 0 {
+		return limitedReadCloser{Reader: io.LimitReader(f, size), Closer: f}, nil
+	}
+	return f, nil
+}
+
+// limitedReadCloser reads a part of a file and closes the file.
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// coldTier moves old segments to the cold store.
+type coldTier struct {
+	store ColdStore
+	// afterRounds is the number of rounds after which a segment is moved to the cold store.
+	afterRounds   int64
+	checkInterval time.Duration
+}
+
+func initColdTier(cViper *viper.Viper) (*coldTier, error) {
+	ct := &coldTier{
+		afterRounds:   cViper.GetInt64("after_rounds"),
+		checkInterval: cViper.GetDuration("check_interval"),
+	}
+	if ct.afterRounds <= 0 {
+		return nil, errors.New("cold tier: after_rounds must be positive")
+	}
+	if ct.checkInterval <= 0 {
+		ct.checkInterval = DefaultColdCheckInterval
+	}
+
+	var err error
+	switch t := cViper.GetString("type"); t {
+	case "fs":
+		ct.store, err = NewFSColdStore(cViper.GetString("path"))
+	case "s3":
+		s3Viper := cViper.Sub("s3")
+		if s3Viper == nil {
+			return nil, errors.New("cold tier: missing s3 config")
+		}
+		ct.store, err = NewS3ColdStore(S3ColdStoreConfig{
+			Endpoint:  s3Viper.GetString("endpoint"),
+			Region:    s3Viper.GetString("region"),
+			Bucket:    s3Viper.GetString("bucket"),
+			Prefix:    s3Viper.GetString("prefix"),
+			AccessKey: s3Viper.GetString("access_key"),
+			SecretKey: s3Viper.GetString("secret_key"),
+			PathStyle: s3Viper.GetBool("path_style"),
+		})
+	default:
+		return nil, fmt.Errorf("cold tier: unknown type %q", t)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ct, nil
+}
+
+// SetColdTier enables moving the segments older than afterRounds to the cold store.
+func (ss *SegmentStore) SetColdTier(store ColdStore, afterRounds int64, checkInterval time.Duration) {
+	if checkInterval <= 0 {
+		checkInterval = DefaultColdCheckInterval
+	}
+	ss.cold = &coldTier{store: store, afterRounds: afterRounds, checkInterval: checkInterval}
+}
+
+// coldTierWorker periodically moves the old segments to the cold tier.
+func (ss *SegmentStore) coldTierWorker(ctx context.Context) {
+	ticker := time.NewTicker(ss.cold.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ss.moveToColdTier(ctx); err != nil {
+				logging.Logger.Error("segment store - move to cold tier failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// moveToColdTier moves the sealed segments whose rounds are all older than the
+// configured number of rounds to the cold tier.
+func (ss *SegmentStore) moveToColdTier(ctx context.Context) error {
+	ss.mutex.Lock()
+	threshold := ss.latestRound - ss.cold.afterRounds
+	var starts []int64
+	for _, start := range ss.starts {
+		if start+ss.segmentRounds-1 >= threshold {
+			break
+		}
+		if ss.active != nil && ss.active.start == start {
+			continue
+		}
+		if !ss.isSealed(start) || ss.isCold(start) {
+			continue
+		}
+		starts = append(starts, start)
+	}
+	ss.mutex.Unlock()
+
+	for _, start := range starts {
+		if err := ss.moveSegmentToCold(ctx, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveSegmentToCold uploads the segment and removes its data file locally. The
+// upload is done without holding the store lock, the data file is kept when
+// the segment has been opened again in the meantime.
+func (ss *SegmentStore) moveSegmentToCold(ctx context.Context, start int64) error {
+	dataFile := ss.dataFile(start)
+	fi, err := os.Stat(dataFile)
+	if err != nil {
+		return err
+	}
+	if err := ss.uploadToCold(ctx, dataFile); err != nil {
+		return err
+	}
+	// the header stays locally, a copy is kept along with the data
+	if err := ss.uploadToCold(ctx, ss.headerFile(start)); err != nil {
+		return err
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	cur, err := os.Stat(dataFile)
+	if err != nil {
+		return err
+	}
+	if !ss.isSealed(start) || cur.Size() != fi.Size() || !cur.ModTime().Equal(fi.ModTime()) {
+		logging.Logger.Info("segment store - segment changed while moving to cold tier",
+			zap.Int64("start_round", start))
+		return nil
+	}
+	if err := os.Remove(dataFile); err != nil {
+		return err
+	}
+	logging.Logger.Info("segment store - moved segment to cold tier", zap.Int64("start_round", start))
+	return nil
+}
+
+func (ss *SegmentStore) uploadToCold(ctx context.Context, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return ss.cold.store.Put(ctx, filepath.Base(file), f, fi.Size())
+}
+
+// restoreFromCold downloads the data file of a segment from the cold tier.
+func (ss *SegmentStore) restoreFromCold(start int64) error {
+	if ss.cold == nil {
+		return fmt.Errorf("segment %d is in the cold tier but it's not configured", start)
+	}
+	dataFile := ss.dataFile(start)
+	r, err := ss.cold.store.Get(context.TODO(), filepath.Base(dataFile), 0, 0)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tmp := dataFile + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	logging.Logger.Info("segment store - restored segment from cold tier", zap.Int64("start_round", start))
+	return os.Rename(tmp, dataFile)
+}
+
+// readCold returns the record at the offset of a data file in the cold tier,
+// the length of the record is read first so that only the record is
+// downloaded.
+func (ss *SegmentStore) readCold(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	r, err := ss.cold.store.Get(ctx, key, offset, recordLengthSize)
+	if err != nil {
+		return nil, err
+	}
+	var dlen int32
+	err = binary.Read(r, binary.LittleEndian, &dlen)
+	r.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading the record length: %v", err)
+	}
+	return ss.cold.store.Get(ctx, key, offset, recordLengthSize+int64(dlen))
+}
@@ -0,0 +1,232 @@
+package blockstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentStoreColdTier(t *testing.T) {
+	cs, err := NewFSColdStore(t.TempDir())
+	require.NoError(t, err)
+
+	ss, err := NewSegmentStore(t.TempDir(), 10, nil)
+	require.NoError(t, err)
+	ss.SetColdTier(cs, 15, 0)
+
+	for r := int64(1); r <= 40; r++ {
+		require.NoError(t, ss.Write(newSegmentTestBlock(r)))
+	}
+	require.NoError(t, ss.moveToColdTier(context.Background()))
+
+	// rounds up to 24 are older than 40 - 15
+	require.True(t, ss.isCold(0))
+	require.True(t, ss.isCold(10))
+	require.False(t, ss.isCold(20))
+	require.False(t, ss.isCold(30))
+
+	for r := int64(1); r <= 40; r++ {
+		b := newSegmentTestBlock(r)
+		b1, err := ss.ReadWithBlockSummary(&block.BlockSummary{Hash: b.Hash, Round: r})
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, b1.Hash)
+
+		b1, err = ss.ReadByRound(r)
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, b1.Hash)
+	}
+
+	b1, err := ss.Read(newSegmentTestBlock(3).Hash)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), b1.Round)
+
+	// a late block is written to the active segment, the segment stays in the
+	// cold tier
+	late := newSegmentTestBlock(0)
+	require.NoError(t, ss.Write(late))
+	require.True(t, ss.isCold(0))
+	for r := int64(0); r < 10; r++ {
+		_, err := ss.ReadByRound(r)
+		require.NoError(t, err)
+	}
+}
+
+func TestSegmentStoreColdTierRestart(t *testing.T) {
+	cs, err := NewFSColdStore(t.TempDir())
+	require.NoError(t, err)
+
+	basePath := t.TempDir()
+	ss, err := NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	ss.SetColdTier(cs, 5, 0)
+	for r := int64(1); r <= 25; r++ {
+		require.NoError(t, ss.Write(newSegmentTestBlock(r)))
+	}
+	require.NoError(t, ss.moveToColdTier(context.Background()))
+	require.NoError(t, ss.Close())
+
+	ss, err = NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 10, 20}, ss.starts)
+
+	// without the cold tier the moved blocks can't be read
+	_, err = ss.ReadByRound(5)
+	require.Error(t, err)
+
+	ss.SetColdTier(cs, 5, 0)
+	b, err := ss.ReadByRound(5)
+	require.NoError(t, err)
+	require.Equal(t, newSegmentTestBlock(5).Hash, b.Hash)
+}
+
+// fakeS3 is a minimal path style S3 API stand-in supporting PUT and ranged GET
+// of objects, it records the ranges of the GETs.
+type fakeS3 struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+	ranges  []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.objects[r.URL.Path] = data
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+			return
+		}
+		offset, end := 0, len(data)-1
+		rng := r.Header.Get("Range")
+		f.ranges = append(f.ranges, rng)
+		if rng != "" {
+			fmt.Sscanf(strings.TrimPrefix(rng, "bytes="), "%d-%d", &offset, &end)
+			if end >= len(data) {
+				end = len(data) - 1
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, end, len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		_, _ = w.Write(data[offset : end+1])
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3ColdStore(t *testing.T) {
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	cs, err := NewS3ColdStore(S3ColdStoreConfig{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "blocks",
+		Prefix:    "sharder/",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	})
+	require.NoError(t, err)
+
+	data := []byte("segment data")
+	require.NoError(t, cs.Put(context.Background(), "seg_1.dat", bytes.NewReader(data), int64(len(data))))
+	require.Contains(t, fake.objects, "/blocks/sharder/seg_1.dat")
+
+	read := func(offset, size int64) string {
+		r, err := cs.Get(context.Background(), "seg_1.dat", offset, size)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		return string(got)
+	}
+	require.Equal(t, "data", read(8, 0))
+	require.Equal(t, "segment", read(0, 7))
+	require.Equal(t, "segment data", read(0, 0))
+	require.Equal(t, []string{"bytes=8-", "bytes=0-6", ""}, fake.ranges)
+
+	_, err = cs.Get(context.Background(), "missing.dat", 0, 0)
+	require.Equal(t, ErrColdObjectNotFound, err)
+
+	// the segment store works on top of it
+	ss, err := NewSegmentStore(t.TempDir(), 10, nil)
+	require.NoError(t, err)
+	ss.SetColdTier(cs, 5, 0)
+	for r := int64(1); r <= 25; r++ {
+		require.NoError(t, ss.Write(newSegmentTestBlock(r)))
+	}
+	require.NoError(t, ss.moveToColdTier(context.Background()))
+	require.True(t, ss.isCold(0))
+	_, err = os.Stat(ss.dataFile(0))
+	require.True(t, os.IsNotExist(err))
+
+	// only the length and the record of the block are downloaded
+	fake.ranges = nil
+	b, err := ss.ReadByRound(7)
+	require.NoError(t, err)
+	require.Equal(t, newSegmentTestBlock(7).Hash, b.Hash)
+	require.Len(t, fake.ranges, 2)
+	var offset, end int64
+	_, err = fmt.Sscanf(fake.ranges[0], "bytes=%d-%d", &offset, &end)
+	require.NoError(t, err)
+	require.EqualValues(t, recordLengthSize-1, end-offset)
+	_, err = fmt.Sscanf(fake.ranges[1], "bytes=%d-%d", &offset, &end)
+	require.NoError(t, err)
+	require.Less(t, end-offset, int64(len(fake.objects["/blocks/sharder/"+filepath.Base(ss.dataFile(0))])))
+}
+
+func TestFSColdStoreGet(t *testing.T) {
+	cs, err := NewFSColdStore(t.TempDir())
+	require.NoError(t, err)
+	data := []byte("segment data")
+	require.NoError(t, cs.Put(context.Background(), "seg_1.dat", bytes.NewReader(data), int64(len(data))))
+
+	r, err := cs.Get(context.Background(), "seg_1.dat", 8, 2)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "da", string(got))
+}
+
+func TestHasColdTier(t *testing.T) {
+	for _, tc := range []struct {
+		config string
+		cold   bool
+	}{
+		{config: "segment:\n  enabled: true\n", cold: false},
+		{config: "segment:\n  cold:\n    enabled: true\n", cold: true},
+		{config: "segment:\n  cold:\n    enabled: false\n", cold: false},
+		{config: "cold:\n  enabled: true\n", cold: true},
+	} {
+		v := viper.New()
+		v.SetConfigType("yaml")
+		require.NoError(t, v.ReadConfig(strings.NewReader(tc.config)))
+		require.Equal(t, tc.cold, hasColdTier(v), tc.config)
+	}
+}
@@ -212,6 +212,12 @@ func (bStore *BlockStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.B
 	return bStore.Read(bs.Hash)
 }
 
+// hasColdTier tells whether a cold tier is enabled in the storage config, at
+// the top level or in the segment section.
+func hasColdTier(sViper *viper.Viper) bool {
+	return sViper.GetBool("cold.enabled") || sViper.GetBool("segment.cold.enabled")
+}
+
 // Init checks for minimum disk size, inodes requirement and assigns
 // block storer to a variable. If any error occurs during initialization
 // it will panic.
@@ -231,17 +237,30 @@ func Init(workDir string, sViper *viper.Viper) {
 		}
 	}
 
-	bStore := &BlockStore{
-		cache:                 noOpCache{},
-		blockMetadataProvider: datastore.GetEntityMetadata("block"),
-		basePath:              basePath,
-	}
-
+	var c cacher = noOpCache{}
 	if sViper != nil {
 		cViper := sViper.Sub("cache")
 		if cViper != nil {
-			bStore.cache = initCache(cViper)
+			c = initCache(cViper)
 		}
 	}
+
+	if sViper != nil {
+		sgViper := sViper.Sub("segment")
+		if sgViper != nil && sgViper.GetBool("enabled") {
+			logging.Logger.Info("Using segment block storage")
+			SetupStore(initSegmentStore(basePath, sgViper, c))
+			return
+		}
+		if hasColdTier(sViper) {
+			panic("cold tier: requires the segment store")
+		}
+	}
+
+	bStore := &BlockStore{
+		cache:                 c,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		basePath:              basePath,
+	}
 	SetupStore(bStore)
 }
@@ -0,0 +1,188 @@
+package blockstore
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"0chain.net/core/common"
+	"0chain.net/core/viper"
+	"0chain.net/sharder/blockdb"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxOpenMigrationSegments limits the number of segment files kept open
+	// while the blocks of the file per block layout are migrated.
+	maxOpenMigrationSegments = 256
+	migrationLogInterval     = 10000
+)
+
+// MigrateFrom moves the blocks stored one file per block by BlockStore under
+// legacyPath into the segments. Every file is removed once its block has been
+// appended to a segment, so an interrupted migration resumes where it stopped.
+// It must be called before the store is used for writing.
+func (ss *SegmentStore) MigrateFrom(legacyPath string) error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	if ss.active != nil {
+		if err := ss.seal(ss.active); err != nil {
+			return err
+		}
+		ss.active = nil
+	}
+
+	var (
+		legacy = &BlockStore{
+			basePath:              legacyPath,
+			blockMetadataProvider: ss.blockMetadataProvider,
+		}
+		open     = make(map[int64]*blockdb.BlockDB)
+		touched  = make(map[int64]struct{})
+		dirs     []string
+		migrated int
+		skipped  int
+		suffix   = "." + extension
+	)
+
+	closeOpen := func() {
+		for start, db := range open {
+			db.Close()
+			delete(open, start)
+		}
+	}
+	defer closeOpen()
+
+	err := filepath.WalkDir(legacyPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == ss.segmentsPath() {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		}
+		if !strings.HasSuffix(path, suffix) {
+			return nil
+		}
+
+		// a corrupt file is left in place, it doesn't stop the sharder
+		hash, err := legacyBlockHash(legacyPath, path)
+		if err == nil && len(hash) != blockKeyLength {
+			err = fmt.Errorf("invalid block hash: %s", hash)
+		}
+		if err != nil {
+			logging.Logger.Error("segment store - skipping legacy block file",
+				zap.String("path", path), zap.Error(err))
+			skipped++
+			return nil
+		}
+		b, err := legacy.readFromDisk(hash)
+		if err != nil {
+			logging.Logger.Error("segment store - skipping legacy block file",
+				zap.String("path", path), zap.Error(err))
+			skipped++
+			return nil
+		}
+
+		start := ss.segmentStart(b.Round)
+		db, ok := open[start]
+		if !ok {
+			if len(open) >= maxOpenMigrationSegments {
+				closeOpen()
+			}
+			if err := ss.unseal(start); err != nil {
+				return err
+			}
+			if db, err = blockdb.NewBlockDB(ss.segmentFile(start), blockKeyLength, true); err != nil {
+				return err
+			}
+			if err := db.Create(); err != nil {
+				return err
+			}
+			open[start] = db
+			touched[start] = struct{}{}
+		}
+
+		if err := db.WriteData(&blockRecord{key: blockdb.Key(hash), block: b}); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		migrated++
+		if migrated%migrationLogInterval == 0 {
+			logging.Logger.Info("segment store - migrating blocks", zap.Int("migrated", migrated))
+		}
+		return nil
+	})
+	closeOpen()
+	if err != nil {
+		return err
+	}
+
+	// the data files of the touched segments are complete, rebuild their indexes
+	for start := range touched {
+		seg, err := ss.reopenSegment(start)
+		if err != nil {
+			return err
+		}
+		if err := ss.seal(seg); err != nil {
+			return err
+		}
+	}
+
+	// remove the emptied directories of the file per block layout, deepest first
+	for i := len(dirs) - 1; i > 0; i-- {
+		_ = os.Remove(dirs[i])
+	}
+
+	if migrated > 0 || skipped > 0 {
+		logging.Logger.Info("segment store - migration done",
+			zap.Int("migrated", migrated), zap.Int("skipped", skipped),
+			zap.Int("segments", len(touched)))
+	}
+	return nil
+}
+
+// legacyBlockHash restores the hash of a block from its path in the file per block layout.
+func legacyBlockHash(basePath, path string) (string, error) {
+	rel, err := filepath.Rel(basePath, path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.ReplaceAll(rel, string(os.PathSeparator), ""), "."+extension), nil
+}
+
+// initSegmentStore sets up the segment store under basePath, blocks of the
+// file per block layout found there are migrated unless disabled. Old segments
+// are moved to the cold tier when it's configured.
+func initSegmentStore(basePath string, sViper *viper.Viper, c cacher) BlockStoreI {
+	ss, err := NewSegmentStore(basePath, sViper.GetInt64("rounds"), c)
+	if err != nil {
+		panic(err)
+	}
+
+	if !sViper.IsSet("migrate") || sViper.GetBool("migrate") {
+		if err := ss.MigrateFrom(basePath); err != nil {
+			panic(err)
+		}
+	}
+
+	if cViper := sViper.Sub("cold"); cViper != nil && cViper.GetBool("enabled") {
+		ct, err := initColdTier(cViper)
+		if err != nil {
+			panic(err)
+		}
+		ss.cold = ct
+		go ss.coldTierWorker(common.GetRootContext())
+	}
+	return ss
+}
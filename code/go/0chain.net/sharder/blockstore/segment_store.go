@@ -0,0 +1,591 @@
+package blockstore
+
+// SegmentStore packs finalized blocks into append-only segment files, each
+// covering a fixed range of rounds. A segment is a blockdb.BlockDB where the
+// data file holds the compressed blocks and the header file holds the hash
+// index and the round index of the segment.
+//
+// Only the segment of the latest rounds is kept open for writing (active). It
+// gets sealed, i.e. its header gets saved, as soon as a block of a later
+// segment arrives. A block that arrives late for an already sealed segment is
+// appended to the active segment, sealed segments are never written again.
+//
+// The keys of all the blocks are kept in memory along with the segment holding
+// them, so a block is read from a single segment.
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/sharder/blockdb"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSegmentRounds is the number of rounds a segment covers when it's not configured.
+	DefaultSegmentRounds = 100000
+	// blockKeyLength is the length of the keys of a segment index, i.e. block hashes.
+	blockKeyLength = 64
+
+	segmentsDir       = "segments"
+	segmentFilePrefix = "seg_"
+)
+
+// ErrBlockNotFound is returned when no segment contains the requested block.
+var ErrBlockNotFound = errors.New("block not found")
+
+// blockRecord is the record of a segment. The key is stored along with the block
+// as magic blocks are additionally indexed by the magic block hash.
+type blockRecord struct {
+	key   blockdb.Key
+	block *block.Block
+}
+
+func (br *blockRecord) GetKey() blockdb.Key {
+	return br.key
+}
+
+func (br *blockRecord) Encode(writer io.Writer) error {
+	if _, err := writer.Write([]byte{byte(len(br.key))}); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(writer, string(br.key)); err != nil {
+		return err
+	}
+	return datastore.WriteMsgpack(writer, br.block)
+}
+
+func (br *blockRecord) Decode(reader io.Reader) error {
+	klen := make([]byte, 1)
+	if _, err := io.ReadFull(reader, klen); err != nil {
+		return err
+	}
+	key := make([]byte, klen[0])
+	if _, err := io.ReadFull(reader, key); err != nil {
+		return err
+	}
+	br.key = blockdb.Key(key)
+	return datastore.ReadMsgpack(reader, br.block)
+}
+
+type blockRecordProvider struct {
+	blockMetadataProvider datastore.EntityMetadata
+}
+
+func (brp *blockRecordProvider) NewRecord() blockdb.Record {
+	return &blockRecord{block: brp.blockMetadataProvider.Instance().(*block.Block)}
+}
+
+// segmentHeader is saved in the header file of a segment along with the hash index.
+type segmentHeader struct {
+	StartRound int64            `msgpack:"s"`
+	EndRound   int64            `msgpack:"e"`
+	Rounds     map[int64]string `msgpack:"r"`
+}
+
+func newSegmentHeader(start, end int64) *segmentHeader {
+	return &segmentHeader{StartRound: start, EndRound: end, Rounds: make(map[int64]string)}
+}
+
+func (sh *segmentHeader) Encode(writer io.Writer) error {
+	_, err := common.ToMsgpack(sh).WriteTo(writer)
+	return err
+}
+
+func (sh *segmentHeader) Decode(reader io.Reader) error {
+	return common.FromMsgpack(reader, sh)
+}
+
+// add records the block in the round index, magic block keys are left out.
+func (sh *segmentHeader) add(br *blockRecord) {
+	if string(br.key) == br.block.Hash {
+		sh.Rounds[br.block.Round] = br.block.Hash
+	}
+}
+
+// segment is the segment open for writing.
+type segment struct {
+	start  int64
+	db     *blockdb.BlockDB
+	header *segmentHeader
+}
+
+type SegmentStore struct {
+	// basePath is the path to the directory containing the "segments" directory.
+	basePath              string
+	segmentRounds         int64
+	blockMetadataProvider datastore.EntityMetadata
+	cache                 cacher
+
+	mutex sync.Mutex
+	// active is the segment of the latest rounds, it's nil until the first write.
+	active *segment
+	// starts are the starting rounds of all the existing segments in ascending order.
+	starts []int64
+	// latestRound is the highest round written since the store was opened.
+	latestRound int64
+	// index maps the keys of the blocks, the block hashes and the magic block
+	// hashes, to the start round of the segment holding them.
+	index map[string]int64
+	// lateRounds maps the rounds of the blocks written late to the start round
+	// of the segment holding them, which isn't the segment of their round.
+	lateRounds map[int64]int64
+
+	// cold is the optional cold tier old segments are moved to.
+	cold *coldTier
+}
+
+var _ BlockStoreI = (*SegmentStore)(nil)
+
+// NewSegmentStore opens the segment store under basePath. Segments left
+// unsealed by an unclean shutdown are recovered, the latest one becomes active.
+func NewSegmentStore(basePath string, segmentRounds int64, c cacher) (*SegmentStore, error) {
+	if segmentRounds <= 0 {
+		segmentRounds = DefaultSegmentRounds
+	}
+	if c == nil {
+		c = noOpCache{}
+	}
+	ss := &SegmentStore{
+		basePath:              basePath,
+		segmentRounds:         segmentRounds,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 c,
+		index:                 make(map[string]int64),
+		lateRounds:            make(map[int64]int64),
+	}
+	if err := os.MkdirAll(ss.segmentsPath(), 0700); err != nil {
+		return nil, err
+	}
+	if err := ss.loadSegments(); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+func (ss *SegmentStore) segmentsPath() string {
+	return filepath.Join(ss.basePath, segmentsDir)
+}
+
+// segmentFile returns the path of the segment files without the extension.
+func (ss *SegmentStore) segmentFile(start int64) string {
+	return filepath.Join(ss.segmentsPath(), fmt.Sprintf("%s%012d", segmentFilePrefix, start))
+}
+
+func (ss *SegmentStore) segmentStart(round int64) int64 {
+	if round < 0 {
+		round = 0
+	}
+	return round - round%ss.segmentRounds
+}
+
+func (ss *SegmentStore) headerFile(start int64) string {
+	return ss.segmentFile(start) + "." + blockdb.FileExtHeader
+}
+
+func (ss *SegmentStore) dataFile(start int64) string {
+	return ss.segmentFile(start) + "." + blockdb.FileExtData
+}
+
+func (ss *SegmentStore) isSealed(start int64) bool {
+	_, err := os.Stat(ss.headerFile(start))
+	return err == nil
+}
+
+// isCold tells whether the data of a sealed segment has been moved to the cold tier.
+func (ss *SegmentStore) isCold(start int64) bool {
+	if !ss.isSealed(start) {
+		return false
+	}
+	_, err := os.Stat(ss.dataFile(start))
+	return os.IsNotExist(err)
+}
+
+// loadSegments lists the existing segments, seals all unsealed segments but
+// the latest one and opens the latest unsealed one for writing. The keys of
+// the sealed segments are loaded from their headers.
+func (ss *SegmentStore) loadSegments() error {
+	entries, err := os.ReadDir(ss.segmentsPath())
+	if err != nil {
+		return err
+	}
+	// the data file of a segment moved to the cold tier is gone, only its header is left
+	for _, e := range entries {
+		name := e.Name()
+		ext := filepath.Ext(name)
+		if !strings.HasPrefix(name, segmentFilePrefix) ||
+			(ext != "."+blockdb.FileExtData && ext != "."+blockdb.FileExtHeader) {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, segmentFilePrefix), ext), 10, 64)
+		if err != nil {
+			continue
+		}
+		ss.addStart(start)
+	}
+
+	if len(ss.starts) > 0 {
+		ss.latestRound = ss.starts[len(ss.starts)-1]
+	}
+
+	for i, start := range ss.starts {
+		if ss.isSealed(start) {
+			if err := ss.loadSealedIndex(start); err != nil {
+				return err
+			}
+			continue
+		}
+		seg, err := ss.reopenSegment(start)
+		if err != nil {
+			return err
+		}
+		if i == len(ss.starts)-1 {
+			ss.active = seg
+			for round := range seg.header.Rounds {
+				if round > ss.latestRound {
+					ss.latestRound = round
+				}
+			}
+			break
+		}
+		logging.Logger.Info("segment store - sealing recovered segment", zap.Int64("start_round", start))
+		if err := ss.seal(seg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSealedIndex adds the keys of a sealed segment to the in-memory index,
+// only the header is read so it works for the segments in the cold tier.
+func (ss *SegmentStore) loadSealedIndex(start int64) error {
+	db, err := blockdb.NewBlockDB(ss.segmentFile(start), blockKeyLength, true)
+	if err != nil {
+		return err
+	}
+	header := newSegmentHeader(start, start+ss.segmentRounds-1)
+	db.SetDBHeader(header)
+	if err := db.OpenHeader(); err != nil {
+		return err
+	}
+	defer db.Close()
+	ss.indexSegment(start, db.GetKeys(), header)
+	return nil
+}
+
+// indexSegment adds the keys and the late rounds of a segment to the
+// in-memory indexes.
+func (ss *SegmentStore) indexSegment(start int64, keys []blockdb.Key, header *segmentHeader) {
+	for _, key := range keys {
+		ss.index[string(key)] = start
+	}
+	for round := range header.Rounds {
+		if ss.segmentStart(round) != start {
+			ss.lateRounds[round] = start
+		}
+	}
+}
+
+// reopenSegment opens an unsealed or sealed segment for appending, the indexes
+// are rebuilt from the data file.
+func (ss *SegmentStore) reopenSegment(start int64) (*segment, error) {
+	db, err := blockdb.NewBlockDB(ss.segmentFile(start), blockKeyLength, true)
+	if err != nil {
+		return nil, err
+	}
+	header := newSegmentHeader(start, start+ss.segmentRounds-1)
+	err = db.Reopen(context.TODO(), func(_ context.Context, record blockdb.Record) error {
+		header.add(record.(*blockRecord))
+		return nil
+	}, &blockRecordProvider{blockMetadataProvider: ss.blockMetadataProvider})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	db.SetDBHeader(header)
+	ss.indexSegment(start, db.GetKeys(), header)
+	return &segment{start: start, db: db, header: header}, nil
+}
+
+// openSegment opens the segment for writing, a sealed segment is unsealed first.
+func (ss *SegmentStore) openSegment(start int64) (*segment, error) {
+	if err := ss.unseal(start); err != nil {
+		return nil, err
+	}
+	return ss.reopenSegment(start)
+}
+
+// unseal prepares a segment for appending, the data of a segment in the cold
+// tier is brought back to the local disk.
+func (ss *SegmentStore) unseal(start int64) error {
+	if !ss.isSealed(start) {
+		ss.addStart(start)
+		return nil
+	}
+	if ss.isCold(start) {
+		if err := ss.restoreFromCold(start); err != nil {
+			return err
+		}
+	}
+	return os.Remove(ss.headerFile(start))
+}
+
+func (ss *SegmentStore) addStart(start int64) {
+	i := sort.Search(len(ss.starts), func(i int) bool { return ss.starts[i] >= start })
+	if i < len(ss.starts) && ss.starts[i] == start {
+		return
+	}
+	ss.starts = append(ss.starts, 0)
+	copy(ss.starts[i+1:], ss.starts[i:])
+	ss.starts[i] = start
+}
+
+// seal saves the header of the segment and closes it.
+func (ss *SegmentStore) seal(seg *segment) error {
+	return seg.db.Save()
+}
+
+func (ss *SegmentStore) writeRecord(seg *segment, br *blockRecord) error {
+	if err := seg.db.WriteData(br); err != nil {
+		return err
+	}
+	seg.header.add(br)
+	ss.index[string(br.key)] = seg.start
+	if string(br.key) == br.block.Hash && ss.segmentStart(br.block.Round) != seg.start {
+		ss.lateRounds[br.block.Round] = seg.start
+	}
+	return nil
+}
+
+// write appends the records of a block to the segment of its round, or to the
+// active segment when the segment of its round is already sealed.
+func (ss *SegmentStore) write(b *block.Block, records ...*blockRecord) error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	if b.Round > ss.latestRound {
+		ss.latestRound = b.Round
+	}
+
+	start := ss.segmentStart(b.Round)
+	if ss.active == nil || start > ss.active.start {
+		if ss.active != nil {
+			logging.Logger.Info("segment store - sealing segment", zap.Int64("start_round", ss.active.start))
+			if err := ss.seal(ss.active); err != nil {
+				return err
+			}
+			ss.active = nil
+		}
+		// a late block written first after a restart goes to the latest segment
+		seg, err := ss.openSegment(ss.segmentStart(ss.latestRound))
+		if err != nil {
+			return err
+		}
+		ss.active = seg
+	}
+
+	if ss.active.start != start {
+		logging.Logger.Info("segment store - late block written to the active segment",
+			zap.Int64("round", b.Round),
+			zap.Int64("start_round", ss.active.start))
+	}
+	for _, br := range records {
+		if err := ss.writeRecord(ss.active, br); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ss *SegmentStore) Write(b *block.Block) error {
+	if len(b.Hash) != blockKeyLength {
+		return fmt.Errorf("invalid block hash: %s", b.Hash)
+	}
+	records := []*blockRecord{{key: blockdb.Key(b.Hash), block: b}}
+	if b.MagicBlock != nil && b.Round == b.MagicBlock.StartingRound && b.MagicBlock.Hash != b.Hash {
+		logging.Logger.Debug("save magic block",
+			zap.Int64("round", b.Round),
+			zap.String("mb hash", b.MagicBlock.Hash),
+		)
+		if len(b.MagicBlock.Hash) != blockKeyLength {
+			return fmt.Errorf("invalid magic block hash: %s", b.MagicBlock.Hash)
+		}
+		records = append(records, &blockRecord{key: blockdb.Key(b.MagicBlock.Hash), block: b})
+	}
+
+	if err := ss.write(b, records...); err != nil {
+		return err
+	}
+
+	go func() {
+		ctx, ctxCncl := context.WithTimeout(context.TODO(), CacheWriteTimeOut)
+		defer ctxCncl()
+		if err := ss.cache.Write(ctx, b.Hash, b); err != nil {
+			logging.Logger.Error(err.Error())
+		}
+	}()
+	return nil
+}
+
+// readFromSegment reads the block from the segment starting at the given round.
+func (ss *SegmentStore) readFromSegment(start int64, hash string) (*block.Block, error) {
+	ss.mutex.Lock()
+	if ss.active != nil && ss.active.start == start {
+		br := (&blockRecordProvider{blockMetadataProvider: ss.blockMetadataProvider}).NewRecord()
+		err := ss.active.db.Read(blockdb.Key(hash), br)
+		ss.mutex.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return br.(*blockRecord).block, nil
+	}
+	ss.mutex.Unlock()
+
+	return ss.readSealed(start, func(*segmentHeader) (string, bool) { return hash, true })
+}
+
+// readSealed reads a block from a sealed segment, the key of the block is looked
+// up in the segment header. The block is read from the cold tier when the data
+// of the segment is not available locally.
+func (ss *SegmentStore) readSealed(start int64, lookup func(*segmentHeader) (string, bool)) (*block.Block, error) {
+	db, err := blockdb.NewBlockDB(ss.segmentFile(start), blockKeyLength, true)
+	if err != nil {
+		return nil, err
+	}
+	header := newSegmentHeader(start, start+ss.segmentRounds-1)
+	db.SetDBHeader(header)
+
+	var cold bool
+	if err := db.Open(); err != nil {
+		if ss.cold == nil || !os.IsNotExist(err) || !ss.isSealed(start) {
+			return nil, err
+		}
+		// moved to the cold tier
+		if err := db.OpenHeader(); err != nil {
+			return nil, err
+		}
+		cold = true
+	}
+	defer db.Close()
+
+	hash, ok := lookup(header)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+	br := (&blockRecordProvider{blockMetadataProvider: ss.blockMetadataProvider}).NewRecord()
+	if cold {
+		err = db.ReadFrom(blockdb.Key(hash), br, func(offset int64) (io.ReadCloser, error) {
+			return ss.readCold(context.TODO(), filepath.Base(ss.dataFile(start)), offset)
+		})
+	} else {
+		err = db.Read(blockdb.Key(hash), br)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return br.(*blockRecord).block, nil
+}
+
+func (ss *SegmentStore) readFromCache(hash string) *block.Block {
+	data, err := ss.cache.Read(hash)
+	if data == nil || err != nil {
+		return nil
+	}
+	b := ss.blockMetadataProvider.Instance().(*block.Block)
+	if err := datastore.ReadMsgpack(bytes.NewReader(data), b); err != nil {
+		return nil
+	}
+	return b
+}
+
+func (ss *SegmentStore) cacheBlock(b *block.Block) {
+	go func() {
+		ctx, ctxCncl := context.WithTimeout(context.TODO(), CacheWriteTimeOut)
+		defer ctxCncl()
+		if err := ss.cache.Write(ctx, b.Hash, b); err != nil {
+			logging.Logger.Error(err.Error())
+		}
+	}()
+}
+
+// Read reads the block from the segment holding it.
+func (ss *SegmentStore) Read(hash string) (*block.Block, error) {
+	if b := ss.readFromCache(hash); b != nil {
+		return b, nil
+	}
+
+	ss.mutex.Lock()
+	start, ok := ss.index[hash]
+	ss.mutex.Unlock()
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	b, err := ss.readFromSegment(start, hash)
+	if err == blockdb.ErrKeyNotFound || os.IsNotExist(err) {
+		return nil, ErrBlockNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	ss.cacheBlock(b)
+	return b, nil
+}
+
+// ReadWithBlockSummary - read the block given the block summary
+func (ss *SegmentStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
+	return ss.Read(bs.Hash)
+}
+
+// ReadByRound reads the finalized block of the given round.
+func (ss *SegmentStore) ReadByRound(round int64) (*block.Block, error) {
+	start := ss.segmentStart(round)
+
+	ss.mutex.Lock()
+	if late, ok := ss.lateRounds[round]; ok {
+		start = late
+	}
+	if ss.active != nil && ss.active.start == start {
+		hash, ok := ss.active.header.Rounds[round]
+		ss.mutex.Unlock()
+		if !ok {
+			return nil, ErrBlockNotFound
+		}
+		return ss.readFromSegment(start, hash)
+	}
+	ss.mutex.Unlock()
+
+	b, err := ss.readSealed(start, func(header *segmentHeader) (string, bool) {
+		hash, ok := header.Rounds[round]
+		return hash, ok
+	})
+	if os.IsNotExist(err) {
+		return nil, ErrBlockNotFound
+	}
+	return b, err
+}
+
+// Close seals the active segment.
+func (ss *SegmentStore) Close() error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if ss.active == nil {
+		return nil
+	}
+	err := ss.seal(ss.active)
+	ss.active = nil
+	return err
+}
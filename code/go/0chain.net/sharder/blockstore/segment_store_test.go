@@ -0,0 +1,227 @@
+package blockstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func newSegmentTestBlock(round int64) *block.Block {
+	b := new(block.Block)
+	b.Round = round
+	b.Hash = encryption.Hash(fmt.Sprintf("block %d", round))
+	return b
+}
+
+func TestSegmentStoreWriteRead(t *testing.T) {
+	basePath := t.TempDir()
+	ss, err := NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+
+	var blocks []*block.Block
+	for r := int64(1); r <= 35; r++ {
+		b := newSegmentTestBlock(r)
+		require.NoError(t, ss.Write(b))
+		blocks = append(blocks, b)
+	}
+
+	// 3 sealed segments and the active one
+	require.Equal(t, []int64{0, 10, 20, 30}, ss.starts)
+	for _, start := range []int64{0, 10, 20} {
+		require.True(t, ss.isSealed(start))
+	}
+	require.False(t, ss.isSealed(30))
+
+	for _, b := range blocks {
+		b1, err := ss.ReadWithBlockSummary(&block.BlockSummary{Hash: b.Hash, Round: b.Round})
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, b1.Hash)
+		require.Equal(t, b.Round, b1.Round)
+
+		b1, err = ss.Read(b.Hash)
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, b1.Hash)
+
+		b1, err = ss.ReadByRound(b.Round)
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, b1.Hash)
+	}
+
+	_, err = ss.Read(encryption.Hash("unknown"))
+	require.Equal(t, ErrBlockNotFound, err)
+
+	short := new(block.Block)
+	short.Hash = "short hash"
+	require.Error(t, ss.Write(short))
+}
+
+func TestSegmentStoreMagicBlock(t *testing.T) {
+	ss, err := NewSegmentStore(t.TempDir(), 10, nil)
+	require.NoError(t, err)
+
+	b := newSegmentTestBlock(15)
+	b.MagicBlock = block.NewMagicBlock()
+	b.MagicBlock.StartingRound = 15
+	b.MagicBlock.Hash = encryption.Hash("magic block")
+	require.NoError(t, ss.Write(b))
+	require.NoError(t, ss.Write(newSegmentTestBlock(25)))
+
+	b1, err := ss.Read(b.MagicBlock.Hash)
+	require.NoError(t, err)
+	require.Equal(t, b.Hash, b1.Hash)
+
+	b1, err = ss.ReadByRound(15)
+	require.NoError(t, err)
+	require.Equal(t, b.Hash, b1.Hash)
+}
+
+func TestSegmentStoreLateWrite(t *testing.T) {
+	basePath := t.TempDir()
+	ss, err := NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Write(newSegmentTestBlock(1)))
+	require.NoError(t, ss.Write(newSegmentTestBlock(11)))
+	require.True(t, ss.isSealed(0))
+	fi, err := os.Stat(ss.dataFile(0))
+	require.NoError(t, err)
+
+	// the late block is appended to the active segment, the sealed one is
+	// left as it is
+	late := newSegmentTestBlock(5)
+	require.NoError(t, ss.Write(late))
+	require.True(t, ss.isSealed(0))
+	cur, err := os.Stat(ss.dataFile(0))
+	require.NoError(t, err)
+	require.Equal(t, fi.Size(), cur.Size())
+	require.Equal(t, int64(10), ss.index[late.Hash])
+
+	check := func() {
+		for _, r := range []int64{1, 5, 11} {
+			b, err := ss.ReadByRound(r)
+			require.NoError(t, err)
+			require.Equal(t, r, b.Round)
+
+			b, err = ss.Read(newSegmentTestBlock(r).Hash)
+			require.NoError(t, err)
+			require.Equal(t, r, b.Round)
+		}
+	}
+	check()
+
+	// the late rounds are restored from the sealed segment headers
+	require.NoError(t, ss.Close())
+	ss, err = NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]int64{5: 10}, ss.lateRounds)
+	check()
+
+	// a late block written first after a restart goes to the latest segment
+	require.NoError(t, ss.Write(newSegmentTestBlock(3)))
+	require.Equal(t, int64(10), ss.active.start)
+	b, err := ss.ReadByRound(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), b.Round)
+}
+
+func TestSegmentStoreRecovery(t *testing.T) {
+	basePath := t.TempDir()
+	ss, err := NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	for r := int64(1); r <= 15; r++ {
+		require.NoError(t, ss.Write(newSegmentTestBlock(r)))
+	}
+	// unclean shutdown, the active segment is never sealed
+	require.NoError(t, ss.active.db.Close())
+
+	ss, err = NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	require.NotNil(t, ss.active)
+	require.Equal(t, int64(10), ss.active.start)
+	require.Len(t, ss.active.header.Rounds, 6)
+
+	require.NoError(t, ss.Write(newSegmentTestBlock(16)))
+	require.NoError(t, ss.Close())
+
+	ss, err = NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	require.Nil(t, ss.active)
+	for r := int64(1); r <= 16; r++ {
+		b, err := ss.ReadByRound(r)
+		require.NoError(t, err)
+		require.Equal(t, newSegmentTestBlock(r).Hash, b.Hash)
+	}
+}
+
+func TestSegmentStoreMigrateFrom(t *testing.T) {
+	basePath := t.TempDir()
+	legacy := &BlockStore{
+		basePath:              basePath,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+	}
+
+	var blocks []*block.Block
+	for r := int64(1); r <= 25; r++ {
+		b := newSegmentTestBlock(r)
+		require.NoError(t, legacy.writeToDisk(b.Hash, b))
+		blocks = append(blocks, b)
+	}
+
+	ss, err := NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	require.NoError(t, ss.MigrateFrom(basePath))
+	require.Equal(t, []int64{0, 10, 20}, ss.starts)
+
+	for _, b := range blocks {
+		bp, err := getBlockFilePath(b.Hash)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(basePath, bp))
+		require.True(t, os.IsNotExist(err))
+
+		b1, err := ss.ReadWithBlockSummary(&block.BlockSummary{Hash: b.Hash, Round: b.Round})
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, b1.Hash)
+	}
+
+	entries, err := os.ReadDir(basePath)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, segmentsDir, entries[0].Name())
+}
+
+func TestSegmentStoreMigrateCorruptFile(t *testing.T) {
+	basePath := t.TempDir()
+	legacy := &BlockStore{
+		basePath:              basePath,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+	}
+
+	b := newSegmentTestBlock(1)
+	require.NoError(t, legacy.writeToDisk(b.Hash, b))
+
+	corrupt := newSegmentTestBlock(2)
+	bp, err := getBlockFilePath(corrupt.Hash)
+	require.NoError(t, err)
+	corruptPath := filepath.Join(basePath, bp)
+	require.NoError(t, os.MkdirAll(filepath.Dir(corruptPath), 0700))
+	require.NoError(t, os.WriteFile(corruptPath, []byte("not a block"), 0600))
+
+	// the corrupt file is skipped and left in place
+	ss, err := NewSegmentStore(basePath, 10, nil)
+	require.NoError(t, err)
+	require.NoError(t, ss.MigrateFrom(basePath))
+	_, err = os.Stat(corruptPath)
+	require.NoError(t, err)
+
+	b1, err := ss.Read(b.Hash)
+	require.NoError(t, err)
+	require.Equal(t, b.Hash, b1.Hash)
+	_, err = ss.Read(corrupt.Hash)
+	require.Equal(t, ErrBlockNotFound, err)
+}
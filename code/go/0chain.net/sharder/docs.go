@@ -24,6 +24,9 @@ type BlockResponse struct {
 
 	// Will be returned if only merkle tree is requested.
 	MerkleTree []string `json:"merkle_tree"`
+
+	// Will be returned if the notarized header is requested.
+	NotarizedHeader *block.Header `json:"notarized_header"`
 }
 
 // swagger:model HealthCheckResponse
@@ -0,0 +1,205 @@
+package sharder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// eventStreamKeepAlive is the interval of the comments sent to keep an idle events stream open.
+const eventStreamKeepAlive = 15 * time.Second
+
+// swagger:route GET /v1/events/subscribe sharder SubscribeEvents
+// Subscribe to the events.
+// Streams the events of the finalized blocks as server-sent events. The id of every
+// sent event is its sequence number, a subscriber resumes from the last received
+// event with the from_sequence parameter or the Last-Event-ID header.
+//
+// parameters:
+//
+//	+name: tags
+//	 description: comma separated list of the event tags, by name (with or without the Tag prefix) or number
+//	 in: query
+//	 type: string
+//	+name: types
+//	 description: comma separated list of the event types, by name or number
+//	 in: query
+//	 type: string
+//	+name: index
+//	 description: comma separated list of the event indexes (entity ids)
+//	 in: query
+//	 type: string
+//	+name: client_id
+//	 description: comma separated list of the client ids the events are related to
+//	 in: query
+//	 type: string
+//	+name: from_sequence
+//	 description: sequence number of the last received event, the events after it are sent first
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200:
+//	400:
+func EventsSubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	edb := GetSharderChain().Chain.GetEventDb()
+	if edb == nil || edb.Stream() == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("event database is not enabled"))
+		return
+	}
+
+	filter, fromSequence, err := parseEventsSubscription(r)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		common.Respond(w, r, nil, common.NewErrInternal("streaming is not supported"))
+		return
+	}
+
+	stream := edb.Stream()
+	sub, err := stream.Subscribe(filter, fromSequence)
+	if err != nil {
+		if errors.Is(err, event.ErrSequenceTooOld) {
+			common.Respond(w, r, nil, common.NewErrNoResource(err.Error()))
+			return
+		}
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+	defer stream.Unsubscribe(sub)
+
+	// the stream outlives the server write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		logging.Logger.Debug("events subscription - can't reset write deadline", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for _, e := range sub.Backlog() {
+		if err := writeStreamEvent(w, e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-sub.Done():
+			if err := sub.Err(); err != nil {
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+				flusher.Flush()
+			}
+			return
+		case e := <-sub.Events():
+			if err := writeStreamEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(w http.ResponseWriter, e event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		logging.Logger.Error("events subscription - marshal event",
+			zap.Int64("sequence_number", e.SequenceNumber), zap.Error(err))
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.SequenceNumber, e.Tag.String(), data)
+	return err
+}
+
+func parseEventsSubscription(r *http.Request) (event.EventFilter, int64, error) {
+	var (
+		filter event.EventFilter
+		query  = r.URL.Query()
+	)
+
+	for _, s := range splitQueryList(query.Get("tags")) {
+		tag, err := event.ParseEventTag(s)
+		if err != nil {
+			return filter, 0, err
+		}
+		if filter.Tags == nil {
+			filter.Tags = make(map[event.EventTag]struct{})
+		}
+		filter.Tags[tag] = struct{}{}
+	}
+
+	for _, s := range splitQueryList(query.Get("types")) {
+		typ, err := event.ParseEventType(s)
+		if err != nil {
+			return filter, 0, err
+		}
+		if filter.Types == nil {
+			filter.Types = make(map[event.EventType]struct{})
+		}
+		filter.Types[typ] = struct{}{}
+	}
+
+	for _, s := range splitQueryList(query.Get("index")) {
+		if filter.Indexes == nil {
+			filter.Indexes = make(map[string]struct{})
+		}
+		filter.Indexes[s] = struct{}{}
+	}
+
+	for _, s := range splitQueryList(query.Get("client_id")) {
+		if filter.ClientIDs == nil {
+			filter.ClientIDs = make(map[string]struct{})
+		}
+		filter.ClientIDs[s] = struct{}{}
+	}
+
+	from := query.Get("from_sequence")
+	if from == "" {
+		from = r.Header.Get("Last-Event-ID")
+	}
+	var fromSequence int64
+	if from != "" {
+		var err error
+		fromSequence, err = strconv.ParseInt(from, 10, 64)
+		if err != nil || fromSequence < 0 {
+			return filter, 0, fmt.Errorf("invalid sequence number: %s", from)
+		}
+	}
+	return filter, fromSequence, nil
+}
+
+func splitQueryList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
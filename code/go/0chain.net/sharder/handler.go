@@ -16,6 +16,7 @@ import (
 	"0chain.net/core/build"
 	"0chain.net/core/common"
 	"0chain.net/core/config"
+	"0chain.net/core/datastore"
 )
 
 func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
@@ -23,6 +24,7 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 		"/v1/block/get":                    common.ToJSONResponse(BlockHandler),
 		"/v1/block/magic/get":              common.ToJSONResponse(MagicBlockHandler),
 		"/v1/transaction/get/confirmation": common.ToJSONResponse(TransactionConfirmationHandler),
+		"/v1/transaction/get/receipt":      common.ToJSONResponse(TransactionReceiptHandler),
 		"/v1/healthcheck":                  common.ToJSONResponse(HealthcheckHandler),
 		"/v1/chain/get/stats":              common.ToJSONResponse(ChainStatsHandler),
 		"/_chain_stats":                    ChainStatsWriter,
@@ -31,6 +33,7 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 		"/v1/state/nodes":                  common.ToJSONResponse(chain.StateNodesHandler),
 		"/v1/block/state_change":           common.ToJSONResponse(BlockStateChangeHandler),
 		"/_transaction_errors":             TransactionErrorWriter,
+		"/v1/events/subscribe":             EventsSubscribeHandler,
 	}
 
 	handlers := make(map[string]func(http.ResponseWriter, *http.Request))
@@ -40,6 +43,40 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 	return handlers
 }
 
+/*TransactionReceiptHandler - given a transaction hash, get the receipt of the finalized transaction */
+// swagger:route GET /v1/transaction/get/receipt sharder GetTransactionReceipt
+// Get transaction receipt.
+// Get the receipt of a finalized transaction: the status, the output, the events it emitted,
+// the transfers and mints it made and the fee charged. The receipt merkle path proves the
+// output hash and the results hash, the hash of the events, transfers, mints and fee, against
+// the receipt merkle tree root of the block.
+//
+// parameters:
+//    +name: hash
+//      in: query
+//      required: true
+//      type: string
+//      description: Transaction hash
+//
+// responses:
+//    200: Receipt
+//    400:
+//    404:
+func TransactionReceiptHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	hash := r.FormValue("hash")
+	if hash == "" {
+		return nil, common.InvalidRequest("transaction hash (parameter hash) is required")
+	}
+	receipt, err := GetSharderChain().GetTransactionReceipt(ctx, hash)
+	if err != nil {
+		if cerr, ok := err.(*common.Error); ok && cerr.Code == datastore.EntityNotFound {
+			return nil, common.NewErrNoResource("transaction receipt not found")
+		}
+		return nil, err
+	}
+	return receipt, nil
+}
+
 func BlockStateChangeHandler(ctx context.Context, r *http.Request) (interface{}, error) {
 	c := chain.GetServerChain()
 	return c.BlockStateChangeHandler(ctx, r)
@@ -84,6 +121,7 @@ func HealthcheckHandler(ctx context.Context, r *http.Request) (interface{}, erro
 // If "content" == "full", the response has the full Block in `block` field.
 // If "content" == "header", the response has the BlockSummary in `header` field.
 // If "content" == "merkle_tree", the response has the Merkle Tree of the transactions in the block in `merkle_tree` field.
+// If "content" == "notarized_header", the response has the BlockHeader with the verification tickets in `notarized_header` field.
 //
 // parameters:
 //   +name: block
@@ -97,7 +135,7 @@ func HealthcheckHandler(ctx context.Context, r *http.Request) (interface{}, erro
 //   +name: content
 //	 in: query
 //	 type: string
-//	 description: A comma-separated list of parts of the block to retrieve. Possible values are "full" to retrieve the full block, "header" to retrieve summary, "merkle_tree" to retrieve Merkle Tree of the transactions inside the block. Default is "header".
+//	 description: A comma-separated list of parts of the block to retrieve. Possible values are "full" to retrieve the full block, "header" to retrieve summary, "merkle_tree" to retrieve Merkle Tree of the transactions inside the block, "notarized_header" to retrieve the header with the verification tickets. Default is "header".
 //
 // responses:
 //  200: BlockResponse
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"0chain.net/chaincore/chain"
 	"0chain.net/chaincore/node"
 	"0chain.net/chaincore/round"
 	"0chain.net/core/common"
@@ -75,12 +76,24 @@ func (sc *Chain) UpdateFinalizedBlock(ctx context.Context, b *block.Block) {
 	self.Underlying().Info.AvgBlockTxns = int(math.Round(bsHistogram.Mean()))
 
 	wg.Run("store transactions", b.Round, func() error {
+		_, span := chain.StartBlockSpan(ctx, "store_transactions", b)
+		defer span.End()
 		if err := sc.StoreTransactions(b); err != nil {
 			Logger.Panic(fmt.Sprintf("db store transaction failed. Error: %v", err))
 		}
 		return nil
 	})
 
+	wg.Run("store transaction receipts", b.Round, func() error {
+		if err := sc.StoreTxnReceipts(b); err != nil {
+			Logger.Error("store transaction receipts failed",
+				zap.Int64("round", b.Round),
+				zap.String("block", b.Hash),
+				zap.Error(err))
+		}
+		return nil
+	})
+
 	wg.Run("store block summary", b.Round, func() error {
 		if err := sc.StoreBlockSummaryFromBlock(b); err != nil {
 			Logger.Panic(
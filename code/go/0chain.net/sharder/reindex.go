@@ -0,0 +1,147 @@
+package sharder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// reindexProgressInterval is the interval of the progress reports of a reindex
+const reindexProgressInterval = 10 * time.Second
+
+// getFinalizedBlock returns the finalized block of the round
+func (sc *Chain) getFinalizedBlock(ctx context.Context, round int64) (*block.Block, error) {
+	hash, err := sc.GetBlockHash(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	return sc.GetBlockFromHash(ctx, hash, round)
+}
+
+// reindexProgress returns the progress of a reindex of the rounds from and to,
+// the saved progress is resumed if it starts from the same round whatever
+// round it was reindexing to.
+func reindexProgress(saved *event.EventsReindex, from, to int64) *event.EventsReindex {
+	if saved == nil || saved.FromRound != from {
+		return &event.EventsReindex{FromRound: from, ToRound: to, LastRound: from - 1}
+	}
+	progress := *saved
+	progress.ToRound = to
+	return &progress
+}
+
+// ReindexEvents rebuilds the event db from the finalized blocks of the rounds
+// from and to, the latest finalized round if 0. The genesis block is replayed
+// from its initial state and the other blocks are re-executed on the state of
+// their previous block, or their stored events are used when the state has
+// been pruned. The progress is saved in the event db with the events of each
+// block, reindexing from the same round again resumes after the last
+// reindexed round.
+//
+// The events of the blocks are added to the event db as they are, a full
+// rebuild should start from round 0 on an empty event db.
+func (sc *Chain) ReindexEvents(ctx context.Context, from, to int64) error {
+	edb := sc.GetEventDb()
+	if edb == nil {
+		return errors.New("event db is disabled")
+	}
+
+	lfb := sc.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return errors.New("latest finalized block not available")
+	}
+	if to <= 0 || to > lfb.Round {
+		to = lfb.Round
+	}
+	if from < 0 || from > to {
+		return fmt.Errorf("invalid rounds range [%d, %d]", from, to)
+	}
+
+	progress, err := edb.GetEventsReindex()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return fmt.Errorf("can't read progress: %v", err)
+	}
+	progress = reindexProgress(progress, from, to)
+	if progress.LastRound >= to {
+		logging.Logger.Info("reindex events - already done",
+			zap.Int64("from", from), zap.Int64("to", to))
+		return nil
+	}
+
+	start := progress.LastRound + 1
+	logging.Logger.Info("reindex events - start",
+		zap.Int64("from", from),
+		zap.Int64("to", to),
+		zap.Int64("resume_from", start))
+
+	var prev *block.Block
+	if start > 0 {
+		if prev, err = sc.getFinalizedBlock(ctx, start-1); err != nil {
+			return fmt.Errorf("can't get block of round %d: %v", start-1, err)
+		}
+	}
+
+	var (
+		ts         = time.Now()
+		lastReport = ts
+	)
+	for round := start; round <= to; round++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		b, err := sc.getFinalizedBlock(ctx, round)
+		if err != nil {
+			return fmt.Errorf("can't get block of round %d: %v", round, err)
+		}
+
+		events, err := sc.ReplayBlockEvents(ctx, b, prev)
+		if err != nil {
+			return err
+		}
+
+		next := *progress
+		next.LastRound = round
+		if err := sc.ReprocessBlockEvents(ctx, b, events,
+			event.WithinTx(func(tx *event.EventDb) error {
+				return tx.SaveEventsReindex(&next)
+			})); err != nil {
+			return fmt.Errorf("can't process events of round %d: %v", round, err)
+		}
+		progress.LastRound = round
+		prev = b
+
+		if now := time.Now(); now.Sub(lastReport) >= reindexProgressInterval || round == to {
+			lastReport = now
+			done := round - start + 1
+			rate := float64(done) / now.Sub(ts).Seconds()
+			var eta time.Duration
+			if rate > 0 {
+				eta = time.Duration(float64(to-round)/rate) * time.Second
+			}
+			logging.Logger.Info("reindex events - progress",
+				zap.Int64("round", round),
+				zap.Int64("to", to),
+				zap.Float64("percent", float64(round-from+1)*100/float64(to-from+1)),
+				zap.Float64("rounds_per_second", rate),
+				zap.Duration("eta", eta))
+		}
+	}
+
+	logging.Logger.Info("reindex events - done",
+		zap.Int64("from", from),
+		zap.Int64("to", to),
+		zap.Duration("duration", time.Since(ts)))
+	return nil
+}
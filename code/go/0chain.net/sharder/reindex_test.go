@@ -0,0 +1,33 @@
+package sharder
+
+import (
+	"context"
+	"testing"
+
+	"0chain.net/smartcontract/dbs/event"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_ReindexEventsDisabledEventDb(t *testing.T) {
+	sc := makeTestChain(t)
+	require.Nil(t, sc.GetEventDb())
+
+	err := sc.ReindexEvents(context.Background(), 0, 10)
+	require.EqualError(t, err, "event db is disabled")
+}
+
+func TestReindexProgress(t *testing.T) {
+	require.Equal(t, &event.EventsReindex{FromRound: 5, ToRound: 100, LastRound: 4},
+		reindexProgress(nil, 5, 100))
+
+	// the reindex resumes when it is extended to the new latest finalized round
+	saved := &event.EventsReindex{FromRound: 5, ToRound: 100, LastRound: 60}
+	require.Equal(t, &event.EventsReindex{FromRound: 5, ToRound: 120, LastRound: 60},
+		reindexProgress(saved, 5, 120))
+	require.EqualValues(t, 100, saved.ToRound)
+
+	// a reindex from another round starts over
+	require.Equal(t, &event.EventsReindex{FromRound: 10, ToRound: 120, LastRound: 9},
+		reindexProgress(saved, 10, 120))
+}
@@ -52,6 +52,12 @@ func main() {
 	flag.String("nodes_file", "", "nodes_file (deprecated)")
 	workdir := ""
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
+	reindexEvents := flag.Bool("reindex_events", false, "rebuild the event db from the stored blocks and exit")
+	reindexEventsFrom := flag.Int64("reindex_events_from", 0, "first round of the event db rebuild, 0 to replay the genesis block")
+	reindexEventsTo := flag.Int64("reindex_events_to", 0, "last round of the event db rebuild, the latest finalized round if 0")
+	auditEvents := flag.Bool("audit_events", false, "compare the event db with the MPT and exit")
+	auditEventsRound := flag.Int64("audit_events_round", 0, "round of the event db audit, the latest finalized round if 0")
+	auditEventsRepair := flag.Bool("audit_events_repair", false, "overwrite the event db rows differing from the MPT")
 
 	flag.Parse()
 	config.Configuration().DeploymentMode = byte(*deploymentMode)
@@ -192,6 +198,7 @@ func main() {
 		zap.String("id", selfNode.GetKey()))
 
 	registerInConductor(node.Self.Underlying().GetKey())
+	chain.SetupTracing(workdir)
 
 	var server *http.Server
 	if config.Development() {
@@ -223,6 +230,24 @@ func main() {
 
 	Logger.Info("finish load latest blocks from store")
 
+	if *reindexEvents {
+		if err := sc.ReindexEvents(ctx, *reindexEventsFrom, *reindexEventsTo); err != nil {
+			Logger.Fatal("reindex events", zap.Error(err))
+		}
+		Logger.Info("reindex events - finished, exiting")
+		return
+	}
+
+	if *auditEvents {
+		report, err := sc.AuditEventDb(ctx, *auditEventsRound, *auditEventsRepair)
+		if err != nil {
+			Logger.Fatal("audit event db", zap.Error(err))
+		}
+		Logger.Info("audit event db - finished, exiting",
+			zap.Int("mismatches", len(report.Mismatches)))
+		return
+	}
+
 	sharder.SetupWorkers(ctx)
 
 	startBlocksInfoLogs(sc)
@@ -402,11 +427,13 @@ func initEntities(workdir string) {
 	block.SetupBlockSummaryDB(workdir)
 	block.SetupMagicBlockMapDB(workdir)
 	block.SetupBlockEventDB(workdir)
+	block.SetupTxnReceiptDB(workdir)
 
 	transaction.SetupTxnSummaryDB(workdir)
 	ememoryStorage := ememorystore.GetStorageProvider()
 	block.SetupBlockSummaryEntity(ememoryStorage)
 	block.SetupBlockEventEntity(ememoryStorage)
+	block.SetupTxnReceiptEntity(ememoryStorage)
 
 	block.SetupStateChange(memoryStorage)
 	state.SetupPartialState(memoryStorage)
@@ -98,6 +98,42 @@ func (sc *Chain) GetTransactionConfirmation(ctx context.Context, hash string) (*
 	return confirmation, nil
 }
 
+/*GetTransactionReceipt - given a transaction hash, get the receipt of the finalized transaction */
+func (sc *Chain) GetTransactionReceipt(ctx context.Context, hash string) (*block.Receipt, error) {
+	receiptMetadata := datastore.GetEntityMetadata(block.TxnReceiptMetaName)
+	rctx := ememorystore.WithEntityConnection(ctx, receiptMetadata)
+	defer ememorystore.Close(rctx)
+
+	receipt := receiptMetadata.Instance().(*block.Receipt)
+	if err := receipt.Read(rctx, hash); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+/*StoreTxnReceipts - persists the receipts of the transactions of the block and drops the results */
+func (sc *Chain) StoreTxnReceipts(b *block.Block) error {
+	defer b.ClearTxnResults()
+	receipts := b.GetReceipts()
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	entities := make([]datastore.Entity, len(receipts))
+	for i, r := range receipts {
+		entities[i] = r
+	}
+
+	receiptMetadata := datastore.GetEntityMetadata(block.TxnReceiptMetaName)
+	rctx := ememorystore.WithEntityConnection(common.GetRootContext(), receiptMetadata)
+	defer ememorystore.Close(rctx)
+
+	if err := receiptMetadata.GetStore().MultiWrite(rctx, receiptMetadata, entities); err != nil {
+		return err
+	}
+	return ememorystore.GetEntityCon(rctx, receiptMetadata).Commit()
+}
+
 /*StoreTransactions - persists given list of transactions*/
 func (sc *Chain) StoreTransactions(b *block.Block) error {
 	var sTxns = make([]datastore.Entity, len(b.Txns))
@@ -33,6 +33,8 @@ const (
 	MultiSig
 	ZCNSCBridge
 	ZCNSCBridgeRest
+	Governance
+	GovernanceRest
 	Control
 	EventDatabase
 	EventDatabaseEvents
@@ -53,6 +55,8 @@ var (
 		"multi_sig",
 		"zcnscbridge",
 		"zcnscbridge_rest",
+		"governance",
+		"governance_rest",
 		"control",
 		"event_db",
 		"event_db_events",
@@ -71,6 +75,8 @@ var (
 		SourceNames[MultiSig]:                MultiSig,
 		SourceNames[ZCNSCBridge]:             ZCNSCBridge,
 		SourceNames[ZCNSCBridgeRest]:         ZCNSCBridgeRest,
+		SourceNames[Governance]:              Governance,
+		SourceNames[GovernanceRest]:          GovernanceRest,
 		SourceNames[Control]:                 Control,
 		SourceNames[EventDatabase]:           EventDatabase,
 		SourceNames[EventDatabaseEvents]:     EventDatabaseEvents,
@@ -124,6 +130,7 @@ const (
 	FaucetSc      = "faucetsc."
 	VestingSc     = "vestingsc."
 	ZcnSc         = "zcnsc."
+	GovSc         = "govsc."
 	DbsEvents     = "dbs.events."
 	DbSettings    = "dbs.settings."
 
@@ -224,6 +231,17 @@ const (
 
 	FaucetOwner = SmartContract + FaucetSc + "owner_id"
 
+	GovernanceMinProposalStake     = SmartContract + GovSc + "min_proposal_stake"
+	GovernanceVotingPeriod         = SmartContract + GovSc + "voting_period"
+	GovernanceTimelock             = SmartContract + GovSc + "timelock"
+	GovernanceQuorum               = SmartContract + GovSc + "quorum"
+	GovernanceThreshold            = SmartContract + GovSc + "threshold"
+	GovernanceMaxActiveProposals   = SmartContract + GovSc + "max_active_proposals"
+	GovernanceMaxVoters            = SmartContract + GovSc + "max_voters"
+	GovernanceMaxVotePools         = SmartContract + GovSc + "max_vote_pools"
+	GovernanceMaxDescriptionLength = SmartContract + GovSc + "max_description_length"
+	GovernanceMinVoteStake         = SmartContract + GovSc + "min_vote_stake"
+
 	ZcnOwner              = SmartContract + ZcnSc + "owner_id"
 	ZcnMinMintAmount      = SmartContract + ZcnSc + "min_mint"
 	ZcnMinBurnAmount      = SmartContract + ZcnSc + "min_burn"
@@ -237,6 +255,9 @@ const (
 	ZcnMaxDelegates       = SmartContract + ZcnSc + "max_delegates"
 	HealthCheckPeriod     = SmartContract + ZcnSc + "health_check_period"
 
+	ZcnSlashFraction           = SmartContract + ZcnSc + "slash_fraction"
+	ZcnChallengeRewardFraction = SmartContract + ZcnSc + "challenge_reward_fraction"
+
 	EventDbEnabled         = DbsEvents + "enabled"
 	EventDbName            = DbsEvents + "name"
 	EventDbUser            = DbsEvents + "user"
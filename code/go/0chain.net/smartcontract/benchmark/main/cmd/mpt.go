@@ -29,6 +29,7 @@ import (
 	"0chain.net/smartcontract/benchmark/main/cmd/log"
 
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/govsc"
 
 	"0chain.net/chaincore/node"
 
@@ -470,6 +471,14 @@ func setUpMpt(
 		vestingsc.AddMockConfig(balances)
 		log.Println("added vesting pools\t", time.Since(timer))
 	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		timer := time.Now()
+		govsc.AddMockConfig(balances)
+		govsc.AddMockProposals(clients, miners, balances)
+		log.Println("added governance proposals\t", time.Since(timer))
+	}()
 
 	wg.Add(1)
 	go func() {
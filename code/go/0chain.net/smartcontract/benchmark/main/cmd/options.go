@@ -11,6 +11,7 @@ import (
 
 	"0chain.net/smartcontract/benchmark/main/cmd/control"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/govsc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/storagesc"
@@ -37,6 +38,8 @@ var benchmarkSources = map[bk.Source]func(data bk.BenchData, sigScheme bk.Signat
 	bk.MultiSig:        multisigsc.BenchmarkTests,
 	bk.ZCNSCBridge:     zcnsc.BenchmarkTests,
 	bk.ZCNSCBridgeRest: zcnsc.BenchmarkRestTests,
+	bk.Governance:      govsc.BenchmarkTests,
+	bk.GovernanceRest:  govsc.BenchmarkRestTests,
 	bk.Control:         control.BenchmarkTests,
 }
 
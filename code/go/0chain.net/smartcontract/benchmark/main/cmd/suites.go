@@ -23,6 +23,7 @@ import (
 	"0chain.net/smartcontract/benchmark/main/cmd/log"
 	ebk "0chain.net/smartcontract/dbs/benchmark"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/govsc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
@@ -82,6 +83,7 @@ func runSuites(
 		},
 	}
 	faucetsc.SetupRestHandler(restSetup)
+	govsc.SetupRestHandler(restSetup)
 	minersc.SetupRestHandler(restSetup)
 	storagesc.SetupRestHandler(restSetup)
 	vestingsc.SetupRestHandler(restSetup)
@@ -130,35 +130,37 @@ func mergeAddAllocationEvents() *eventsMergerImpl[Allocation] {
 	return newEventsMerger[Allocation](TagAddAllocation)
 }
 
+// allocationUpdateColumns are the columns of the allocations updates
+var allocationUpdateColumns = []string{
+	"transaction_id",
+	"data_shards",
+	"parity_shards",
+	"size",
+	"expiration",
+	"owner",
+	"owner_public_key",
+	"read_price_min",
+	"read_price_max",
+	"write_price_min",
+	"write_price_max",
+	"start_time",
+	"finalized",
+	"cancelled",
+	"used_size",
+	"moved_to_challenge",
+	"moved_back",
+	"moved_to_validators",
+	"time_unit",
+	"write_pool",
+	"num_writes",
+	"num_reads",
+	"latest_closed_challenge_txn",
+	"third_party_extendable",
+	"file_options",
+}
+
 func (edb *EventDb) updateAllocations(allocs []Allocation) error {
 	ts := time.Now()
-	updateColumns := []string{
-		"transaction_id",
-		"data_shards",
-		"parity_shards",
-		"size",
-		"expiration",
-		"owner",
-		"owner_public_key",
-		"read_price_min",
-		"read_price_max",
-		"write_price_min",
-		"write_price_max",
-		"start_time",
-		"finalized",
-		"cancelled",
-		"used_size",
-		"moved_to_challenge",
-		"moved_back",
-		"moved_to_validators",
-		"time_unit",
-		"write_pool",
-		"num_writes",
-		"num_reads",
-		"latest_closed_challenge_txn",
-		"third_party_extendable",
-		"file_options",
-	}
 
 	columns, err := Columnize(allocs)
 	if err != nil {
@@ -170,7 +172,7 @@ func (edb *EventDb) updateAllocations(allocs []Allocation) error {
 	}
 
 	updater := CreateBuilder("allocations", "allocation_id", ids)
-	for _, fieldKey := range updateColumns {
+	for _, fieldKey := range allocationUpdateColumns {
 		if fieldKey == "allocation_id" {
 			continue
 		}
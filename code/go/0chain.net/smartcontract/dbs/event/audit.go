@@ -0,0 +1,249 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"0chain.net/smartcontract/stakepool/spenum"
+	"gorm.io/gorm/clause"
+)
+
+// AuditMismatch is a difference between a row of the event db and the object
+// of the MPT it is derived from.
+type AuditMismatch struct {
+	Table  string      `json:"table"`
+	ID     string      `json:"id"`
+	Column string      `json:"column,omitempty"` // empty if the row is missing
+	Db     interface{} `json:"db,omitempty"`
+	MPT    interface{} `json:"mpt,omitempty"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+func (m AuditMismatch) String() string {
+	if m.Column == "" {
+		return fmt.Sprintf("%s %s: %s", m.Table, m.ID, m.Reason)
+	}
+	return fmt.Sprintf("%s %s: %s db=%v mpt=%v", m.Table, m.ID, m.Column, m.Db, m.MPT)
+}
+
+// AuditRepairs are the rows of the event db to overwrite with the values of
+// the MPT.
+type AuditRepairs struct {
+	Blobbers      []Blobber
+	Allocations   []Allocation
+	DelegatePools []DelegatePool
+	Users         []User
+}
+
+// Len returns the number of rows to repair.
+func (ar *AuditRepairs) Len() int {
+	return len(ar.Blobbers) + len(ar.Allocations) + len(ar.DelegatePools) + len(ar.Users)
+}
+
+// AuditReport is the result of the comparison of the event db with the MPT
+// at a finalized round.
+type AuditReport struct {
+	Round      int64           `json:"round"`
+	Block      string          `json:"block"`
+	Checked    map[string]int  `json:"checked"`
+	Mismatches []AuditMismatch `json:"mismatches"`
+	Repairs    AuditRepairs    `json:"-"`
+}
+
+// NewAuditReport creates the report of the audit of the block.
+func NewAuditReport(round int64, block string) *AuditReport {
+	return &AuditReport{
+		Round:   round,
+		Block:   block,
+		Checked: make(map[string]int),
+	}
+}
+
+// Missing reports the row of the table missing in the event db or the MPT.
+func (r *AuditReport) Missing(table, id, reason string) {
+	r.Mismatches = append(r.Mismatches, AuditMismatch{
+		Table:  table,
+		ID:     id,
+		Reason: reason,
+	})
+}
+
+// CompareRows compares the columns of the row of the event db with the row
+// built from the MPT object, both of the same type, and reports the
+// different values. It returns true if the rows match.
+func (r *AuditReport) CompareRows(table, id string, dbRow, mptRow interface{}, columns []string) (bool, error) {
+	if reflect.TypeOf(dbRow) != reflect.TypeOf(mptRow) {
+		return false, fmt.Errorf("audit %s %s: rows of different types %T and %T", table, id, dbRow, mptRow)
+	}
+	dbCols, err := Columnize([]interface{}{dbRow})
+	if err != nil {
+		return false, err
+	}
+	mptCols, err := Columnize([]interface{}{mptRow})
+	if err != nil {
+		return false, err
+	}
+
+	r.Checked[table]++
+	match := true
+	for _, col := range columns {
+		dbv, ok := dbCols[col]
+		if !ok {
+			continue
+		}
+		mptv := mptCols[col]
+		if reflect.DeepEqual(dbv[0], mptv[0]) {
+			continue
+		}
+		match = false
+		r.Mismatches = append(r.Mismatches, AuditMismatch{
+			Table:  table,
+			ID:     id,
+			Column: col,
+			Db:     dbv[0],
+			MPT:    mptv[0],
+		})
+	}
+	return match, nil
+}
+
+// CompareBlobber compares the blobber row with the one built from the MPT.
+func (r *AuditReport) CompareBlobber(db, mpt *Blobber) error {
+	match, err := r.CompareRows("blobbers", db.ID, *db, *mpt, blobberUpdateColumns)
+	if err == nil && !match {
+		r.Repairs.Blobbers = append(r.Repairs.Blobbers, *mpt)
+	}
+	return err
+}
+
+// CompareAllocation compares the allocation row with the one built from the MPT.
+func (r *AuditReport) CompareAllocation(db, mpt *Allocation) error {
+	match, err := r.CompareRows("allocations", db.AllocationID, *db, *mpt, allocationUpdateColumns)
+	if err == nil && !match {
+		r.Repairs.Allocations = append(r.Repairs.Allocations, *mpt)
+	}
+	return err
+}
+
+var delegatePoolAuditColumns = []string{"delegate_id", "balance", "reward", "status"}
+
+// CompareDelegatePool compares the delegate pool row with the one built from
+// the MPT, a nil row is missing in the event db.
+func (r *AuditReport) CompareDelegatePool(db, mpt *DelegatePool) error {
+	id := fmt.Sprintf("%s:%s:%s", mpt.ProviderType, mpt.ProviderID, mpt.PoolID)
+	if db == nil {
+		r.Checked["delegate_pools"]++
+		r.Missing("delegate_pools", id, "missing in event db")
+		r.Repairs.DelegatePools = append(r.Repairs.DelegatePools, *mpt)
+		return nil
+	}
+
+	match, err := r.CompareRows("delegate_pools", id, *db, *mpt, delegatePoolAuditColumns)
+	if err == nil && !match {
+		r.Repairs.DelegatePools = append(r.Repairs.DelegatePools, *mpt)
+	}
+	return err
+}
+
+var userAuditColumns = []string{"balance", "nonce"}
+
+// CompareUser compares the user row with the one built from the client state.
+func (r *AuditReport) CompareUser(db, mpt *User) error {
+	match, err := r.CompareRows("users", db.UserID, *db, *mpt, userAuditColumns)
+	if err == nil && !match {
+		u := *db
+		u.Balance = mpt.Balance
+		u.Nonce = mpt.Nonce
+		u.Round = r.Round
+		r.Repairs.Users = append(r.Repairs.Users, u)
+	}
+	return err
+}
+
+// GetAuditBlobbers returns the blobbers not killed with an id after the one
+// given, ordered by id.
+func (edb *EventDb) GetAuditBlobbers(afterID string, limit int) ([]Blobber, error) {
+	var blobbers []Blobber
+	return blobbers, edb.Store.Get().Model(&Blobber{}).
+		Where("id > ? AND is_killed = ?", afterID, false).
+		Order("id").
+		Limit(limit).
+		Find(&blobbers).Error
+}
+
+// GetAuditAllocations returns the allocations neither finalized nor
+// cancelled, which are removed from the MPT, with an allocation id after the
+// one given, ordered by allocation id.
+func (edb *EventDb) GetAuditAllocations(afterID string, limit int) ([]Allocation, error) {
+	var allocs []Allocation
+	return allocs, edb.Store.Get().Model(&Allocation{}).
+		Where("allocation_id > ? AND finalized = ? AND cancelled = ?", afterID, false, false).
+		Order("allocation_id").
+		Limit(limit).
+		Find(&allocs).Error
+}
+
+// GetAuditUsers returns the users with an id after the one given, ordered
+// by id.
+func (edb *EventDb) GetAuditUsers(afterID string, limit int) ([]User, error) {
+	var users []User
+	return users, edb.Store.Get().Model(&User{}).
+		Where("user_id > ?", afterID).
+		Order("user_id").
+		Limit(limit).
+		Find(&users).Error
+}
+
+// GetProviderDelegatePools returns the delegate pools of the provider not
+// deleted.
+func (edb *EventDb) GetProviderDelegatePools(pType spenum.Provider, pID string) ([]DelegatePool, error) {
+	var dps []DelegatePool
+	return dps, edb.Store.Get().Model(&DelegatePool{}).
+		Where("provider_type = ? AND provider_id = ? AND status != ?", pType, pID, spenum.Deleted).
+		Find(&dps).Error
+}
+
+// RepairAudit overwrites the rows of the event db with the values of the
+// MPT found by an audit, in a single transaction.
+func (edb *EventDb) RepairAudit(ctx context.Context, repairs *AuditRepairs) error {
+	tx, err := edb.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.repairAudit(repairs); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%v, rollback: %v", err, rerr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func (edb *EventDb) repairAudit(repairs *AuditRepairs) error {
+	if len(repairs.Blobbers) > 0 {
+		if err := edb.updateBlobber(repairs.Blobbers); err != nil {
+			return fmt.Errorf("repair blobbers: %v", err)
+		}
+	}
+	if len(repairs.Allocations) > 0 {
+		if err := edb.updateAllocations(repairs.Allocations); err != nil {
+			return fmt.Errorf("repair allocations: %v", err)
+		}
+	}
+	if len(repairs.DelegatePools) > 0 {
+		if err := edb.Store.Get().Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "provider_id"}, {Name: "provider_type"}, {Name: "pool_id"}},
+			DoUpdates: clause.AssignmentColumns(delegatePoolAuditColumns),
+		}).Create(&repairs.DelegatePools).Error; err != nil {
+			return fmt.Errorf("repair delegate pools: %v", err)
+		}
+	}
+	if len(repairs.Users) > 0 {
+		if err := edb.addOrUpdateUsers(repairs.Users); err != nil {
+			return fmt.Errorf("repair users: %v", err)
+		}
+	}
+	return nil
+}
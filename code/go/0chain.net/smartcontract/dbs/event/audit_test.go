@@ -0,0 +1,62 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditReportCompare(t *testing.T) {
+	report := NewAuditReport(10, "hash")
+
+	db := &Blobber{
+		Provider:  Provider{ID: "b1", TotalStake: 100, DelegateWallet: "w"},
+		Capacity:  1000,
+		Allocated: 10,
+	}
+	mpt := &Blobber{
+		Provider:  Provider{ID: "b1", TotalStake: 200, DelegateWallet: "w"},
+		Capacity:  1000,
+		Allocated: 10,
+	}
+	require.NoError(t, report.CompareBlobber(db, db))
+	require.Empty(t, report.Mismatches)
+	require.Empty(t, report.Repairs.Blobbers)
+
+	require.NoError(t, report.CompareBlobber(db, mpt))
+	require.Len(t, report.Mismatches, 1)
+	require.Equal(t, AuditMismatch{
+		Table:  "blobbers",
+		ID:     "b1",
+		Column: "total_stake",
+		Db:     db.TotalStake,
+		MPT:    mpt.TotalStake,
+	}, report.Mismatches[0])
+	require.Equal(t, []Blobber{*mpt}, report.Repairs.Blobbers)
+
+	dp := &DelegatePool{
+		PoolID:       "p1",
+		ProviderType: spenum.Blobber,
+		ProviderID:   "b1",
+		DelegateID:   "d1",
+		Balance:      10,
+		Status:       spenum.Active,
+	}
+	require.NoError(t, report.CompareDelegatePool(nil, dp))
+	require.Len(t, report.Mismatches, 2)
+	require.Equal(t, "missing in event db", report.Mismatches[1].Reason)
+
+	user := &User{UserID: "u1", Balance: 5, Nonce: 2, TxnHash: "txn"}
+	require.NoError(t, report.CompareUser(user, &User{UserID: "u1", Balance: 5, Nonce: 3}))
+	require.Len(t, report.Mismatches, 3)
+	require.Equal(t, "nonce", report.Mismatches[2].Column)
+	require.Equal(t, int64(3), report.Repairs.Users[0].Nonce)
+	require.Equal(t, "txn", report.Repairs.Users[0].TxnHash)
+
+	require.Equal(t, map[string]int{"blobbers": 2, "delegate_pools": 1, "users": 1}, report.Checked)
+	require.Equal(t, 3, report.Repairs.Len())
+
+	_, err := report.CompareRows("users", "u1", *user, *dp, userAuditColumns)
+	require.Error(t, err)
+}
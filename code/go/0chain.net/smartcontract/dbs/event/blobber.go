@@ -295,27 +295,29 @@ func (edb *EventDb) addBlobbers(blobbers []Blobber) error {
 	return edb.Store.Get().Create(&blobbers).Error
 }
 
+// blobberUpdateColumns are the columns of the blobbers updates, the fields
+// match storagesc.emitUpdateBlobber
+var blobberUpdateColumns = []string{
+	"base_url",
+	"read_price",
+	"write_price",
+	"max_offer_duration",
+	"capacity",
+	"allocated",
+	"saved_data",
+	"not_available",
+	"is_restricted",
+	"offers_total",
+	"delegate_wallet",
+	"num_delegates",
+	"service_charge",
+	"last_health_check",
+	"total_stake",
+}
+
 func (edb *EventDb) updateBlobber(blobbers []Blobber) error {
 	ts := time.Now()
 
-	// fields match storagesc.emitUpdateBlobber
-	updateColumns := []string{
-		"base_url",
-		"read_price",
-		"write_price",
-		"max_offer_duration",
-		"capacity",
-		"allocated",
-		"saved_data",
-		"not_available",
-		"is_restricted",
-		"offers_total",
-		"delegate_wallet",
-		"num_delegates",
-		"service_charge",
-		"last_health_check",
-		"total_stake",
-	}
 	columns, err := Columnize(blobbers)
 	if err != nil {
 		return err
@@ -326,7 +328,7 @@ func (edb *EventDb) updateBlobber(blobbers []Blobber) error {
 	}
 
 	updater := CreateBuilder("blobbers", "id", ids)
-	for _, fieldKey := range updateColumns {
+	for _, fieldKey := range blobberUpdateColumns {
 		if fieldKey == "id" {
 			continue
 		}
@@ -14,16 +14,19 @@ import (
 
 type BurnTicket struct {
 	model.UpdatableModel
+	ChainID         string        `json:"chain_id" gorm:"not null;default:''"` // target chain, empty for the default one
 	EthereumAddress string        `json:"ethereum_address" gorm:"not null"`
 	Hash            string        `json:"hash" gorm:"unique"`
 	Amount          currency.Coin `json:"amount" gorm:"not null"`
 	Nonce           int64         `json:"nonce" gorm:"not null"`
 }
 
-func (edb *EventDb) GetBurnTickets(ethereumAddress string) ([]BurnTicket, error) {
+// GetBurnTickets returns the burn tickets of the address on the target chain
+func (edb *EventDb) GetBurnTickets(chainID, ethereumAddress string) ([]BurnTicket, error) {
 	var burnTickets []BurnTicket
 	err := edb.Store.Get().Model(&BurnTicket{}).
-		Where(&BurnTicket{EthereumAddress: ethereumAddress}).Find(&burnTickets).Error
+		Where("chain_id = ? AND ethereum_address = ?", chainID, ethereumAddress).
+		Find(&burnTickets).Error
 
 	if err != nil && err == gorm.ErrRecordNotFound {
 		return nil, util.ErrValueNotPresent
@@ -39,6 +42,8 @@ func (edb *EventDb) addBurnTicket(burnTicket BurnTicket) error {
 	}()
 
 	result := edb.Store.Get().Model(&BurnTicket{}).
+		Where("chain_id = ?",
+			burnTicket.ChainID).
 		Where("ethereum_address = ?",
 			burnTicket.EthereumAddress).
 		Where("nonce = ?",
@@ -50,7 +55,7 @@ func (edb *EventDb) addBurnTicket(burnTicket BurnTicket) error {
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("burn ticket with the given ethereum address and nonce already exists")
+		return errors.New("burn ticket with the given chain, ethereum address and nonce already exists")
 	}
 	return nil
 }
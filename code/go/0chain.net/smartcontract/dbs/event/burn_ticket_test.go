@@ -53,7 +53,7 @@ func TestBurnTicketEvent(t *testing.T) {
 	eventDb.Get().Table("burn_tickets").Count(&count)
 	require.Equal(t, int64(1), count, "BurnTicket not getting inserted")
 
-	burnTickets, err := eventDb.GetBurnTickets(ethereumAddress)
+	burnTickets, err := eventDb.GetBurnTickets("", ethereumAddress)
 	require.NoError(t, err, "Error while fetching burn tickets by ethereumAddress")
 	require.Len(t, burnTickets, 1)
 
@@ -86,7 +86,7 @@ func TestBurnTicketEvent(t *testing.T) {
 	eventDb.Get().Table("burn_tickets").Count(&count)
 	require.Equal(t, int64(2), count, "BurnTicket not getting inserted")
 
-	burnTickets, err = eventDb.GetBurnTickets(ethereumAddress)
+	burnTickets, err = eventDb.GetBurnTickets("", ethereumAddress)
 	require.NoError(t, err, "Error while fetching burn tickets by ethereumAddress")
 	require.Len(t, burnTickets, 2)
 
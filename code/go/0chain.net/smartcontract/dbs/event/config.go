@@ -61,6 +61,7 @@ type TestConfigData struct {
 	BlockProposalMaxWaitTime time.Duration `json:"block_proposal_max_wait_time"` // max time to wait to receive a block proposal
 	BlockProposalWaitMode    int8          `json:"block_proposal_wait_mode"`     // wait time for the block proposal is static (0) or dynamic (1)
 	BlockFinalizationTimeout time.Duration `json:"block_finalization_timeout"`   // time after which the block finalization will timeout
+	ParallelExecutionWorkers int           `json:"parallel_execution_workers"`   // transactions executed ahead concurrently, 0 disables it
 
 	ReuseTransactions bool `json:"reuse_txns"` // indicates if transactions from unrelated blocks can be reused
 
@@ -279,6 +280,10 @@ func (t *TestConfig) BlockFinalizationTimeout() time.Duration {
 	return t.conf.BlockFinalizationTimeout
 }
 
+func (t *TestConfig) ParallelExecutionWorkers() int {
+	return t.conf.ParallelExecutionWorkers
+}
+
 func (t *TestConfig) TxnTransferCost() int {
 	return t.conf.TxnTransferCost
 }
@@ -113,6 +113,7 @@ const (
 	TagShutdownProvider
 	TagInsertReadpool
 	TagUpdateReadpool
+	TagMinerEquivocation
 	NumberOfTags
 )
 
@@ -203,6 +204,7 @@ func initTagString() {
 	TagString[TagShutdownProvider] = "TagShutdownProvider"
 	TagString[TagInsertReadpool] = "TagInsertReadpool"
 	TagString[TagUpdateReadpool] = "TagUpdateReadpool"
+	TagString[TagMinerEquivocation] = "TagMinerEquivocation"
 	TagString[NumberOfTags] = "invalid"
 }
 
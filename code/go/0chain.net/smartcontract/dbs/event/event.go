@@ -34,6 +34,9 @@ type Event struct {
 	Version                  EventVersion `json:"version" gorm:"-"`
 }
 
+// defaultSinkPublishTimeout is how long the events of a block are waited for to be acknowledged by the event sink.
+const defaultSinkPublishTimeout = 50 * time.Second
+
 // FinalizationToKafkaLatencyMetric - a metric which tracks how much time it takes from a block which got finalized to respective event being pushed into kafka
 var FinalizationToKafkaLatencyMetric = metrics.NewHistogram(metrics.NewUniformSample(20000))
 
@@ -106,91 +109,95 @@ func (edb *EventDb) addEvents(ctx context.Context, events BlockEvents) error {
 		return nil
 	}
 
-	if events.round >= edb.Config().KafkaTriggerRound {
-		edb.mustPushEventsToKafka(&events, false)
+	if events.round >= edb.Config().SinkTriggerRound() {
+		edb.mustPushEventsToSink(&events, false)
 	}
 
 	if err := edb.Store.Get().WithContext(ctx).Create(&events.events).Error; err != nil {
 		return err
 	}
+	edb.streamEvents = append(edb.streamEvents, events.events...)
 
 	return nil
 }
 
-func (edb *EventDb) mustPushEventsToKafka(events *BlockEvents, updateColumn bool) {
+func (edb *EventDb) mustPushEventsToSink(events *BlockEvents, updateColumn bool) {
 	if edb.Store == nil {
 		logging.Logger.Panic("event database is nil")
 	}
 
-	if edb.dbConfig.KafkaEnabled {
-		var (
-			//filteredEvents = filterEvents(events.events)
-			broker    = edb.GetKafkaProv()
-			topic     = edb.dbConfig.KafkaTopic
-			eventsMap = make(map[int64]*Event)
-		)
+	if edb.sink == nil {
+		return
+	}
+
+	var (
+		//filteredEvents = filterEvents(events.events)
+		sink      = edb.sink
+		topic     = edb.dbConfig.SinkTopic()
+		eventsMap = make(map[int64]*Event)
+	)
 
-		for i, e := range events.events {
-			eventsMap[e.SequenceNumber] = &events.events[i]
+	for i, e := range events.events {
+		eventsMap[e.SequenceNumber] = &events.events[i]
+	}
+	var results []chan error
+	self := node.Self.Underlying()
+	for _, filteredEvent := range events.events {
+		data := map[string]interface{}{
+			"event":  filteredEvent,
+			"round":  events.round,
+			"source": self.ID,
+		}
+		eventJson, err := json.Marshal(data)
+		if err != nil {
+			logging.Logger.Panic(fmt.Sprintf("Failed to get marshal event: %v", err))
 		}
-		var results []chan int64
-		self := node.Self.Underlying()
-		for _, filteredEvent := range events.events {
-			data := map[string]interface{}{
-				"event":  filteredEvent,
-				"round":  events.round,
-				"source": self.ID,
-			}
-			eventJson, err := json.Marshal(data)
-			if err != nil {
-				logging.Logger.Panic(fmt.Sprintf("Failed to get marshal event: %v", err))
-			}
 
-			ts := time.Now()
-			key := filteredEvent.EventKey
-			res := broker.PublishToKafka(topic, []byte(key), eventJson)
-			results = append(results, res)
-			if filteredEvent.Tag == TagFinalizeBlock {
-				blockData := filteredEvent.Data.(*Block)
-				finalizationTime := blockData.FinalizationTime
-				FinalizationToKafkaLatencyMetric.Update(time.Since(finalizationTime).Milliseconds()) // update block finalization to kafka push latency metric
-			}
+		ts := time.Now()
+		key := filteredEvent.EventKey
+		res := sink.Publish(topic, []byte(key), eventJson)
+		results = append(results, res)
+		if filteredEvent.Tag == TagFinalizeBlock {
+			blockData := filteredEvent.Data.(*Block)
+			finalizationTime := blockData.FinalizationTime
+			FinalizationToKafkaLatencyMetric.Update(time.Since(finalizationTime).Milliseconds()) // update block finalization to event sink push latency metric
+		}
 
-			eventsMap[filteredEvent.SequenceNumber].IsPublished = true
+		eventsMap[filteredEvent.SequenceNumber].IsPublished = true
 
-			logging.Logger.Debug("Pushed event to kafka",
-				zap.String("event", filteredEvent.Tag.String()),
-				zap.Int64("seq", filteredEvent.SequenceNumber),
-				zap.Int64("round", events.round))
+		logging.Logger.Debug("Pushed event to event sink",
+			zap.String("event", filteredEvent.Tag.String()),
+			zap.Int64("seq", filteredEvent.SequenceNumber),
+			zap.Int64("round", events.round))
 
-			tm := time.Since(ts)
-			KafkaEventPushLatencyMetric.Update(tm.Milliseconds()) // update kafka latency metric
-			if tm > 100*time.Millisecond {
-				logging.Logger.Debug("Push to kafka slow", zap.Int64("round", events.round), zap.Duration("duration", tm))
-			}
+		tm := time.Since(ts)
+		KafkaEventPushLatencyMetric.Update(tm.Milliseconds()) // update event sink latency metric
+		if tm > 100*time.Millisecond {
+			logging.Logger.Debug("Push to event sink slow", zap.Int64("round", events.round), zap.Duration("duration", tm))
 		}
+	}
 
-		//wait for all responses
-		timeout, cancelFunc := context.WithTimeout(context.Background(), 50*time.Second)
-		defer cancelFunc()
-		sent := 0
-	L:
-		for _, ch := range results {
-			select {
-			case <-ch:
-				sent++
-				if sent == len(events.events) {
-					break L
-				}
-			case <-timeout.Done():
-				logging.Logger.Panic("Timeout to publish event to kafka")
+	//wait for all responses
+	publishTimeout := edb.dbConfig.EventSink.PublishTimeout
+	if publishTimeout <= 0 {
+		publishTimeout = defaultSinkPublishTimeout
+	}
+	timeout, cancelFunc := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancelFunc()
+	for _, ch := range results {
+		select {
+		case err := <-ch:
+			if err != nil {
+				logging.Logger.Panic("Failed to publish event to event sink", zap.Error(err))
 			}
+		case <-timeout.Done():
+			logging.Logger.Panic("Timeout to publish event to event sink")
 		}
-		if updateColumn {
-			// updates the events as published
-			if err := edb.setEventPublished(events.round); err != nil {
-				logging.Logger.Panic(fmt.Sprintf("Failed to update event as published: %v", err))
-			}
+	}
+	if updateColumn {
+		// updates the events as published
+		if err := edb.setEventPublished(events.round); err != nil {
+			logging.Logger.Panic(fmt.Sprintf("Failed to update event as published: %v", err))
 		}
 	}
 }
@@ -301,6 +308,11 @@ func (edb *EventDb) Drop() error {
 		return err
 	}
 
+	err = edb.Store.Get().Migrator().DropTable(&UserMintNonce{})
+	if err != nil {
+		return err
+	}
+
 	err = edb.Store.Get().Migrator().DropTable(&RewardMint{})
 	if err != nil {
 		return err
@@ -336,6 +348,11 @@ func (edb *EventDb) Drop() error {
 		return err
 	}
 
+	err = edb.Store.Get().Migrator().DropTable(&MinerEquivocation{})
+	if err != nil {
+		return err
+	}
+
 	err = edb.Store.Get().Migrator().DropTable(&TransactionErrors{})
 	if err != nil {
 		return err
@@ -12,7 +12,9 @@ import (
 	"0chain.net/smartcontract/dbs/postgresql"
 	"0chain.net/smartcontract/dbs/queueProvider"
 	"0chain.net/smartcontract/dbs/sqlite"
+	"github.com/0chain/common/core/logging"
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
 )
 
 func NewEventDbWithWorker(config config.DbAccess, settings config.DbSettings,
@@ -26,6 +28,8 @@ func NewEventDbWithWorker(config config.DbAccess, settings config.DbSettings,
 		return nil, err
 	}
 	goose.Migrate(sqldb)
+	eventDb.stream.getBlockEvents = getBlockEventsFunc
+	eventDb.stream.getRound = eventDb.getRoundAfterSequence
 	go eventDb.addEventsWorker(common.GetRootContext(), getBlockEventsFunc)
 
 	return eventDb, nil
@@ -45,12 +49,13 @@ func NewEventDbWithoutWorker(config config.DbAccess, settings config.DbSettings)
 		partitionChan:          make(chan int64, 100),
 		permanentPartitionChan: make(chan int64, 100),
 		settings:               settings,
+		stream:                 NewEventStream(DefaultStreamRecentSize, DefaultStreamBufferSize),
 	}
 
-	if config.KafkaEnabled {
-		eventDb.kafka = queueProvider.NewKafkaProvider(config.KafkaHost,
-			config.KafkaUsername, config.KafkaPassword, config.KafkaWriteTimeout)
+	if eventDb.sink, err = newEventSink(config); err != nil {
+		return nil, err
 	}
+	eventDb.ownsSink = eventDb.sink != nil
 
 	// Load last sequence number. Useful when the sharder is restarted.
 	var maxSequenceNumber uint64
@@ -74,6 +79,7 @@ func NewInMemoryEventDb(config config.DbAccess, settings config.DbSettings) (*Ev
 		partitionChan:          make(chan int64, 100),
 		permanentPartitionChan: make(chan int64, 100),
 		settings:               settings,
+		stream:                 NewEventStream(DefaultStreamRecentSize, DefaultStreamBufferSize),
 	}
 
 	go eventDb.addEventsWorker(common.GetRootContext(), func(round int64) (int64, []Event, error) {
@@ -85,15 +91,52 @@ func NewInMemoryEventDb(config config.DbAccess, settings config.DbSettings) (*Ev
 	return eventDb, nil
 }
 
+// newEventSink creates the sink the events are published to, nil when none is configured.
+func newEventSink(access config.DbAccess) (queueProvider.EventSink, error) {
+	sc := access.EventSink
+	switch t := access.SinkType(); t {
+	case "":
+		return nil, nil
+	case config.EventSinkKafka:
+		return queueProvider.NewKafkaProvider(access.KafkaHost,
+			access.KafkaUsername, access.KafkaPassword, access.KafkaWriteTimeout), nil
+	case config.EventSinkNats:
+		return queueProvider.NewNatsSink(sc.NatsURL, sc.NatsUsername, sc.NatsPassword, sc.PublishTimeout)
+	case config.EventSinkFile:
+		return queueProvider.NewFileSink(sc.FilePath)
+	case config.EventSinkWebhook:
+		return queueProvider.NewWebhookSink(sc.WebhookURL, sc.WebhookSecret,
+			sc.WebhookTimeout, sc.WebhookMaxRetries, sc.WebhookRetryDelay), nil
+	default:
+		return nil, fmt.Errorf("unknown event sink type: %v", t)
+	}
+}
+
 type EventDb struct {
 	dbs.Store
 	dbConfig               config.DbAccess   // depends on the sharder, change on restart
 	settings               config.DbSettings // the same across all sharders, needs to mirror blockchain
 	eventsChannel          chan BlockEvents
 	eventsCounter          atomic.Uint64
-	kafka                  queueProvider.KafkaProviderI
+	sink                   queueProvider.EventSink
+	ownsSink               bool // the transactions and the clones share the sink, only its creator closes it
 	partitionChan          chan int64
 	permanentPartitionChan chan int64
+	stream                 *EventStream
+	// streamEvents are the events added in the transaction, sent to the stream on commit.
+	streamEvents []Event
+}
+
+// Close closes the database and the event sink created with it.
+func (edb *EventDb) Close() {
+	edb.Store.Close()
+	if !edb.ownsSink {
+		return
+	}
+	if err := edb.sink.Close(); err != nil {
+		logging.Logger.Error("closing event sink", zap.Error(err))
+	}
+	edb.ownsSink = false
 }
 
 func (edb *EventDb) Begin(ctx context.Context) (*EventDb, error) {
@@ -102,18 +145,6 @@ func (edb *EventDb) Begin(ctx context.Context) (*EventDb, error) {
 		return nil, fmt.Errorf("begin transcation: %v", tx.Error)
 	}
 
-	var kafka queueProvider.KafkaProviderI
-	if edb.kafka != nil {
-		kafka = edb.kafka
-	} else {
-		kafka = queueProvider.NewKafkaProvider(
-			edb.dbConfig.KafkaHost,
-			edb.dbConfig.KafkaUsername,
-			edb.dbConfig.KafkaPassword,
-			edb.dbConfig.KafkaWriteTimeout,
-		)
-	}
-
 	edbTx := EventDb{
 		Store: edbTx{
 			Store: edb,
@@ -121,10 +152,11 @@ func (edb *EventDb) Begin(ctx context.Context) (*EventDb, error) {
 		},
 		dbConfig:               edb.dbConfig,
 		settings:               edb.settings,
-		kafka:                  kafka,
+		sink:                   edb.sink,
 		eventsChannel:          edb.eventsChannel,
 		partitionChan:          edb.partitionChan,
 		permanentPartitionChan: edb.permanentPartitionChan,
+		stream:                 edb.stream,
 	}
 	return &edbTx, nil
 }
@@ -133,7 +165,14 @@ func (edb *EventDb) Commit() error {
 	if edb.Store.Get() == nil {
 		return errors.New("committing nil transaction")
 	}
-	return edb.Store.Get().Commit().Error
+	if err := edb.Store.Get().Commit().Error; err != nil {
+		return err
+	}
+	if edb.stream != nil {
+		edb.stream.Publish(edb.streamEvents)
+		edb.streamEvents = nil
+	}
+	return nil
 }
 
 func (edb *EventDb) Rollback() error {
@@ -162,6 +201,8 @@ func (edb *EventDb) Clone(dbName string, pdb *postgresql.PostgresDB) (*EventDb,
 		KafkaTopic:          edb.dbConfig.KafkaTopic,
 		KafkaTopicPartition: edb.dbConfig.KafkaTopicPartition,
 		KafkaWriteTimeout:   edb.dbConfig.KafkaWriteTimeout,
+		KafkaTriggerRound:   edb.dbConfig.KafkaTriggerRound,
+		EventSink:           edb.dbConfig.EventSink,
 	}
 	clone, err := pdb.Clone(cloneConfig, dbName, edb.dbConfig.Name)
 	if err != nil {
@@ -169,24 +210,12 @@ func (edb *EventDb) Clone(dbName string, pdb *postgresql.PostgresDB) (*EventDb,
 		return nil, err
 	}
 
-	var kafka queueProvider.KafkaProviderI
-	if edb.kafka != nil {
-		kafka = edb.kafka
-	} else {
-		kafka = queueProvider.NewKafkaProvider(
-			edb.dbConfig.KafkaHost,
-			edb.dbConfig.KafkaUsername,
-			edb.dbConfig.KafkaPassword,
-			edb.dbConfig.KafkaWriteTimeout,
-		)
-	}
-
 	newEdb := &EventDb{
 		Store:         clone,
 		dbConfig:      cloneConfig,
 		eventsChannel: nil,
 		settings:      edb.settings,
-		kafka:         kafka,
+		sink:          edb.sink,
 	}
 
 	return newEdb, nil
@@ -204,6 +233,12 @@ func (edb *EventDb) AggregatePeriod() int64 {
 	return edb.settings.AggregatePeriod
 }
 
+// HistoryPeriod is the number of rounds between two provider and network
+// history records.
+func (edb *EventDb) HistoryPeriod() int64 {
+	return edb.settings.HistoryPeriod
+}
+
 func (edb *EventDb) PageLimit() int64 {
 	return edb.settings.PageLimit
 }
@@ -237,6 +272,7 @@ func (edb *EventDb) AutoMigrate() error {
 		&Event{},
 		&Blobber{},
 		&User{},
+		&UserMintNonce{},
 		&BurnTicket{},
 		&Transaction{},
 		&WriteMarker{},
@@ -257,6 +293,8 @@ func (edb *EventDb) AutoMigrate() error {
 		&RewardDelegate{},
 		&RewardProvider{},
 		&ReadPool{},
+		&MinerEquivocation{},
+		&EventsReindex{},
 	); err != nil {
 		return err
 	}
@@ -0,0 +1,301 @@
+package event
+
+import (
+	"fmt"
+
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// MaxHistoryBuckets is the max number of time buckets of a history query
+const MaxHistoryBuckets = 1000
+
+// historyTables are partitioned like the transactions and blocks, they're
+// kept and moved to the slow tablespace
+var historyTables = []string{"provider_histories", "network_histories"}
+
+// ProviderHistory is the state of a provider at the end of a history
+// period, the rows are kept in permanent partitions of the rounds.
+type ProviderHistory struct {
+	Round               int64           `json:"round"`
+	CreationDate        int64           `json:"creation_date"`
+	ProviderID          string          `json:"provider_id"`
+	ProviderType        spenum.Provider `json:"provider_type"`
+	TotalStake          currency.Coin   `json:"total_stake"`
+	TotalRewards        currency.Coin   `json:"total_rewards"`
+	Capacity            int64           `json:"capacity"`
+	Allocated           int64           `json:"allocated"`
+	SavedData           int64           `json:"saved_data"`
+	ChallengesPassed    uint64          `json:"challenges_passed"`
+	ChallengesCompleted uint64          `json:"challenges_completed"`
+	Fees                currency.Coin   `json:"fees"`
+	TotalMint           currency.Coin   `json:"total_mint"`
+	TotalBurn           currency.Coin   `json:"total_burn"`
+}
+
+func (ph *ProviderHistory) TableName() string {
+	return "provider_histories"
+}
+
+// NetworkHistory is the state of the network at the end of a history
+// period, the totals of the providers and the transaction fees and the
+// rewards minted during the period.
+type NetworkHistory struct {
+	Round               int64         `json:"round"`
+	CreationDate        int64         `json:"creation_date"`
+	TotalStake          currency.Coin `json:"total_stake"`
+	TotalRewards        currency.Coin `json:"total_rewards"`
+	Capacity            int64         `json:"capacity"`
+	Allocated           int64         `json:"allocated"`
+	SavedData           int64         `json:"saved_data"`
+	ChallengesPassed    uint64        `json:"challenges_passed"`
+	ChallengesCompleted uint64        `json:"challenges_completed"`
+	TotalMint           currency.Coin `json:"total_mint"`
+	TotalBurn           currency.Coin `json:"total_burn"`
+	TxnFees             currency.Coin `json:"txn_fees"`
+	Minted              currency.Coin `json:"minted"`
+}
+
+func (nh *NetworkHistory) TableName() string {
+	return "network_histories"
+}
+
+// HistoryDelta is the change of the counters of a history over a time bucket.
+type HistoryDelta struct {
+	Rewards          int64 `json:"rewards"`
+	ChallengesPassed int64 `json:"challenges_passed"`
+	ChallengesFailed int64 `json:"challenges_failed"`
+	Fees             int64 `json:"fees"`
+	Mint             int64 `json:"mint"`
+	Burn             int64 `json:"burn"`
+}
+
+// ProviderHistoryBucket is the last state of the provider in the time bucket
+// starting at Bucket and its change since the previous bucket.
+type ProviderHistoryBucket struct {
+	Bucket int64 `json:"bucket"`
+	ProviderHistory
+	Delta HistoryDelta `json:"delta" gorm:"-"`
+}
+
+// NetworkHistoryBucket is the last state of the network in the time bucket
+// starting at Bucket and its change since the previous bucket, the
+// transaction fees and the rewards minted are the totals of the bucket.
+type NetworkHistoryBucket struct {
+	Bucket int64 `json:"bucket"`
+	NetworkHistory
+	Delta HistoryDelta `json:"delta" gorm:"-"`
+}
+
+// HistoryQuery selects the time buckets of a history, the buckets start from
+// a multiple of the bucket duration.
+type HistoryQuery struct {
+	From   int64 // unix time, included
+	To     int64 // unix time, excluded
+	Bucket int64 // seconds
+}
+
+// Validate the time range and the number of buckets of the query.
+func (q HistoryQuery) Validate() error {
+	if q.Bucket <= 0 {
+		return fmt.Errorf("invalid bucket %d", q.Bucket)
+	}
+	if q.From >= q.To {
+		return fmt.Errorf("invalid time range [%d, %d)", q.From, q.To)
+	}
+	if n := (q.To - q.From) / q.Bucket; n > MaxHistoryBuckets {
+		return fmt.Errorf("too many buckets %d, max %d", n, MaxHistoryBuckets)
+	}
+	return nil
+}
+
+// historyProviderColumns are the expressions of the columns of the provider
+// histories of each provider table, p being the provider row
+var historyProviderColumns = map[spenum.Provider]struct {
+	table, capacity, allocated, savedData, passed, completed, fees, mint, burn string
+}{
+	spenum.Blobber:    {"blobbers", "p.capacity", "p.allocated", "p.saved_data", "p.challenges_passed", "p.challenges_completed", "0", "0", "0"},
+	spenum.Validator:  {"validators", "0", "0", "0", "0", "0", "0", "0", "0"},
+	spenum.Miner:      {"miners", "0", "0", "0", "0", "0", "p.fees", "0", "0"},
+	spenum.Sharder:    {"sharders", "0", "0", "0", "0", "0", "p.fees", "0", "0"},
+	spenum.Authorizer: {"authorizers", "0", "0", "0", "0", "0", "0", "p.total_mint", "p.total_burn"},
+}
+
+// addHistory saves the state of the providers not killed and of the network
+// at the end of the history period of the round.
+func (edb *EventDb) addHistory(round, period int64) error {
+	var creationDate int64
+	if err := edb.Store.Get().Model(&Block{}).
+		Select("COALESCE(MAX(creation_date), 0)").
+		Where("round = ?", round).
+		Scan(&creationDate).Error; err != nil {
+		return fmt.Errorf("history block time: %v", err)
+	}
+
+	for _, pType := range []spenum.Provider{
+		spenum.Miner, spenum.Sharder, spenum.Blobber, spenum.Validator, spenum.Authorizer,
+	} {
+		c := historyProviderColumns[pType]
+		raw := fmt.Sprintf(`INSERT INTO provider_histories (round, creation_date, provider_id, provider_type,
+	total_stake, total_rewards, capacity, allocated, saved_data, challenges_passed, challenges_completed,
+	fees, total_mint, total_burn)
+SELECT ?, ?, p.id, ?, p.total_stake, COALESCE(r.total_rewards, 0), %s, %s, %s, %s, %s, %s, %s, %s
+FROM %s p LEFT JOIN provider_rewards r ON r.provider_id = p.id
+WHERE p.is_killed IS NOT TRUE`,
+			c.capacity, c.allocated, c.savedData, c.passed, c.completed, c.fees, c.mint, c.burn, c.table)
+		if err := edb.Store.Get().Exec(raw, round, creationDate, pType).Error; err != nil {
+			return fmt.Errorf("history of %s: %v", c.table, err)
+		}
+	}
+
+	return edb.Store.Get().Exec(`INSERT INTO network_histories (round, creation_date,
+	total_stake, total_rewards, capacity, allocated, saved_data, challenges_passed, challenges_completed,
+	total_mint, total_burn, txn_fees, minted)
+SELECT ?, ?, COALESCE(SUM(total_stake), 0), COALESCE(SUM(total_rewards), 0), COALESCE(SUM(capacity), 0),
+	COALESCE(SUM(allocated), 0), COALESCE(SUM(saved_data), 0), COALESCE(SUM(challenges_passed), 0),
+	COALESCE(SUM(challenges_completed), 0), COALESCE(SUM(total_mint), 0), COALESCE(SUM(total_burn), 0),
+	(SELECT COALESCE(SUM(fee), 0) FROM transactions WHERE round > ? AND round <= ?),
+	(SELECT COALESCE(SUM(amount), 0) FROM reward_mints WHERE block_number > ? AND block_number <= ?)
+FROM provider_histories WHERE round = ?`,
+		round, creationDate, round-period, round, round-period, round, round).Error
+}
+
+// GetProviderHistory returns the history of the provider in time buckets.
+func (edb *EventDb) GetProviderHistory(pType spenum.Provider, pID string, q HistoryQuery) ([]ProviderHistoryBucket, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buckets []ProviderHistoryBucket
+	if err := edb.Store.Get().Raw(`SELECT DISTINCT ON (bucket) creation_date - creation_date % ? AS bucket, *
+FROM provider_histories
+WHERE provider_id = ? AND provider_type = ? AND creation_date >= ? AND creation_date < ?
+ORDER BY bucket, round DESC`, q.Bucket, pID, pType, q.From, q.To).
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+
+	var prev ProviderHistory
+	res := edb.Store.Get().Model(&ProviderHistory{}).
+		Where("provider_id = ? AND provider_type = ? AND creation_date < ?", pID, pType, q.From).
+		Order("creation_date DESC").
+		Limit(1).
+		Scan(&prev)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		setProviderHistoryDeltas(buckets, &prev)
+	} else {
+		setProviderHistoryDeltas(buckets, nil)
+	}
+	return buckets, nil
+}
+
+// setProviderHistoryDeltas sets the changes of the buckets since the previous
+// state, the first bucket has no change without a previous state
+func setProviderHistoryDeltas(buckets []ProviderHistoryBucket, prev *ProviderHistory) {
+	for i := range buckets {
+		if prev != nil {
+			buckets[i].Delta = buckets[i].counters().delta(prev.counters())
+		}
+		prev = &buckets[i].ProviderHistory
+	}
+}
+
+// GetNetworkHistory returns the history of the network in time buckets.
+func (edb *EventDb) GetNetworkHistory(q HistoryQuery) ([]NetworkHistoryBucket, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buckets []NetworkHistoryBucket
+	if err := edb.Store.Get().Raw(`WITH h AS (
+	SELECT creation_date - creation_date % ? AS bucket, *
+	FROM network_histories
+	WHERE creation_date >= ? AND creation_date < ?
+)
+SELECT DISTINCT ON (bucket) bucket, round, creation_date, total_stake, total_rewards, capacity,
+	allocated, saved_data, challenges_passed, challenges_completed, total_mint, total_burn,
+	SUM(txn_fees) OVER (PARTITION BY bucket) AS txn_fees,
+	SUM(minted) OVER (PARTITION BY bucket) AS minted
+FROM h
+ORDER BY bucket, round DESC`, q.Bucket, q.From, q.To).
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+
+	var prev NetworkHistory
+	res := edb.Store.Get().Model(&NetworkHistory{}).
+		Where("creation_date < ?", q.From).
+		Order("creation_date DESC").
+		Limit(1).
+		Scan(&prev)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		setNetworkHistoryDeltas(buckets, &prev)
+	} else {
+		setNetworkHistoryDeltas(buckets, nil)
+	}
+	return buckets, nil
+}
+
+// setNetworkHistoryDeltas sets the changes of the buckets since the previous
+// state, the first bucket has no change but its fees without a previous state
+func setNetworkHistoryDeltas(buckets []NetworkHistoryBucket, prev *NetworkHistory) {
+	for i := range buckets {
+		if prev != nil {
+			buckets[i].Delta = buckets[i].counters().delta(prev.counters())
+		}
+		buckets[i].Delta.Fees = int64(buckets[i].TxnFees)
+		prev = &buckets[i].NetworkHistory
+	}
+}
+
+// historyCounters are the counters of a history, the changes over a time
+// bucket are reported
+type historyCounters struct {
+	rewards, fees, mint, burn currency.Coin
+	passed, completed         uint64
+}
+
+func (ph *ProviderHistory) counters() historyCounters {
+	return historyCounters{
+		rewards:   ph.TotalRewards,
+		fees:      ph.Fees,
+		mint:      ph.TotalMint,
+		burn:      ph.TotalBurn,
+		passed:    ph.ChallengesPassed,
+		completed: ph.ChallengesCompleted,
+	}
+}
+
+// counters of the network history, the transaction fees are not counters
+// but the totals of the periods
+func (nh *NetworkHistory) counters() historyCounters {
+	return historyCounters{
+		rewards:   nh.TotalRewards,
+		mint:      nh.TotalMint,
+		burn:      nh.TotalBurn,
+		passed:    nh.ChallengesPassed,
+		completed: nh.ChallengesCompleted,
+	}
+}
+
+// delta returns the change of the counters from the previous ones
+func (c historyCounters) delta(prev historyCounters) HistoryDelta {
+	passed := int64(c.passed) - int64(prev.passed)
+	completed := int64(c.completed) - int64(prev.completed)
+	return HistoryDelta{
+		Rewards:          int64(c.rewards) - int64(prev.rewards),
+		ChallengesPassed: passed,
+		ChallengesFailed: completed - passed,
+		Fees:             int64(c.fees) - int64(prev.fees),
+		Mint:             int64(c.mint) - int64(prev.mint),
+		Burn:             int64(c.burn) - int64(prev.burn),
+	}
+}
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryQueryValidate(t *testing.T) {
+	require.NoError(t, HistoryQuery{From: 0, To: 3600, Bucket: 60}.Validate())
+	require.Error(t, HistoryQuery{From: 0, To: 3600, Bucket: 0}.Validate())
+	require.Error(t, HistoryQuery{From: 3600, To: 3600, Bucket: 60}.Validate())
+	require.Error(t, HistoryQuery{From: 0, To: 3600 * MaxHistoryBuckets * 2, Bucket: 1800}.Validate())
+}
+
+func TestHistoryDelta(t *testing.T) {
+	prev := &ProviderHistory{
+		TotalRewards:        100,
+		ChallengesPassed:    8,
+		ChallengesCompleted: 10,
+		Fees:                5,
+	}
+	cur := &ProviderHistory{
+		TotalRewards:        250,
+		ChallengesPassed:    15,
+		ChallengesCompleted: 20,
+		Fees:                5,
+		TotalMint:           7,
+	}
+	require.Equal(t, HistoryDelta{
+		Rewards:          150,
+		ChallengesPassed: 7,
+		ChallengesFailed: 3,
+		Mint:             7,
+	}, cur.counters().delta(prev.counters()))
+
+	// the fees of the network are the totals of the periods
+	nh := &NetworkHistory{TotalRewards: 10, TxnFees: 3}
+	require.Equal(t, HistoryDelta{Rewards: 10}, nh.counters().delta(historyCounters{}))
+}
+
+func TestHistoryFirstBucket(t *testing.T) {
+	buckets := []ProviderHistoryBucket{
+		{Bucket: 0, ProviderHistory: ProviderHistory{TotalRewards: 100, TotalMint: 4}},
+		{Bucket: 1, ProviderHistory: ProviderHistory{TotalRewards: 130, TotalMint: 6}},
+	}
+	setProviderHistoryDeltas(buckets, nil)
+	require.Equal(t, HistoryDelta{}, buckets[0].Delta)
+	require.Equal(t, HistoryDelta{Rewards: 30, Mint: 2}, buckets[1].Delta)
+
+	setProviderHistoryDeltas(buckets, &ProviderHistory{TotalRewards: 60, TotalMint: 1})
+	require.Equal(t, HistoryDelta{Rewards: 40, Mint: 3}, buckets[0].Delta)
+
+	network := []NetworkHistoryBucket{
+		{Bucket: 0, NetworkHistory: NetworkHistory{TotalRewards: 100, TxnFees: 3}},
+		{Bucket: 1, NetworkHistory: NetworkHistory{TotalRewards: 110, TxnFees: 5}},
+	}
+	setNetworkHistoryDeltas(network, nil)
+	require.Equal(t, HistoryDelta{Fees: 3}, network[0].Delta)
+	require.Equal(t, HistoryDelta{Rewards: 10, Fees: 5}, network[1].Delta)
+}
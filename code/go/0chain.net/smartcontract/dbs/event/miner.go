@@ -9,6 +9,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"0chain.net/smartcontract/dbs"
+	"0chain.net/smartcontract/dbs/model"
 	"github.com/guregu/null"
 	"gorm.io/gorm"
 )
@@ -318,3 +319,31 @@ func mergeUpdateMinerTotalStakesEvents() *eventsMergerImpl[Miner] {
 func mergeMinerHealthCheckEvents() *eventsMergerImpl[dbs.DbHealthCheck] {
 	return newEventsMerger[dbs.DbHealthCheck](TagMinerHealthCheck, withUniqueEventOverwrite())
 }
+
+// MinerEquivocation is a miner slashed for signing two conflicting messages
+// in a round, the slashed stake is paid to the reporter and burned.
+type MinerEquivocation struct {
+	model.ImmutableModel
+	MinerID    string        `json:"miner_id" gorm:"index:idx_miner_equivocation_miner"`
+	Round      int64         `json:"round"`
+	Kind       string        `json:"kind"`
+	FirstHash  string        `json:"first_hash"`
+	SecondHash string        `json:"second_hash"`
+	Reporter   string        `json:"reporter"`
+	Slashed    currency.Coin `json:"slashed"`
+	Reward     currency.Coin `json:"reward"`
+	Burned     currency.Coin `json:"burned"`
+}
+
+func (edb *EventDb) addMinerEquivocation(me MinerEquivocation) error {
+	return edb.Store.Get().Create(&me).Error
+}
+
+// GetMinerEquivocations returns the equivocations the miner was slashed for
+func (edb *EventDb) GetMinerEquivocations(minerID string) ([]MinerEquivocation, error) {
+	var mes []MinerEquivocation
+	return mes, edb.Store.Get().Model(&MinerEquivocation{}).
+		Where("miner_id = ?", minerID).
+		Order("round").
+		Find(&mes).Error
+}
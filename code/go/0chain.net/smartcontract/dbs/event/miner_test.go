@@ -739,3 +739,33 @@ func buildMockMiner(t *testing.T, ownerId string, pid string) Miner {
 	miner.Rewards = ProviderRewards{}
 	return miner
 }
+
+func TestTagMinerEquivocation(t *testing.T) {
+	edb, clean := GetTestEventDB(t)
+	defer clean()
+
+	me := MinerEquivocation{
+		MinerID:    "miner_id",
+		Round:      90,
+		Kind:       "proposal",
+		FirstHash:  "first",
+		SecondHash: "second",
+		Reporter:   "reporter",
+		Slashed:    100,
+		Reward:     10,
+		Burned:     90,
+	}
+	require.NoError(t, edb.addStat(Event{
+		Type:  TypeStats,
+		Tag:   TagMinerEquivocation,
+		Index: me.MinerID,
+		Data:  me,
+	}))
+
+	mes, err := edb.GetMinerEquivocations("miner_id")
+	require.NoError(t, err)
+	require.Len(t, mes, 1)
+	require.Equal(t, int64(90), mes[0].Round)
+	require.Equal(t, "reporter", mes[0].Reporter)
+	require.Equal(t, currency.Coin(90), mes[0].Burned)
+}
@@ -0,0 +1,162 @@
+package event
+
+import (
+	"0chain.net/smartcontract/stakepool/spenum"
+	"gorm.io/gorm"
+)
+
+// The pages below are ordered by the primary key of the rows and start after
+// a key, the cursor of the GraphQL api. Unlike an offset, rows added to or
+// removed from the previous pages don't shift the next page.
+
+// AllocationFilter filters the allocations of a page, the zero values match
+// any allocation.
+type AllocationFilter struct {
+	AllocationID string
+	Owner        string
+	BlobberID    string
+	Finalized    *bool
+	Cancelled    *bool
+}
+
+// GetAllocationsAfter returns the allocations with an id greater than after,
+// with their blobber terms.
+func (edb *EventDb) GetAllocationsAfter(f AllocationFilter, after uint, limit int) ([]Allocation, error) {
+	q := edb.Store.Get().Model(&Allocation{}).
+		Preload("Terms", func(db *gorm.DB) *gorm.DB {
+			return db.Order("alloc_blobber_idx")
+		}).
+		Where("id > ?", after)
+	if f.AllocationID != "" {
+		q = q.Where("allocation_id = ?", f.AllocationID)
+	}
+	if f.Owner != "" {
+		q = q.Where("owner = ?", f.Owner)
+	}
+	if f.BlobberID != "" {
+		q = q.Where("id IN (?)", edb.Store.Get().Model(&AllocationBlobberTerm{}).
+			Select("alloc_id").
+			Where("blobber_id = ?", f.BlobberID))
+	}
+	if f.Finalized != nil {
+		q = q.Where("finalized = ?", *f.Finalized)
+	}
+	if f.Cancelled != nil {
+		q = q.Where("cancelled = ?", *f.Cancelled)
+	}
+
+	var allocs []Allocation
+	err := q.Order("id").Limit(limit).Find(&allocs).Error
+	return allocs, err
+}
+
+// BlobberFilter filters the blobbers of a page, the zero values match any
+// blobber.
+type BlobberFilter struct {
+	IDs             []string
+	Active          *bool // not killed nor shut down
+	MinFreeCapacity int64
+	MaxReadPrice    *int64
+	MaxWritePrice   *int64
+}
+
+// GetBlobbersAfter returns the blobbers with an id greater than after, with
+// their rewards.
+func (edb *EventDb) GetBlobbersAfter(f BlobberFilter, after string, limit int) ([]Blobber, error) {
+	q := edb.Store.Get().Model(&Blobber{}).
+		Preload("Rewards").
+		Where("id > ?", after)
+	if len(f.IDs) > 0 {
+		q = q.Where("id IN ?", f.IDs)
+	}
+	if f.Active != nil {
+		if *f.Active {
+			q = q.Where("is_killed IS NOT TRUE AND is_shutdown IS NOT TRUE")
+		} else {
+			q = q.Where("(is_killed IS TRUE OR is_shutdown IS TRUE)")
+		}
+	}
+	if f.MinFreeCapacity > 0 {
+		q = q.Where("capacity - allocated >= ?", f.MinFreeCapacity)
+	}
+	if f.MaxReadPrice != nil {
+		q = q.Where("read_price <= ?", *f.MaxReadPrice)
+	}
+	if f.MaxWritePrice != nil {
+		q = q.Where("write_price <= ?", *f.MaxWritePrice)
+	}
+
+	var blobbers []Blobber
+	err := q.Order("id").Limit(limit).Find(&blobbers).Error
+	return blobbers, err
+}
+
+// DelegatePoolFilter filters the delegate pools of a page, the zero values
+// match any delegate pool but the deleted ones.
+type DelegatePoolFilter struct {
+	ProviderID   string
+	ProviderType spenum.Provider
+	DelegateID   string
+	Status       *spenum.PoolStatus
+}
+
+// GetDelegatePoolsAfter returns the delegate pools with an id greater than
+// after.
+func (edb *EventDb) GetDelegatePoolsAfter(f DelegatePoolFilter, after uint, limit int) ([]DelegatePool, error) {
+	q := edb.Store.Get().Model(&DelegatePool{}).Where("id > ?", after)
+	if f.ProviderID != "" {
+		q = q.Where("provider_id = ?", f.ProviderID)
+	}
+	if f.ProviderType != 0 {
+		q = q.Where("provider_type = ?", f.ProviderType)
+	}
+	if f.DelegateID != "" {
+		q = q.Where("delegate_id = ?", f.DelegateID)
+	}
+	if f.Status != nil {
+		q = q.Where("status = ?", *f.Status)
+	} else {
+		q = q.Where("status != ?", spenum.Deleted)
+	}
+
+	var dps []DelegatePool
+	err := q.Order("id").Limit(limit).Find(&dps).Error
+	return dps, err
+}
+
+// MarkerFilter filters the read or write markers of a page, the zero values
+// match any marker.
+type MarkerFilter struct {
+	AllocationID string
+	BlobberID    string
+	ClientID     string
+}
+
+func (f MarkerFilter) apply(q *gorm.DB) *gorm.DB {
+	if f.AllocationID != "" {
+		q = q.Where("allocation_id = ?", f.AllocationID)
+	}
+	if f.BlobberID != "" {
+		q = q.Where("blobber_id = ?", f.BlobberID)
+	}
+	if f.ClientID != "" {
+		q = q.Where("client_id = ?", f.ClientID)
+	}
+	return q
+}
+
+// GetWriteMarkersAfter returns the write markers with an id greater than after.
+func (edb *EventDb) GetWriteMarkersAfter(f MarkerFilter, after uint, limit int) ([]WriteMarker, error) {
+	var wms []WriteMarker
+	err := f.apply(edb.Store.Get().Model(&WriteMarker{}).Where("id > ?", after)).
+		Order("id").Limit(limit).Find(&wms).Error
+	return wms, err
+}
+
+// GetReadMarkersAfter returns the read markers with an id greater than after.
+func (edb *EventDb) GetReadMarkersAfter(f MarkerFilter, after uint, limit int) ([]ReadMarker, error) {
+	var rms []ReadMarker
+	err := f.apply(edb.Store.Get().Model(&ReadMarker{}).Where("id > ?", after)).
+		Order("id").Limit(limit).Find(&rms).Error
+	return rms, err
+}
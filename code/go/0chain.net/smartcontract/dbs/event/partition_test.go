@@ -53,3 +53,23 @@ func TestPartitionCreate(t *testing.T) {
 	db.Store.Get().Raw(req).Scan(&partitions)
 	require.Equal(t, 13, len(partitions))
 }
+
+func TestAddLastPermanentPartitions(t *testing.T) {
+	db, f := GetTestEventDB(t)
+	defer f()
+
+	// the event db is past the partitions created ahead on start
+	period := db.settings.PermanentPartitionChangePeriod
+	round := 12*period + 5
+	require.NoError(t, db.addPermanentPartition(12, "blocks"))
+	require.NoError(t, db.addOrUpdateBlock(Block{Hash: "last", Round: round}))
+
+	require.NoError(t, db.addLastPermanentPartitions())
+	for _, table := range historyTables {
+		var name *string
+		require.NoError(t, db.Store.Get().
+			Raw("SELECT to_regclass(?)::text", db.partTableName(table, 12*period, 13*period)).
+			Scan(&name).Error)
+		require.NotNil(t, name, table)
+	}
+}
@@ -26,6 +26,7 @@ var ErrInvalidEventData = errors.New("invalid event data")
 type (
 	ProcessEventsOptions struct {
 		CommitNow bool
+		WithinTx  func(tx *EventDb) error
 	}
 	ProcessEventsOptionsFunc func(peo *ProcessEventsOptions)
 )
@@ -36,6 +37,14 @@ func CommitNow() ProcessEventsOptionsFunc {
 	}
 }
 
+// WithinTx runs f in the transaction of the processed events, before it is
+// committed or returned. The events are rolled back if f fails.
+func WithinTx(f func(tx *EventDb) error) ProcessEventsOptionsFunc {
+	return func(peo *ProcessEventsOptions) {
+		peo.WithinTx = f
+	}
+}
+
 // CommitOrRollbackFunc represents the callback function to do commit
 // or rollback.
 type CommitOrRollbackFunc func(rollback bool) error
@@ -140,6 +149,15 @@ func (edb *EventDb) ProcessEvents(
 			f(&opt)
 		}
 
+		if opt.WithinTx != nil {
+			if err := opt.WithinTx(tx); err != nil {
+				if rerr := txRollback(); rerr != nil {
+					logging.Logger.Error("can't rollback", zap.Error(rerr))
+				}
+				return nil, 0, err
+			}
+		}
+
 		if opt.CommitNow {
 			return nil, localCounter, tx.Commit()
 		}
@@ -303,6 +321,9 @@ func (edb *EventDb) addEventsWorker(ctx context.Context,
 	if err != nil {
 		logging.Logger.Error("can't manage permanent partitions")
 	}
+	if err := edb.addLastPermanentPartitions(); err != nil {
+		logging.Logger.Error("can't add the permanent partitions of the last round", zap.Error(err))
+	}
 	err = edb.managePartitions(0)
 	if err != nil {
 		logging.Logger.Error("can't manage partitions")
@@ -330,12 +351,12 @@ func (edb *EventDb) addEventsWorker(ctx context.Context,
 }
 
 func (edb *EventDb) publishUnPublishedEvents(getBlockEvents func(round int64) (int64, []Event, error)) error {
-	logging.Logger.Debug("kafka - publish unpublished events")
-	if !edb.dbConfig.KafkaEnabled {
+	logging.Logger.Debug("event sink - publish unpublished events")
+	if edb.sink == nil {
 		return nil
 	}
 
-	logging.Logger.Debug("kafka - publish unpublished events enabled")
+	logging.Logger.Debug("event sink - publish unpublished events enabled")
 	// get last published round, it's not guaranteed that all events in that block is published.
 	// so we still need to re-publish all events in that block.
 	round, err := edb.getLastPublishedRound()
@@ -343,18 +364,18 @@ func (edb *EventDb) publishUnPublishedEvents(getBlockEvents func(round int64) (i
 		if err != gorm.ErrRecordNotFound {
 			logging.Logger.Panic("could not get unpublished events", zap.Error(err))
 		}
-		logging.Logger.Debug("kafka - see no published round events")
+		logging.Logger.Debug("event sink - see no published round events")
 		// when see gorm.ErrRecordNotFound, it means there is no published events, which could
-		// happen when kafka is just introduced and run the first time.
+		// happen when the event sink is just introduced and run the first time.
 		return nil
 	}
 
 	lfbRound, err := edb.getLatestFinalizedBlock()
 	if err != nil {
 		if err != gorm.ErrRecordNotFound {
-			logging.Logger.Panic("kafka - could not get latest finalized block", zap.Error(err))
+			logging.Logger.Panic("event sink - could not get latest finalized block", zap.Error(err))
 		}
-		logging.Logger.Debug("kafka - see no lfb")
+		logging.Logger.Debug("event sink - see no lfb")
 		return nil
 	}
 
@@ -362,7 +383,7 @@ func (edb *EventDb) publishUnPublishedEvents(getBlockEvents func(round int64) (i
 		return nil
 	}
 
-	if round < edb.Config().KafkaTriggerRound {
+	if round < edb.Config().SinkTriggerRound() {
 		return nil
 	}
 	// since we are not sure if the lfb events are all published, so we will publish all events in
@@ -370,7 +391,7 @@ func (edb *EventDb) publishUnPublishedEvents(getBlockEvents func(round int64) (i
 	if round < lfbRound {
 		if round < lfbRound {
 			// see missed events
-			logging.Logger.Debug("kafka - see unpublished events", zap.Int64("from", round), zap.Int64("to", lfbRound))
+			logging.Logger.Debug("event sink - see unpublished events", zap.Int64("from", round), zap.Int64("to", lfbRound))
 		}
 
 		// get all events from round to lfbRound
@@ -384,8 +405,8 @@ func (edb *EventDb) publishUnPublishedEvents(getBlockEvents func(round int64) (i
 				events: events,
 			}
 
-			if es.round >= edb.Config().KafkaTriggerRound {
-				edb.mustPushEventsToKafka(es, true)
+			if es.round >= edb.Config().SinkTriggerRound() {
+				edb.mustPushEventsToSink(es, true)
 			}
 		}
 	}
@@ -483,6 +504,15 @@ func (edb *EventDb) WorkEvents(
 		}
 	}
 
+	if period := edb.HistoryPeriod(); period > 0 && blockEvents.round%period == 0 {
+		if err := edb.addHistory(blockEvents.round, period); err != nil {
+			logging.Logger.Error("error saving history",
+				zap.Int64("round", blockEvents.round),
+				zap.Error(err))
+			return tags, err
+		}
+	}
+
 	return tags, nil
 }
 
@@ -584,6 +614,11 @@ func (edb *EventDb) movePermanentPartitions(current int64) {
 	if err := edb.movePartitionToSlowTableSpace(current, "blocks"); err != nil {
 		logging.Logger.Error("error moving partition", zap.Error(err))
 	}
+	for _, t := range historyTables {
+		if err := edb.movePartitionToSlowTableSpace(current, t); err != nil {
+			logging.Logger.Error("error moving partition", zap.Error(err))
+		}
+	}
 }
 
 func (edb *EventDb) AddPartitions(current int64) error {
@@ -600,7 +635,7 @@ func (edb *EventDb) AddPartitions(current int64) error {
 }
 
 func (edb *EventDb) AddPermanentPartitions(current int64) error {
-	tables := []string{"transactions", "blocks"}
+	tables := append([]string{"transactions", "blocks"}, historyTables...)
 	for _, t := range tables {
 		if err := edb.addPermanentPartition(current, t); err != nil {
 			logging.Logger.Error("error creating partition", zap.Error(err))
@@ -611,6 +646,27 @@ func (edb *EventDb) AddPermanentPartitions(current int64) error {
 	return nil
 }
 
+// addLastPermanentPartitions creates the permanent partitions of the last
+// round, the ones of the tables added since they were created are missing
+// until the next partitions change.
+func (edb *EventDb) addLastPermanentPartitions() error {
+	period := edb.settings.PermanentPartitionChangePeriod
+	if period <= 0 {
+		return nil
+	}
+
+	var round int64
+	if err := edb.Store.Get().Model(&Block{}).
+		Select("COALESCE(MAX(round), 0)").
+		Scan(&round).Error; err != nil {
+		return err
+	}
+	if round < period {
+		return nil
+	}
+	return edb.AddPermanentPartitions(round / period)
+}
+
 func (edb *EventDb) dropPartitions(current int64) error {
 	tables := []string{"events", "snapshots", "blobber_aggregates", "miner_aggregates",
 		"sharder_aggregates", "validator_aggregates", "authorizer_aggregates", "user_aggregates"}
@@ -1064,12 +1120,21 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			return ErrInvalidEventData
 		}
 		users := make([]User, 0, len(*bms))
+		var chainNonces []UserMintNonce
 		authMint := make(map[string]currency.Coin)
 		for _, bm := range *bms {
-			users = append(users, User{
-				UserID:    bm.UserID,
-				MintNonce: bm.MintNonce,
-			})
+			if bm.ChainID == "" {
+				users = append(users, User{
+					UserID:    bm.UserID,
+					MintNonce: bm.MintNonce,
+				})
+			} else {
+				chainNonces = append(chainNonces, UserMintNonce{
+					UserID:    bm.UserID,
+					ChainID:   bm.ChainID,
+					MintNonce: bm.MintNonce,
+				})
+			}
 
 			for _, sig := range bm.Signers {
 				mv, ok := authMint[sig]
@@ -1088,17 +1153,30 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			})
 		}
 
-		err := edb.updateUserMintNonce(users)
-		if err != nil {
-			return err
+		if len(users) > 0 {
+			if err := edb.updateUserMintNonce(users); err != nil {
+				return err
+			}
 		}
 
-		err = edb.updateAuthorizersTotalMint(mints)
+		if len(chainNonces) > 0 {
+			if err := edb.updateUserChainMintNonce(chainNonces); err != nil {
+				return err
+			}
+		}
+
+		err := edb.updateAuthorizersTotalMint(mints)
 		if err != nil {
 			return err
 		}
 		return nil
 
+	case TagMinerEquivocation:
+		me, ok := fromEvent[MinerEquivocation](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addMinerEquivocation(*me)
 	case TagShutdownProvider:
 		u, ok := fromEvent[[]dbs.ProviderID](event.Data)
 		if !ok {
@@ -1157,19 +1235,7 @@ func setEventData[T any](e *Event, data interface{}) error {
 	return ErrInvalidEventData
 }
 
-func (edb *EventDb) kafkaProv() *queueProvider.KafkaProvider {
-	kafka := queueProvider.NewKafkaProvider(
-		edb.dbConfig.KafkaHost,
-		edb.dbConfig.KafkaUsername,
-		edb.dbConfig.KafkaPassword,
-		edb.dbConfig.KafkaWriteTimeout)
-	return kafka
-}
-
-func (edb *EventDb) GetKafkaProv() queueProvider.KafkaProviderI {
-	if edb.kafka == nil {
-		kafka := edb.kafkaProv()
-		edb.kafka = kafka
-	}
-	return edb.kafka
+// GetEventSink returns the sink the events are published to, nil when none is configured.
+func (edb *EventDb) GetEventSink() queueProvider.EventSink {
+	return edb.sink
 }
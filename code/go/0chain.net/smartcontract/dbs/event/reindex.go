@@ -0,0 +1,44 @@
+package event
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+// eventsReindexID is the id of the single events reindex row
+const eventsReindexID = 1
+
+// EventsReindex is the progress of a rebuild of the event db from the stored
+// blocks. It is saved in the transaction of the events of the last reindexed
+// round, so it never gets ahead of or behind the reindexed events.
+type EventsReindex struct {
+	ID        int64 `gorm:"primaryKey"`
+	FromRound int64
+	ToRound   int64
+	LastRound int64
+}
+
+func (EventsReindex) TableName() string {
+	return "events_reindex"
+}
+
+// GetEventsReindex returns the progress of the events reindex,
+// gorm.ErrRecordNotFound if no reindex has been done.
+func (edb *EventDb) GetEventsReindex() (*EventsReindex, error) {
+	var er EventsReindex
+	err := edb.Store.Get().Model(&EventsReindex{}).
+		Where("id = ?", eventsReindexID).
+		Take(&er).Error
+	if err != nil {
+		return nil, err
+	}
+	return &er, nil
+}
+
+// SaveEventsReindex saves the progress of the events reindex
+func (edb *EventDb) SaveEventsReindex(er *EventsReindex) error {
+	er.ID = eventsReindexID
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(er).Error
+}
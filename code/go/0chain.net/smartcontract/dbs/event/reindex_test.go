@@ -0,0 +1,25 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestEventsReindex(t *testing.T) {
+	edb, clean := GetTestEventDB(t)
+	defer clean()
+
+	_, err := edb.GetEventsReindex()
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	require.NoError(t, edb.SaveEventsReindex(&EventsReindex{FromRound: 0, ToRound: 100, LastRound: 10}))
+	require.NoError(t, edb.SaveEventsReindex(&EventsReindex{FromRound: 0, ToRound: 100, LastRound: 11}))
+
+	er, err := edb.GetEventsReindex()
+	require.NoError(t, err)
+	require.EqualValues(t, 0, er.FromRound)
+	require.EqualValues(t, 100, er.ToRound)
+	require.EqualValues(t, 11, er.LastRound)
+}
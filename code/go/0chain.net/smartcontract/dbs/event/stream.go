@@ -0,0 +1,418 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultStreamRecentSize is the number of latest events kept in memory for resuming subscriptions.
+	DefaultStreamRecentSize = 10000
+	// DefaultStreamBufferSize is the number of events buffered for a subscriber, a subscriber
+	// lagging further behind is disconnected and has to resume.
+	DefaultStreamBufferSize = 1024
+)
+
+var (
+	// ErrSequenceTooOld is returned when a subscription can't be resumed because the
+	// events after the requested sequence number are not available anymore.
+	ErrSequenceTooOld = errors.New("events after the sequence number are not available anymore")
+	// ErrSubscriberLagging is the reason a subscription is closed when the subscriber doesn't keep up.
+	ErrSubscriberLagging = errors.New("subscriber is lagging behind, resume from the last received sequence number")
+)
+
+// clientIDKeys are the keys of the event data fields holding a client id, compared
+// lower cased and without underscores.
+var clientIDKeys = map[string]struct{}{
+	"clientid":       {},
+	"client":         {},
+	"toclientid":     {},
+	"userid":         {},
+	"ownerid":        {},
+	"owner":          {},
+	"payerid":        {},
+	"delegateid":     {},
+	"delegatewallet": {},
+}
+
+// EventFilter selects the events of a subscription, an empty set matches all the events.
+type EventFilter struct {
+	Tags      map[EventTag]struct{}
+	Types     map[EventType]struct{}
+	Indexes   map[string]struct{}
+	ClientIDs map[string]struct{}
+}
+
+// ParseEventTag parses an event tag given by its name, with or without the "Tag" prefix, or by its number.
+func ParseEventTag(s string) (EventTag, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= int(TagNone) || n >= int(NumberOfTags) {
+			return TagNone, errors.New("invalid event tag: " + s)
+		}
+		return EventTag(n), nil
+	}
+	name := s
+	if !strings.HasPrefix(name, "Tag") {
+		name = "Tag" + name
+	}
+	for i, ts := range TagString {
+		if strings.EqualFold(ts, name) && EventTag(i) != TagNone && EventTag(i) != NumberOfTags {
+			return EventTag(i), nil
+		}
+	}
+	return TagNone, errors.New("unknown event tag: " + s)
+}
+
+// ParseEventType parses an event type given by its name or number.
+func ParseEventType(s string) (EventType, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= int(TypeNone) || n >= int(NumberOfTypes) {
+			return TypeNone, errors.New("invalid event type: " + s)
+		}
+		return EventType(n), nil
+	}
+	for i, ts := range TypeString {
+		if strings.EqualFold(ts, s) && EventType(i) != TypeNone && EventType(i) != NumberOfTypes {
+			return EventType(i), nil
+		}
+	}
+	return TypeNone, errors.New("unknown event type: " + s)
+}
+
+// Match tells whether the event passes the filter. An event data holding a list of
+// entries, like the merged events of a block, is narrowed down to the entries of the
+// filtered clients.
+func (f *EventFilter) Match(e Event) (Event, bool) {
+	if len(f.Tags) > 0 {
+		if _, ok := f.Tags[e.Tag]; !ok {
+			return e, false
+		}
+	}
+	if len(f.Types) > 0 {
+		if _, ok := f.Types[e.Type]; !ok {
+			return e, false
+		}
+	}
+	if len(f.Indexes) > 0 {
+		if _, ok := f.Indexes[e.Index]; !ok {
+			return e, false
+		}
+	}
+	if len(f.ClientIDs) == 0 {
+		return e, true
+	}
+	if _, ok := f.ClientIDs[e.Index]; ok {
+		return e, true
+	}
+
+	if entries, ok := e.Data.([]interface{}); ok {
+		var matched []interface{}
+		for _, entry := range entries {
+			if f.matchClient(entry) {
+				matched = append(matched, entry)
+			}
+		}
+		if len(matched) == 0 {
+			return e, false
+		}
+		e.Data = matched
+		return e, true
+	}
+	return e, f.matchClient(e.Data)
+}
+
+func (f *EventFilter) matchClient(data interface{}) bool {
+	fields, ok := data.(map[string]interface{})
+	if !ok {
+		if id, ok := data.(string); ok {
+			_, match := f.ClientIDs[id]
+			return match
+		}
+		return false
+	}
+	for k, v := range fields {
+		if _, ok := clientIDKeys[strings.ReplaceAll(strings.ToLower(k), "_", "")]; !ok {
+			continue
+		}
+		if id, ok := v.(string); ok {
+			if _, match := f.ClientIDs[id]; match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Subscription receives the events passing its filter.
+type Subscription struct {
+	filter  EventFilter
+	backlog []Event
+	ch      chan Event
+	done    chan struct{}
+	once    sync.Once
+	err     error
+}
+
+// Backlog returns the events missed since the sequence number the subscription resumed from.
+func (s *Subscription) Backlog() []Event {
+	return s.backlog
+}
+
+// Events returns the channel of the live events.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Done is closed when the subscription is closed, Err tells the reason.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the reason the subscription has been closed by the stream.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+func (s *Subscription) close(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
+
+// EventStream fans the events of the processed blocks out to the live subscribers.
+// The latest events are kept in memory, older ones are read from the stored block
+// events, so that a subscriber can resume from a sequence number.
+type EventStream struct {
+	mutex       sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	// recent holds the latest published events in the sequence number order.
+	recent     []Event
+	recentSize int
+	bufferSize int
+
+	getBlockEvents func(round int64) (int64, []Event, error)
+	// getRound returns the round of the first event after the sequence number.
+	getRound func(sequence int64) (int64, error)
+}
+
+func NewEventStream(recentSize, bufferSize int) *EventStream {
+	if recentSize <= 0 {
+		recentSize = DefaultStreamRecentSize
+	}
+	if bufferSize <= 0 {
+		bufferSize = DefaultStreamBufferSize
+	}
+	return &EventStream{
+		subscribers: make(map[*Subscription]struct{}),
+		recentSize:  recentSize,
+		bufferSize:  bufferSize,
+	}
+}
+
+// toStreamEvent converts the event data to its JSON form so that it's filtered and
+// sent the same way whether it's live or read from the stored block events.
+func toStreamEvent(e Event) Event {
+	if e.Data == nil {
+		return e
+	}
+	if _, ok := e.Data.(map[string]interface{}); ok {
+		return e
+	}
+	if _, ok := e.Data.([]interface{}); ok {
+		return e
+	}
+	if reflect.TypeOf(e.Data).Kind() == reflect.String {
+		return e
+	}
+	raw, err := json.Marshal(e.Data)
+	if err != nil {
+		logging.Logger.Error("event stream - marshal event data", zap.Error(err))
+		return e
+	}
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		logging.Logger.Error("event stream - unmarshal event data", zap.Error(err))
+		return e
+	}
+	e.Data = data
+	return e
+}
+
+// Publish sends the events of a processed block to the subscribers.
+func (es *EventStream) Publish(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	converted := make([]Event, 0, len(events))
+	for _, e := range events {
+		converted = append(converted, toStreamEvent(e))
+	}
+
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	es.recent = append(es.recent, converted...)
+	if over := len(es.recent) - es.recentSize; over > 0 {
+		es.recent = append(es.recent[:0:0], es.recent[over:]...)
+	}
+
+	for s := range es.subscribers {
+		for _, e := range converted {
+			me, ok := s.filter.Match(e)
+			if !ok {
+				continue
+			}
+			select {
+			case s.ch <- me:
+			default:
+				delete(es.subscribers, s)
+				s.close(ErrSubscriberLagging)
+			}
+			if s.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+// Subscribe registers a subscriber. When fromSequence is positive, the events
+// published after it are returned in the subscription backlog.
+func (es *EventStream) Subscribe(filter EventFilter, fromSequence int64) (*Subscription, error) {
+	s := &Subscription{
+		filter: filter,
+		ch:     make(chan Event, es.bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	var stored []Event
+	if fromSequence > 0 {
+		es.mutex.RLock()
+		missing := len(es.recent) == 0 || es.recent[0].SequenceNumber > fromSequence+1
+		es.mutex.RUnlock()
+		if missing {
+			var err error
+			if stored, err = es.storedEvents(fromSequence); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	if fromSequence > 0 {
+		last := fromSequence
+		for _, e := range stored {
+			if e.SequenceNumber <= last {
+				continue
+			}
+			if me, ok := filter.Match(e); ok {
+				s.backlog = append(s.backlog, me)
+			}
+			last = e.SequenceNumber
+		}
+		if len(es.recent) > 0 && es.recent[0].SequenceNumber > last+1 &&
+			es.recent[0].SequenceNumber > fromSequence+1 {
+			return nil, ErrSequenceTooOld
+		}
+		for _, e := range es.recent {
+			if e.SequenceNumber <= last {
+				continue
+			}
+			if me, ok := filter.Match(e); ok {
+				s.backlog = append(s.backlog, me)
+			}
+		}
+	}
+
+	es.subscribers[s] = struct{}{}
+	return s, nil
+}
+
+// storedEvents reads the events after the sequence number from the stored block
+// events up to the oldest event kept in memory.
+func (es *EventStream) storedEvents(fromSequence int64) ([]Event, error) {
+	if es.getRound == nil || es.getBlockEvents == nil {
+		return nil, ErrSequenceTooOld
+	}
+	round, err := es.getRound(fromSequence)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// nothing has happened since the sequence number
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	es.mutex.RLock()
+	toRound := int64(-1)
+	if len(es.recent) > 0 {
+		toRound = es.recent[0].BlockNumber
+	}
+	es.mutex.RUnlock()
+
+	var events []Event
+	for r := round; toRound < 0 || r <= toRound; r++ {
+		rd, bes, err := es.getBlockEvents(r)
+		if err != nil {
+			if toRound < 0 && len(events) > 0 {
+				// reached the latest stored round
+				break
+			}
+			return nil, ErrSequenceTooOld
+		}
+		if rd != r {
+			return nil, ErrSequenceTooOld
+		}
+		for _, e := range bes {
+			events = append(events, toStreamEvent(e))
+		}
+	}
+	return events, nil
+}
+
+// Unsubscribe removes the subscriber.
+func (es *EventStream) Unsubscribe(s *Subscription) {
+	es.mutex.Lock()
+	delete(es.subscribers, s)
+	es.mutex.Unlock()
+	s.close(nil)
+}
+
+// SubscribersCount returns the number of live subscribers.
+func (es *EventStream) SubscribersCount() int {
+	es.mutex.RLock()
+	defer es.mutex.RUnlock()
+	return len(es.subscribers)
+}
+
+func (edb *EventDb) getRoundAfterSequence(sequence int64) (int64, error) {
+	var e Event
+	err := edb.Store.Get().Model(&Event{}).
+		Where("sequence_number > ?", sequence).
+		Order("sequence_number asc").
+		First(&e).Error
+	if err != nil {
+		return 0, err
+	}
+	return e.BlockNumber, nil
+}
+
+// Stream returns the live events stream of the event database.
+func (edb *EventDb) Stream() *EventStream {
+	return edb.stream
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamTestEvents(round, firstSequence int64, n int) []Event {
+	events := make([]Event, 0, n)
+	for i := 0; i < n; i++ {
+		events = append(events, Event{
+			BlockNumber:    round,
+			Type:           TypeStats,
+			Tag:            TagAddTransactions,
+			Index:          "tx",
+			SequenceNumber: firstSequence + int64(i),
+			Data:           Transaction{ClientId: "client", ToClientId: "to_client"},
+		})
+	}
+	return events
+}
+
+func TestEventFilter(t *testing.T) {
+	e := toStreamEvent(Event{
+		Type:  TypeStats,
+		Tag:   TagAddOrOverwriteUser,
+		Index: "user1",
+		Data: []User{
+			{UserID: "user1"},
+			{UserID: "user2"},
+		},
+	})
+
+	f := EventFilter{}
+	_, ok := f.Match(e)
+	require.True(t, ok)
+
+	f = EventFilter{Tags: map[EventTag]struct{}{TagAddBlobber: {}}}
+	_, ok = f.Match(e)
+	require.False(t, ok)
+
+	f = EventFilter{Types: map[EventType]struct{}{TypeStats: {}}, Indexes: map[string]struct{}{"user1": {}}}
+	_, ok = f.Match(e)
+	require.True(t, ok)
+
+	f = EventFilter{ClientIDs: map[string]struct{}{"user2": {}}}
+	me, ok := f.Match(e)
+	require.True(t, ok)
+	entries := me.Data.([]interface{})
+	require.Len(t, entries, 1)
+	require.Equal(t, "user2", entries[0].(map[string]interface{})["user_id"])
+
+	f = EventFilter{ClientIDs: map[string]struct{}{"user3": {}}}
+	_, ok = f.Match(e)
+	require.False(t, ok)
+}
+
+func TestParseEventTag(t *testing.T) {
+	tag, err := ParseEventTag("TagAddBlobber")
+	require.NoError(t, err)
+	require.Equal(t, TagAddBlobber, tag)
+
+	tag, err = ParseEventTag("addblobber")
+	require.NoError(t, err)
+	require.Equal(t, TagAddBlobber, tag)
+
+	_, err = ParseEventTag("unknown")
+	require.Error(t, err)
+
+	typ, err := ParseEventType("stats")
+	require.NoError(t, err)
+	require.Equal(t, TypeStats, typ)
+}
+
+func TestEventStreamSubscribe(t *testing.T) {
+	es := NewEventStream(10, 100)
+
+	live, err := es.Subscribe(EventFilter{}, 0)
+	require.NoError(t, err)
+	require.Empty(t, live.Backlog())
+
+	es.Publish(newStreamTestEvents(1, 1, 5))
+	es.Publish(newStreamTestEvents(2, 6, 5))
+	require.Len(t, live.Events(), 10)
+	first := <-live.Events()
+	require.Equal(t, int64(1), first.SequenceNumber)
+	require.Equal(t, "client", first.Data.(map[string]interface{})["client_id"])
+
+	// resume from the events kept in memory
+	resumed, err := es.Subscribe(EventFilter{}, 7)
+	require.NoError(t, err)
+	require.Len(t, resumed.Backlog(), 3)
+	require.Equal(t, int64(8), resumed.Backlog()[0].SequenceNumber)
+
+	// the events before the ones kept in memory are not available
+	es.Publish(newStreamTestEvents(3, 11, 5))
+	_, err = es.Subscribe(EventFilter{}, 2)
+	require.True(t, errors.Is(err, ErrSequenceTooOld))
+
+	es.Unsubscribe(resumed)
+	require.Equal(t, 1, es.SubscribersCount())
+	require.NoError(t, resumed.Err())
+}
+
+func TestEventStreamResumeFromStoredEvents(t *testing.T) {
+	es := NewEventStream(5, 100)
+	stored := map[int64][]Event{
+		1: newStreamTestEvents(1, 1, 5),
+		2: newStreamTestEvents(2, 6, 5),
+		3: newStreamTestEvents(3, 11, 5),
+	}
+	es.getBlockEvents = func(round int64) (int64, []Event, error) {
+		events, ok := stored[round]
+		if !ok {
+			return 0, nil, errors.New("not found")
+		}
+		return round, events, nil
+	}
+	es.getRound = func(sequence int64) (int64, error) {
+		return (sequence)/5 + 1, nil
+	}
+	es.Publish(stored[3])
+
+	sub, err := es.Subscribe(EventFilter{}, 3)
+	require.NoError(t, err)
+	require.Len(t, sub.Backlog(), 12)
+	for i, e := range sub.Backlog() {
+		require.Equal(t, int64(4+i), e.SequenceNumber)
+	}
+}
+
+func TestEventStreamLaggingSubscriber(t *testing.T) {
+	es := NewEventStream(100, 3)
+	sub, err := es.Subscribe(EventFilter{}, 0)
+	require.NoError(t, err)
+
+	es.Publish(newStreamTestEvents(1, 1, 5))
+	<-sub.Done()
+	require.Equal(t, ErrSubscriberLagging, sub.Err())
+	require.Equal(t, 0, es.SubscribersCount())
+}
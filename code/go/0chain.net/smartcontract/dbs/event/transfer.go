@@ -37,6 +37,7 @@ type ChallengePoolLock struct {
 }
 
 type BridgeMint struct {
+	ChainID   string        `json:"chain_id"` // source chain, empty for the default one
 	UserID    string        `json:"user_id"`
 	MintNonce int64         `json:"mint_nonce"`
 	Amount    currency.Coin `json:"amount"`
@@ -47,6 +47,42 @@ func (edb *EventDb) updateUserMintNonce(users []User) error {
 	}).Create(&users).Error
 }
 
+// UserMintNonce is the latest mint nonce of a user on a chain other than the
+// default one, the nonce of the default chain is the mint nonce of the user.
+type UserMintNonce struct {
+	model.UpdatableModel
+	UserID    string `json:"user_id" gorm:"uniqueIndex:idx_user_mint_nonce"`
+	ChainID   string `json:"chain_id" gorm:"uniqueIndex:idx_user_mint_nonce"`
+	MintNonce int64  `json:"mint_nonce"`
+}
+
+// GetUserMintNonce returns the latest mint nonce of the user on the chain
+func (edb *EventDb) GetUserMintNonce(userID, chainID string) (int64, error) {
+	if chainID == "" {
+		user, err := edb.GetUser(userID)
+		if err != nil {
+			return 0, err
+		}
+		return user.MintNonce, nil
+	}
+
+	var un UserMintNonce
+	err := edb.Store.Get().Model(&UserMintNonce{}).
+		Where("user_id = ? AND chain_id = ?", userID, chainID).
+		First(&un).Error
+	if err == gorm.ErrRecordNotFound {
+		return 0, util.ErrValueNotPresent
+	}
+	return un.MintNonce, err
+}
+
+func (edb *EventDb) updateUserChainMintNonce(nonces []UserMintNonce) error {
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "chain_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mint_nonce", "updated_at"}),
+	}).Create(&nonces).Error
+}
+
 func mergeUpdateUserCollectedRewardsEvents() *eventsMergerImpl[UserAggregate] {
 	return newEventsMerger[UserAggregate](TagUpdateUserCollectedRewards, withCollectedRewardsMerged())
 }
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE miner_equivocations (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    miner_id text,
+    round bigint,
+    kind text,
+    first_hash text,
+    second_hash text,
+    reporter text,
+    slashed bigint,
+    reward bigint,
+    burned bigint
+);
+
+CREATE INDEX idx_miner_equivocation_miner ON miner_equivocations USING btree (miner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS miner_equivocations;
+-- +goose StatementEnd
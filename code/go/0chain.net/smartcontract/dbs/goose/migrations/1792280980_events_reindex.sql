@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE events_reindex (
+    id bigint PRIMARY KEY,
+    from_round bigint,
+    to_round bigint,
+    last_round bigint
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS events_reindex;
+-- +goose StatementEnd
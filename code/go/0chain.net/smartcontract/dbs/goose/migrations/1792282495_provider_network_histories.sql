@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE provider_histories (
+    round bigint NOT NULL,
+    creation_date bigint NOT NULL,
+    provider_id text NOT NULL,
+    provider_type smallint NOT NULL,
+    total_stake bigint DEFAULT 0 NOT NULL,
+    total_rewards bigint DEFAULT 0 NOT NULL,
+    capacity bigint DEFAULT 0 NOT NULL,
+    allocated bigint DEFAULT 0 NOT NULL,
+    saved_data bigint DEFAULT 0 NOT NULL,
+    challenges_passed bigint DEFAULT 0 NOT NULL,
+    challenges_completed bigint DEFAULT 0 NOT NULL,
+    fees bigint DEFAULT 0 NOT NULL,
+    total_mint bigint DEFAULT 0 NOT NULL,
+    total_burn bigint DEFAULT 0 NOT NULL
+)
+PARTITION BY RANGE (round);
+
+CREATE INDEX idx_provider_histories ON provider_histories USING btree (provider_id, provider_type, creation_date);
+
+CREATE TABLE network_histories (
+    round bigint NOT NULL,
+    creation_date bigint NOT NULL,
+    total_stake bigint DEFAULT 0 NOT NULL,
+    total_rewards bigint DEFAULT 0 NOT NULL,
+    capacity bigint DEFAULT 0 NOT NULL,
+    allocated bigint DEFAULT 0 NOT NULL,
+    saved_data bigint DEFAULT 0 NOT NULL,
+    challenges_passed bigint DEFAULT 0 NOT NULL,
+    challenges_completed bigint DEFAULT 0 NOT NULL,
+    total_mint bigint DEFAULT 0 NOT NULL,
+    total_burn bigint DEFAULT 0 NOT NULL,
+    txn_fees bigint DEFAULT 0 NOT NULL,
+    minted bigint DEFAULT 0 NOT NULL
+)
+PARTITION BY RANGE (round);
+
+CREATE INDEX idx_network_histories ON network_histories USING btree (creation_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS provider_histories;
+DROP TABLE IF EXISTS network_histories;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE burn_tickets ADD COLUMN IF NOT EXISTS chain_id text DEFAULT '' NOT NULL;
+
+CREATE TABLE user_mint_nonces (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    user_id text,
+    chain_id text,
+    mint_nonce bigint
+);
+
+CREATE UNIQUE INDEX idx_user_mint_nonce ON user_mint_nonces USING btree (user_id, chain_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_mint_nonces;
+ALTER TABLE burn_tickets DROP COLUMN IF EXISTS chain_id;
+-- +goose StatementEnd
@@ -0,0 +1,78 @@
+package queueProvider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileSink appends the events to local JSON lines files, one file per topic.
+// Every line holds the key and the message of an event, a message published
+// again by the recovery is appended again with the same key.
+type FileSink struct {
+	path  string
+	mutex sync.Mutex
+	files map[string]*os.File
+}
+
+var _ EventSink = (*FileSink)(nil)
+
+type fileSinkLine struct {
+	Key     string          `json:"key"`
+	Message json.RawMessage `json:"message"`
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, err
+	}
+	return &FileSink{
+		path:  path,
+		files: make(map[string]*os.File),
+	}, nil
+}
+
+// TopicFile returns the path of the file the events of the topic are appended to.
+func (fs *FileSink) TopicFile(topic string) string {
+	return filepath.Join(fs.path, topic+".jsonl")
+}
+
+func (fs *FileSink) Publish(topic string, key, message []byte) chan error {
+	line, err := json.Marshal(fileSinkLine{Key: string(key), Message: message})
+	if err != nil {
+		return publishResult(err)
+	}
+	line = append(line, '\n')
+
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	f, ok := fs.files[topic]
+	if !ok {
+		f, err = os.OpenFile(fs.TopicFile(topic), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return publishResult(err)
+		}
+		fs.files[topic] = f
+	}
+
+	if _, err := f.Write(line); err != nil {
+		return publishResult(err)
+	}
+	return publishResult(f.Sync())
+}
+
+func (fs *FileSink) Close() error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	var err error
+	for topic, f := range fs.files {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		delete(fs.files, topic)
+	}
+	return err
+}
@@ -17,6 +17,8 @@ type KafkaProviderI interface {
 	CloseAllWriters() error
 }
 
+var _ EventSink = (*KafkaProvider)(nil)
+
 type KafkaProvider struct {
 	Host         string
 	WriteTimeout time.Duration
@@ -85,6 +87,22 @@ func (k *KafkaProvider) PublishToKafka(topic string, key, message []byte) chan i
 	return res
 }
 
+// Publish implements EventSink, the delivery errors are handled by the kafka writers.
+func (k *KafkaProvider) Publish(topic string, key, message []byte) chan error {
+	offset := k.PublishToKafka(topic, key, message)
+	res := make(chan error, 1)
+	go func() {
+		<-offset
+		res <- nil
+	}()
+	return res
+}
+
+// Close implements EventSink.
+func (k *KafkaProvider) Close() error {
+	return k.CloseAllWriters()
+}
+
 func (k *KafkaProvider) ReconnectWriter(topic string) error {
 	k.mutex.Lock()
 	defer k.mutex.Unlock()
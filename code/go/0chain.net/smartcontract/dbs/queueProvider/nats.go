@@ -0,0 +1,78 @@
+package queueProvider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/0chain/common/core/logging"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const natsMaxPendingPublish = 4096
+
+// NatsSink publishes the events to a NATS JetStream stream, the topic is used
+// as the subject. The event key is sent as the message id so that the messages
+// published again by the recovery are dropped by the stream deduplication.
+type NatsSink struct {
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+var _ EventSink = (*NatsSink)(nil)
+
+func NewNatsSink(url, username, password string, timeout time.Duration) (*NatsSink, error) {
+	logging.Logger.Debug("New nats sink", zap.String("url", url))
+
+	opts := []nats.Option{
+		nats.Name("0chain-events"),
+		nats.MaxReconnects(-1),
+	}
+	if username != "" {
+		opts = append(opts, nats.UserInfo(username, password))
+	}
+	if timeout > 0 {
+		opts = append(opts, nats.Timeout(timeout))
+	}
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("nats connect: %v", err)
+	}
+
+	js, err := conn.JetStream(nats.PublishAsyncMaxPending(natsMaxPendingPublish))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("nats jetstream: %v", err)
+	}
+	return &NatsSink{conn: conn, js: js}, nil
+}
+
+func (n *NatsSink) Publish(topic string, key, message []byte) chan error {
+	msg := nats.NewMsg(topic)
+	msg.Header.Set(nats.MsgIdHdr, string(key))
+	msg.Data = message
+
+	ack, err := n.js.PublishMsgAsync(msg)
+	if err != nil {
+		return publishResult(err)
+	}
+
+	res := make(chan error, 1)
+	go func() {
+		select {
+		case <-ack.Ok():
+			res <- nil
+		case err := <-ack.Err():
+			res <- err
+		}
+	}()
+	return res
+}
+
+func (n *NatsSink) Close() error {
+	if err := n.conn.Drain(); err != nil {
+		logging.Logger.Error("error draining nats connection", zap.Error(err))
+		n.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package queueProvider
+
+// EventSink publishes the events to an external consumer. The events are
+// delivered at least once: a message whose result hasn't been received is
+// published again after a restart, consumers deduplicate them by key.
+type EventSink interface {
+	// Publish sends the message with the given key to the topic. The returned
+	// channel receives nil once the sink has acknowledged the message, or the
+	// error the message couldn't be delivered with.
+	Publish(topic string, key, message []byte) chan error
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// publishResult returns a result channel holding the given result.
+func publishResult(err error) chan error {
+	res := make(chan error, 1)
+	res <- err
+	return res
+}
@@ -0,0 +1,138 @@
+package queueProvider
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0chain/common/core/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logging.InitLogging("development", "")
+}
+
+func TestFileSink(t *testing.T) {
+	fs, err := NewFileSink(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, <-fs.Publish("events", []byte("1:1"), []byte(`{"round":1}`)))
+	require.NoError(t, <-fs.Publish("events", []byte("1:2"), []byte(`{"round":1}`)))
+	require.Error(t, <-fs.Publish("events", []byte("1:3"), []byte(`not json`)))
+	require.NoError(t, fs.Close())
+
+	// a reopened sink appends
+	fs, err = NewFileSink(fs.path)
+	require.NoError(t, err)
+	require.NoError(t, <-fs.Publish("events", []byte("2:1"), []byte(`{"round":2}`)))
+	require.NoError(t, fs.Close())
+
+	f, err := os.Open(fs.TopicFile("events"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var keys []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line fileSinkLine
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		keys = append(keys, line.Key)
+	}
+	require.Equal(t, []string{"1:1", "1:2", "2:1"}, keys)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		received []string
+		failures = 2
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		require.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get(WebhookSignatureHeader))
+		require.Equal(t, "events", r.Header.Get(WebhookTopicHeader))
+		received = append(received, r.Header.Get(WebhookKeyHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ws := NewWebhookSink(srv.URL, "secret", time.Second, 3, time.Millisecond)
+	defer ws.Close()
+
+	var results []chan error
+	for _, key := range []string{"1:1", "1:2", "1:3"} {
+		results = append(results, ws.Publish("events", []byte(key), []byte(`{}`)))
+	}
+	for _, res := range results {
+		require.NoError(t, <-res)
+	}
+	require.Equal(t, []string{"1:1", "1:2", "1:3"}, received)
+}
+
+func TestWebhookSinkGivesUp(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	ws := NewWebhookSink(srv.URL, "", time.Second, 2, time.Millisecond)
+	require.Error(t, <-ws.Publish("events", []byte("1:1"), []byte(`{}`)))
+	require.Equal(t, 3, attempts)
+
+	require.NoError(t, ws.Close())
+	require.Equal(t, ErrWebhookSinkClosed, <-ws.Publish("events", []byte("1:2"), []byte(`{}`)))
+}
+
+func TestWebhookSinkCloseWithFullQueue(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ws := NewWebhookSink(srv.URL, "", 100*time.Millisecond, 1, time.Millisecond)
+
+	// the endpoint doesn't answer, the last publish waits on the full queue
+	last := make(chan chan error)
+	go func() {
+		var res chan error
+		for i := 0; i < webhookQueueSize+2; i++ {
+			res = ws.Publish("events", []byte("1:1"), []byte(`{}`))
+		}
+		last <- res
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, ws.Close())
+
+	select {
+	case res := <-last:
+		require.Equal(t, ErrWebhookSinkClosed, <-res)
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish is blocked after close")
+	}
+}
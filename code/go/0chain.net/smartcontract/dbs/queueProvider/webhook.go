@@ -0,0 +1,194 @@
+package queueProvider
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultWebhookTimeout    = 10 * time.Second
+	DefaultWebhookMaxRetries = 5
+	DefaultWebhookRetryDelay = time.Second
+
+	webhookMaxRetryDelay = time.Minute
+	webhookQueueSize     = 4096
+
+	// WebhookTopicHeader, WebhookKeyHeader and WebhookSignatureHeader are the headers
+	// of the webhook requests holding the topic, the key and the hex encoded
+	// HMAC-SHA256 of the body computed with the webhook secret.
+	WebhookTopicHeader     = "X-Event-Topic"
+	WebhookKeyHeader       = "X-Event-Key"
+	WebhookSignatureHeader = "X-Event-Signature"
+)
+
+var ErrWebhookSinkClosed = errors.New("webhook sink is closed")
+
+type webhookMessage struct {
+	topic   string
+	key     []byte
+	message []byte
+	res     chan error
+}
+
+// WebhookSink posts every event to an HTTP endpoint. The messages are posted
+// one by one in the publishing order, a failed request is retried with an
+// exponential backoff. A message is acknowledged by a 2xx response.
+type WebhookSink struct {
+	url        string
+	secret     []byte
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+
+	queue  chan webhookMessage
+	done   chan struct{}
+	mutex  sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+var _ EventSink = (*WebhookSink)(nil)
+
+func NewWebhookSink(url, secret string, timeout time.Duration, maxRetries int, retryDelay time.Duration) *WebhookSink {
+	logging.Logger.Debug("New webhook sink", zap.String("url", url))
+
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	if maxRetries <= 0 {
+		maxRetries = DefaultWebhookMaxRetries
+	}
+	if retryDelay <= 0 {
+		retryDelay = DefaultWebhookRetryDelay
+	}
+
+	ws := &WebhookSink{
+		url:        url,
+		secret:     []byte(secret),
+		client:     &http.Client{Timeout: timeout},
+		maxRetries: maxRetries,
+		retryDelay: retryDelay,
+		queue:      make(chan webhookMessage, webhookQueueSize),
+		done:       make(chan struct{}),
+	}
+	ws.wg.Add(1)
+	go ws.worker()
+	return ws
+}
+
+func (ws *WebhookSink) Publish(topic string, key, message []byte) chan error {
+	ws.mutex.RLock()
+	closed := ws.closed
+	ws.mutex.RUnlock()
+	if closed {
+		return publishResult(ErrWebhookSinkClosed)
+	}
+
+	// the lock is not held while waiting on a full queue, so a slow endpoint
+	// doesn't block Close
+	res := make(chan error, 1)
+	select {
+	case ws.queue <- webhookMessage{topic: topic, key: key, message: message, res: res}:
+		return res
+	case <-ws.done:
+		return publishResult(ErrWebhookSinkClosed)
+	}
+}
+
+func (ws *WebhookSink) Close() error {
+	ws.mutex.Lock()
+	if !ws.closed {
+		ws.closed = true
+		close(ws.done)
+	}
+	ws.mutex.Unlock()
+	ws.wg.Wait()
+	return nil
+}
+
+func (ws *WebhookSink) worker() {
+	defer ws.wg.Done()
+	for {
+		select {
+		case <-ws.done:
+			// fail the queued messages, they are published again by the recovery
+			for {
+				select {
+				case msg := <-ws.queue:
+					msg.res <- ErrWebhookSinkClosed
+				default:
+					return
+				}
+			}
+		case msg := <-ws.queue:
+			msg.res <- ws.deliver(msg)
+		}
+	}
+}
+
+// deliver posts the message, retrying up to the configured number of times.
+func (ws *WebhookSink) deliver(msg webhookMessage) error {
+	delay := ws.retryDelay
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = ws.post(msg); err == nil {
+			return nil
+		}
+		if attempt >= ws.maxRetries {
+			return fmt.Errorf("webhook: giving up after %d attempts: %v", attempt+1, err)
+		}
+
+		logging.Logger.Warn("webhook sink - post failed, retrying",
+			zap.String("key", string(msg.key)),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ws.done:
+			return ErrWebhookSinkClosed
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > webhookMaxRetryDelay {
+			delay = webhookMaxRetryDelay
+		}
+	}
+}
+
+func (ws *WebhookSink) post(msg webhookMessage) error {
+	req, err := http.NewRequest(http.MethodPost, ws.url, bytes.NewReader(msg.message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTopicHeader, msg.topic)
+	req.Header.Set(WebhookKeyHeader, string(msg.key))
+	if len(ws.secret) > 0 {
+		mac := hmac.New(sha256.New, ws.secret)
+		mac.Write(msg.message)
+		req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -124,9 +124,8 @@ func (fc *FaucetSmartContract) updateSettings(
 	balances c_state.StateContextI,
 	gn *GlobalNode,
 ) (string, error) {
-	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
-		return gn.FaucetConfig.OwnerId == t.ClientID
-	}); err != nil {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings",
+		gn.FaucetConfig.OwnerId, t.ClientID); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,35 @@
+package govsc
+
+import (
+	"0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/rest"
+)
+
+func BenchmarkRestTests(
+	data benchmark.BenchData, _ benchmark.SignatureScheme,
+) benchmark.TestSuite {
+	rh := rest.NewRestHandler(&rest.TestQueryChainer{})
+	grh := NewGovernanceRestHandler(rh)
+	return benchmark.GetRestTests(
+		[]benchmark.TestParameters{
+			{
+				FuncName: "governance-config",
+				Endpoint: grh.getConfig,
+			},
+			{
+				FuncName: "getProposal",
+				Params: map[string]string{
+					"proposal_id": getMockProposalId(0),
+				},
+				Endpoint: grh.getProposal,
+			},
+			{
+				FuncName: "getActiveProposals",
+				Endpoint: grh.getActiveProposals,
+			},
+		},
+		ADDRESS,
+		grh,
+		benchmark.GovernanceRest,
+	)
+}
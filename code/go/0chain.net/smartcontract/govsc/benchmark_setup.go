@@ -0,0 +1,99 @@
+package govsc
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/0chain/common/core/currency"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/core/viper"
+	"0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+func AddMockConfig(balances cstate.StateContextI) {
+	var (
+		conf config
+		err  error
+	)
+	conf.MinProposalStake, err = currency.ParseZCN(viper.GetFloat64(benchmark.GovernanceMinProposalStake))
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf.Quorum, err = currency.ParseZCN(viper.GetFloat64(benchmark.GovernanceQuorum))
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf.VotingPeriod = viper.GetDuration(benchmark.GovernanceVotingPeriod)
+	conf.Timelock = viper.GetDuration(benchmark.GovernanceTimelock)
+	conf.Threshold = viper.GetFloat64(benchmark.GovernanceThreshold)
+	conf.MaxActiveProposals = viper.GetInt(benchmark.GovernanceMaxActiveProposals)
+	conf.MaxVoters = viper.GetInt(benchmark.GovernanceMaxVoters)
+	conf.MaxVotePools = viper.GetInt(benchmark.GovernanceMaxVotePools)
+	conf.MaxDescriptionLength = viper.GetInt(benchmark.GovernanceMaxDescriptionLength)
+	conf.MinVoteStake, err = currency.ParseZCN(viper.GetFloat64(benchmark.GovernanceMinVoteStake))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), &conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// AddMockProposals adds active proposals voted by the delegates of the first
+// miner, half of them with an ended voting
+func AddMockProposals(
+	clients, miners []string,
+	balances cstate.StateContextI,
+) {
+	var (
+		now          = common.Timestamp(viper.GetInt64(benchmark.MptCreationTime))
+		votingPeriod = toSeconds(viper.GetDuration(benchmark.GovernanceVotingPeriod))
+		numVoters    = viper.GetInt(benchmark.NumMinerDelegates)
+		pools        = []ProviderPool{{ProviderType: spenum.Miner, ProviderID: miners[0]}}
+		ap           activeProposals
+	)
+	// leave room for a new proposal
+	for i := 0; i < viper.GetInt(benchmark.GovernanceMaxActiveProposals)-1; i++ {
+		p := &Proposal{
+			ID:           getMockProposalId(i),
+			Creator:      clients[i%len(clients)],
+			Address:      minersc.ADDRESS,
+			FunctionName: "update_settings",
+			Changes:      map[string]string{"max_n": strconv.Itoa(100 + i)},
+			Description:  "mock proposal",
+			CreatedAt:    now,
+			VotingEndsAt: now + votingPeriod,
+			Status:       ProposalVoting,
+		}
+		if i%2 == 1 {
+			p.CreatedAt = now - votingPeriod
+			p.VotingEndsAt = now
+		}
+		for j := 0; j < numVoters && j < len(clients); j++ {
+			p.Ballots = append(p.Ballots, &Ballot{
+				Voter:   clients[j],
+				Yes:     j%3 != 0,
+				Pools:   pools,
+				VotedAt: p.CreatedAt,
+			})
+		}
+		if err := p.save(balances); err != nil {
+			log.Fatal(err)
+		}
+		ap.IDs = append(ap.IDs, p.ID)
+	}
+	if err := ap.save(balances); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func getMockProposalId(i int) string {
+	return encryption.Hash("mock proposal" + strconv.Itoa(i))
+}
@@ -0,0 +1,150 @@
+package govsc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	bk "0chain.net/smartcontract/benchmark"
+	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+type BenchTest struct {
+	name     string
+	endpoint func(
+		*transaction.Transaction,
+		[]byte,
+		cstate.StateContextI,
+	) (string, error)
+	txn   *transaction.Transaction
+	input []byte
+}
+
+func (bt BenchTest) Name() string {
+	return bt.name
+}
+
+func (bt BenchTest) Transaction() *transaction.Transaction {
+	return &transaction.Transaction{
+		HashIDField: datastore.HashIDField{
+			Hash: bt.txn.Hash,
+		},
+		ClientID:     bt.txn.ClientID,
+		ToClientID:   bt.txn.ToClientID,
+		CreationDate: bt.txn.CreationDate,
+	}
+}
+
+func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, _ *testing.B) error {
+	_, err := bt.endpoint(bt.Transaction(), bt.input, balances)
+	return err
+}
+
+func BenchmarkTests(
+	data bk.BenchData, _ bk.SignatureScheme,
+) bk.TestSuite {
+	creationTimeRaw := viper.GetInt64(bk.MptCreationTime)
+	creationTime := common.Now()
+	if creationTimeRaw != 0 {
+		creationTime = common.Timestamp(creationTimeRaw)
+	}
+
+	var gsc = GovernanceSmartContract{
+		SmartContract: sci.NewSC(ADDRESS),
+	}
+	gsc.setSC(gsc.SmartContract, &smartcontract.BCContext{})
+
+	pools := []ProviderPool{{ProviderType: spenum.Miner, ProviderID: data.Miners[0]}}
+	marshal := func(v interface{}) []byte {
+		b, _ := json.Marshal(v)
+		return b
+	}
+
+	var tests = []BenchTest{
+		{
+			name:     "governance.create_proposal",
+			endpoint: gsc.createProposal,
+			txn: &transaction.Transaction{
+				HashIDField:  datastore.HashIDField{Hash: encryption.Hash("new proposal")},
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: marshal(&createProposalRequest{
+				Address:      minersc.ADDRESS,
+				FunctionName: "update_settings",
+				Changes:      map[string]string{"max_n": "100"},
+				Description:  "benchmark proposal",
+				Pools:        pools,
+			}),
+		},
+		{
+			name:     "governance.vote",
+			endpoint: gsc.vote,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: marshal(&voteRequest{
+				ProposalID: getMockProposalId(2),
+				Yes:        true,
+				Pools:      pools,
+			}),
+		},
+		{
+			name:     "governance.cancel_proposal",
+			endpoint: gsc.cancelProposal,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: marshal(&proposalRequest{
+				ProposalID: getMockProposalId(0),
+			}),
+		},
+		{
+			name:     "governance.apply_proposals",
+			endpoint: gsc.applyProposals,
+			txn: &transaction.Transaction{
+				ClientID:     data.Miners[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: []byte("{}"),
+		},
+		{
+			name:     "governance.update_settings",
+			endpoint: gsc.updateSettings,
+			txn: &transaction.Transaction{
+				ClientID:     ADDRESS,
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: marshal(&config2.StringMap{
+				Fields: map[string]string{
+					Settings[MaxVoters]: "100",
+				},
+			}),
+		},
+	}
+	var testsI []bk.BenchTestI
+	for _, test := range tests {
+		testsI = append(testsI, test)
+	}
+	return bk.TestSuite{
+		Source:     bk.Governance,
+		Benchmarks: testsI,
+	}
+}
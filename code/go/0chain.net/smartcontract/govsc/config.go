@@ -0,0 +1,337 @@
+package govsc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+type Setting int
+
+const (
+	MinProposalStake Setting = iota
+	VotingPeriod
+	Timelock
+	Quorum
+	Threshold
+	MaxActiveProposals
+	MaxVoters
+	MaxVotePools
+	MaxDescriptionLength
+	MinVoteStake
+	Cost
+)
+
+var (
+	Settings = []string{
+		"min_proposal_stake",
+		"voting_period",
+		"timelock",
+		"quorum",
+		"threshold",
+		"max_active_proposals",
+		"max_voters",
+		"max_vote_pools",
+		"max_description_length",
+		"min_vote_stake",
+		"cost",
+	}
+
+	costFunctions = []string{
+		"create_proposal",
+		"vote",
+		"cancel_proposal",
+		"apply_proposals",
+		"update_settings",
+	}
+)
+
+func scConfigKey(scKey string) datastore.Key {
+	return scKey + encryption.Hash("govsc_config")
+}
+
+// config represents SC configurations ('govsc:' from sc.yaml)
+type config struct {
+	// MinProposalStake is the stake the creator of a proposal needs
+	MinProposalStake currency.Coin `json:"min_proposal_stake"`
+	VotingPeriod     time.Duration `json:"voting_period"`
+	// Timelock is the delay between the end of the voting and the
+	// application of a passed proposal
+	Timelock time.Duration `json:"timelock"`
+	// Quorum is the stake that must vote for a proposal to pass
+	Quorum currency.Coin `json:"quorum"`
+	// Threshold is the ratio of the voted stake that must vote yes
+	Threshold            float64 `json:"threshold"`
+	MaxActiveProposals   int     `json:"max_active_proposals"`
+	MaxVoters            int     `json:"max_voters"`
+	MaxVotePools         int     `json:"max_vote_pools"`
+	MaxDescriptionLength int     `json:"max_description_length"`
+	// MinVoteStake is the stake a voter needs, the ballots of a proposal
+	// being limited to MaxVoters
+	MinVoteStake currency.Coin  `json:"min_vote_stake"`
+	Cost         map[string]int `json:"cost"`
+}
+
+func (c *config) validate() (err error) {
+	switch {
+	case toSeconds(c.VotingPeriod) < 1:
+		return errors.New("invalid voting_period (< 1s)")
+	case c.Timelock < 0:
+		return errors.New("invalid timelock (< 0)")
+	case c.Quorum == 0:
+		return errors.New("invalid quorum (0)")
+	case c.Threshold <= 0.5 || c.Threshold > 1:
+		return errors.New("invalid threshold, not in (0.5; 1]")
+	case c.MaxActiveProposals < 1:
+		return errors.New("invalid max_active_proposals (< 1)")
+	case c.MaxVoters < 1:
+		return errors.New("invalid max_voters (< 1)")
+	case c.MaxVotePools < 1:
+		return errors.New("invalid max_vote_pools (< 1)")
+	case c.MaxDescriptionLength < 1:
+		return errors.New("invalid max_description_length (< 1)")
+	}
+	return
+}
+
+func (c *config) update(changes *config2.StringMap) error {
+	for key, value := range changes.Fields {
+		switch key {
+		case Settings[MinProposalStake], Settings[Quorum], Settings[MinVoteStake]:
+			fValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to currency.Coin, "+
+					"failing to set config key %s", value, key)
+			}
+			cValue, err := currency.ParseZCN(fValue)
+			if err != nil {
+				return err
+			}
+			switch key {
+			case Settings[Quorum]:
+				c.Quorum = cValue
+			case Settings[MinVoteStake]:
+				c.MinVoteStake = cValue
+			default:
+				c.MinProposalStake = cValue
+			}
+		case Settings[VotingPeriod], Settings[Timelock]:
+			dValue, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to time.Duration, "+
+					"failing to set config key %s", value, key)
+			}
+			if key == Settings[Timelock] {
+				c.Timelock = dValue
+			} else {
+				c.VotingPeriod = dValue
+			}
+		case Settings[Threshold]:
+			fValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to float64, "+
+					"failing to set config key %s", value, key)
+			}
+			c.Threshold = fValue
+		case Settings[MaxActiveProposals], Settings[MaxVoters],
+			Settings[MaxVotePools], Settings[MaxDescriptionLength]:
+			iValue, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to int, "+
+					"failing to set config key %s", value, key)
+			}
+			switch key {
+			case Settings[MaxActiveProposals]:
+				c.MaxActiveProposals = iValue
+			case Settings[MaxVoters]:
+				c.MaxVoters = iValue
+			case Settings[MaxVotePools]:
+				c.MaxVotePools = iValue
+			default:
+				c.MaxDescriptionLength = iValue
+			}
+		default:
+			if err := c.setCostValue(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *config) setCostValue(key, value string) error {
+	if !strings.HasPrefix(key, Settings[Cost]) {
+		return fmt.Errorf("config setting %s not found", key)
+	}
+
+	costKey := strings.ToLower(strings.TrimPrefix(key, Settings[Cost]+"."))
+	for _, costFunction := range costFunctions {
+		if costKey != strings.ToLower(costFunction) {
+			continue
+		}
+		costValue, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("key %s, unable to convert %v to integer", key, value)
+		}
+
+		if costValue < 0 {
+			return fmt.Errorf("cost.%s contains invalid value %s", key, value)
+		}
+
+		if c.Cost == nil {
+			c.Cost = make(map[string]int)
+		}
+		c.Cost[costKey] = costValue
+
+		return nil
+	}
+
+	return fmt.Errorf("cost config setting %s not found", costKey)
+}
+
+func (c *config) getConfigMap() config2.StringMap {
+	fields := map[string]string{
+		Settings[MinProposalStake]:     fmt.Sprintf("%v", float64(c.MinProposalStake)/1e10),
+		Settings[VotingPeriod]:         fmt.Sprintf("%v", c.VotingPeriod),
+		Settings[Timelock]:             fmt.Sprintf("%v", c.Timelock),
+		Settings[Quorum]:               fmt.Sprintf("%v", float64(c.Quorum)/1e10),
+		Settings[Threshold]:            fmt.Sprintf("%v", c.Threshold),
+		Settings[MaxActiveProposals]:   fmt.Sprintf("%v", c.MaxActiveProposals),
+		Settings[MaxVoters]:            fmt.Sprintf("%v", c.MaxVoters),
+		Settings[MaxVotePools]:         fmt.Sprintf("%v", c.MaxVotePools),
+		Settings[MaxDescriptionLength]: fmt.Sprintf("%v", c.MaxDescriptionLength),
+		Settings[MinVoteStake]:         fmt.Sprintf("%v", float64(c.MinVoteStake)/1e10),
+	}
+
+	for _, key := range costFunctions {
+		fields[fmt.Sprintf("cost.%s", key)] = fmt.Sprintf("%0v", c.Cost[strings.ToLower(key)])
+	}
+
+	return config2.StringMap{
+		Fields: fields,
+	}
+}
+
+// updateSettings of the governance smart contract, only through a passed
+// proposal, there is no owner
+func (gsc *GovernanceSmartContract) updateSettings(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (resp string, err error) {
+	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
+		return txn.ClientID == ADDRESS
+	}); err != nil {
+		return "", err
+	}
+
+	var conf *config
+	if conf, err = getConfig(balances); err != nil {
+		return "", common.NewError("update_settings",
+			"can't get config: "+err.Error())
+	}
+
+	update := &config2.StringMap{}
+	if err = update.Decode(input); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	if err := conf.update(update); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	if err := conf.validate(); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	if err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	return "", nil
+}
+
+//
+// helpers
+//
+
+func toSeconds(dur time.Duration) common.Timestamp {
+	return common.Timestamp(dur / time.Second)
+}
+
+// configurations from sc.yaml
+func getConfiguredConfig() (conf *config, err error) {
+	const prefix = "smart_contracts.govsc."
+
+	conf = new(config)
+
+	// short hand
+	var scconf = config2.SmartContractConfig
+	conf.MinProposalStake, err = currency.ParseZCN(scconf.GetFloat64(prefix + "min_proposal_stake"))
+	if err != nil {
+		return nil, err
+	}
+	conf.VotingPeriod = scconf.GetDuration(prefix + "voting_period")
+	conf.Timelock = scconf.GetDuration(prefix + "timelock")
+	conf.Quorum, err = currency.ParseZCN(scconf.GetFloat64(prefix + "quorum"))
+	if err != nil {
+		return nil, err
+	}
+	conf.Threshold = scconf.GetFloat64(prefix + "threshold")
+	conf.MaxActiveProposals = scconf.GetInt(prefix + "max_active_proposals")
+	conf.MaxVoters = scconf.GetInt(prefix + "max_voters")
+	conf.MaxVotePools = scconf.GetInt(prefix + "max_vote_pools")
+	conf.MaxDescriptionLength = scconf.GetInt(prefix + "max_description_length")
+	conf.MinVoteStake, err = currency.ParseZCN(scconf.GetFloat64(prefix + "min_vote_stake"))
+	if err != nil {
+		return nil, err
+	}
+	conf.Cost = scconf.GetStringMapInt(prefix + "cost")
+
+	err = conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func getConfig(balances cstate.CommonStateContextI) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(ADDRESS), conf)
+	switch err {
+	case nil:
+		return conf, nil
+	case util.ErrValueNotPresent:
+		return getConfiguredConfig()
+	default:
+		return nil, err
+	}
+}
+
+func InitConfig(balances cstate.StateContextI) error {
+	err := balances.GetTrieNode(scConfigKey(ADDRESS), &config{})
+	if err == util.ErrValueNotPresent {
+		conf, err := getConfiguredConfig()
+		if err != nil {
+			return err
+		}
+		_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+		return err
+	}
+	return err
+}
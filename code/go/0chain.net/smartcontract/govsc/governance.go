@@ -0,0 +1,483 @@
+package govsc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+// create_proposal request
+type createProposalRequest struct {
+	Address      string            `json:"address"`
+	FunctionName string            `json:"function_name"`
+	Changes      map[string]string `json:"changes"`
+	Description  string            `json:"description"`
+	// Pools of the stake of the creator
+	Pools []ProviderPool `json:"pools"`
+}
+
+func (cr *createProposalRequest) decode(b []byte) error {
+	return json.Unmarshal(b, cr)
+}
+
+func (cr *createProposalRequest) validate(conf *config) error {
+	switch {
+	case !isSettingsFunction(cr.Address, cr.FunctionName):
+		return fmt.Errorf("%s is not a settings function of smart contract %s",
+			cr.FunctionName, cr.Address)
+	case len(cr.Changes) == 0:
+		return errors.New("no settings changes")
+	case len(cr.Description) > conf.MaxDescriptionLength:
+		return errors.New("description is too long")
+	}
+	return validatePools(cr.Pools, conf.MaxVotePools)
+}
+
+// vote request
+type voteRequest struct {
+	ProposalID string `json:"proposal_id"`
+	Yes        bool   `json:"yes"`
+	// Pools of the stake of the voter
+	Pools []ProviderPool `json:"pools"`
+}
+
+func (vr *voteRequest) decode(b []byte) error {
+	return json.Unmarshal(b, vr)
+}
+
+// cancel_proposal request
+type proposalRequest struct {
+	ProposalID string `json:"proposal_id"`
+}
+
+func (pr *proposalRequest) decode(b []byte) error {
+	return json.Unmarshal(b, pr)
+}
+
+// create a proposal of settings changes of a smart contract, the creator
+// votes yes with the stake of the pools
+func (gsc *GovernanceSmartContract) createProposal(t *transaction.Transaction,
+	input []byte, balances cstate.StateContextI) (string, error) {
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"can't get config: "+err.Error())
+	}
+
+	var cr createProposalRequest
+	if err = cr.decode(input); err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"malformed request: "+err.Error())
+	}
+	if err = cr.validate(conf); err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"invalid request: "+err.Error())
+	}
+
+	stake, err := getStake(cr.Pools, t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"can't get stake: "+err.Error())
+	}
+	if stake < conf.MinProposalStake {
+		return "", common.NewError("create_proposal_failed",
+			fmt.Sprintf("not enough stake in the pools: %v < %v", stake, conf.MinProposalStake))
+	}
+
+	ap, err := getActiveProposals(balances)
+	if err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"can't get active proposals: "+err.Error())
+	}
+	if len(ap.IDs) >= conf.MaxActiveProposals {
+		return "", common.NewError("create_proposal_failed",
+			"too many active proposals")
+	}
+
+	p := &Proposal{
+		ID:           t.Hash,
+		Creator:      t.ClientID,
+		Address:      cr.Address,
+		FunctionName: cr.FunctionName,
+		Changes:      cr.Changes,
+		Description:  cr.Description,
+		CreatedAt:    t.CreationDate,
+		VotingEndsAt: t.CreationDate + toSeconds(conf.VotingPeriod),
+		Status:       ProposalVoting,
+		Ballots: []*Ballot{{
+			Voter:   t.ClientID,
+			Yes:     true,
+			Pools:   cr.Pools,
+			VotedAt: t.CreationDate,
+		}},
+	}
+	if err = p.save(balances); err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	ap.IDs = append(ap.IDs, p.ID)
+	if err = ap.save(balances); err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"saving active proposals: "+err.Error())
+	}
+
+	return string(p.Encode()), nil
+}
+
+// vote for or against a proposal with the stake of the pools, a new vote of
+// the same stakeholder replaces the previous one
+func (gsc *GovernanceSmartContract) vote(t *transaction.Transaction,
+	input []byte, balances cstate.StateContextI) (string, error) {
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get config: "+err.Error())
+	}
+
+	var vr voteRequest
+	if err = vr.decode(input); err != nil {
+		return "", common.NewError("vote_failed",
+			"malformed request: "+err.Error())
+	}
+	if err = validatePools(vr.Pools, conf.MaxVotePools); err != nil {
+		return "", common.NewError("vote_failed",
+			"invalid request: "+err.Error())
+	}
+
+	p, err := getProposal(vr.ProposalID, balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get proposal: "+err.Error())
+	}
+	if p.Status != ProposalVoting || t.CreationDate >= p.VotingEndsAt {
+		return "", common.NewError("vote_failed", "voting is closed")
+	}
+
+	stake, err := getStake(vr.Pools, t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get stake: "+err.Error())
+	}
+	if stake == 0 {
+		return "", common.NewError("vote_failed", "no stake in the pools")
+	}
+	if stake < conf.MinVoteStake {
+		return "", common.NewError("vote_failed",
+			fmt.Sprintf("not enough stake in the pools: %v < %v", stake, conf.MinVoteStake))
+	}
+
+	b := &Ballot{
+		Voter:   t.ClientID,
+		Yes:     vr.Yes,
+		Pools:   vr.Pools,
+		VotedAt: t.CreationDate,
+	}
+	if i, _ := p.ballot(t.ClientID); i >= 0 {
+		p.Ballots[i] = b
+	} else {
+		if len(p.Ballots) >= conf.MaxVoters {
+			return "", common.NewError("vote_failed", "too many voters")
+		}
+		p.Ballots = append(p.Ballots, b)
+	}
+
+	if err = p.save(balances); err != nil {
+		return "", common.NewError("vote_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	return string(p.Encode()), nil
+}
+
+// cancel a proposal by its creator while voting
+func (gsc *GovernanceSmartContract) cancelProposal(t *transaction.Transaction,
+	input []byte, balances cstate.StateContextI) (string, error) {
+
+	var pr proposalRequest
+	if err := pr.decode(input); err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"malformed request: "+err.Error())
+	}
+
+	p, err := getProposal(pr.ProposalID, balances)
+	if err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"can't get proposal: "+err.Error())
+	}
+	if p.Creator != t.ClientID {
+		return "", common.NewError("cancel_proposal_failed",
+			"only the creator can cancel the proposal")
+	}
+	if p.Status != ProposalVoting || t.CreationDate >= p.VotingEndsAt {
+		return "", common.NewError("cancel_proposal_failed", "voting is closed")
+	}
+
+	p.Status = ProposalCancelled
+	if err = p.save(balances); err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	ap, err := getActiveProposals(balances)
+	if err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"can't get active proposals: "+err.Error())
+	}
+	ap.remove(p.ID)
+	if err = ap.save(balances); err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"saving active proposals: "+err.Error())
+	}
+
+	return string(p.Encode()), nil
+}
+
+// apply_proposals tallies the ended votings and applies the passed proposals
+// once the timelock is over. It's a build-in transaction of the miners, but
+// anyone can trigger it.
+func (gsc *GovernanceSmartContract) applyProposals(t *transaction.Transaction,
+	_ []byte, balances cstate.StateContextI) (string, error) {
+
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewError("apply_proposals_failed",
+			"can't get config: "+err.Error())
+	}
+
+	ap, err := getActiveProposals(balances)
+	if err != nil {
+		return "", common.NewError("apply_proposals_failed",
+			"can't get active proposals: "+err.Error())
+	}
+
+	var (
+		now     = t.CreationDate
+		active  = make([]string, 0, len(ap.IDs))
+		updated []*Proposal
+	)
+	for _, id := range ap.IDs {
+		p, err := getProposal(id, balances)
+		if err != nil {
+			return "", common.NewError("apply_proposals_failed",
+				"can't get proposal: "+err.Error())
+		}
+
+		changed := false
+		if p.Status == ProposalVoting && now >= p.VotingEndsAt {
+			if err := p.tally(conf, balances); err != nil {
+				return "", common.NewError("apply_proposals_failed",
+					"tally proposal "+p.ID+": "+err.Error())
+			}
+			changed = true
+		}
+		if p.Status == ProposalPassed && now >= p.ExecutableAt {
+			if err := gsc.execute(t, p, balances); err != nil {
+				if cstate.ErrInvalidState(err) {
+					return "", err
+				}
+				logging.Logger.Info("governance - proposal failed",
+					zap.String("proposal", p.ID),
+					zap.Error(err))
+				p.Status = ProposalFailed
+				p.Error = err.Error()
+			} else {
+				p.Status = ProposalApplied
+			}
+			changed = true
+		}
+
+		if changed {
+			if err := p.save(balances); err != nil {
+				return "", common.NewError("apply_proposals_failed",
+					"saving proposal: "+err.Error())
+			}
+			updated = append(updated, p)
+		}
+		if !p.isFinished() {
+			active = append(active, p.ID)
+		}
+	}
+
+	if len(updated) == 0 {
+		return "", nil
+	}
+
+	ap.IDs = active
+	if err = ap.save(balances); err != nil {
+		return "", common.NewError("apply_proposals_failed",
+			"saving active proposals: "+err.Error())
+	}
+
+	b, err := json.Marshal(updated)
+	if err != nil {
+		return "", common.NewError("apply_proposals_failed", err.Error())
+	}
+	return string(b), nil
+}
+
+// errClientStateChange is returned when a settings update changes a client state
+var errClientStateChange = errors.New("settings update can't change client states")
+
+// proposalStateContext is the state context of a settings update applied by
+// the governance smart contract, it can't transfer tokens. The trie nodes
+// changes and the events are buffered and only applied to the state once the
+// update succeeded, a failed update doesn't change the state.
+type proposalStateContext struct {
+	cstate.StateContextI
+	txn *transaction.Transaction
+
+	// nodes are the serialized values of the changed trie nodes, nil when
+	// deleted
+	nodes map[datastore.Key][]byte
+	// changes are the changes to apply, in order
+	changes []func() error
+}
+
+func newProposalStateContext(balances cstate.StateContextI,
+	txn *transaction.Transaction) *proposalStateContext {
+	return &proposalStateContext{
+		StateContextI: balances,
+		txn:           txn,
+		nodes:         make(map[datastore.Key][]byte),
+	}
+}
+
+func (pc *proposalStateContext) GetTransaction() *transaction.Transaction {
+	return pc.txn
+}
+
+func (pc *proposalStateContext) AddTransfer(*state.Transfer) error {
+	return state.ErrInvalidTransfer
+}
+
+func (pc *proposalStateContext) AddMint(*state.Mint) error {
+	return state.ErrInvalidMint
+}
+
+func (pc *proposalStateContext) SetClientState(datastore.Key, *state.State) (util.Key, error) {
+	return nil, errClientStateChange
+}
+
+func (pc *proposalStateContext) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	b, ok := pc.nodes[key]
+	if !ok {
+		return pc.StateContextI.GetTrieNode(key, v)
+	}
+	if b == nil {
+		return util.ErrValueNotPresent
+	}
+	_, err := v.UnmarshalMsg(b)
+	return err
+}
+
+func (pc *proposalStateContext) InsertTrieNode(key datastore.Key, v util.MPTSerializable) (datastore.Key, error) {
+	b, err := v.MarshalMsg(nil)
+	if err != nil {
+		return "", err
+	}
+	pc.nodes[key] = b
+	pc.changes = append(pc.changes, func() error {
+		_, err := pc.StateContextI.InsertTrieNode(key, v)
+		return err
+	})
+	return key, nil
+}
+
+func (pc *proposalStateContext) DeleteTrieNode(key datastore.Key) (datastore.Key, error) {
+	pc.nodes[key] = nil
+	pc.changes = append(pc.changes, func() error {
+		_, err := pc.StateContextI.DeleteTrieNode(key)
+		return err
+	})
+	return key, nil
+}
+
+func (pc *proposalStateContext) EmitEvent(eventType event.EventType, eventTag event.EventTag,
+	index string, data interface{}, appender ...cstate.Appender) {
+	pc.changes = append(pc.changes, func() error {
+		pc.StateContextI.EmitEvent(eventType, eventTag, index, data, appender...)
+		return nil
+	})
+}
+
+func (pc *proposalStateContext) EmitEventWithVersion(eventVersion event.EventVersion,
+	eventType event.EventType, eventTag event.EventTag, index string, data interface{},
+	appender ...cstate.Appender) {
+	pc.changes = append(pc.changes, func() error {
+		pc.StateContextI.EmitEventWithVersion(eventVersion, eventType, eventTag, index, data, appender...)
+		return nil
+	})
+}
+
+func (pc *proposalStateContext) EmitError(err error) {
+	pc.changes = append(pc.changes, func() error {
+		pc.StateContextI.EmitError(err)
+		return nil
+	})
+}
+
+// commit applies the buffered changes to the state
+func (pc *proposalStateContext) commit() error {
+	for _, apply := range pc.changes {
+		if err := apply(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute the settings update function of the passed proposal as the
+// governance smart contract. The changes of the update are applied once it
+// succeeded, a failed update doesn't change the state.
+func (gsc *GovernanceSmartContract) execute(t *transaction.Transaction, p *Proposal,
+	balances cstate.StateContextI) error {
+
+	contract := smartcontract.GetSmartContract(p.Address)
+	if contract == nil {
+		return fmt.Errorf("smart contract %s is not enabled", p.Address)
+	}
+
+	input, err := json.Marshal(&config2.StringMap{Fields: p.Changes})
+	if err != nil {
+		return err
+	}
+	scData := &transaction.SmartContractData{
+		FunctionName: p.FunctionName,
+		InputData:    input,
+	}
+	data, err := json.Marshal(scData)
+	if err != nil {
+		return err
+	}
+
+	txn := &transaction.Transaction{
+		HashIDField:       datastore.HashIDField{Hash: t.Hash},
+		ClientID:          ADDRESS,
+		ToClientID:        p.Address,
+		TransactionData:   string(data),
+		CreationDate:      t.CreationDate,
+		TransactionType:   transaction.TxnTypeSmartContract,
+		SmartContractData: scData,
+	}
+
+	pc := newProposalStateContext(balances, txn)
+	if _, err := smartcontract.ExecuteWithStats(contract, txn, pc); err != nil {
+		return err
+	}
+	return pc.commit()
+}
@@ -0,0 +1,419 @@
+package govsc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logging.Logger = zap.NewNop()
+}
+
+const (
+	testMiner = "miner"
+	voterA    = "voter_a"
+	voterB    = "voter_b"
+	voterC    = "voter_c"
+	voterD    = "voter_d"
+)
+
+type testBalances struct {
+	cstate.StateContextI
+	tree   map[datastore.Key][]byte
+	events []event.Event
+}
+
+func newTestBalances() *testBalances {
+	return &testBalances{tree: make(map[datastore.Key][]byte)}
+}
+
+func (tb *testBalances) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	b, ok := tb.tree[key]
+	if !ok {
+		return util.ErrValueNotPresent
+	}
+	_, err := v.UnmarshalMsg(b)
+	return err
+}
+
+func (tb *testBalances) InsertTrieNode(key datastore.Key, v util.MPTSerializable) (datastore.Key, error) {
+	b, err := v.MarshalMsg(nil)
+	if err != nil {
+		return "", err
+	}
+	tb.tree[key] = b
+	return key, nil
+}
+
+func (tb *testBalances) EmitEvent(eventType event.EventType, eventTag event.EventTag,
+	index string, data interface{}, _ ...cstate.Appender) {
+	tb.events = append(tb.events, event.Event{Type: eventType, Tag: eventTag, Index: index, Data: data})
+}
+
+// testSettingsKey is the key of the settings saved by the test contract
+var testSettingsKey = encryption.Hash("test_settings")
+
+// testContract records and saves the settings updates and fails them on
+// demand, before or after saving them
+type testContract struct {
+	sci.SmartContractInterface
+	txns       []*transaction.Transaction
+	changes    []map[string]string
+	err        error
+	partialErr error
+}
+
+func (tc *testContract) Execute(t *transaction.Transaction, function string, input []byte,
+	balances cstate.StateContextI) (string, error) {
+	if tc.err != nil {
+		return "", tc.err
+	}
+	var changes config2.StringMap
+	if err := changes.Decode(input); err != nil {
+		return "", err
+	}
+	if _, err := balances.InsertTrieNode(testSettingsKey, &changes); err != nil {
+		return "", err
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUpdateMiner, testMiner, changes.Fields)
+	if tc.partialErr != nil {
+		return "", tc.partialErr
+	}
+	tc.txns = append(tc.txns, t)
+	tc.changes = append(tc.changes, changes.Fields)
+	return "", nil
+}
+
+func (tc *testContract) GetExecutionStats() map[string]interface{} {
+	return nil
+}
+
+func setupGovernance(t *testing.T) (*GovernanceSmartContract, *testBalances, *testContract) {
+	contract := &testContract{}
+	smartcontract.ContractMap[minersc.ADDRESS] = contract
+	t.Cleanup(func() { delete(smartcontract.ContractMap, minersc.ADDRESS) })
+
+	balances := newTestBalances()
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), &config{
+		MinProposalStake:     10,
+		VotingPeriod:         100 * time.Second,
+		Timelock:             50 * time.Second,
+		Quorum:               50,
+		Threshold:            0.66,
+		MaxActiveProposals:   2,
+		MaxVoters:            3,
+		MaxVotePools:         2,
+		MaxDescriptionLength: 20,
+		MinVoteStake:         5,
+	})
+	require.NoError(t, err)
+
+	setStake(t, balances, map[string]currency.Coin{voterA: 60, voterB: 30, voterC: 10})
+
+	gsc := &GovernanceSmartContract{SmartContract: sci.NewSC(ADDRESS)}
+	gsc.setSC(gsc.SmartContract, &smartcontract.BCContext{})
+	return gsc, balances, contract
+}
+
+// setStake of the delegates in the test miner, voterC's pool is pending
+func setStake(t *testing.T, balances *testBalances, stakes map[string]currency.Coin) {
+	mn := minersc.NewMinerNode()
+	mn.ID = testMiner
+	mn.ProviderType = spenum.Miner
+	for id, stake := range stakes {
+		status := spenum.Active
+		if id == voterC {
+			status = spenum.Pending
+		}
+		mn.Pools[id] = &stakepool.DelegatePool{Balance: stake, DelegateID: id, Status: status}
+	}
+	_, err := balances.InsertTrieNode(mn.GetKey(), mn)
+	require.NoError(t, err)
+}
+
+var testPools = []ProviderPool{{ProviderType: spenum.Miner, ProviderID: testMiner}}
+
+func testTxn(clientID string, now common.Timestamp) *transaction.Transaction {
+	return &transaction.Transaction{
+		HashIDField:  datastore.HashIDField{Hash: encryption.Hash(fmt.Sprintf("%s:%d", clientID, now))},
+		ClientID:     clientID,
+		ToClientID:   ADDRESS,
+		CreationDate: now,
+	}
+}
+
+func createProposal(t *testing.T, gsc *GovernanceSmartContract, balances *testBalances,
+	clientID string, now common.Timestamp, cr createProposalRequest) (*Proposal, error) {
+	input, err := json.Marshal(&cr)
+	require.NoError(t, err)
+	resp, err := gsc.createProposal(testTxn(clientID, now), input, balances)
+	if err != nil {
+		return nil, err
+	}
+	var p Proposal
+	require.NoError(t, json.Unmarshal([]byte(resp), &p))
+	return &p, nil
+}
+
+func vote(gsc *GovernanceSmartContract, balances *testBalances, clientID string,
+	now common.Timestamp, vr voteRequest) error {
+	input, _ := json.Marshal(&vr)
+	_, err := gsc.vote(testTxn(clientID, now), input, balances)
+	return err
+}
+
+func applyProposals(t *testing.T, gsc *GovernanceSmartContract, balances *testBalances, now common.Timestamp) {
+	_, err := gsc.applyProposals(testTxn("miner", now), nil, balances)
+	require.NoError(t, err)
+}
+
+func requireProposal(t *testing.T, balances *testBalances, id string) *Proposal {
+	p, err := getProposal(id, balances)
+	require.NoError(t, err)
+	return p
+}
+
+func TestGovernance(t *testing.T) {
+	gsc, balances, contract := setupGovernance(t)
+
+	cr := createProposalRequest{
+		Address:      minersc.ADDRESS,
+		FunctionName: "update_settings",
+		Changes:      map[string]string{"max_n": "100"},
+		Description:  "more miners",
+		Pools:        testPools,
+	}
+
+	t.Run("invalid proposals", func(t *testing.T) {
+		invalid := cr
+		invalid.FunctionName = "add_miner"
+		_, err := createProposal(t, gsc, balances, voterA, 0, invalid)
+		require.ErrorContains(t, err, "is not a settings function")
+
+		invalid = cr
+		invalid.Pools = append(testPools, testPools...)
+		_, err = createProposal(t, gsc, balances, voterA, 0, invalid)
+		require.ErrorContains(t, err, "duplicate stake pool")
+
+		_, err = createProposal(t, gsc, balances, voterC, 0, cr)
+		require.ErrorContains(t, err, "not enough stake in the pools")
+	})
+
+	p, err := createProposal(t, gsc, balances, voterA, 0, cr)
+	require.NoError(t, err)
+	require.Equal(t, ProposalVoting, p.Status)
+	require.Equal(t, common.Timestamp(100), p.VotingEndsAt)
+
+	require.ErrorContains(t, vote(gsc, balances, voterC, 10, voteRequest{ProposalID: p.ID, Pools: testPools}),
+		"no stake in the pools")
+	require.NoError(t, vote(gsc, balances, voterB, 10, voteRequest{ProposalID: p.ID, Pools: testPools}))
+
+	// voting is not over
+	applyProposals(t, gsc, balances, 99)
+	require.Equal(t, ProposalVoting, requireProposal(t, balances, p.ID).Status)
+
+	require.ErrorContains(t, vote(gsc, balances, voterB, 100, voteRequest{ProposalID: p.ID, Pools: testPools}),
+		"voting is closed")
+
+	// 60 yes of 90 voted
+	applyProposals(t, gsc, balances, 100)
+	p = requireProposal(t, balances, p.ID)
+	require.Equal(t, ProposalPassed, p.Status)
+	require.Equal(t, currency.Coin(60), p.YesStake)
+	require.Equal(t, currency.Coin(30), p.NoStake)
+	require.Equal(t, common.Timestamp(150), p.ExecutableAt)
+	require.Empty(t, contract.txns)
+
+	// timelock
+	applyProposals(t, gsc, balances, 149)
+	require.Empty(t, contract.txns)
+
+	applyProposals(t, gsc, balances, 150)
+	require.Equal(t, ProposalApplied, requireProposal(t, balances, p.ID).Status)
+	require.Len(t, contract.txns, 1)
+	require.Equal(t, ADDRESS, contract.txns[0].ClientID)
+	require.Equal(t, "update_settings", contract.txns[0].FunctionName)
+	require.Equal(t, cr.Changes, contract.changes[0])
+
+	var saved config2.StringMap
+	require.NoError(t, balances.GetTrieNode(testSettingsKey, &saved))
+	require.Equal(t, cr.Changes, saved.Fields)
+	require.Len(t, balances.events, 1)
+
+	ap, err := getActiveProposals(balances)
+	require.NoError(t, err)
+	require.Empty(t, ap.IDs)
+}
+
+func TestGovernanceTally(t *testing.T) {
+	gsc, balances, contract := setupGovernance(t)
+
+	cr := createProposalRequest{
+		Address:      minersc.ADDRESS,
+		FunctionName: "update_settings",
+		Changes:      map[string]string{"max_n": "100"},
+		Pools:        testPools,
+	}
+
+	t.Run("stake at the end of the voting", func(t *testing.T) {
+		p, err := createProposal(t, gsc, balances, voterA, 0, cr)
+		require.NoError(t, err)
+		require.NoError(t, vote(gsc, balances, voterB, 0, voteRequest{ProposalID: p.ID, Pools: testPools}))
+
+		setStake(t, balances, map[string]currency.Coin{voterA: 20, voterB: 30})
+		defer setStake(t, balances, map[string]currency.Coin{voterA: 60, voterB: 30})
+
+		applyProposals(t, gsc, balances, 100)
+		p = requireProposal(t, balances, p.ID)
+		require.Equal(t, ProposalRejected, p.Status)
+		require.Equal(t, currency.Coin(20), p.YesStake)
+	})
+
+	t.Run("quorum", func(t *testing.T) {
+		p, err := createProposal(t, gsc, balances, voterA, 100, cr)
+		require.NoError(t, err)
+
+		setStake(t, balances, map[string]currency.Coin{voterA: 49})
+		defer setStake(t, balances, map[string]currency.Coin{voterA: 60, voterB: 30})
+
+		applyProposals(t, gsc, balances, 200)
+		require.Equal(t, ProposalRejected, requireProposal(t, balances, p.ID).Status)
+	})
+
+	t.Run("failed update", func(t *testing.T) {
+		p, err := createProposal(t, gsc, balances, voterA, 200, cr)
+		require.NoError(t, err)
+
+		contract.err = errors.New("invalid max_n")
+		defer func() { contract.err = nil }()
+
+		applyProposals(t, gsc, balances, 350)
+		p = requireProposal(t, balances, p.ID)
+		require.Equal(t, ProposalFailed, p.Status)
+		require.Equal(t, "invalid max_n", p.Error)
+	})
+
+	t.Run("partly failed update", func(t *testing.T) {
+		p, err := createProposal(t, gsc, balances, voterA, 300, cr)
+		require.NoError(t, err)
+
+		contract.partialErr = errors.New("invalid max_n")
+		defer func() { contract.partialErr = nil }()
+
+		applyProposals(t, gsc, balances, 450)
+		p = requireProposal(t, balances, p.ID)
+		require.Equal(t, ProposalFailed, p.Status)
+		require.Equal(t, "invalid max_n", p.Error)
+
+		// the changes saved before the failure are not applied
+		var saved config2.StringMap
+		require.ErrorIs(t, balances.GetTrieNode(testSettingsKey, &saved), util.ErrValueNotPresent)
+		require.Empty(t, balances.events)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		p, err := createProposal(t, gsc, balances, voterA, 400, cr)
+		require.NoError(t, err)
+
+		input, _ := json.Marshal(&proposalRequest{ProposalID: p.ID})
+		_, err = gsc.cancelProposal(testTxn(voterB, 410), input, balances)
+		require.ErrorContains(t, err, "only the creator")
+		_, err = gsc.cancelProposal(testTxn(voterA, 410), input, balances)
+		require.NoError(t, err)
+		require.Equal(t, ProposalCancelled, requireProposal(t, balances, p.ID).Status)
+	})
+
+	t.Run("max active proposals", func(t *testing.T) {
+		_, err := createProposal(t, gsc, balances, voterA, 500, cr)
+		require.NoError(t, err)
+		_, err = createProposal(t, gsc, balances, voterB, 500, cr)
+		require.NoError(t, err)
+		_, err = createProposal(t, gsc, balances, voterA, 501, cr)
+		require.ErrorContains(t, err, "too many active proposals")
+	})
+}
+
+func TestGovernanceVoters(t *testing.T) {
+	gsc, balances, _ := setupGovernance(t)
+
+	dust := []string{"dust_1", "dust_2", "dust_3"}
+	setStake(t, balances, map[string]currency.Coin{
+		voterA: 60, voterB: 30, voterD: 5, dust[0]: 4, dust[1]: 4, dust[2]: 4,
+	})
+
+	p, err := createProposal(t, gsc, balances, voterA, 0, createProposalRequest{
+		Address:      minersc.ADDRESS,
+		FunctionName: "update_settings",
+		Changes:      map[string]string{"max_n": "100"},
+		Pools:        testPools,
+	})
+	require.NoError(t, err)
+
+	// the dust stakes can't fill the ballots
+	for _, id := range dust {
+		require.ErrorContains(t, vote(gsc, balances, id, 10, voteRequest{ProposalID: p.ID, Pools: testPools}),
+			"not enough stake in the pools")
+	}
+	require.NoError(t, vote(gsc, balances, voterB, 10, voteRequest{ProposalID: p.ID, Pools: testPools}))
+	require.NoError(t, vote(gsc, balances, voterD, 10, voteRequest{ProposalID: p.ID, Pools: testPools}))
+
+	// the ballots are full
+	setStake(t, balances, map[string]currency.Coin{voterA: 60, voterB: 30, voterD: 5, "voter_e": 50})
+	require.ErrorContains(t, vote(gsc, balances, "voter_e", 20, voteRequest{ProposalID: p.ID, Pools: testPools}),
+		"too many voters")
+
+	// a voter of the full ballots votes again
+	require.NoError(t, vote(gsc, balances, voterB, 20, voteRequest{ProposalID: p.ID, Yes: true, Pools: testPools}))
+	p = requireProposal(t, balances, p.ID)
+	require.Len(t, p.Ballots, 3)
+	_, b := p.ballot(voterB)
+	require.True(t, b.Yes)
+}
+
+func TestGovernanceUpdateSettings(t *testing.T) {
+	gsc, balances, _ := setupGovernance(t)
+
+	input, err := json.Marshal(&config2.StringMap{Fields: map[string]string{
+		Settings[Quorum]:           "1000",
+		Settings[Timelock]:         "1h",
+		Settings[MinVoteStake]:     "2",
+		"cost." + costFunctions[0]: "200",
+	}})
+	require.NoError(t, err)
+
+	_, err = gsc.updateSettings(testTxn(voterA, 0), input, balances)
+	require.ErrorContains(t, err, "unauthorized access")
+
+	txn := testTxn(voterA, 0)
+	txn.ClientID = ADDRESS
+	_, err = gsc.updateSettings(txn, input, balances)
+	require.NoError(t, err)
+
+	conf, err := getConfig(balances)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(1000e10), conf.Quorum)
+	require.Equal(t, time.Hour, conf.Timelock)
+	require.Equal(t, currency.Coin(2e10), conf.MinVoteStake)
+	require.Equal(t, 200, conf.Cost["create_proposal"])
+}
@@ -0,0 +1,101 @@
+package govsc
+
+import (
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/rest"
+)
+
+type GovernanceRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewGovernanceRestHandler(rh rest.RestHandlerI) *GovernanceRestHandler {
+	return &GovernanceRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	grh := NewGovernanceRestHandler(rh)
+	governance := "/v1/screst/" + ADDRESS
+	return []rest.Endpoint{
+		rest.MakeEndpoint(governance+"/getProposal", common.UserRateLimit(grh.getProposal)),
+		rest.MakeEndpoint(governance+"/getActiveProposals", common.UserRateLimit(grh.getActiveProposals)),
+		rest.MakeEndpoint(governance+"/governance-config", common.UserRateLimit(grh.getConfig)),
+	}
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/getProposal getProposal
+// get a governance proposal with its ballots
+//
+// parameters:
+//    +name: proposal_id
+//     description: id of the proposal
+//     required: true
+//     in: query
+//     type: string
+//
+// responses:
+//  200: Proposal
+//  400:
+//  500:
+func (grh *GovernanceRestHandler) getProposal(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("proposal_id")
+	if id == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing proposal_id"))
+		return
+	}
+
+	p, err := getProposal(id, grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get proposal"))
+		return
+	}
+	common.Respond(w, r, p, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/getActiveProposals getActiveProposals
+// get the proposals voting or waiting for the timelock
+//
+// responses:
+//  200: []Proposal
+//  500:
+func (grh *GovernanceRestHandler) getActiveProposals(w http.ResponseWriter, r *http.Request) {
+	balances := grh.GetQueryStateContext()
+	ap, err := getActiveProposals(balances)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get active proposals", err.Error()))
+		return
+	}
+
+	proposals := make([]*Proposal, 0, len(ap.IDs))
+	for _, id := range ap.IDs {
+		p, err := getProposal(id, balances)
+		if err != nil {
+			common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get proposal"))
+			return
+		}
+		proposals = append(proposals, p)
+	}
+	common.Respond(w, r, proposals, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e1/governance-config governance-config
+// get governance configuration settings
+//
+// responses:
+//  200: StringMap
+//  500:
+func (grh *GovernanceRestHandler) getConfig(w http.ResponseWriter, r *http.Request) {
+	conf, err := getConfig(grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get config", err.Error()))
+		return
+	}
+	common.Respond(w, r, conf.getConfigMap(), nil)
+}
@@ -0,0 +1,285 @@
+package govsc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"0chain.net/smartcontract/storagesc"
+	"0chain.net/smartcontract/vestingsc"
+	"0chain.net/smartcontract/zcnsc"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// ProposalStatus is the stage of a proposal
+type ProposalStatus string
+
+const (
+	// ProposalVoting - the stakeholders are voting
+	ProposalVoting ProposalStatus = "voting"
+	// ProposalPassed - waiting for the timelock to be applied
+	ProposalPassed ProposalStatus = "passed"
+	// ProposalRejected - the quorum or the threshold was not reached
+	ProposalRejected ProposalStatus = "rejected"
+	// ProposalApplied - the settings changes were applied
+	ProposalApplied ProposalStatus = "applied"
+	// ProposalFailed - the settings update of the target contract failed
+	ProposalFailed ProposalStatus = "failed"
+	// ProposalCancelled - cancelled by the creator while voting
+	ProposalCancelled ProposalStatus = "cancelled"
+)
+
+// settingsFunctions are the settings update functions of the smart contracts
+// a proposal can target
+var settingsFunctions = map[string][]string{
+	minersc.ADDRESS:   {"update_settings", "update_globals"},
+	storagesc.ADDRESS: {"update_settings"},
+	faucetsc.ADDRESS:  {"update-settings"},
+	vestingsc.ADDRESS: {"vestingsc-update-settings"},
+	zcnsc.ADDRESS:     {zcnsc.UpdateGlobalConfigFunc},
+	ADDRESS:           {"update_settings"},
+}
+
+func isSettingsFunction(address, functionName string) bool {
+	for _, name := range settingsFunctions[address] {
+		if name == functionName {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderPool is a miner, sharder or blobber stake pool of a stakeholder
+type ProviderPool struct {
+	ProviderType spenum.Provider `json:"provider_type"`
+	ProviderID   string          `json:"provider_id"`
+}
+
+func (pp ProviderPool) validate() error {
+	switch pp.ProviderType {
+	case spenum.Miner, spenum.Sharder, spenum.Blobber:
+	default:
+		return fmt.Errorf("invalid provider type %s", pp.ProviderType)
+	}
+	if pp.ProviderID == "" {
+		return errors.New("missing provider id")
+	}
+	return nil
+}
+
+func validatePools(pools []ProviderPool, max int) error {
+	if len(pools) == 0 {
+		return errors.New("no stake pools")
+	}
+	if len(pools) > max {
+		return fmt.Errorf("too many stake pools, max %d", max)
+	}
+	seen := make(map[ProviderPool]struct{}, len(pools))
+	for _, pp := range pools {
+		if err := pp.validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[pp]; ok {
+			return fmt.Errorf("duplicate stake pool %s %s", pp.ProviderType, pp.ProviderID)
+		}
+		seen[pp] = struct{}{}
+	}
+	return nil
+}
+
+// getStakePoolBalance of the delegate in the stake pool of the provider. The
+// inactive and missing pools have no stake.
+func getStakePoolBalance(pp ProviderPool, delegateID string, balances cstate.CommonStateContextI) (
+	currency.Coin, error) {
+	var (
+		sp  *stakepool.StakePool
+		err error
+	)
+	switch pp.ProviderType {
+	case spenum.Miner, spenum.Sharder:
+		sp, err = minersc.GetStakePool(pp.ProviderType, pp.ProviderID, balances)
+	case spenum.Blobber:
+		sp, err = storagesc.GetStakePool(pp.ProviderType, pp.ProviderID, balances)
+	default:
+		return 0, fmt.Errorf("invalid provider type %s", pp.ProviderType)
+	}
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return 0, nil
+	default:
+		return 0, err
+	}
+
+	dp, ok := sp.Pools[delegateID]
+	if !ok || dp.Status != spenum.Active {
+		return 0, nil
+	}
+	return dp.Balance, nil
+}
+
+// getStake of the delegate in the stake pools
+func getStake(pools []ProviderPool, delegateID string, balances cstate.CommonStateContextI) (
+	currency.Coin, error) {
+	var stake currency.Coin
+	for _, pp := range pools {
+		balance, err := getStakePoolBalance(pp, delegateID, balances)
+		if err != nil {
+			return 0, err
+		}
+		if stake, err = currency.AddCoin(stake, balance); err != nil {
+			return 0, err
+		}
+	}
+	return stake, nil
+}
+
+// Ballot of a stakeholder, weighted by the stake of the voter in the pools
+type Ballot struct {
+	Voter   string           `json:"voter"`
+	Yes     bool             `json:"yes"`
+	Pools   []ProviderPool   `json:"pools"`
+	VotedAt common.Timestamp `json:"voted_at"`
+}
+
+// Proposal of settings changes of a smart contract
+type Proposal struct {
+	ID           string            `json:"id"`
+	Creator      string            `json:"creator"`
+	Address      string            `json:"address"`
+	FunctionName string            `json:"function_name"`
+	Changes      map[string]string `json:"changes"`
+	Description  string            `json:"description"`
+	CreatedAt    common.Timestamp  `json:"created_at"`
+	VotingEndsAt common.Timestamp  `json:"voting_ends_at"`
+	// ExecutableAt is the end of the timelock of a passed proposal
+	ExecutableAt common.Timestamp `json:"executable_at"`
+	Status       ProposalStatus   `json:"status"`
+	Ballots      []*Ballot        `json:"ballots"`
+	// YesStake and NoStake are the stakes of the voters at the end of the voting
+	YesStake currency.Coin `json:"yes_stake"`
+	NoStake  currency.Coin `json:"no_stake"`
+	// Error of the settings update of a failed proposal
+	Error string `json:"error,omitempty"`
+}
+
+func proposalKey(id string) datastore.Key {
+	return ADDRESS + encryption.Hash("proposal:"+id)
+}
+
+func (p *Proposal) Encode() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return b
+}
+
+func (p *Proposal) ballot(voter string) (int, *Ballot) {
+	for i, b := range p.Ballots {
+		if b.Voter == voter {
+			return i, b
+		}
+	}
+	return -1, nil
+}
+
+// tally the stakes of the ballots at the end of the voting, the stake of
+// each voter is the current one, moving stake between votes has no effect
+func (p *Proposal) tally(conf *config, balances cstate.CommonStateContextI) error {
+	var yes, no currency.Coin
+	for _, b := range p.Ballots {
+		stake, err := getStake(b.Pools, b.Voter, balances)
+		if err != nil {
+			return err
+		}
+		if b.Yes {
+			yes, err = currency.AddCoin(yes, stake)
+		} else {
+			no, err = currency.AddCoin(no, stake)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	p.YesStake, p.NoStake = yes, no
+
+	total, err := currency.AddCoin(yes, no)
+	if err != nil {
+		return err
+	}
+	if total >= conf.Quorum && float64(yes) >= conf.Threshold*float64(total) {
+		p.Status = ProposalPassed
+		p.ExecutableAt = p.VotingEndsAt + toSeconds(conf.Timelock)
+	} else {
+		p.Status = ProposalRejected
+	}
+	return nil
+}
+
+func (p *Proposal) isFinished() bool {
+	switch p.Status {
+	case ProposalVoting, ProposalPassed:
+		return false
+	}
+	return true
+}
+
+func getProposal(id string, balances cstate.CommonStateContextI) (*Proposal, error) {
+	p := new(Proposal)
+	if err := balances.GetTrieNode(proposalKey(id), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Proposal) save(balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(proposalKey(p.ID), p)
+	return err
+}
+
+// activeProposals are the ids of the proposals voting or waiting for the
+// timelock, in the creation order
+type activeProposals struct {
+	IDs []string `json:"ids"`
+}
+
+func activeProposalsKey() datastore.Key {
+	return ADDRESS + encryption.Hash("active_proposals")
+}
+
+func getActiveProposals(balances cstate.CommonStateContextI) (*activeProposals, error) {
+	ap := new(activeProposals)
+	err := balances.GetTrieNode(activeProposalsKey(), ap)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return ap, nil
+	default:
+		return nil, err
+	}
+}
+
+func (ap *activeProposals) save(balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(activeProposalsKey(), ap)
+	return err
+}
+
+func (ap *activeProposals) remove(id string) {
+	for i, pid := range ap.IDs {
+		if pid == id {
+			ap.IDs = append(ap.IDs[:i], ap.IDs[i+1:]...)
+			return
+		}
+	}
+}
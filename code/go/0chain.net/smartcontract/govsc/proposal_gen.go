@@ -0,0 +1,590 @@
+package govsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Ballot) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Voter"
+	o = append(o, 0x84, 0xa5, 0x56, 0x6f, 0x74, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Voter)
+	// string "Yes"
+	o = append(o, 0xa3, 0x59, 0x65, 0x73)
+	o = msgp.AppendBool(o, z.Yes)
+	// string "Pools"
+	o = append(o, 0xa5, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Pools)))
+	for za0001 := range z.Pools {
+		// map header, size 2
+		// string "ProviderType"
+		o = append(o, 0x82, 0xac, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65)
+		o, err = z.Pools[za0001].ProviderType.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Pools", za0001, "ProviderType")
+			return
+		}
+		// string "ProviderID"
+		o = append(o, 0xaa, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x44)
+		o = msgp.AppendString(o, z.Pools[za0001].ProviderID)
+	}
+	// string "VotedAt"
+	o = append(o, 0xa7, 0x56, 0x6f, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.VotedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "VotedAt")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Ballot) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Voter":
+			z.Voter, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Voter")
+				return
+			}
+		case "Yes":
+			z.Yes, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Yes")
+				return
+			}
+		case "Pools":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Pools")
+				return
+			}
+			if cap(z.Pools) >= int(zb0002) {
+				z.Pools = (z.Pools)[:zb0002]
+			} else {
+				z.Pools = make([]ProviderPool, zb0002)
+			}
+			for za0001 := range z.Pools {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Pools", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Pools", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "ProviderType":
+						bts, err = z.Pools[za0001].ProviderType.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Pools", za0001, "ProviderType")
+							return
+						}
+					case "ProviderID":
+						z.Pools[za0001].ProviderID, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Pools", za0001, "ProviderID")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Pools", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "VotedAt":
+			bts, err = z.VotedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VotedAt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Ballot) Msgsize() (s int) {
+	s = 1 + 6 + msgp.StringPrefixSize + len(z.Voter) + 4 + msgp.BoolSize + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Pools {
+		s += 1 + 13 + z.Pools[za0001].ProviderType.Msgsize() + 11 + msgp.StringPrefixSize + len(z.Pools[za0001].ProviderID)
+	}
+	s += 8 + z.VotedAt.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Proposal) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 14
+	// string "ID"
+	o = append(o, 0x8e, 0xa2, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ID)
+	// string "Creator"
+	o = append(o, 0xa7, 0x43, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.Creator)
+	// string "Address"
+	o = append(o, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendString(o, z.Address)
+	// string "FunctionName"
+	o = append(o, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.FunctionName)
+	// string "Changes"
+	o = append(o, 0xa7, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Changes)))
+	keys_za0001 := make([]string, 0, len(z.Changes))
+	for k := range z.Changes {
+		keys_za0001 = append(keys_za0001, k)
+	}
+	msgp.Sort(keys_za0001)
+	for _, k := range keys_za0001 {
+		za0002 := z.Changes[k]
+		o = msgp.AppendString(o, k)
+		o = msgp.AppendString(o, za0002)
+	}
+	// string "Description"
+	o = append(o, 0xab, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Description)
+	// string "CreatedAt"
+	o = append(o, 0xa9, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.CreatedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "CreatedAt")
+		return
+	}
+	// string "VotingEndsAt"
+	o = append(o, 0xac, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74)
+	o, err = z.VotingEndsAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "VotingEndsAt")
+		return
+	}
+	// string "ExecutableAt"
+	o = append(o, 0xac, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x74)
+	o, err = z.ExecutableAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ExecutableAt")
+		return
+	}
+	// string "Status"
+	o = append(o, 0xa6, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73)
+	o = msgp.AppendString(o, string(z.Status))
+	// string "Ballots"
+	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Ballots)))
+	for za0003 := range z.Ballots {
+		if z.Ballots[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Ballots[za0003].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Ballots", za0003)
+				return
+			}
+		}
+	}
+	// string "YesStake"
+	o = append(o, 0xa8, 0x59, 0x65, 0x73, 0x53, 0x74, 0x61, 0x6b, 0x65)
+	o, err = z.YesStake.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "YesStake")
+		return
+	}
+	// string "NoStake"
+	o = append(o, 0xa7, 0x4e, 0x6f, 0x53, 0x74, 0x61, 0x6b, 0x65)
+	o, err = z.NoStake.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "NoStake")
+		return
+	}
+	// string "Error"
+	o = append(o, 0xa5, 0x45, 0x72, 0x72, 0x6f, 0x72)
+	o = msgp.AppendString(o, z.Error)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "Creator":
+			z.Creator, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Creator")
+				return
+			}
+		case "Address":
+			z.Address, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "FunctionName":
+			z.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FunctionName")
+				return
+			}
+		case "Changes":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Changes")
+				return
+			}
+			if z.Changes == nil {
+				z.Changes = make(map[string]string, zb0002)
+			} else if len(z.Changes) > 0 {
+				for key := range z.Changes {
+					delete(z.Changes, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 string
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Changes")
+					return
+				}
+				za0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Changes", za0001)
+					return
+				}
+				z.Changes[za0001] = za0002
+			}
+		case "Description":
+			z.Description, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Description")
+				return
+			}
+		case "CreatedAt":
+			bts, err = z.CreatedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedAt")
+				return
+			}
+		case "VotingEndsAt":
+			bts, err = z.VotingEndsAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VotingEndsAt")
+				return
+			}
+		case "ExecutableAt":
+			bts, err = z.ExecutableAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExecutableAt")
+				return
+			}
+		case "Status":
+			{
+				var zb0003 string
+				zb0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Status")
+					return
+				}
+				z.Status = ProposalStatus(zb0003)
+			}
+		case "Ballots":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Ballots")
+				return
+			}
+			if cap(z.Ballots) >= int(zb0004) {
+				z.Ballots = (z.Ballots)[:zb0004]
+			} else {
+				z.Ballots = make([]*Ballot, zb0004)
+			}
+			for za0003 := range z.Ballots {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Ballots[za0003] = nil
+				} else {
+					if z.Ballots[za0003] == nil {
+						z.Ballots[za0003] = new(Ballot)
+					}
+					bts, err = z.Ballots[za0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Ballots", za0003)
+						return
+					}
+				}
+			}
+		case "YesStake":
+			bts, err = z.YesStake.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "YesStake")
+				return
+			}
+		case "NoStake":
+			bts, err = z.NoStake.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NoStake")
+				return
+			}
+		case "Error":
+			z.Error, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Error")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Proposal) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 8 + msgp.StringPrefixSize + len(z.Creator) + 8 + msgp.StringPrefixSize + len(z.Address) + 13 + msgp.StringPrefixSize + len(z.FunctionName) + 8 + msgp.MapHeaderSize
+	if z.Changes != nil {
+		for za0001, za0002 := range z.Changes {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.StringPrefixSize + len(za0002)
+		}
+	}
+	s += 12 + msgp.StringPrefixSize + len(z.Description) + 10 + z.CreatedAt.Msgsize() + 13 + z.VotingEndsAt.Msgsize() + 13 + z.ExecutableAt.Msgsize() + 7 + msgp.StringPrefixSize + len(string(z.Status)) + 8 + msgp.ArrayHeaderSize
+	for za0003 := range z.Ballots {
+		if z.Ballots[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Ballots[za0003].Msgsize()
+		}
+	}
+	s += 9 + z.YesStake.Msgsize() + 8 + z.NoStake.Msgsize() + 6 + msgp.StringPrefixSize + len(z.Error)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z ProposalStatus) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendString(o, string(z))
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ProposalStatus) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	{
+		var zb0001 string
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = ProposalStatus(zb0001)
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z ProposalStatus) Msgsize() (s int) {
+	s = msgp.StringPrefixSize + len(string(z))
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ProviderPool) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "ProviderType"
+	o = append(o, 0x82, 0xac, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65)
+	o, err = z.ProviderType.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ProviderType")
+		return
+	}
+	// string "ProviderID"
+	o = append(o, 0xaa, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ProviderID)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ProviderPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ProviderType":
+			bts, err = z.ProviderType.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProviderType")
+				return
+			}
+		case "ProviderID":
+			z.ProviderID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProviderID")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ProviderPool) Msgsize() (s int) {
+	s = 1 + 13 + z.ProviderType.Msgsize() + 11 + msgp.StringPrefixSize + len(z.ProviderID)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *activeProposals) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "IDs"
+	o = append(o, 0x81, 0xa3, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.IDs)))
+	for za0001 := range z.IDs {
+		o = msgp.AppendString(o, z.IDs[za0001])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *activeProposals) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "IDs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IDs")
+				return
+			}
+			if cap(z.IDs) >= int(zb0002) {
+				z.IDs = (z.IDs)[:zb0002]
+			} else {
+				z.IDs = make([]string, zb0002)
+			}
+			for za0001 := range z.IDs {
+				z.IDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "IDs", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *activeProposals) Msgsize() (s int) {
+	s = 1 + 4 + msgp.ArrayHeaderSize
+	for za0001 := range z.IDs {
+		s += msgp.StringPrefixSize + len(z.IDs[za0001])
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package govsc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+const (
+	ADDRESS = smartcontractinterface.GovernanceAddress
+	name    = "governance"
+)
+
+type GovernanceSmartContract struct {
+	*smartcontractinterface.SmartContract
+}
+
+func NewGovernanceSmartContract() smartcontractinterface.SmartContractInterface {
+	var gscCopy = &GovernanceSmartContract{
+		smartcontractinterface.NewSC(ADDRESS),
+	}
+	gscCopy.setSC(gscCopy.SmartContract, &smartcontract.BCContext{})
+	return gscCopy
+}
+
+func (gsc *GovernanceSmartContract) GetHandlerStats(ctx context.Context, params url.Values) (interface{}, error) {
+	return gsc.SmartContract.HandlerStats(ctx, params)
+}
+
+func (gsc *GovernanceSmartContract) GetExecutionStats() map[string]interface{} {
+	return gsc.SmartContractExecutionStats
+}
+
+func (gsc *GovernanceSmartContract) GetName() string {
+	return name
+}
+
+func (gsc *GovernanceSmartContract) GetAddress() string {
+	return ADDRESS
+}
+
+func (gsc *GovernanceSmartContract) GetCostTable(balances cstate.StateContextI) (map[string]int, error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return map[string]int{}, err
+	}
+	if conf.Cost == nil {
+		return map[string]int{}, err
+	}
+	return conf.Cost, nil
+}
+
+func (gsc *GovernanceSmartContract) setSC(sc *smartcontractinterface.SmartContract,
+	_ smartcontractinterface.BCContextI) {
+
+	gsc.SmartContract = sc
+
+	// proposal of settings changes of a smart contract, voted by stakeholders
+	gsc.SmartContractExecutionStats["create_proposal"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "create_proposal"), nil)
+	gsc.SmartContractExecutionStats["vote"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "vote"), nil)
+	gsc.SmartContractExecutionStats["cancel_proposal"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "cancel_proposal"), nil)
+
+	// tally the ended votings and apply the passed proposals after the timelock
+	gsc.SmartContractExecutionStats["apply_proposals"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "apply_proposals"), nil)
+
+	gsc.SmartContractExecutionStats["update_settings"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", gsc.ID, "update_settings"), nil)
+}
+
+func (gsc *GovernanceSmartContract) Execute(t *transaction.Transaction,
+	function string, input []byte, balances cstate.StateContextI) (
+	resp string, err error) {
+
+	switch function {
+	case "create_proposal":
+		resp, err = gsc.createProposal(t, input, balances)
+	case "vote":
+		resp, err = gsc.vote(t, input, balances)
+	case "cancel_proposal":
+		resp, err = gsc.cancelProposal(t, input, balances)
+	case "apply_proposals":
+		resp, err = gsc.applyProposals(t, input, balances)
+	case "update_settings":
+		resp, err = gsc.updateSettings(t, input, balances)
+	default:
+		err = common.NewError("governance_sc_failed",
+			fmt.Sprintf("no function with %q name", function))
+	}
+	return
+}
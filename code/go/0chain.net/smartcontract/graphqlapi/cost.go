@@ -0,0 +1,177 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// DefaultPageSize is the number of nodes of a page without a first argument
+	DefaultPageSize = 20
+	// MaxPageSize is the max first argument of the list fields
+	MaxPageSize = 100
+	// MaxQueryDepth is the max nesting of the objects of a query
+	MaxQueryDepth = 10
+	// MaxQueryCost is the max cost of a query, see queryCost
+	MaxQueryCost = 10000
+)
+
+// listSizes are the sizes of the list fields without pagination used by the
+// cost of a query, the terms are at most the max_blobbers_per_allocation of
+// the storage sc.
+var listSizes = map[string]int{
+	"Allocation.terms": 40,
+}
+
+// queryCost is the cost of the operation of a valid query: each object
+// costs one and the objects of a list field cost the max size of the list
+// times. The query is rejected before execution if it costs more than
+// MaxQueryCost or is nested deeper than MaxQueryDepth.
+func queryCost(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) (int, error) {
+	var op *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				op = d
+			}
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		}
+	}
+	if op == nil {
+		return 0, fmt.Errorf("unknown operation %q", operationName)
+	}
+	if op.Operation != ast.OperationTypeQuery {
+		return 0, fmt.Errorf("unsupported operation %s, the api is read only", op.Operation)
+	}
+
+	c := &coster{fragments: fragments, variables: variables, defaults: make(map[string]ast.Value)}
+	for _, vd := range op.VariableDefinitions {
+		if vd.DefaultValue != nil {
+			c.defaults[vd.Variable.Name.Value] = vd.DefaultValue
+		}
+	}
+	return c.selectionSetCost(schema.QueryType(), op.SelectionSet, 1)
+}
+
+type coster struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	defaults  map[string]ast.Value // of the variables
+}
+
+func (c *coster) selectionSetCost(parent *graphql.Object, set *ast.SelectionSet, depth int) (int, error) {
+	if depth > MaxQueryDepth {
+		return 0, fmt.Errorf("query deeper than %d", MaxQueryDepth)
+	}
+
+	var cost int
+	for _, f := range c.fields(set) {
+		def, ok := parent.Fields()[f.Name.Value]
+		if !ok {
+			continue // __typename
+		}
+		obj, ok := graphql.GetNamed(def.Type).(*graphql.Object)
+		if !ok || f.SelectionSet == nil {
+			continue // the scalars are loaded with their object
+		}
+
+		childrenCost, err := c.selectionSetCost(obj, f.SelectionSet, depth+1)
+		if err != nil {
+			return 0, err
+		}
+		cost += 1 + c.listSize(parent, def, f)*childrenCost
+		if cost > MaxQueryCost {
+			return 0, fmt.Errorf("query cost more than %d", MaxQueryCost)
+		}
+	}
+	return cost, nil
+}
+
+// fields of a selection set with the fields of its fragments.
+func (c *coster) fields(set *ast.SelectionSet) []*ast.Field {
+	var fields []*ast.Field
+	for _, s := range set.Selections {
+		switch s := s.(type) {
+		case *ast.Field:
+			fields = append(fields, s)
+		case *ast.InlineFragment:
+			fields = append(fields, c.fields(s.SelectionSet)...)
+		case *ast.FragmentSpread:
+			if fd, ok := c.fragments[s.Name.Value]; ok {
+				fields = append(fields, c.fields(fd.SelectionSet)...)
+			}
+		}
+	}
+	return fields
+}
+
+// listSize is the number of nodes of a page or the size of a list field, one
+// for the other fields.
+func (c *coster) listSize(parent *graphql.Object, def *graphql.FieldDefinition, f *ast.Field) int {
+	if size, ok := listSizes[parent.Name()+"."+def.Name]; ok {
+		return size
+	}
+
+	for _, arg := range def.Args {
+		if arg.Name() != "first" {
+			continue
+		}
+		for _, a := range f.Arguments {
+			if a.Name.Value == "first" {
+				// the pages out of range are rejected when resolved
+				return min(max(c.intValue(a.Value, DefaultPageSize), 0), MaxPageSize)
+			}
+		}
+		return DefaultPageSize
+	}
+	return 1
+}
+
+func (c *coster) intValue(v ast.Value, defaultValue int) int {
+	switch v := v.(type) {
+	case *ast.IntValue:
+		if i, err := strconv.Atoi(v.Value); err == nil {
+			return i
+		}
+	case *ast.Variable:
+		switch i := c.variables[v.Name.Value].(type) {
+		case float64: // json numbers
+			return int(i)
+		case int:
+			return i
+		case nil:
+			if dv, ok := c.defaults[v.Name.Value]; ok {
+				return c.intValue(dv, defaultValue)
+			}
+		}
+	}
+	return defaultValue
+}
+
+// selected reports if a field of the object resolved is selected.
+func selected(p graphql.ResolveParams, name string) bool {
+	c := &coster{fragments: make(map[string]*ast.FragmentDefinition)}
+	for fName, def := range p.Info.Fragments {
+		if fd, ok := def.(*ast.FragmentDefinition); ok {
+			c.fragments[fName] = fd
+		}
+	}
+
+	for _, f := range p.Info.FieldASTs {
+		if f.SelectionSet == nil {
+			continue
+		}
+		for _, child := range c.fields(f.SelectionSet) {
+			if child.Name.Value == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package graphqlapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/require"
+)
+
+const allocationPageQuery = `query($id: String!) {
+	allocation(id: $id) {
+		id
+		size
+		terms {
+			readPrice
+			blobber {
+				url
+				stakePool {
+					totalStake
+					delegatePools(first: 10) {
+						nodes { delegateId balance status }
+						pageInfo { endCursor hasNextPage }
+					}
+				}
+			}
+		}
+		writeMarkers(first: 5) { nodes { transactionId size } }
+	}
+}`
+
+func TestQueryCost(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		cost      int
+		err       string
+	}{
+		{
+			name:  "allocation page",
+			query: allocationPageQuery,
+			// allocation + terms + 40 * (blobber + stake pool + delegate pools
+			// + 10 * (nodes + page info)) + write markers + 5 nodes
+			cost: 1 + 1 + 40*(1+1+1+10*(1+1)) + 1 + 5,
+		},
+		{
+			name:  "scalars",
+			query: `{ allocation(id: "a") { id size } }`,
+			cost:  1,
+		},
+		{
+			name:  "default page",
+			query: `{ blobbers { nodes { id } } }`,
+			cost:  1 + DefaultPageSize,
+		},
+		{
+			name:      "page of the variables",
+			query:     `query($n: Int) { blobbers(first: $n) { nodes { id } } }`,
+			variables: map[string]interface{}{"n": float64(3)},
+			cost:      1 + 3,
+		},
+		{
+			name:  "page of the default variable",
+			query: `query($n: Int = 7) { blobbers(first: $n) { nodes { id } } }`,
+			cost:  1 + 7,
+		},
+		{
+			name: "fragments",
+			query: `{ blobbers(first: 2) { ...nodes } }
+fragment nodes on BlobberConnection { nodes { ... on Blobber { stakePool { totalStake } } } }`,
+			cost: 1 + 2*(1+1),
+		},
+		{
+			name: "too costly",
+			query: `{ allocations(first: 100) { nodes { terms { blobber {
+				allocations(first: 100) { nodes { id } } } } } } }`,
+			err: "query cost more than",
+		},
+		{
+			name: "too deep",
+			query: `{ blobber(id: "b") { allocations(first: 1) { nodes { terms { blobber {
+				allocations(first: 1) { nodes { terms { blobber {
+				allocations(first: 1) { nodes { id } } } } } } } } } } } }`,
+			err: "query deeper than",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			require.NoError(t, err)
+			require.True(t, graphql.ValidateDocument(&Schema, doc, nil).IsValid)
+
+			cost, err := queryCost(&Schema, doc, "", tt.variables)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.cost, cost)
+		})
+	}
+}
+
+func TestDoRejected(t *testing.T) {
+	// rejected before any db access
+	for _, query := range []string{
+		`{ allocation(id: "a") { id `,
+		`{ allocation(id: "a") { unknown } }`,
+		`mutation { allocation(id: "a") { id } }`,
+		`{ allocations(first: 100) { nodes { terms { blobber { allocations(first: 100) { nodes { id } } } } } } }`,
+	} {
+		res := Do(context.Background(), nil, Request{Query: query})
+		require.True(t, res.HasErrors(), query)
+		require.Nil(t, res.Data, query)
+	}
+}
+
+func TestPage(t *testing.T) {
+	pg, err := getPage(map[string]interface{}{"first": 2})
+	require.NoError(t, err)
+
+	c := newConnection([]string{"a", "b", "c"}, pg, func(s *string) string { return *s })
+	require.True(t, c.PageInfo.HasNextPage)
+	require.Len(t, c.Nodes, 2)
+	require.NotNil(t, c.PageInfo.EndCursor)
+
+	next, err := getPage(map[string]interface{}{"first": 2, "after": *c.PageInfo.EndCursor})
+	require.NoError(t, err)
+	require.Equal(t, "b", next.after)
+
+	c = newConnection([]string{"c"}, next, func(s *string) string { return *s })
+	require.False(t, c.PageInfo.HasNextPage)
+
+	_, err = getPage(map[string]interface{}{"first": MaxPageSize + 1})
+	require.Error(t, err)
+
+	_, err = getPage(map[string]interface{}{"after": "!"})
+	require.Error(t, err)
+
+	pg, err = getPage(map[string]interface{}{"after": encodeCursor("12")})
+	require.NoError(t, err)
+	id, err := pg.afterID()
+	require.NoError(t, err)
+	require.Equal(t, uint(12), id)
+}
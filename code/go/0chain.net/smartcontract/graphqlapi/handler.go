@@ -0,0 +1,120 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/rest"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// maxRequestSize is the max size of the body of a query request
+const maxRequestSize = 64 * 1024
+
+type GraphQLRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewGraphQLRestHandler(rh rest.RestHandlerI) *GraphQLRestHandler {
+	return &GraphQLRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	grh := NewGraphQLRestHandler(rh)
+	return []rest.Endpoint{
+		rest.MakeEndpoint("/v1/graphql", common.UserRateLimit(grh.query)),
+	}
+}
+
+// Request is a GraphQL query request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// swagger:route POST /v1/graphql graphql
+// read only GraphQL query of the event db, the query is also accepted as a
+// GET request with the query, operationName and variables parameters.
+// The list fields are paginated with the first and after arguments, the
+// queries costing more than MaxQueryCost are rejected.
+//
+// responses:
+//  200:
+//  400:
+//  500:
+func (grh *GraphQLRestHandler) query(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				common.Respond(w, r, nil, common.NewErrBadRequest("invalid variables: "+err.Error()))
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest("invalid request: "+err.Error()))
+			return
+		}
+	default:
+		common.Respond(w, r, nil, common.NewErrBadRequest("unsupported method "+r.Method))
+		return
+	}
+	if req.Query == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing query"))
+		return
+	}
+
+	edb := grh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	common.Respond(w, r, Do(r.Context(), edb, req), nil)
+}
+
+// Do parses, validates and executes the query, the queries nested too deep
+// or too costly are rejected before any db access.
+func Do(ctx context.Context, edb *event.EventDb, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(req.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	if vr := graphql.ValidateDocument(&Schema, doc, nil); !vr.IsValid {
+		return &graphql.Result{Errors: vr.Errors}
+	}
+
+	if _, err := queryCost(&Schema, doc, req.OperationName, req.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        Schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoader(ctx, newLoader(edb)),
+	})
+}
@@ -0,0 +1,385 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/graphql-go/graphql"
+)
+
+type loaderKey struct{}
+
+// loader loads the objects of a query from the event db, the blobbers of the
+// terms of the allocations are loaded at once and cached for the query.
+// The fields are resolved one after the other, there is no need to lock.
+type loader struct {
+	edb      *event.EventDb
+	blobbers map[string]*event.Blobber
+}
+
+func newLoader(edb *event.EventDb) *loader {
+	return &loader{edb: edb, blobbers: make(map[string]*event.Blobber)}
+}
+
+func withLoader(ctx context.Context, l *loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func getLoader(p graphql.ResolveParams) *loader {
+	return p.Context.Value(loaderKey{}).(*loader)
+}
+
+// loadBlobbers caches the blobbers of the ids not in the cache yet.
+func (l *loader) loadBlobbers(ids []string) error {
+	missing := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := l.blobbers[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	blobbers, err := l.edb.GetBlobbersAfter(event.BlobberFilter{IDs: missing}, "", len(missing))
+	if err != nil {
+		return fmt.Errorf("loading blobbers: %v", err)
+	}
+	for _, id := range missing {
+		l.blobbers[id] = nil
+	}
+	for i := range blobbers {
+		l.blobbers[blobbers[i].ID] = &blobbers[i]
+	}
+	return nil
+}
+
+func (l *loader) blobber(id string) (*event.Blobber, error) {
+	if err := l.loadBlobbers([]string{id}); err != nil {
+		return nil, err
+	}
+	return l.blobbers[id], nil
+}
+
+// stakePool is the stake pool of a provider of any type.
+type stakePool struct {
+	*event.Provider
+	Type spenum.Provider
+}
+
+type pageInfo struct {
+	EndCursor   *string
+	HasNextPage bool
+}
+
+type connection struct {
+	Nodes    interface{}
+	PageInfo pageInfo
+}
+
+// page is the cursor of the previous page and the size of the page, one more
+// row than the size is loaded to know if there is a next page.
+type page struct {
+	first int
+	after string
+}
+
+func getPage(args map[string]interface{}) (page, error) {
+	pg := page{first: DefaultPageSize}
+	if first, ok := args["first"].(int); ok {
+		pg.first = first
+	}
+	if pg.first < 0 || pg.first > MaxPageSize {
+		return page{}, fmt.Errorf("invalid first %d, max %d", pg.first, MaxPageSize)
+	}
+	if after, ok := args["after"].(string); ok && after != "" {
+		key, err := base64.RawURLEncoding.DecodeString(after)
+		if err != nil {
+			return page{}, errors.New("invalid cursor")
+		}
+		pg.after = string(key)
+	}
+	return pg, nil
+}
+
+// afterID is the cursor of the pages of the rows with an integer key.
+func (pg page) afterID() (uint, error) {
+	if pg.after == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(pg.after, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	return uint(id), nil
+}
+
+func (pg page) limit() int {
+	return pg.first + 1
+}
+
+// newConnection returns the nodes of the page, key is the cursor of a node.
+func newConnection[T any](rows []T, pg page, key func(*T) string) *connection {
+	c := &connection{}
+	if len(rows) > pg.first {
+		rows = rows[:pg.first]
+		c.PageInfo.HasNextPage = true
+	}
+
+	nodes := make([]*T, len(rows))
+	for i := range rows {
+		nodes[i] = &rows[i]
+	}
+	c.Nodes = nodes
+
+	if len(nodes) > 0 {
+		cursor := encodeCursor(key(nodes[len(nodes)-1]))
+		c.PageInfo.EndCursor = &cursor
+	}
+	return c
+}
+
+// encodeCursor returns the opaque cursor of the key of a row.
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func idKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func allocationKey(a *event.Allocation) string      { return idKey(a.ID) }
+func blobberKey(b *event.Blobber) string            { return b.ID }
+func delegatePoolKey(dp *event.DelegatePool) string { return idKey(dp.ID) }
+func writeMarkerKey(wm *event.WriteMarker) string   { return idKey(wm.ID) }
+func readMarkerKey(rm *event.ReadMarker) string     { return idKey(rm.ID) }
+
+func stringArg(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func boolArg(args map[string]interface{}, name string) *bool {
+	if b, ok := args[name].(bool); ok {
+		return &b
+	}
+	return nil
+}
+
+func longArg(args map[string]interface{}, name string) *int64 {
+	if l, ok := args[name].(int64); ok {
+		return &l
+	}
+	return nil
+}
+
+func resolveAllocation(p graphql.ResolveParams) (interface{}, error) {
+	allocs, err := getLoader(p).edb.GetAllocationsAfter(event.AllocationFilter{
+		AllocationID: stringArg(p.Args, "id"),
+	}, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocs) == 0 {
+		return nil, nil
+	}
+	return &allocs[0], nil
+}
+
+func getAllocations(p graphql.ResolveParams, f event.AllocationFilter) (interface{}, error) {
+	pg, err := getPage(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	after, err := pg.afterID()
+	if err != nil {
+		return nil, err
+	}
+
+	f.Finalized = boolArg(p.Args, "finalized")
+	f.Cancelled = boolArg(p.Args, "cancelled")
+	allocs, err := getLoader(p).edb.GetAllocationsAfter(f, after, pg.limit())
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(allocs, pg, allocationKey), nil
+}
+
+func resolveAllocations(p graphql.ResolveParams) (interface{}, error) {
+	return getAllocations(p, event.AllocationFilter{
+		Owner:     stringArg(p.Args, "owner"),
+		BlobberID: stringArg(p.Args, "blobberId"),
+	})
+}
+
+func resolveBlobberAllocations(p graphql.ResolveParams) (interface{}, error) {
+	return getAllocations(p, event.AllocationFilter{
+		BlobberID: p.Source.(*event.Blobber).ID,
+	})
+}
+
+// resolveAllocationTerms loads the blobbers of the terms at once, the
+// blobbers of the terms are resolved from the cache.
+func resolveAllocationTerms(p graphql.ResolveParams) (interface{}, error) {
+	alloc := p.Source.(*event.Allocation)
+	terms := make([]*event.AllocationBlobberTerm, len(alloc.Terms))
+	ids := make([]string, len(alloc.Terms))
+	for i := range alloc.Terms {
+		terms[i] = &alloc.Terms[i]
+		ids[i] = alloc.Terms[i].BlobberID
+	}
+
+	if selected(p, "blobber") {
+		if err := getLoader(p).loadBlobbers(ids); err != nil {
+			return nil, err
+		}
+	}
+	return terms, nil
+}
+
+func resolveTermBlobber(p graphql.ResolveParams) (interface{}, error) {
+	b, err := getLoader(p).blobber(p.Source.(*event.AllocationBlobberTerm).BlobberID)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func resolveBlobber(p graphql.ResolveParams) (interface{}, error) {
+	b, err := getLoader(p).blobber(stringArg(p.Args, "id"))
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func resolveBlobbers(p graphql.ResolveParams) (interface{}, error) {
+	pg, err := getPage(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	f := event.BlobberFilter{
+		Active:        boolArg(p.Args, "active"),
+		MaxReadPrice:  longArg(p.Args, "maxReadPrice"),
+		MaxWritePrice: longArg(p.Args, "maxWritePrice"),
+	}
+	if ids, ok := p.Args["ids"].([]interface{}); ok {
+		for _, id := range ids {
+			f.IDs = append(f.IDs, id.(string))
+		}
+	}
+	if c := longArg(p.Args, "minFreeCapacity"); c != nil {
+		f.MinFreeCapacity = *c
+	}
+
+	blobbers, err := getLoader(p).edb.GetBlobbersAfter(f, pg.after, pg.limit())
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(blobbers, pg, blobberKey), nil
+}
+
+func getDelegatePools(p graphql.ResolveParams, f event.DelegatePoolFilter) (interface{}, error) {
+	pg, err := getPage(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	after, err := pg.afterID()
+	if err != nil {
+		return nil, err
+	}
+
+	f.DelegateID = stringArg(p.Args, "delegateId")
+	if status, ok := p.Args["status"].(spenum.PoolStatus); ok {
+		f.Status = &status
+	}
+	dps, err := getLoader(p).edb.GetDelegatePoolsAfter(f, after, pg.limit())
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(dps, pg, delegatePoolKey), nil
+}
+
+func resolveDelegatePools(p graphql.ResolveParams) (interface{}, error) {
+	f := event.DelegatePoolFilter{ProviderID: stringArg(p.Args, "providerId")}
+	if pType, ok := p.Args["providerType"].(spenum.Provider); ok {
+		f.ProviderType = pType
+	}
+	return getDelegatePools(p, f)
+}
+
+func resolveStakePoolDelegatePools(p graphql.ResolveParams) (interface{}, error) {
+	sp := p.Source.(*stakePool)
+	return getDelegatePools(p, event.DelegatePoolFilter{
+		ProviderID:   sp.ID,
+		ProviderType: sp.Type,
+	})
+}
+
+func markerFilter(p graphql.ResolveParams) event.MarkerFilter {
+	return event.MarkerFilter{
+		AllocationID: stringArg(p.Args, "allocationId"),
+		BlobberID:    stringArg(p.Args, "blobberId"),
+		ClientID:     stringArg(p.Args, "clientId"),
+	}
+}
+
+func getWriteMarkers(p graphql.ResolveParams, f event.MarkerFilter) (interface{}, error) {
+	pg, err := getPage(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	after, err := pg.afterID()
+	if err != nil {
+		return nil, err
+	}
+
+	wms, err := getLoader(p).edb.GetWriteMarkersAfter(f, after, pg.limit())
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(wms, pg, writeMarkerKey), nil
+}
+
+func resolveWriteMarkers(p graphql.ResolveParams) (interface{}, error) {
+	return getWriteMarkers(p, markerFilter(p))
+}
+
+func resolveAllocationWriteMarkers(p graphql.ResolveParams) (interface{}, error) {
+	f := markerFilter(p)
+	f.AllocationID = p.Source.(*event.Allocation).AllocationID
+	return getWriteMarkers(p, f)
+}
+
+func getReadMarkers(p graphql.ResolveParams, f event.MarkerFilter) (interface{}, error) {
+	pg, err := getPage(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	after, err := pg.afterID()
+	if err != nil {
+		return nil, err
+	}
+
+	rms, err := getLoader(p).edb.GetReadMarkersAfter(f, after, pg.limit())
+	if err != nil {
+		return nil, err
+	}
+	return newConnection(rms, pg, readMarkerKey), nil
+}
+
+func resolveReadMarkers(p graphql.ResolveParams) (interface{}, error) {
+	return getReadMarkers(p, markerFilter(p))
+}
+
+func resolveAllocationReadMarkers(p graphql.ResolveParams) (interface{}, error) {
+	f := markerFilter(p)
+	f.AllocationID = p.Source.(*event.Allocation).AllocationID
+	return getReadMarkers(p, f)
+}
@@ -0,0 +1,411 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Schema is the read only schema of the GraphQL api, the objects are the
+// models of the event db.
+var Schema graphql.Schema
+
+func init() {
+	var err error
+	Schema, err = newSchema()
+	if err != nil {
+		panic(fmt.Sprintf("graphql schema: %v", err))
+	}
+}
+
+// Long is an int64, the GraphQL Int is 32 bits only. The tokens and the
+// sizes are longs.
+var Long = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Long",
+	Description: "64 bits integer",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case int64:
+			return v
+		case currency.Coin:
+			return int64(v)
+		case int:
+			return int64(v)
+		case uint64:
+			return int64(v)
+		case uint:
+			return int64(v)
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case float64: // json numbers of the variables
+			return int64(v)
+		case int:
+			return int64(v)
+		case int64:
+			return v
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i
+			}
+		}
+		return nil
+	},
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch v := value.(type) {
+		case *ast.IntValue:
+			if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				return i
+			}
+		case *ast.StringValue:
+			if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				return i
+			}
+		}
+		return nil
+	},
+})
+
+var providerTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "ProviderType",
+	Values: graphql.EnumValueConfigMap{
+		"MINER":      {Value: spenum.Miner},
+		"SHARDER":    {Value: spenum.Sharder},
+		"BLOBBER":    {Value: spenum.Blobber},
+		"VALIDATOR":  {Value: spenum.Validator},
+		"AUTHORIZER": {Value: spenum.Authorizer},
+	},
+})
+
+var poolStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "PoolStatus",
+	Values: graphql.EnumValueConfigMap{
+		"ACTIVE":  {Value: spenum.Active},
+		"PENDING": {Value: spenum.Pending},
+		"DELETED": {Value: spenum.Deleted},
+	},
+})
+
+// field resolves a field of the source *T of an object.
+func field[T any](t graphql.Output, get func(*T) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*T)), nil
+		},
+	}
+}
+
+var (
+	str     = graphql.NewNonNull(graphql.String)
+	integer = graphql.NewNonNull(graphql.Int)
+	long    = graphql.NewNonNull(Long)
+	float   = graphql.NewNonNull(graphql.Float)
+	boolean = graphql.NewNonNull(graphql.Boolean)
+)
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"endCursor":   field(graphql.String, func(pi *pageInfo) interface{} { return pi.EndCursor }),
+		"hasNextPage": field(boolean, func(pi *pageInfo) interface{} { return pi.HasNextPage }),
+	},
+})
+
+// connectionType is the page of nodes of a list field with the cursor
+// of the next page.
+func connectionType(node *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: node.Name() + "Connection",
+		Fields: graphql.Fields{
+			"nodes":    field(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(node))), func(c *connection) interface{} { return c.Nodes }),
+			"pageInfo": field(graphql.NewNonNull(pageInfoType), func(c *connection) interface{} { return &c.PageInfo }),
+		},
+	})
+}
+
+// pageArgs are the arguments of the list fields, the cursor pagination.
+func pageArgs(filters graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"first": {
+			Type:         graphql.Int,
+			DefaultValue: DefaultPageSize,
+			Description:  fmt.Sprintf("number of nodes of the page, max %d", MaxPageSize),
+		},
+		"after": {
+			Type:        graphql.String,
+			Description: "end cursor of the previous page",
+		},
+	}
+	for name, arg := range filters {
+		args[name] = arg
+	}
+	return args
+}
+
+func newSchema() (graphql.Schema, error) {
+	delegatePoolType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "DelegatePool",
+		Fields: graphql.Fields{
+			"poolId":       field(str, func(dp *event.DelegatePool) interface{} { return dp.PoolID }),
+			"providerId":   field(str, func(dp *event.DelegatePool) interface{} { return dp.ProviderID }),
+			"providerType": field(graphql.NewNonNull(providerTypeEnum), func(dp *event.DelegatePool) interface{} { return dp.ProviderType }),
+			"delegateId":   field(str, func(dp *event.DelegatePool) interface{} { return dp.DelegateID }),
+			"balance":      field(long, func(dp *event.DelegatePool) interface{} { return dp.Balance }),
+			"reward":       field(long, func(dp *event.DelegatePool) interface{} { return dp.Reward }),
+			"totalReward":  field(long, func(dp *event.DelegatePool) interface{} { return dp.TotalReward }),
+			"totalPenalty": field(long, func(dp *event.DelegatePool) interface{} { return dp.TotalPenalty }),
+			"status":       field(graphql.NewNonNull(poolStatusEnum), func(dp *event.DelegatePool) interface{} { return dp.Status }),
+			"roundCreated": field(long, func(dp *event.DelegatePool) interface{} { return dp.RoundCreated }),
+			"stakedAt":     field(long, func(dp *event.DelegatePool) interface{} { return int64(dp.StakedAt) }),
+		},
+	})
+	delegatePoolConnection := connectionType(delegatePoolType)
+
+	delegatePoolFilters := graphql.FieldConfigArgument{
+		"delegateId": {Type: graphql.String},
+		"status":     {Type: poolStatusEnum, Description: "any status but deleted if not set"},
+	}
+
+	stakePoolType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StakePool",
+		Description: "stake pool of a provider",
+		Fields: graphql.Fields{
+			"providerId":       field(str, func(sp *stakePool) interface{} { return sp.ID }),
+			"providerType":     field(graphql.NewNonNull(providerTypeEnum), func(sp *stakePool) interface{} { return sp.Type }),
+			"delegateWallet":   field(str, func(sp *stakePool) interface{} { return sp.DelegateWallet }),
+			"numDelegates":     field(integer, func(sp *stakePool) interface{} { return sp.NumDelegates }),
+			"serviceCharge":    field(float, func(sp *stakePool) interface{} { return sp.ServiceCharge }),
+			"totalStake":       field(long, func(sp *stakePool) interface{} { return sp.TotalStake }),
+			"unclaimedRewards": field(long, func(sp *stakePool) interface{} { return sp.Rewards.Rewards }),
+			"totalRewards":     field(long, func(sp *stakePool) interface{} { return sp.Rewards.TotalRewards }),
+			"delegatePools": {
+				Type:    graphql.NewNonNull(delegatePoolConnection),
+				Args:    pageArgs(delegatePoolFilters),
+				Resolve: resolveStakePoolDelegatePools,
+			},
+		},
+	})
+
+	writeMarkerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "WriteMarker",
+		Fields: graphql.Fields{
+			"transactionId":          field(str, func(wm *event.WriteMarker) interface{} { return wm.TransactionID }),
+			"clientId":               field(str, func(wm *event.WriteMarker) interface{} { return wm.ClientID }),
+			"blobberId":              field(str, func(wm *event.WriteMarker) interface{} { return wm.BlobberID }),
+			"allocationId":           field(str, func(wm *event.WriteMarker) interface{} { return wm.AllocationID }),
+			"allocationRoot":         field(str, func(wm *event.WriteMarker) interface{} { return wm.AllocationRoot }),
+			"previousAllocationRoot": field(str, func(wm *event.WriteMarker) interface{} { return wm.PreviousAllocationRoot }),
+			"fileMetaRoot":           field(str, func(wm *event.WriteMarker) interface{} { return wm.FileMetaRoot }),
+			"size":                   field(long, func(wm *event.WriteMarker) interface{} { return wm.Size }),
+			"chainSize":              field(long, func(wm *event.WriteMarker) interface{} { return wm.ChainSize }),
+			"timestamp":              field(long, func(wm *event.WriteMarker) interface{} { return wm.Timestamp }),
+			"blockNumber":            field(long, func(wm *event.WriteMarker) interface{} { return wm.BlockNumber }),
+		},
+	})
+	writeMarkerConnection := connectionType(writeMarkerType)
+
+	readMarkerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ReadMarker",
+		Fields: graphql.Fields{
+			"transactionId": field(str, func(rm *event.ReadMarker) interface{} { return rm.TransactionID }),
+			"clientId":      field(str, func(rm *event.ReadMarker) interface{} { return rm.ClientID }),
+			"ownerId":       field(str, func(rm *event.ReadMarker) interface{} { return rm.OwnerID }),
+			"payerId":       field(str, func(rm *event.ReadMarker) interface{} { return rm.PayerID }),
+			"blobberId":     field(str, func(rm *event.ReadMarker) interface{} { return rm.BlobberID }),
+			"allocationId":  field(str, func(rm *event.ReadMarker) interface{} { return rm.AllocationID }),
+			"authTicket":    field(str, func(rm *event.ReadMarker) interface{} { return rm.AuthTicket }),
+			"timestamp":     field(long, func(rm *event.ReadMarker) interface{} { return rm.Timestamp }),
+			"readCounter":   field(long, func(rm *event.ReadMarker) interface{} { return rm.ReadCounter }),
+			"readSize":      field(float, func(rm *event.ReadMarker) interface{} { return rm.ReadSize }),
+			"blockNumber":   field(long, func(rm *event.ReadMarker) interface{} { return rm.BlockNumber }),
+		},
+	})
+	readMarkerConnection := connectionType(readMarkerType)
+
+	markerFilters := graphql.FieldConfigArgument{
+		"blobberId": {Type: graphql.String},
+		"clientId":  {Type: graphql.String},
+	}
+
+	allocationFilters := graphql.FieldConfigArgument{
+		"finalized": {Type: graphql.Boolean},
+		"cancelled": {Type: graphql.Boolean},
+	}
+
+	blobberType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Blobber",
+		Fields: graphql.Fields{
+			"id":                  field(str, func(b *event.Blobber) interface{} { return b.ID }),
+			"url":                 field(str, func(b *event.Blobber) interface{} { return b.BaseURL }),
+			"readPrice":           field(long, func(b *event.Blobber) interface{} { return b.ReadPrice }),
+			"writePrice":          field(long, func(b *event.Blobber) interface{} { return b.WritePrice }),
+			"capacity":            field(long, func(b *event.Blobber) interface{} { return b.Capacity }),
+			"allocated":           field(long, func(b *event.Blobber) interface{} { return b.Allocated }),
+			"savedData":           field(long, func(b *event.Blobber) interface{} { return b.SavedData }),
+			"readData":            field(long, func(b *event.Blobber) interface{} { return b.ReadData }),
+			"notAvailable":        field(boolean, func(b *event.Blobber) interface{} { return b.NotAvailable }),
+			"isRestricted":        field(boolean, func(b *event.Blobber) interface{} { return b.IsRestricted }),
+			"isKilled":            field(boolean, func(b *event.Blobber) interface{} { return b.IsKilled }),
+			"isShutdown":          field(boolean, func(b *event.Blobber) interface{} { return b.IsShutdown }),
+			"lastHealthCheck":     field(long, func(b *event.Blobber) interface{} { return int64(b.LastHealthCheck) }),
+			"challengesPassed":    field(long, func(b *event.Blobber) interface{} { return b.ChallengesPassed }),
+			"challengesCompleted": field(long, func(b *event.Blobber) interface{} { return b.ChallengesCompleted }),
+			"openChallenges":      field(long, func(b *event.Blobber) interface{} { return b.OpenChallenges }),
+			"rankMetric":          field(float, func(b *event.Blobber) interface{} { return b.RankMetric }),
+			"totalBlockRewards":   field(long, func(b *event.Blobber) interface{} { return b.TotalBlockRewards }),
+			"totalStorageIncome":  field(long, func(b *event.Blobber) interface{} { return b.TotalStorageIncome }),
+			"totalReadIncome":     field(long, func(b *event.Blobber) interface{} { return b.TotalReadIncome }),
+			"totalSlashedStake":   field(long, func(b *event.Blobber) interface{} { return b.TotalSlashedStake }),
+			"creationRound":       field(long, func(b *event.Blobber) interface{} { return b.CreationRound }),
+			"stakePool": field(graphql.NewNonNull(stakePoolType), func(b *event.Blobber) interface{} {
+				return &stakePool{Provider: &b.Provider, Type: spenum.Blobber}
+			}),
+		},
+	})
+	blobberConnection := connectionType(blobberType)
+
+	blobberTermType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "BlobberTerm",
+		Description: "terms of a blobber of an allocation",
+		Fields: graphql.Fields{
+			"blobberId":  field(str, func(t *event.AllocationBlobberTerm) interface{} { return t.BlobberID }),
+			"index":      field(integer, func(t *event.AllocationBlobberTerm) interface{} { return int(t.AllocBlobberIdx) }),
+			"readPrice":  field(long, func(t *event.AllocationBlobberTerm) interface{} { return t.ReadPrice }),
+			"writePrice": field(long, func(t *event.AllocationBlobberTerm) interface{} { return t.WritePrice }),
+			"blobber": {
+				Type:    blobberType,
+				Resolve: resolveTermBlobber,
+			},
+		},
+	})
+
+	allocationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Allocation",
+		Fields: graphql.Fields{
+			"id":                   field(str, func(a *event.Allocation) interface{} { return a.AllocationID }),
+			"transactionId":        field(str, func(a *event.Allocation) interface{} { return a.TransactionID }),
+			"owner":                field(str, func(a *event.Allocation) interface{} { return a.Owner }),
+			"ownerPublicKey":       field(str, func(a *event.Allocation) interface{} { return a.OwnerPublicKey }),
+			"dataShards":           field(integer, func(a *event.Allocation) interface{} { return a.DataShards }),
+			"parityShards":         field(integer, func(a *event.Allocation) interface{} { return a.ParityShards }),
+			"size":                 field(long, func(a *event.Allocation) interface{} { return a.Size }),
+			"usedSize":             field(long, func(a *event.Allocation) interface{} { return a.UsedSize }),
+			"startTime":            field(long, func(a *event.Allocation) interface{} { return a.StartTime }),
+			"expiration":           field(long, func(a *event.Allocation) interface{} { return a.Expiration }),
+			"finalized":            field(boolean, func(a *event.Allocation) interface{} { return a.Finalized }),
+			"cancelled":            field(boolean, func(a *event.Allocation) interface{} { return a.Cancelled }),
+			"readPriceMin":         field(long, func(a *event.Allocation) interface{} { return a.ReadPriceMin }),
+			"readPriceMax":         field(long, func(a *event.Allocation) interface{} { return a.ReadPriceMax }),
+			"writePriceMin":        field(long, func(a *event.Allocation) interface{} { return a.WritePriceMin }),
+			"writePriceMax":        field(long, func(a *event.Allocation) interface{} { return a.WritePriceMax }),
+			"writePool":            field(long, func(a *event.Allocation) interface{} { return a.WritePool }),
+			"movedToChallenge":     field(long, func(a *event.Allocation) interface{} { return a.MovedToChallenge }),
+			"movedBack":            field(long, func(a *event.Allocation) interface{} { return a.MovedBack }),
+			"movedToValidators":    field(long, func(a *event.Allocation) interface{} { return a.MovedToValidators }),
+			"numWrites":            field(long, func(a *event.Allocation) interface{} { return a.NumWrites }),
+			"numReads":             field(long, func(a *event.Allocation) interface{} { return a.NumReads }),
+			"totalChallenges":      field(long, func(a *event.Allocation) interface{} { return a.TotalChallenges }),
+			"openChallenges":       field(long, func(a *event.Allocation) interface{} { return a.OpenChallenges }),
+			"successfulChallenges": field(long, func(a *event.Allocation) interface{} { return a.SuccessfulChallenges }),
+			"failedChallenges":     field(long, func(a *event.Allocation) interface{} { return a.FailedChallenges }),
+			"thirdPartyExtendable": field(boolean, func(a *event.Allocation) interface{} { return a.ThirdPartyExtendable }),
+			"fileOptions":          field(integer, func(a *event.Allocation) interface{} { return int(a.FileOptions) }),
+			"terms": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(blobberTermType))),
+				Resolve: resolveAllocationTerms,
+			},
+			"writeMarkers": {
+				Type:    graphql.NewNonNull(writeMarkerConnection),
+				Args:    pageArgs(markerFilters),
+				Resolve: resolveAllocationWriteMarkers,
+			},
+			"readMarkers": {
+				Type:    graphql.NewNonNull(readMarkerConnection),
+				Args:    pageArgs(markerFilters),
+				Resolve: resolveAllocationReadMarkers,
+			},
+		},
+	})
+	allocationConnection := connectionType(allocationType)
+
+	blobberType.AddFieldConfig("allocations", &graphql.Field{
+		Type:    graphql.NewNonNull(allocationConnection),
+		Args:    pageArgs(allocationFilters),
+		Resolve: resolveBlobberAllocations,
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"allocation": {
+				Type:    allocationType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: str}},
+				Resolve: resolveAllocation,
+			},
+			"allocations": {
+				Type: graphql.NewNonNull(allocationConnection),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"owner":     {Type: graphql.String},
+					"blobberId": {Type: graphql.String},
+					"finalized": {Type: graphql.Boolean},
+					"cancelled": {Type: graphql.Boolean},
+				}),
+				Resolve: resolveAllocations,
+			},
+			"blobber": {
+				Type:    blobberType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: str}},
+				Resolve: resolveBlobber,
+			},
+			"blobbers": {
+				Type: graphql.NewNonNull(blobberConnection),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"ids":             {Type: graphql.NewList(str)},
+					"active":          {Type: graphql.Boolean, Description: "neither killed nor shut down"},
+					"minFreeCapacity": {Type: Long},
+					"maxReadPrice":    {Type: Long},
+					"maxWritePrice":   {Type: Long},
+				}),
+				Resolve: resolveBlobbers,
+			},
+			"delegatePools": {
+				Type: graphql.NewNonNull(delegatePoolConnection),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"providerId":   {Type: graphql.String},
+					"providerType": {Type: providerTypeEnum},
+					"delegateId":   {Type: graphql.String},
+					"status":       {Type: poolStatusEnum, Description: "any status but deleted if not set"},
+				}),
+				Resolve: resolveDelegatePools,
+			},
+			"writeMarkers": {
+				Type: graphql.NewNonNull(writeMarkerConnection),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"allocationId": {Type: graphql.String},
+					"blobberId":    {Type: graphql.String},
+					"clientId":     {Type: graphql.String},
+				}),
+				Resolve: resolveWriteMarkers,
+			},
+			"readMarkers": {
+				Type: graphql.NewNonNull(readMarkerConnection),
+				Args: pageArgs(graphql.FieldConfigArgument{
+					"allocationId": {Type: graphql.String},
+					"blobberId":    {Type: graphql.String},
+					"clientId":     {Type: graphql.String},
+				}),
+				Resolve: resolveReadMarkers,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
@@ -177,8 +177,7 @@ func (tb *testBalances) AddMint(mint *state.Mint) error {
 	if mint.Minter != ADDRESS {
 		panic("invalid miner: " + mint.Minter)
 	}
-	tb.balances[mint.ToClientID] += mint.Amount // mint!
-	return nil
+	return tb.AddTransfer(state.NewTransfer(mint.Minter, mint.ToClientID, mint.Amount))
 }
 
 func (tb *testBalances) GetChainCurrentMagicBlock() *block.MagicBlock {
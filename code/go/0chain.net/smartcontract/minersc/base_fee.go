@@ -0,0 +1,288 @@
+package minersc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	config2 "0chain.net/core/config"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+//go:generate msgp -io=false -tests=false -v
+//msgp:ignore baseFeeConfig
+
+var BaseFeeKey = globalKeyHash("base_fee")
+
+// BaseFee is the price of a unit of transaction cost burned from the fees of
+// the transactions of a block. It's adjusted every block from the block cost
+// relative to the max block cost, the rest of a fee is a tip split
+// between the generator and the sharders.
+type BaseFee struct {
+	// Fee is the base fee per unit of cost of the next block
+	Fee currency.Coin `json:"fee"`
+	// Round of the last adjustment
+	Round int64 `json:"round"`
+	// BlockCost is the cost of the block of the last adjustment
+	BlockCost int `json:"block_cost"`
+	// Burned is the total of the burned base fees
+	Burned currency.Coin `json:"burned"`
+}
+
+func (bf *BaseFee) save(balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(BaseFeeKey, bf)
+	return err
+}
+
+// adjust the base fee by at most 1/denominator toward the block cost target
+func (bf *BaseFee) adjust(cost int, conf *baseFeeConfig) {
+	target := int64(float64(conf.maxBlockCost) * conf.targetUtilization)
+	if target <= 0 || conf.maxChangeDenominator <= 0 || int64(cost) == target {
+		return
+	}
+
+	diff := int64(cost) - target
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > target {
+		diff = target
+	}
+	delta := int64(bf.Fee) * diff / target / int64(conf.maxChangeDenominator)
+
+	if int64(cost) > target {
+		if delta == 0 {
+			delta = 1
+		}
+		bf.Fee += currency.Coin(delta)
+		return
+	}
+
+	if currency.Coin(delta) > bf.Fee-conf.min {
+		bf.Fee = conf.min
+		return
+	}
+	bf.Fee -= currency.Coin(delta)
+}
+
+type baseFeeConfig struct {
+	enabled              bool
+	initial              currency.Coin
+	min                  currency.Coin
+	maxChangeDenominator int
+	targetUtilization    float64
+	maxBlockCost         int
+}
+
+func getBaseFeeConfig(balances cstate.CommonStateContextI) (*baseFeeConfig, error) {
+	gl, err := getGlobalSettings(balances)
+	if err != nil {
+		if err != util.ErrValueNotPresent {
+			return nil, err
+		}
+		gl = newGlobalSettings()
+	}
+
+	conf := new(baseFeeConfig)
+	if conf.enabled, err = gl.GetBool(config2.TransactionBaseFeeEnabled); err != nil || !conf.enabled {
+		return conf, err
+	}
+
+	initial, err := gl.GetFloat64(config2.TransactionBaseFeeInitial)
+	if err != nil {
+		return nil, err
+	}
+	if conf.initial, err = currency.ParseZCN(initial); err != nil {
+		return nil, err
+	}
+	minFee, err := gl.GetFloat64(config2.TransactionBaseFeeMin)
+	if err != nil {
+		return nil, err
+	}
+	if conf.min, err = currency.ParseZCN(minFee); err != nil {
+		return nil, err
+	}
+	if conf.initial < conf.min {
+		conf.initial = conf.min
+	}
+	if conf.maxChangeDenominator, err = gl.GetInt(config2.TransactionBaseFeeMaxChangeDenominator); err != nil {
+		return nil, err
+	}
+	if conf.targetUtilization, err = gl.GetFloat64(config2.TransactionBaseFeeTargetUtilization); err != nil {
+		return nil, err
+	}
+	if conf.maxBlockCost, err = gl.GetInt(config2.BlockMaxCost); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func getBaseFee(conf *baseFeeConfig, balances cstate.CommonStateContextI) (*BaseFee, error) {
+	bf := new(BaseFee)
+	err := balances.GetTrieNode(BaseFeeKey, bf)
+	switch err {
+	case nil:
+		if bf.Fee < conf.min {
+			bf.Fee = conf.min
+		}
+		return bf, nil
+	case util.ErrValueNotPresent:
+		return &BaseFee{Fee: conf.initial}, nil
+	default:
+		return nil, err
+	}
+}
+
+// GetBaseFee returns the current base fee per unit of cost, 0 if the base
+// fee is disabled.
+func GetBaseFee(balances cstate.CommonStateContextI) (currency.Coin, error) {
+	conf, err := getBaseFeeConfig(balances)
+	if err != nil {
+		return 0, err
+	}
+	if !conf.enabled {
+		return 0, nil
+	}
+
+	bf, err := getBaseFee(conf, balances)
+	if err != nil {
+		return 0, err
+	}
+	return bf.Fee, nil
+}
+
+// TxnBaseFee is the base fee of a transaction of the cost, the part of its
+// fee that is burned. It is limited to the max transaction fee, a max fee of
+// 0 doesn't limit it.
+func TxnBaseFee(baseFee currency.Coin, cost int, maxFee currency.Coin) (currency.Coin, error) {
+	if cost <= 0 || baseFee == 0 {
+		return 0, nil
+	}
+	fee, err := currency.MultCoin(baseFee, currency.Coin(cost))
+	if err != nil {
+		if maxFee > 0 {
+			return maxFee, nil
+		}
+		return 0, err
+	}
+	if maxFee > 0 && fee > maxFee {
+		return maxFee, nil
+	}
+	return fee, nil
+}
+
+// burnBaseFees burns the base fees of the transactions of the block and
+// adjusts the base fee of the next block, it returns the tips of the
+// transactions. The fees are paid to the contract, the burned part is moved
+// to the burn address so it isn't paid out as rewards.
+func (msc *MinerSmartContract) burnBaseFees(b *block.Block, fees currency.Coin,
+	conf *baseFeeConfig, balances cstate.StateContextI) (currency.Coin, error) {
+
+	bf, err := getBaseFee(conf, balances)
+	if err != nil {
+		return 0, fmt.Errorf("can't get base fee: %v", err)
+	}
+
+	var (
+		tables    = make(map[string]map[string]int)
+		exempt    = config2.Configuration().ChainConfig.TxnExempt()
+		maxFee    = config2.Configuration().ChainConfig.MaxTxnFee()
+		blockCost int
+		burned    currency.Coin
+	)
+	for _, txn := range b.Txns {
+		cost, funcName, err := txnCost(txn, tables, conf.maxBlockCost, balances)
+		if err != nil {
+			return 0, err
+		}
+		blockCost += cost
+
+		if txn.Fee == 0 || exempt[funcName] {
+			continue
+		}
+
+		txnBurned, err := TxnBaseFee(bf.Fee, cost, maxFee)
+		if err != nil || txnBurned > txn.Fee {
+			// underpriced by a base fee raised after the transaction was
+			// accepted, burn all the fee
+			txnBurned = txn.Fee
+		}
+		if burned, err = currency.AddCoin(burned, txnBurned); err != nil {
+			return 0, err
+		}
+	}
+
+	if burned > 0 {
+		if err = balances.AddTransfer(state.NewBurnTransfer(ADDRESS, burned)); err != nil {
+			return 0, fmt.Errorf("burning base fees: %v", err)
+		}
+	}
+	if bf.Burned, err = currency.AddCoin(bf.Burned, burned); err != nil {
+		return 0, err
+	}
+	bf.Round = b.Round
+	bf.BlockCost = blockCost
+	bf.adjust(blockCost, conf)
+	if err = bf.save(balances); err != nil {
+		return 0, fmt.Errorf("saving base fee: %v", err)
+	}
+
+	logging.Logger.Debug("pay_fees, burned base fees",
+		zap.Int64("round", b.Round),
+		zap.Int("block_cost", blockCost),
+		zap.Any("burned", burned),
+		zap.Any("next_base_fee", bf.Fee))
+
+	return currency.MinusCoin(fees, burned)
+}
+
+// txnCost returns the cost of the transaction as estimated by the chain and
+// the smart contract function called, the cost of a transaction unknown to
+// the cost tables is the max block cost. A forwarded call costs the function
+// it executes from the hermes hardfork.
+func txnCost(txn *transaction.Transaction, tables map[string]map[string]int,
+	maxBlockCost int, balances cstate.StateContextI) (int, string, error) {
+
+	switch txn.TransactionType {
+	case transaction.TxnTypeSend:
+		return config2.Configuration().ChainConfig.TxnTransferCost(), "", nil
+	case transaction.TxnTypeSmartContract:
+	default:
+		return 0, "", nil
+	}
+
+	var scData sci.SmartContractTransactionData
+	if err := json.Unmarshal([]byte(txn.TransactionData), &scData); err != nil {
+		return maxBlockCost, "", nil
+	}
+
+	address, functionName, err := smartcontract.CostFunction(txn.ToClientID, scData, balances)
+	if err != nil {
+		return 0, "", err
+	}
+
+	table, ok := tables[address]
+	if !ok {
+		if sc := smartcontract.GetSmartContract(address); sc != nil {
+			if table, err = sc.GetCostTable(balances); err != nil && cstate.ErrInvalidState(err) {
+				return 0, "", err
+			}
+		}
+		tables[address] = table
+	}
+
+	cost, ok := table[strings.ToLower(functionName)]
+	if !ok || (maxBlockCost > 0 && cost > maxBlockCost) {
+		cost = maxBlockCost
+	}
+	return cost, scData.FunctionName, nil
+}
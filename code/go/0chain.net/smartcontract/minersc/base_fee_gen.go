@@ -0,0 +1,94 @@
+package minersc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *BaseFee) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Fee"
+	o = append(o, 0x84, 0xa3, 0x46, 0x65, 0x65)
+	o, err = z.Fee.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Fee")
+		return
+	}
+	// string "Round"
+	o = append(o, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	// string "BlockCost"
+	o = append(o, 0xa9, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x73, 0x74)
+	o = msgp.AppendInt(o, z.BlockCost)
+	// string "Burned"
+	o = append(o, 0xa6, 0x42, 0x75, 0x72, 0x6e, 0x65, 0x64)
+	o, err = z.Burned.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Burned")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *BaseFee) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Fee":
+			bts, err = z.Fee.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Fee")
+				return
+			}
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		case "BlockCost":
+			z.BlockCost, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BlockCost")
+				return
+			}
+		case "Burned":
+			bts, err = z.Burned.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Burned")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *BaseFee) Msgsize() (s int) {
+	s = 1 + 4 + z.Fee.Msgsize() + 6 + msgp.Int64Size + 10 + msgp.IntSize + 7 + z.Burned.Msgsize()
+	return
+}
@@ -0,0 +1,167 @@
+package minersc
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/config"
+	"0chain.net/core/config/mocks"
+	"0chain.net/core/datastore"
+	"0chain.net/core/viper"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+// noGlobalsBalances has no global settings saved, they're read from viper
+type noGlobalsBalances struct {
+	*testBalances
+}
+
+func (nb *noGlobalsBalances) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	if key == GLOBALS_KEY {
+		return util.ErrValueNotPresent
+	}
+	return nb.testBalances.GetTrieNode(key, v)
+}
+
+func TestBaseFeeAdjust(t *testing.T) {
+	conf := &baseFeeConfig{
+		min:                  100,
+		maxChangeDenominator: 8,
+		targetUtilization:    0.5,
+		maxBlockCost:         10000,
+	}
+
+	tests := []struct {
+		name string
+		fee  currency.Coin
+		cost int
+		want currency.Coin
+	}{
+		{name: "full block", fee: 8000, cost: 10000, want: 9000},
+		{name: "half full block", fee: 8000, cost: 7500, want: 8500},
+		{name: "target", fee: 8000, cost: 5000, want: 8000},
+		{name: "empty block", fee: 8000, cost: 0, want: 7000},
+		{name: "over max block cost", fee: 8000, cost: 20000, want: 9000},
+		{name: "min fee", fee: 110, cost: 0, want: 100},
+		{name: "small increase", fee: 100, cost: 5001, want: 101},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bf := &BaseFee{Fee: tt.fee}
+			bf.adjust(tt.cost, conf)
+			require.Equal(t, tt.want, bf.Fee)
+		})
+	}
+}
+
+func TestBurnBaseFees(t *testing.T) {
+	const pfx = "server_chain.transaction.base_fee."
+	viper.Set(pfx+"enabled", true)
+	viper.Set(pfx+"initial", 0.0000001) // 1000 SAS
+	viper.Set(pfx+"min", 0.00000001)
+	viper.Set(pfx+"max_change_denominator", 8)
+	viper.Set(pfx+"target_utilization", 0.5)
+	viper.Set("server_chain.block.max_block_cost", 40)
+	t.Cleanup(func() {
+		viper.Set(pfx+"enabled", false)
+	})
+
+	mockChainConfig := mocks.NewChainConfig(t)
+	mockChainConfig.On("TxnExempt").Return(map[string]bool{})
+	mockChainConfig.On("TxnTransferCost").Return(10)
+	mockChainConfig.On("MaxTxnFee").Return(currency.Coin(0))
+	config.Configuration().ChainConfig = mockChainConfig
+
+	var (
+		msc      = newTestMinerSC()
+		balances = &noGlobalsBalances{newTestBalances()}
+		b        = balances.GetBlock()
+	)
+	balances.txn = &transaction.Transaction{ToClientID: ADDRESS}
+	balances.balances[ADDRESS] = 30000
+	b.Txns = []*transaction.Transaction{
+		{TransactionType: transaction.TxnTypeSend, Fee: 15000},
+		{TransactionType: transaction.TxnTypeSend, Fee: 10000},
+		{TransactionType: transaction.TxnTypeSend, Fee: 5000}, // underpriced
+	}
+
+	conf, err := getBaseFeeConfig(balances)
+	require.NoError(t, err)
+	require.True(t, conf.enabled)
+
+	fee, err := GetBaseFee(balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, fee)
+
+	// 10000 + 10000 + 5000 are burned
+	tips, err := msc.burnBaseFees(b, 30000, conf, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 5000, tips)
+
+	// the burned fees leave the contract
+	require.Len(t, balances.transfers, 1)
+	require.Equal(t, state.BurnAddress, balances.transfers[0].ToClientID)
+	require.EqualValues(t, 5000, balances.balances[ADDRESS])
+	require.EqualValues(t, 25000, balances.balances[state.BurnAddress])
+
+	bf, err := getBaseFee(conf, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 25000, bf.Burned)
+	require.EqualValues(t, 30, bf.BlockCost)
+	require.EqualValues(t, b.Round, bf.Round)
+	// the block cost is 1.5 times the target
+	require.EqualValues(t, 1062, bf.Fee)
+
+	fee, err = GetBaseFee(balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 1062, fee)
+}
+
+func TestBurnBaseFeesMaxTxnFee(t *testing.T) {
+	const pfx = "server_chain.transaction.base_fee."
+	viper.Set(pfx+"enabled", true)
+	viper.Set(pfx+"initial", 0.0000001) // 1000 SAS
+	viper.Set(pfx+"min", 0.00000001)
+	viper.Set(pfx+"max_change_denominator", 8)
+	viper.Set(pfx+"target_utilization", 0.5)
+	viper.Set("server_chain.block.max_block_cost", 40)
+	t.Cleanup(func() {
+		viper.Set(pfx+"enabled", false)
+	})
+
+	mockChainConfig := mocks.NewChainConfig(t)
+	mockChainConfig.On("TxnExempt").Return(map[string]bool{})
+	mockChainConfig.On("TxnTransferCost").Return(10)
+	mockChainConfig.On("MaxTxnFee").Return(currency.Coin(8000))
+	config.Configuration().ChainConfig = mockChainConfig
+
+	var (
+		msc      = newTestMinerSC()
+		balances = &noGlobalsBalances{newTestBalances()}
+		b        = balances.GetBlock()
+	)
+	balances.txn = &transaction.Transaction{ToClientID: ADDRESS}
+	balances.balances[ADDRESS] = 20000
+	b.Txns = []*transaction.Transaction{
+		{TransactionType: transaction.TxnTypeSend, Fee: 15000},
+		{TransactionType: transaction.TxnTypeSend, Fee: 5000}, // underpriced
+	}
+
+	conf, err := getBaseFeeConfig(balances)
+	require.NoError(t, err)
+
+	// the base fee of 10000 is capped at the max transaction fee, as estimated
+	// by the chain
+	fee, err := TxnBaseFee(1000, 10, 8000)
+	require.NoError(t, err)
+	require.EqualValues(t, 8000, fee)
+
+	// 8000 + 5000 are burned
+	tips, err := msc.burnBaseFees(b, 20000, conf, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 7000, tips)
+	require.EqualValues(t, 13000, balances.balances[state.BurnAddress])
+}
@@ -83,7 +83,7 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) er
 }
 
 func BenchmarkTests(
-	data bk.BenchData, _ bk.SignatureScheme,
+	data bk.BenchData, sigScheme bk.SignatureScheme,
 ) bk.TestSuite {
 	creationTimeRaw := viper.GetInt64("MptCreationTime")
 	creationTime := common.Now()
@@ -211,6 +211,50 @@ func BenchmarkTests(
 				CreationDate: creationTime,
 			},
 		},
+		{
+			name: "miner.report_equivocation",
+			endpoint: func(t *transaction.Transaction,
+				input []byte, gn *GlobalNode, balances cstate.StateContextI) (
+				resp string, err error) {
+				// the benchmark miners keys are not known, sign with a client key
+				mn, err := getMinerNode(data.Miners[0], balances)
+				if err != nil {
+					return "", err
+				}
+				mn.PublicKey = data.PublicKeys[0]
+				if err := mn.save(balances); err != nil {
+					return "", err
+				}
+				return msc.reportEquivocation(t, input, gn, balances)
+			},
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				_ = sigScheme.SetPublicKey(data.PublicKeys[0])
+				sigScheme.SetPrivateKey(data.PrivateKeys[0])
+				signed := func(prevHash string) SignedHeader {
+					h := &block.Header{
+						MinerID:      data.Miners[0],
+						PrevHash:     prevHash,
+						CreationDate: creationTime,
+						Round:        viper.GetInt64(bk.NumBlocks),
+					}
+					h.Hash = h.ComputeHash()
+					signature, _ := sigScheme.Sign(h.Hash)
+					return SignedHeader{Header: h, Signature: signature}
+				}
+				bytes, _ := json.Marshal(&EquivocationEvidence{
+					MinerID: data.Miners[0],
+					Kind:    EquivocationProposal,
+					First:   signed(encryption.Hash("first")),
+					Second:  signed(encryption.Hash("second")),
+				})
+				return bytes
+			}(),
+		},
 		{
 			name:     "miner.contributeMpk",
 			endpoint: msc.contributeMpk,
@@ -323,6 +367,10 @@ func BenchmarkTests(
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.report_equivocation":                     "111",
+					"equivocation_slash":                           "0.1",
+					"equivocation_reporter_reward":                 "0.1",
+					"max_equivocation_age":                         "1000",
 				},
 			}).Encode(),
 		},
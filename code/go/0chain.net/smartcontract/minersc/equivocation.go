@@ -0,0 +1,230 @@
+package minersc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -v
+//msgp:ignore EquivocationKind EquivocationEvidence SignedHeader
+
+// EquivocationKind is the kind of the conflicting messages of an evidence
+type EquivocationKind string
+
+const (
+	// EquivocationProposal is two blocks proposed by the miner for the same round
+	EquivocationProposal EquivocationKind = "proposal"
+	// EquivocationTicket is two verification tickets signed by the miner for
+	// two blocks of the same generator and round
+	EquivocationTicket EquivocationKind = "verification_ticket"
+)
+
+// SignedHeader is a block header along with the signature of the block hash
+// by the miner, the block signature of a proposal or the signature of a
+// verification ticket.
+type SignedHeader struct {
+	Header    *block.Header `json:"header"`
+	Signature string        `json:"signature"`
+}
+
+// EquivocationEvidence is the input of report_equivocation, two conflicting
+// messages signed by the miner for the same round.
+type EquivocationEvidence struct {
+	MinerID string           `json:"miner_id"`
+	Kind    EquivocationKind `json:"kind"`
+	First   SignedHeader     `json:"first"`
+	Second  SignedHeader     `json:"second"`
+}
+
+func (ee *EquivocationEvidence) Decode(input []byte) error {
+	return json.Unmarshal(input, ee)
+}
+
+// round of the conflicting messages, the evidence has to be verified
+func (ee *EquivocationEvidence) round() int64 {
+	return ee.First.Header.Round
+}
+
+// verify checks the messages conflict, are signed by the miner and are at
+// most maxAge rounds old
+func (ee *EquivocationEvidence) verify(publicKey string, maxAge int64, balances cstate.StateContextI) error {
+	if ee.First.Header == nil || ee.Second.Header == nil {
+		return errors.New("missing block header")
+	}
+
+	first, second := ee.First.Header, ee.Second.Header
+	if first.Round != second.Round {
+		return fmt.Errorf("different rounds: %d and %d", first.Round, second.Round)
+	}
+	if first.Round > balances.GetBlock().Round {
+		return fmt.Errorf("round %d is in the future", first.Round)
+	}
+	if balances.GetBlock().Round-first.Round > maxAge {
+		return fmt.Errorf("round %d is older than %d rounds", first.Round, maxAge)
+	}
+	if first.Hash == second.Hash {
+		return errors.New("same block")
+	}
+	// an honest miner regenerates its block after a round timeout with the
+	// new random seed of the round, only the blocks of a seed conflict
+	if first.RoundRandomSeed != second.RoundRandomSeed {
+		return errors.New("blocks of different round random seeds")
+	}
+
+	switch ee.Kind {
+	case EquivocationProposal:
+		if first.MinerID != ee.MinerID || second.MinerID != ee.MinerID {
+			return errors.New("blocks not generated by the miner")
+		}
+	case EquivocationTicket:
+		// an honest miner verifies a single block of a generator in a round
+		if first.MinerID != second.MinerID {
+			return errors.New("blocks of different generators")
+		}
+	default:
+		return fmt.Errorf("unknown evidence kind: %q", ee.Kind)
+	}
+
+	for _, sh := range []SignedHeader{ee.First, ee.Second} {
+		if sh.Header.ComputeHash() != sh.Header.Hash {
+			return fmt.Errorf("block %s hash mismatch", sh.Header.Hash)
+		}
+
+		scheme := balances.GetSignatureScheme()
+		if err := scheme.SetPublicKey(publicKey); err != nil {
+			return err
+		}
+		ok, err := scheme.Verify(sh.Signature, sh.Header.Hash)
+		if err != nil || !ok {
+			return fmt.Errorf("block %s not signed by the miner", sh.Header.Hash)
+		}
+	}
+	return nil
+}
+
+// EquivocationRecord is kept for every miner slashed for a round, a miner is
+// slashed once per round whatever the evidence.
+type EquivocationRecord struct {
+	Reporter string `json:"reporter"`
+	// Round the record was added
+	Round int64 `json:"round"`
+}
+
+func equivocationKey(minerID string, round int64) datastore.Key {
+	return ADDRESS + encryption.Hash("equivocation:"+minerID+":"+strconv.FormatInt(round, 10))
+}
+
+// reportEquivocation slashes the stake pool of a miner that signed two
+// conflicting messages for a round, part of the slashed stake rewards the
+// reporter and the rest is burned.
+func (msc *MinerSmartContract) reportEquivocation(
+	txn *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (resp string, err error) {
+	var ee EquivocationEvidence
+	if err := ee.Decode(input); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	mn, err := getMinerNode(ee.MinerID, balances)
+	if err != nil {
+		return "", common.NewErrorf("report_equivocation_failed", "getting miner %s: %v", ee.MinerID, err)
+	}
+	if txn.ClientID == mn.ID || txn.ClientID == mn.Settings.DelegateWallet {
+		return "", common.NewError("report_equivocation_failed", "a miner can't report itself")
+	}
+
+	if err := ee.verify(mn.PublicKey, gn.MaxEquivocationAge, balances); err != nil {
+		return "", common.NewErrorf("report_equivocation_failed", "invalid evidence: %v", err)
+	}
+
+	key := equivocationKey(mn.ID, ee.round())
+	err = balances.GetTrieNode(key, &EquivocationRecord{})
+	switch err {
+	case nil:
+		return "", common.NewErrorf("report_equivocation_failed",
+			"miner %s already slashed for round %d", mn.ID, ee.round())
+	case util.ErrValueNotPresent:
+	default:
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	before, err := mn.TotalStake()
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	if err := mn.StakePool.SlashFraction(gn.EquivocationSlash, mn.ID, spenum.Miner, balances); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	after, err := mn.TotalStake()
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	slashed, err := currency.MinusCoin(before, after)
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	reward, err := currency.MultFloat64(slashed, gn.EquivocationReporterReward)
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	// the slashed stake is still held by the smart contract
+	if reward > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(ADDRESS, txn.ClientID, reward)); err != nil {
+			return "", common.NewError("report_equivocation_failed", err.Error())
+		}
+	}
+	burned, err := currency.MinusCoin(slashed, reward)
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	if burned > 0 {
+		if err := balances.AddTransfer(state.NewBurnTransfer(ADDRESS, burned)); err != nil {
+			return "", common.NewError("report_equivocation_failed", err.Error())
+		}
+	}
+
+	mn.TotalStaked = after
+	if err := mn.save(balances); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	if _, err := balances.InsertTrieNode(key, &EquivocationRecord{
+		Reporter: txn.ClientID,
+		Round:    balances.GetBlock().Round,
+	}); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	tag, data := event.NewUpdateMinerTotalStakeEvent(mn.ID, after)
+	balances.EmitEvent(event.TypeStats, tag, mn.ID, data)
+	balances.EmitEvent(event.TypeStats, event.TagMinerEquivocation, mn.ID, event.MinerEquivocation{
+		MinerID:    mn.ID,
+		Round:      ee.round(),
+		Kind:       string(ee.Kind),
+		FirstHash:  ee.First.Header.Hash,
+		SecondHash: ee.Second.Header.Hash,
+		Reporter:   txn.ClientID,
+		Slashed:    slashed,
+		Reward:     reward,
+		Burned:     burned,
+	})
+
+	return "", nil
+}
@@ -0,0 +1,138 @@
+package minersc
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func signedHeader(t *testing.T, c *Client, minerID string, round int64, prevHash string) SignedHeader {
+	h := &block.Header{
+		MinerID:      minerID,
+		PrevHash:     prevHash,
+		CreationDate: common.Timestamp(round),
+		Round:        round,
+	}
+	h.Hash = h.ComputeHash()
+	sig, err := c.scheme.Sign(h.Hash)
+	require.NoError(t, err)
+	return SignedHeader{Header: h, Signature: sig}
+}
+
+func TestReportEquivocation(t *testing.T) {
+	const (
+		now      = 100
+		stakeVal = currency.Coin(10e10)
+	)
+
+	var (
+		balances = newTestBalances()
+		msc      = newTestMinerSC()
+		gn       = setConfig(t, balances)
+	)
+	gn.EquivocationSlash = 0.5
+	gn.EquivocationReporterReward = 0.1
+	gn.MaxEquivocationAge = 20
+	mustSave(t, GlobalNodeKey, gn, balances)
+
+	m, err := newMinerWithStake(t, msc, now, 2, stakeVal, true, balances)
+	require.NoError(t, err)
+	mn, err := getMinerNode(m.miner.id, balances)
+	require.NoError(t, err)
+	for _, st := range m.stakers {
+		mn.Pools[st.id] = &stakepool.DelegatePool{
+			Balance:    stakeVal,
+			Status:     spenum.Active,
+			DelegateID: st.id,
+		}
+	}
+	require.NoError(t, mn.save(balances))
+	reporter := newClient(0, balances)
+
+	report := func(c *Client, ee *EquivocationEvidence) error {
+		tx := newTransaction(c.id, ADDRESS, 0, now)
+		balances.txn = tx
+		gn, err := getGlobalNode(balances)
+		require.NoError(t, err)
+		_, err = msc.reportEquivocation(tx, mustEncode(ee), gn, balances)
+		return err
+	}
+
+	evidence := func(round int64) *EquivocationEvidence {
+		return &EquivocationEvidence{
+			MinerID: m.miner.id,
+			Kind:    EquivocationProposal,
+			First:   signedHeader(t, m.miner, m.miner.id, round, "prev1"),
+			Second:  signedHeader(t, m.miner, m.miner.id, round, "prev2"),
+		}
+	}
+
+	t.Run("self report", func(t *testing.T) {
+		require.Error(t, report(m.delegate, evidence(90)))
+	})
+
+	t.Run("same block", func(t *testing.T) {
+		ee := evidence(90)
+		ee.Second = ee.First
+		require.Error(t, report(reporter, ee))
+	})
+
+	t.Run("not signed by the miner", func(t *testing.T) {
+		ee := evidence(90)
+		ee.Second = signedHeader(t, reporter, m.miner.id, 90, "prev2")
+		require.Error(t, report(reporter, ee))
+	})
+
+	t.Run("regenerated block", func(t *testing.T) {
+		// the block regenerated after a round timeout has a new seed
+		ee := evidence(90)
+		h := *ee.Second.Header
+		h.RoundRandomSeed++
+		h.Hash = h.ComputeHash()
+		sig, err := m.miner.scheme.Sign(h.Hash)
+		require.NoError(t, err)
+		ee.Second = SignedHeader{Header: &h, Signature: sig}
+
+		err = report(reporter, ee)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "different round random seeds")
+	})
+
+	t.Run("future round", func(t *testing.T) {
+		require.Error(t, report(reporter, evidence(balances.block.Round+1)))
+	})
+
+	t.Run("slash", func(t *testing.T) {
+		require.NoError(t, report(reporter, evidence(90)))
+
+		mn, err := getMinerNode(m.miner.id, balances)
+		require.NoError(t, err)
+		total, err := mn.TotalStake()
+		require.NoError(t, err)
+		require.Equal(t, stakeVal, total)
+		require.Equal(t, stakeVal, mn.TotalStaked)
+		require.Equal(t, stakeVal/10, balances.balances[reporter.id])
+		// the rest of the slashed stake is burned
+		require.Equal(t, stakeVal-stakeVal/10, balances.balances[state.BurnAddress])
+	})
+
+	t.Run("already slashed", func(t *testing.T) {
+		require.Error(t, report(reporter, evidence(90)))
+		// another round
+		require.NoError(t, report(reporter, evidence(91)))
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		err := report(reporter, evidence(balances.block.Round-21))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "older than 20 rounds")
+		// the oldest round reported
+		require.NoError(t, report(reporter, evidence(balances.block.Round-20)))
+	})
+}
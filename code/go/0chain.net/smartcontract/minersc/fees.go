@@ -303,6 +303,19 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 	if err != nil {
 		return "", fmt.Errorf("error splitting rewards by ratio: %v", err)
 	}
+
+	bfc, err := getBaseFeeConfig(balances)
+	if err != nil {
+		return "", common.NewErrorf("pay_fees", "can't get base fee config: %v", err)
+	}
+
+	if bfc.enabled {
+		// the base fees are burned and the tips are paid as the fees
+		if fees, err = msc.burnBaseFees(b, fees, bfc, balances); err != nil {
+			return "", common.NewErrorf("pay_fees", "burning base fees: %v", err)
+		}
+	}
+
 	minerFees, sharderFees, err := gn.splitByShareRatio(fees)
 	if err != nil {
 		return "", fmt.Errorf("error splitting fees by ratio: %v", err)
@@ -304,9 +304,8 @@ func (msc *MinerSmartContract) updateGlobals(
 	gn *GlobalNode,
 	balances cstate.StateContextI,
 ) (resp string, err error) {
-	if err := smartcontractinterface.AuthorizeWithOwner("update_globals", func() bool {
-		return gn.OwnerId == txn.ClientID
-	}); err != nil {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_globals",
+		gn.OwnerId, txn.ClientID); err != nil {
 		return "", err
 	}
 	var changes config2.StringMap
@@ -51,6 +51,7 @@ func (msc *MinerSmartContract) initSC() {
 	msc.smartContractFunctions["addToDelegatePool"] = msc.addToDelegatePool
 	msc.smartContractFunctions["deleteFromDelegatePool"] = msc.deleteFromDelegatePool
 	msc.smartContractFunctions["sharder_keep"] = msc.sharderKeep
+	msc.smartContractFunctions["report_equivocation"] = msc.reportEquivocation
 }
 
 func (msc *MinerSmartContract) AddMinerIntegrationTests(
@@ -7,6 +7,7 @@ import (
 
 	"0chain.net/smartcontract/dto"
 
+	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
 
 	cstate "0chain.net/chaincore/chain/state"
@@ -340,6 +341,27 @@ func getMinerNode(id string, state cstate.CommonStateContextI) (*MinerNode, erro
 	return mn, nil
 }
 
+// GetStakePool of the miner or the sharder
+func GetStakePool(providerType spenum.Provider, id string, balances cstate.CommonStateContextI) (
+	*stakepool.StakePool, error) {
+	var (
+		node *MinerNode
+		err  error
+	)
+	switch providerType {
+	case spenum.Miner:
+		node, err = getMinerNode(id, balances)
+	case spenum.Sharder:
+		node, err = getSharderNode(id, balances)
+	default:
+		return nil, fmt.Errorf("unsupported provider type %s", providerType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return node.StakePool, nil
+}
+
 func validateNodeSettings(node *MinerNode, gn *GlobalNode, opcode string) error {
 	if node.Settings.ServiceChargeRatio < 0 {
 		return common.NewErrorf(opcode,
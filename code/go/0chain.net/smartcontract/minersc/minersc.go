@@ -42,6 +42,7 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 
 	msc.smartContractFunctions["kill_miner"] = msc.killMiner
 	msc.smartContractFunctions["kill_sharder"] = msc.killSharder
+	msc.smartContractFunctions["report_equivocation"] = msc.reportEquivocation
 
 	msc.smartContractFunctions["miner_health_check"] = msc.minerHealthCheck
 	msc.smartContractFunctions["sharder_health_check"] = msc.sharderHealthCheck
@@ -258,6 +258,16 @@ type GlobalNode struct {
 	OwnerId              string         `json:"owner_id"`
 	CooldownPeriod       int64          `json:"cooldown_period"`
 	Cost                 map[string]int `json:"cost"`
+
+	// EquivocationSlash is the fraction of the stake of a miner slashed for
+	// signing two conflicting messages in a round.
+	EquivocationSlash float64 `json:"equivocation_slash"`
+	// EquivocationReporterReward is the fraction of the slashed stake paid
+	// to the reporter of the evidence.
+	EquivocationReporterReward float64 `json:"equivocation_reporter_reward"`
+	// MaxEquivocationAge is the number of rounds an evidence can be reported
+	// after the round of the conflicting messages.
+	MaxEquivocationAge int64 `json:"max_equivocation_age"`
 }
 
 func (gn *GlobalNode) readConfig() (err error) {
@@ -299,6 +309,9 @@ func (gn *GlobalNode) readConfig() (err error) {
 	gn.RewardDeclineRate = config2.SmartContractConfig.GetFloat64(pfx + SettingName[RewardDeclineRate])
 	gn.OwnerId = config2.SmartContractConfig.GetString(pfx + SettingName[OwnerId])
 	gn.CooldownPeriod = config2.SmartContractConfig.GetInt64(pfx + SettingName[CooldownPeriod])
+	gn.EquivocationSlash = config2.SmartContractConfig.GetFloat64(pfx + SettingName[EquivocationSlash])
+	gn.EquivocationReporterReward = config2.SmartContractConfig.GetFloat64(pfx + SettingName[EquivocationReporterReward])
+	gn.MaxEquivocationAge = config2.SmartContractConfig.GetInt64(pfx + SettingName[MaxEquivocationAge])
 	gn.Cost = config2.SmartContractConfig.GetStringMapInt(pfx + "cost")
 	return nil
 }
@@ -335,6 +348,18 @@ func (gn *GlobalNode) validate() error {
 		return fmt.Errorf("%s cannot be negative: %d",
 			NumShardersRewarded.String(), gn.NumShardersRewarded)
 	}
+	if gn.EquivocationSlash < 0 || gn.EquivocationSlash > 1 {
+		return fmt.Errorf("%s should be in the interval [0,1]: %v",
+			EquivocationSlash.String(), gn.EquivocationSlash)
+	}
+	if gn.EquivocationReporterReward < 0 || gn.EquivocationReporterReward > 1 {
+		return fmt.Errorf("%s should be in the interval [0,1]: %v",
+			EquivocationReporterReward.String(), gn.EquivocationReporterReward)
+	}
+	if gn.MaxEquivocationAge < 0 {
+		return fmt.Errorf("%s cannot be negative: %d",
+			MaxEquivocationAge.String(), gn.MaxEquivocationAge)
+	}
 	return nil
 }
 
@@ -417,6 +442,12 @@ func (gn *GlobalNode) Get(key Setting) (interface{}, error) {
 		return gn.OwnerId, nil
 	case CooldownPeriod:
 		return gn.CooldownPeriod, nil
+	case EquivocationSlash:
+		return gn.EquivocationSlash, nil
+	case EquivocationReporterReward:
+		return gn.EquivocationReporterReward, nil
+	case MaxEquivocationAge:
+		return gn.MaxEquivocationAge, nil
 	default:
 		return nil, errors.New("Setting not implemented")
 	}
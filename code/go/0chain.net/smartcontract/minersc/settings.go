@@ -45,6 +45,9 @@ const (
 	NumSharderDelegatesRewarded
 	OwnerId
 	CooldownPeriod
+	EquivocationSlash
+	EquivocationReporterReward
+	MaxEquivocationAge
 	CostAddMiner
 	CostAddSharder
 	CostDeleteMiner
@@ -65,6 +68,7 @@ const (
 	CostSharderKeep
 	CostKillMiner
 	CostKillSharder
+	CostReportEquivocation
 	HealthCheckPeriod
 	NumberOfSettings
 )
@@ -114,6 +118,9 @@ func initSettingName() {
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CooldownPeriod] = "cooldown_period"
 	SettingName[HealthCheckPeriod] = "health_check_period"
+	SettingName[EquivocationSlash] = "equivocation_slash"
+	SettingName[EquivocationReporterReward] = "equivocation_reporter_reward"
+	SettingName[MaxEquivocationAge] = "max_equivocation_age"
 	SettingName[CostAddMiner] = "cost.add_miner"
 	SettingName[CostAddSharder] = "cost.add_sharder"
 	SettingName[CostDeleteMiner] = "cost.delete_miner"
@@ -134,6 +141,7 @@ func initSettingName() {
 	SettingName[CostSharderKeep] = "cost.sharder_keep"
 	SettingName[CostKillMiner] = "cost.kill_miner"
 	SettingName[CostKillSharder] = "cost.kill_sharder"
+	SettingName[CostReportEquivocation] = "cost.report_equivocation"
 }
 
 func initSettings() {
@@ -165,6 +173,9 @@ func initSettings() {
 		OwnerId.String():                     {OwnerId, config.Key},
 		CooldownPeriod.String():              {CooldownPeriod, config.Int64},
 		HealthCheckPeriod.String():           {HealthCheckPeriod, config.Duration},
+		EquivocationSlash.String():           {EquivocationSlash, config.Float64},
+		EquivocationReporterReward.String():  {EquivocationReporterReward, config.Float64},
+		MaxEquivocationAge.String():          {MaxEquivocationAge, config.Int64},
 		CostAddMiner.String():                {CostAddMiner, config.Cost},
 		CostAddSharder.String():              {CostAddSharder, config.Cost},
 		CostDeleteMiner.String():             {CostDeleteMiner, config.Cost},
@@ -185,6 +196,7 @@ func initSettings() {
 		CostSharderKeep.String():             {CostSharderKeep, config.Cost},
 		CostKillMiner.String():               {CostKillMiner, config.Cost},
 		CostKillSharder.String():             {CostKillSharder, config.Cost},
+		CostReportEquivocation.String():      {CostReportEquivocation, config.Cost},
 	}
 }
 
@@ -246,6 +258,8 @@ func (gn *GlobalNode) setInt64(key string, change int64) error {
 		gn.Epoch = change
 	case CooldownPeriod:
 		gn.CooldownPeriod = change
+	case MaxEquivocationAge:
+		gn.MaxEquivocationAge = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -268,6 +282,10 @@ func (gn *GlobalNode) setFloat64(key string, change float64) error {
 		gn.MaxCharge = change
 	case RewardDeclineRate:
 		gn.RewardDeclineRate = change
+	case EquivocationSlash:
+		gn.EquivocationSlash = change
+	case EquivocationReporterReward:
+		gn.EquivocationReporterReward = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -407,10 +425,8 @@ func (msc *MinerSmartContract) updateSettings(
 	gn *GlobalNode,
 	balances cstate.StateContextI,
 ) (resp string, err error) {
-	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
-		get, _ := gn.Get(OwnerId)
-		return get == t.ClientID
-	}); err != nil {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings",
+		gn.OwnerId, t.ClientID); err != nil {
 		return "", err
 	}
 
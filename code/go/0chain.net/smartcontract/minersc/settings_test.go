@@ -219,6 +219,10 @@ func TestUpdateSettings(t *testing.T) {
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.report_equivocation":                     "111",
+					"equivocation_slash":                           "0.1",
+					"equivocation_reporter_reward":                 "0.1",
+					"max_equivocation_age":                         "1000",
 				},
 			},
 		},
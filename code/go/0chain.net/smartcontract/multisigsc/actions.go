@@ -0,0 +1,128 @@
+package multisigsc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+)
+
+// callStateContext is the state context of a smart contract call executed as
+// the multi-sig wallet. The wallet can't transfer more than the call value.
+type callStateContext struct {
+	cstate.StateContextI
+	txn   *transaction.Transaction
+	spent currency.Coin
+}
+
+func (cc *callStateContext) GetTransaction() *transaction.Transaction {
+	return cc.txn
+}
+
+func (cc *callStateContext) AddTransfer(t *state.Transfer) error {
+	if t.ClientID == cc.txn.ClientID {
+		spent, err := currency.AddCoin(cc.spent, t.Amount)
+		if err != nil {
+			return err
+		}
+		if spent > cc.txn.Value {
+			return state.ErrInvalidTransfer
+		}
+		cc.spent = spent
+	}
+	return cc.StateContextI.AddTransfer(t)
+}
+
+// Execute the smart contract call of the proposal as the multi-sig wallet in
+// the vote transaction. The call fails the vote if it fails.
+func (ms MultiSigSmartContract) executeCall(t *transaction.Transaction, w Wallet, p proposal, balances cstate.StateContextI) (string, error) {
+	if !w.verify(w.PublicKey, p.ClientSignature, p.hash()) {
+		return "", common.NewError("err_vote_recover", " invalid recovered signature")
+	}
+
+	call := p.Call
+	if call.Address == Address {
+		return "", common.NewError("err_call_not_allowed", " can't call the multi-sig smart contract")
+	}
+	contract := smartcontract.GetSmartContract(call.Address)
+	if contract == nil {
+		return "", common.NewError("err_call_invalid_address", " invalid smart contract address")
+	}
+
+	scData := &transaction.SmartContractData{
+		FunctionName: call.FunctionName,
+		InputData:    call.InputData,
+	}
+	data, err := json.Marshal(scData)
+	if err != nil {
+		return "", err
+	}
+
+	txn := &transaction.Transaction{
+		HashIDField:       datastore.HashIDField{Hash: t.Hash},
+		ClientID:          w.ClientID,
+		PublicKey:         w.PublicKey,
+		ToClientID:        call.Address,
+		TransactionData:   string(data),
+		Value:             call.Value,
+		CreationDate:      t.CreationDate,
+		TransactionType:   transaction.TxnTypeSmartContract,
+		SmartContractData: scData,
+	}
+
+	output, err := smartcontract.ExecuteWithStats(contract, txn, &callStateContext{
+		StateContextI: balances,
+		txn:           txn,
+	})
+	if err != nil {
+		if cstate.ErrInvalidState(err) {
+			return "", err
+		}
+		return "", common.NewError("err_call_failed", fmt.Sprintf(" %s.%s: %v", call.Address, call.FunctionName, err))
+	}
+
+	w.Nonce++
+	if err := ms.putWallet(w, balances); err != nil {
+		return "", err
+	}
+
+	return "success 0: call executed with output " + output, nil
+}
+
+// Replace the signers and the threshold of the wallet with the ones of the
+// update proposal. The new signer keys must be shares of the wallet key.
+func (ms MultiSigSmartContract) updateWallet(w Wallet, p proposal, balances cstate.StateContextI) (string, error) {
+	if !w.verify(w.PublicKey, p.ClientSignature, p.hash()) {
+		return "", common.NewError("err_vote_recover", " invalid recovered signature")
+	}
+
+	u := p.Update
+	w.SignerThresholdIDs = u.SignerThresholdIDs
+	w.SignerPublicKeys = u.SignerPublicKeys
+	w.NumRequired = u.NumRequired
+	w.Nonce++
+
+	if _, err := w.valid(u.ClientID); err != nil {
+		return "", err
+	}
+
+	// The new signers must sign with the wallet key.
+	if err := encryption.VerifyThresholdKeyShares(w.SignatureScheme, w.NumRequired, w.PublicKey,
+		w.SignerThresholdIDs, w.SignerPublicKeys); err != nil {
+		return "", common.NewError("err_update_invalid_signers", " "+err.Error())
+	}
+
+	if err := ms.putWallet(w, balances); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("success 0: wallet updated, %d of %d signers required",
+		w.NumRequired, len(w.SignerThresholdIDs)), nil
+}
@@ -55,8 +55,7 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, _ *testing.B) er
 		)
 	case VoteFuncName:
 		_, err = msc.vote(
-			bt.txn.Hash,
-			bt.txn.ClientID,
+			bt.Transaction(),
 			balances.GetBlock().CreationDate,
 			bt.input,
 			balances,
@@ -8,17 +8,20 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
 )
 
 //msgp:ignore Vote
+//msgp:shim json.RawMessage as:[]byte using:[]byte/json.RawMessage
 //go:generate msgp -io=false -tests=false -unexported -v
 
 const (
 	ExpirationTime = 60 * 60 * 24 * 7 // Proposals expire after one week.
 	//ExpirationTime = 30 // Value in seconds that is more appropriate for testing.
-	MaxSigners   = 20
-	MinSigners   = 2
-	MaxFieldSize = 256
+	MaxSigners       = 20
+	MinSigners       = 2
+	MaxFieldSize     = 256
+	MaxInputDataSize = 64 * 1024
 )
 
 type Wallet struct {
@@ -30,6 +33,10 @@ type Wallet struct {
 	SignerPublicKeys   []string `json:"signer_public_keys"`
 
 	NumRequired int `json:"num_required"`
+
+	// Nonce of the next smart contract call or wallet update, so that an
+	// executed one can't be replayed.
+	Nonce int64 `json:"nonce"`
 }
 
 func (w Wallet) Encode() []byte {
@@ -151,17 +158,17 @@ func (w Wallet) isVoteAuthorized(signingClientID string, v Vote) bool {
 		return false
 	}
 
-	err := w.makeSignedTransferForVote(publicKey, v).VerifySignature(false)
-	return err == nil
+	return w.verify(publicKey, v.Signature, v.hash())
 }
 
-func (w Wallet) makeSignedTransferForVote(signingPublicKey string, v Vote) state.SignedTransfer {
-	return state.SignedTransfer{
-		Transfer:   v.Transfer,
-		SchemeName: w.SignatureScheme,
-		PublicKey:  signingPublicKey,
-		Sig:        v.Signature,
+func (w Wallet) verify(publicKey, signature, hash string) bool {
+	scheme := encryption.GetSignatureScheme(w.SignatureScheme)
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return false
 	}
+
+	ok, err := scheme.Verify(signature, hash)
+	return err == nil && ok
 }
 
 func (w Wallet) makeSignedTransferForProposal(p proposal) state.SignedTransfer {
@@ -215,7 +222,7 @@ func (w Wallet) publicKeyForThresholdID(signerThresholdID string) string {
 // Compute the Lagrange polynomial of Wallet.NumRequired signature shares. The
 // y-intercept of this polynomial is the proposal's signature. (This process is
 // called reconstruction in the literature.)
-func (w Wallet) constructTransferSignature(p proposal) (string, error) {
+func (w Wallet) constructSignature(p proposal) (string, error) {
 	t := w.NumRequired
 	n := len(w.SignerThresholdIDs)
 	rec := encryption.GetReconstructSignatureScheme(w.SignatureScheme, t, n)
@@ -247,28 +254,111 @@ func (w Wallet) constructTransferSignature(p proposal) (string, error) {
 		}
 	}
 
-	// All of the SignerSignatures are signatures on the transfer, which means
-	// this reconstructed signature will be, too.
+	// All of the SignerSignatures are signatures on the proposed action, which
+	// means this reconstructed signature will be, too.
 	return rec.Reconstruct()
 }
 
+// SmartContractCall is a smart contract function executed as the multi-sig
+// wallet client once enough signers voted for it.
+type SmartContractCall struct {
+	// Client ID of the multi-sig wallet.
+	ClientID     string          `json:"client_id"`
+	Address      string          `json:"address"`
+	FunctionName string          `json:"function_name"`
+	InputData    json.RawMessage `json:"input_data"`
+	// Value of the call transaction, paid by the multi-sig wallet.
+	Value currency.Coin `json:"value"`
+	// Nonce must be the current nonce of the wallet.
+	Nonce int64 `json:"nonce"`
+}
+
+func (c *SmartContractCall) Encode() []byte {
+	buff, _ := json.Marshal(c)
+	return buff
+}
+
+func (c *SmartContractCall) Decode(input []byte) error {
+	return json.Unmarshal(input, c)
+}
+
+// WalletUpdate replaces the signers and the number of required signatures of
+// the multi-sig wallet. The new signer keys must be shares of the same wallet
+// key, otherwise the wallet can't sign anymore.
+type WalletUpdate struct {
+	// Client ID of the multi-sig wallet.
+	ClientID           string   `json:"client_id"`
+	SignerThresholdIDs []string `json:"signer_threshold_ids"`
+	SignerPublicKeys   []string `json:"signer_public_keys"`
+	NumRequired        int      `json:"num_required"`
+	// Nonce must be the current nonce of the wallet.
+	Nonce int64 `json:"nonce"`
+}
+
+func (u *WalletUpdate) Encode() []byte {
+	buff, _ := json.Marshal(u)
+	return buff
+}
+
+func (u *WalletUpdate) Decode(input []byte) error {
+	return json.Unmarshal(input, u)
+}
+
+// Vote for a proposal. The proposal is either a transfer, a smart contract
+// call or a wallet update, the signature is on the hash of its JSON encoding.
 type Vote struct {
 	ProposalID string `json:"proposal_id"`
 
 	// Client ID in transfer is that of the multi-sig wallet, not the signer.
-	Transfer state.Transfer `json:"transfer"`
+	Transfer state.Transfer     `json:"transfer"`
+	Call     *SmartContractCall `json:"call,omitempty"`
+	Update   *WalletUpdate      `json:"update,omitempty"`
 
 	Signature string `json:"signature"`
 }
 
 func (v Vote) notTooBig() bool {
-	return len(v.ProposalID) <= MaxFieldSize &&
-		len(v.Transfer.ClientID) <= MaxFieldSize &&
-		len(v.Transfer.ToClientID) <= MaxFieldSize &&
-		len(v.Signature) <= MaxFieldSize
+	if len(v.ProposalID) > MaxFieldSize ||
+		len(v.Transfer.ClientID) > MaxFieldSize ||
+		len(v.Transfer.ToClientID) > MaxFieldSize ||
+		len(v.Signature) > MaxFieldSize {
+		return false
+	}
+
+	if v.Call != nil {
+		return len(v.Call.ClientID) <= MaxFieldSize &&
+			len(v.Call.Address) <= MaxFieldSize &&
+			len(v.Call.FunctionName) <= MaxFieldSize &&
+			len(v.Call.InputData) <= MaxInputDataSize
+	}
+
+	if v.Update != nil {
+		// The fields of the new signers are checked as for a registration.
+		return len(v.Update.ClientID) <= MaxFieldSize
+	}
+
+	return true
+}
+
+// hasOneAction - a vote is for exactly one of a transfer, a call or an update.
+func (v Vote) hasOneAction() bool {
+	n := 0
+	if v.Transfer != (state.Transfer{}) {
+		n++
+	}
+	if v.Call != nil {
+		n++
+	}
+	if v.Update != nil {
+		n++
+	}
+	return n == 1
 }
 
 func (v Vote) hasValidAmount() bool {
+	if v.Call != nil || v.Update != nil {
+		return true
+	}
 	return v.Transfer.Amount > 0
 }
 
@@ -276,15 +366,52 @@ func (v Vote) hasSignature() bool {
 	return v.Signature != ""
 }
 
+func (v Vote) clientID() string {
+	switch {
+	case v.Call != nil:
+		return v.Call.ClientID
+	case v.Update != nil:
+		return v.Update.ClientID
+	default:
+		return v.Transfer.ClientID
+	}
+}
+
+// nonce of the wallet the call or the update is for, transfers have none.
+func (v Vote) nonce() (int64, bool) {
+	switch {
+	case v.Call != nil:
+		return v.Call.Nonce, true
+	case v.Update != nil:
+		return v.Update.Nonce, true
+	default:
+		return 0, false
+	}
+}
+
+// hash of the proposed action, signed by the signers.
+func (v Vote) hash() string {
+	switch {
+	case v.Call != nil:
+		return encryption.Hash(v.Call.Encode())
+	case v.Update != nil:
+		return encryption.Hash(v.Update.Encode())
+	default:
+		return encryption.Hash(v.Transfer.Encode())
+	}
+}
+
 func (v Vote) getProposalRef() proposalRef {
 	return proposalRef{
-		ClientID:   v.Transfer.ClientID,
+		ClientID:   v.clientID(),
 		ProposalID: v.ProposalID,
 	}
 }
 
 func (v Vote) isCompatibleWithProposal(p proposal) bool {
-	return v.Transfer == p.Transfer
+	return (v.Call == nil) == (p.Call == nil) &&
+		(v.Update == nil) == (p.Update == nil) &&
+		v.hash() == p.hash()
 }
 
 // Uniquely identifies a proposal. Can be used to refer to one.
@@ -303,7 +430,8 @@ func (pr *proposalRef) Decode(input []byte) error {
 	return err
 }
 
-// Proposal to transfer tokens out of the multi-sig wallet. Built up from T
+// Proposal to transfer tokens out of the multi-sig wallet, to call a smart
+// contract as the multi-sig wallet or to update its signers. Built up from T
 // different votes.
 type proposal struct {
 	// Proposal ID is unique only within a single multi-sig wallet. Globally, a
@@ -315,7 +443,9 @@ type proposal struct {
 	Next proposalRef `json:"next"`
 	Prev proposalRef `json:"prev"`
 
-	Transfer state.Transfer `json:"transfer"`
+	Transfer state.Transfer     `json:"transfer"`
+	Call     *SmartContractCall `json:"call,omitempty"`
+	Update   *WalletUpdate      `json:"update,omitempty"`
 
 	// Pertinent data from votes.
 	SignerThresholdIDs []string `json:"signer_threshold_ids"`
@@ -336,8 +466,24 @@ func (p *proposal) Decode(input []byte) error {
 	return err
 }
 
+func (p proposal) clientID() string {
+	switch {
+	case p.Call != nil:
+		return p.Call.ClientID
+	case p.Update != nil:
+		return p.Update.ClientID
+	default:
+		return p.Transfer.ClientID
+	}
+}
+
+// hash of the proposed action, the wallet signature is on it.
+func (p proposal) hash() string {
+	return Vote{Transfer: p.Transfer, Call: p.Call, Update: p.Update}.hash()
+}
+
 func (p proposal) isEmpty() bool {
-	return p.Transfer.ClientID == ""
+	return p.clientID() == ""
 }
 
 func (p proposal) isExpired(now common.Timestamp) bool {
@@ -346,13 +492,13 @@ func (p proposal) isExpired(now common.Timestamp) bool {
 
 func (p proposal) ref() proposalRef {
 	return proposalRef{
-		ClientID:   p.Transfer.ClientID,
+		ClientID:   p.clientID(),
 		ProposalID: p.ProposalID,
 	}
 }
 
 func (p proposal) getKey() datastore.Key {
-	return getProposalKey(p.Transfer.ClientID, p.ProposalID)
+	return getProposalKey(p.clientID(), p.ProposalID)
 }
 
 func getProposalKey(clientID, proposalID string) datastore.Key {
@@ -3,16 +3,123 @@ package multisigsc
 // Code generated by github.com/tinylib/msgp DO NOT EDIT.
 
 import (
+	"encoding/json"
+
 	"github.com/tinylib/msgp/msgp"
 )
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *SmartContractCall) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 6
 	// string "ClientID"
 	o = append(o, 0x86, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ClientID)
+	// string "Address"
+	o = append(o, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendString(o, z.Address)
+	// string "FunctionName"
+	o = append(o, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.FunctionName)
+	// string "InputData"
+	o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendBytes(o, []byte(z.InputData))
+	// string "Value"
+	o = append(o, 0xa5, 0x56, 0x61, 0x6c, 0x75, 0x65)
+	o, err = z.Value.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Value")
+		return
+	}
+	// string "Nonce"
+	o = append(o, 0xa5, 0x4e, 0x6f, 0x6e, 0x63, 0x65)
+	o = msgp.AppendInt64(o, z.Nonce)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SmartContractCall) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "Address":
+			z.Address, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "FunctionName":
+			z.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FunctionName")
+				return
+			}
+		case "InputData":
+			{
+				var zb0002 []byte
+				zb0002, bts, err = msgp.ReadBytesBytes(bts, []byte(z.InputData))
+				if err != nil {
+					err = msgp.WrapError(err, "InputData")
+					return
+				}
+				z.InputData = json.RawMessage(zb0002)
+			}
+		case "Value":
+			bts, err = z.Value.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Value")
+				return
+			}
+		case "Nonce":
+			z.Nonce, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nonce")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SmartContractCall) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 8 + msgp.StringPrefixSize + len(z.Address) + 13 + msgp.StringPrefixSize + len(z.FunctionName) + 10 + msgp.BytesPrefixSize + len([]byte(z.InputData)) + 6 + z.Value.Msgsize() + 6 + msgp.Int64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "ClientID"
+	o = append(o, 0x87, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
 	// string "SignatureScheme"
 	o = append(o, 0xaf, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65)
 	o = msgp.AppendString(o, z.SignatureScheme)
@@ -34,6 +141,9 @@ func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "NumRequired"
 	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
 	o = msgp.AppendInt(o, z.NumRequired)
+	// string "Nonce"
+	o = append(o, 0xa5, 0x4e, 0x6f, 0x6e, 0x63, 0x65)
+	o = msgp.AppendInt64(o, z.Nonce)
 	return
 }
 
@@ -117,6 +227,12 @@ func (z *Wallet) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "NumRequired")
 				return
 			}
+		case "Nonce":
+			z.Nonce, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nonce")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -139,7 +255,135 @@ func (z *Wallet) Msgsize() (s int) {
 	for za0002 := range z.SignerPublicKeys {
 		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
 	}
-	s += 12 + msgp.IntSize
+	s += 12 + msgp.IntSize + 6 + msgp.Int64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *WalletUpdate) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "ClientID"
+	o = append(o, 0x85, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "SignerThresholdIDs"
+	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
+	for za0001 := range z.SignerThresholdIDs {
+		o = msgp.AppendString(o, z.SignerThresholdIDs[za0001])
+	}
+	// string "SignerPublicKeys"
+	o = append(o, 0xb0, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerPublicKeys)))
+	for za0002 := range z.SignerPublicKeys {
+		o = msgp.AppendString(o, z.SignerPublicKeys[za0002])
+	}
+	// string "NumRequired"
+	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendInt(o, z.NumRequired)
+	// string "Nonce"
+	o = append(o, 0xa5, 0x4e, 0x6f, 0x6e, 0x63, 0x65)
+	o = msgp.AppendInt64(o, z.Nonce)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *WalletUpdate) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "SignerThresholdIDs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerThresholdIDs")
+				return
+			}
+			if cap(z.SignerThresholdIDs) >= int(zb0002) {
+				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0002]
+			} else {
+				z.SignerThresholdIDs = make([]string, zb0002)
+			}
+			for za0001 := range z.SignerThresholdIDs {
+				z.SignerThresholdIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerThresholdIDs", za0001)
+					return
+				}
+			}
+		case "SignerPublicKeys":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerPublicKeys")
+				return
+			}
+			if cap(z.SignerPublicKeys) >= int(zb0003) {
+				z.SignerPublicKeys = (z.SignerPublicKeys)[:zb0003]
+			} else {
+				z.SignerPublicKeys = make([]string, zb0003)
+			}
+			for za0002 := range z.SignerPublicKeys {
+				z.SignerPublicKeys[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerPublicKeys", za0002)
+					return
+				}
+			}
+		case "NumRequired":
+			z.NumRequired, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NumRequired")
+				return
+			}
+		case "Nonce":
+			z.Nonce, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nonce")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *WalletUpdate) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 19 + msgp.ArrayHeaderSize
+	for za0001 := range z.SignerThresholdIDs {
+		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0002 := range z.SignerPublicKeys {
+		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
+	}
+	s += 12 + msgp.IntSize + 6 + msgp.Int64Size
 	return
 }
 
@@ -277,9 +521,9 @@ func (z *expirationQueue) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 11
 	// string "ProposalID"
-	o = append(o, 0x89, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
+	o = append(o, 0x8b, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ProposalID)
 	// string "ExpirationDate"
 	o = append(o, 0xae, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65)
@@ -313,6 +557,28 @@ func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Transfer")
 		return
 	}
+	// string "Call"
+	o = append(o, 0xa4, 0x43, 0x61, 0x6c, 0x6c)
+	if z.Call == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Call.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Call")
+			return
+		}
+	}
+	// string "Update"
+	o = append(o, 0xa6, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65)
+	if z.Update == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Update.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Update")
+			return
+		}
+	}
 	// string "SignerThresholdIDs"
 	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
@@ -440,6 +706,40 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Transfer")
 				return
 			}
+		case "Call":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Call = nil
+			} else {
+				if z.Call == nil {
+					z.Call = new(SmartContractCall)
+				}
+				bts, err = z.Call.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Call")
+					return
+				}
+			}
+		case "Update":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Update = nil
+			} else {
+				if z.Update == nil {
+					z.Update = new(WalletUpdate)
+				}
+				bts, err = z.Update.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Update")
+					return
+				}
+			}
 		case "SignerThresholdIDs":
 			var zb0004 uint32
 			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
@@ -504,7 +804,19 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *proposal) Msgsize() (s int) {
-	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 19 + msgp.ArrayHeaderSize
+	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 5
+	if z.Call == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Call.Msgsize()
+	}
+	s += 7
+	if z.Update == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Update.Msgsize()
+	}
+	s += 19 + msgp.ArrayHeaderSize
 	for za0001 := range z.SignerThresholdIDs {
 		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
 	}
@@ -63,6 +63,19 @@ func (ms *MultiSigSmartContract) GetCostTable(balances c_state.StateContextI) (m
 	return map[string]int{}, nil
 }
 
+// ForwardedCall returns the smart contract function of the call of a vote, the
+// vote is charged the cost of the call.
+func (ms *MultiSigSmartContract) ForwardedCall(funcName string, inputData []byte) (string, string, bool) {
+	if funcName != VoteFuncName {
+		return "", "", false
+	}
+	var v Vote
+	if err := json.Unmarshal(inputData, &v); err != nil || v.Call == nil || v.Call.Address == Address {
+		return "", "", false
+	}
+	return v.Call.Address, v.Call.FunctionName, true
+}
+
 func (ms *MultiSigSmartContract) Execute(t *transaction.Transaction, funcName string, inputData []byte, balances state.StateContextI) (string, error) {
 	if LogTimingInfo {
 		start := time.Now().UnixNano()
@@ -73,7 +86,7 @@ func (ms *MultiSigSmartContract) Execute(t *transaction.Transaction, funcName st
 	case RegisterFuncName:
 		return ms.register(t.ClientID, inputData, balances)
 	case VoteFuncName:
-		return ms.vote(t.Hash, t.ClientID, balances.GetBlock().CreationDate, inputData, balances)
+		return ms.vote(t, balances.GetBlock().CreationDate, inputData, balances)
 	default:
 		return "err_execute_function_not_found: no multi sig smart contract function with that name: " + funcName, nil
 	}
@@ -128,7 +141,9 @@ func (ms MultiSigSmartContract) register(registeringClientID string, inputData [
 	return "success: multi-signature wallet registered", nil
 }
 
-func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now common.Timestamp, inputData []byte, balances state.StateContextI) (string, error) {
+func (ms MultiSigSmartContract) vote(t *transaction.Transaction, now common.Timestamp, inputData []byte, balances state.StateContextI) (string, error) {
+	signingClientID := t.ClientID
+
 	// Garbage collection of old proposals happens incrementally with every
 	// incoming vote.
 	err := ms.pruneExpirationQueue(now, balances)
@@ -150,6 +165,9 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 	if !v.notTooBig() {
 		return "", common.NewError("err_vote_too_big", "an input field exceeded allowable length")
 	}
+	if !v.hasOneAction() {
+		return "", common.NewError("err_vote_invalid_action", "must vote for one of a transfer, a call or an update")
+	}
 	if !v.hasValidAmount() {
 		return "", common.NewError("err_vote_invalid_tokens", "invalid number of tokens to send")
 	}
@@ -176,7 +194,7 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 	}
 
 	// Check that the multi-sig wallet is registered.
-	w, err := ms.getWallet(v.Transfer.ClientID, balances)
+	w, err := ms.getWallet(v.clientID(), balances)
 	if err != nil {
 		// I/O error.
 		return "", err
@@ -185,6 +203,11 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "", common.NewError("err_vote_wallet_not_registered", " wallet not registered")
 	}
 
+	// Calls and updates are for the current wallet nonce only.
+	if nonce, ok := v.nonce(); ok && nonce != w.Nonce {
+		return "", common.NewError("err_vote_nonce", fmt.Sprintf(" wallet nonce is %d", w.Nonce))
+	}
+
 	// Check that the voter is registered on the wallet and that the signature
 	// is valid.
 	signerThresholdID := w.thresholdIdForSigner(signingClientID)
@@ -223,31 +246,41 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return msg, nil
 	}
 
-	// Otherwise we can recover the threshold signature on the transfer and
+	// Otherwise we can recover the threshold signature on the proposal and
 	// execute it.
-	thresholdSignature, err := w.constructTransferSignature(p)
+	thresholdSignature, err := w.constructSignature(p)
 	if err != nil {
 		return "", common.NewError("err_vote_recover", " in signature recovery: "+err.Error())
 	}
 
 	p.ClientSignature = thresholdSignature
-
-	// Request the transfer. The blockchain will validate the signature and
-	// execute the transfer soon. If the signature is found to be invalid,
-	// this vote transaction will fail.
-	signedTransfer := w.makeSignedTransferForProposal(p)
-	balances.AddSignedTransfer(&signedTransfer)
+	p.ExecutedInTxnHash = t.Hash
+
+	var msg string
+	switch {
+	case p.Call != nil:
+		msg, err = ms.executeCall(t, w, p, balances)
+	case p.Update != nil:
+		msg, err = ms.updateWallet(w, p, balances)
+	default:
+		// Request the transfer. The blockchain will validate the signature
+		// and execute the transfer soon. If the signature is found to be
+		// invalid, this vote transaction will fail.
+		signedTransfer := w.makeSignedTransferForProposal(p)
+		balances.AddSignedTransfer(&signedTransfer)
+		msg = "success 0: transfer executed with signature " + p.ClientSignature
+	}
+	if err != nil {
+		return "", err
+	}
 
 	// Save the proposal again.
-	p.ExecutedInTxnHash = currentTxnHash
-
 	err = ms.putProposal(&p, balances)
 	if err != nil {
 		// I/O error.
 		return "", err
 	}
 
-	msg := "success 0: transfer executed with signature " + p.ClientSignature
 	return msg, nil
 }
 
@@ -393,6 +426,8 @@ func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, bal
 		Prev: q.Tail,
 
 		Transfer: v.Transfer,
+		Call:     v.Call,
+		Update:   v.Update,
 
 		SignerThresholdIDs: []string{},
 		SignerSignatures:   []string{},
@@ -0,0 +1,393 @@
+package multisigsc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testCallAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a7671200"
+
+type testBalances struct {
+	cstate.StateContextI
+	tree      map[datastore.Key][]byte
+	transfers []*state.Transfer
+	block     *block.Block
+}
+
+func newTestBalances() *testBalances {
+	return &testBalances{tree: make(map[datastore.Key][]byte)}
+}
+
+func (tb *testBalances) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	b, ok := tb.tree[key]
+	if !ok {
+		return util.ErrValueNotPresent
+	}
+	_, err := v.UnmarshalMsg(b)
+	return err
+}
+
+func (tb *testBalances) InsertTrieNode(key datastore.Key, v util.MPTSerializable) (datastore.Key, error) {
+	b, err := v.MarshalMsg(nil)
+	if err != nil {
+		return "", err
+	}
+	tb.tree[key] = b
+	return key, nil
+}
+
+func (tb *testBalances) DeleteTrieNode(key datastore.Key) (datastore.Key, error) {
+	delete(tb.tree, key)
+	return key, nil
+}
+
+func (tb *testBalances) GetBlock() *block.Block {
+	return tb.block
+}
+
+func (tb *testBalances) AddTransfer(t *state.Transfer) error {
+	tb.transfers = append(tb.transfers, t)
+	return nil
+}
+
+func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer) {
+	tb.transfers = append(tb.transfers, &st.Transfer)
+}
+
+// testContract records its calls and spends the spend input from the caller
+type testContract struct {
+	sci.SmartContractInterface
+	txns []*transaction.Transaction
+}
+
+func (tc *testContract) Execute(t *transaction.Transaction, _ string, input []byte, balances cstate.StateContextI) (string, error) {
+	tc.txns = append(tc.txns, t)
+	var in struct {
+		Spend currency.Coin `json:"spend"`
+	}
+	if err := json.Unmarshal(input, &in); err != nil {
+		return "", err
+	}
+	if err := balances.AddTransfer(state.NewTransfer(t.ClientID, t.ToClientID, in.Spend)); err != nil {
+		return "", err
+	}
+	return "called", nil
+}
+
+func (tc *testContract) GetExecutionStats() map[string]interface{} {
+	return nil
+}
+
+func (tc *testContract) GetCostTable(cstate.StateContextI) (map[string]int, error) {
+	return map[string]int{"stake_pool_lock": 300}, nil
+}
+
+type testSigner struct {
+	clientID string
+	key      encryption.ThresholdSignatureScheme
+}
+
+func clientIDForPublicKey(t *testing.T, publicKey string) string {
+	b, err := hex.DecodeString(publicKey)
+	require.NoError(t, err)
+	return encryption.Hash(b)
+}
+
+func newSigners(t *testing.T, groupKey encryption.SignatureScheme, required, n int) []testSigner {
+	keys, err := encryption.GenerateThresholdKeyShares(encryption.SignatureSchemeBls0chain, required, n, groupKey)
+	require.NoError(t, err)
+
+	signers := make([]testSigner, 0, n)
+	for _, key := range keys {
+		signers = append(signers, testSigner{
+			clientID: clientIDForPublicKey(t, key.GetPublicKey()),
+			key:      key,
+		})
+	}
+	return signers
+}
+
+func signerIDsAndKeys(signers []testSigner) (ids, keys []string) {
+	for _, s := range signers {
+		ids = append(ids, s.key.GetID())
+		keys = append(keys, s.key.GetPublicKey())
+	}
+	return
+}
+
+func setupWallet(t *testing.T, balances *testBalances) (Wallet, encryption.SignatureScheme, []testSigner) {
+	groupKey := encryption.GetSignatureScheme(encryption.SignatureSchemeBls0chain)
+	require.NoError(t, groupKey.GenerateKeys())
+	signers := newSigners(t, groupKey, 2, 3)
+
+	ids, keys := signerIDsAndKeys(signers)
+	w := Wallet{
+		ClientID:           clientIDForPublicKey(t, groupKey.GetPublicKey()),
+		SignatureScheme:    encryption.SignatureSchemeBls0chain,
+		PublicKey:          groupKey.GetPublicKey(),
+		SignerThresholdIDs: ids,
+		SignerPublicKeys:   keys,
+		NumRequired:        2,
+	}
+
+	_, err := MultiSigSmartContract{}.register(w.ClientID, w.Encode(), balances)
+	require.NoError(t, err)
+	return w, groupKey, signers
+}
+
+func vote(t *testing.T, balances *testBalances, signer testSigner, v Vote) (string, error) {
+	sig, err := signer.key.Sign(v.hash())
+	require.NoError(t, err)
+	v.Signature = sig
+
+	input, err := json.Marshal(&v)
+	require.NoError(t, err)
+
+	txn := &transaction.Transaction{
+		HashIDField: datastore.HashIDField{Hash: encryption.Hash(v.ProposalID + signer.clientID)},
+		ClientID:    signer.clientID,
+		ToClientID:  Address,
+	}
+	return MultiSigSmartContract{}.vote(txn, common.Timestamp(100), input, balances)
+}
+
+func TestVoteCall(t *testing.T) {
+	contract := &testContract{}
+	smartcontract.ContractMap[testCallAddress] = contract
+	defer delete(smartcontract.ContractMap, testCallAddress)
+
+	balances := newTestBalances()
+	w, _, signers := setupWallet(t, balances)
+
+	call := &SmartContractCall{
+		ClientID:     w.ClientID,
+		Address:      testCallAddress,
+		FunctionName: "stake_pool_lock",
+		InputData:    json.RawMessage(`{"spend":10}`),
+		Value:        10,
+	}
+
+	resp, err := vote(t, balances, signers[0], Vote{ProposalID: "p1", Call: call})
+	require.NoError(t, err)
+	require.Equal(t, "success 1: need 1 more votes", resp)
+	require.Empty(t, contract.txns)
+
+	resp, err = vote(t, balances, signers[2], Vote{ProposalID: "p1", Call: call})
+	require.NoError(t, err)
+	require.Equal(t, "success 0: call executed with output called", resp)
+
+	require.Len(t, contract.txns, 1)
+	txn := contract.txns[0]
+	require.Equal(t, w.ClientID, txn.ClientID)
+	require.Equal(t, testCallAddress, txn.ToClientID)
+	require.Equal(t, currency.Coin(10), txn.Value)
+	require.Equal(t, "stake_pool_lock", txn.FunctionName)
+	require.Equal(t, []*state.Transfer{state.NewTransfer(w.ClientID, testCallAddress, 10)}, balances.transfers)
+
+	w, err = MultiSigSmartContract{}.getWallet(w.ClientID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, w.Nonce)
+
+	t.Run("executed", func(t *testing.T) {
+		resp, err := vote(t, balances, signers[1], Vote{ProposalID: "p1", Call: call})
+		require.NoError(t, err)
+		require.Contains(t, resp, "proposal previously executed")
+		require.Len(t, contract.txns, 1)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		_, err := vote(t, balances, signers[0], Vote{ProposalID: "p2", Call: call})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "err_vote_nonce")
+	})
+
+	t.Run("spend more than the value", func(t *testing.T) {
+		over := *call
+		over.Nonce = 1
+		over.InputData = json.RawMessage(`{"spend":11}`)
+		_, err := vote(t, balances, signers[0], Vote{ProposalID: "p3", Call: &over})
+		require.NoError(t, err)
+		_, err = vote(t, balances, signers[1], Vote{ProposalID: "p3", Call: &over})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "err_call_failed")
+	})
+
+	t.Run("multi-sig call", func(t *testing.T) {
+		self := *call
+		self.Nonce = 1
+		self.Address = Address
+		_, err := vote(t, balances, signers[0], Vote{ProposalID: "p4", Call: &self})
+		require.NoError(t, err)
+		_, err = vote(t, balances, signers[1], Vote{ProposalID: "p4", Call: &self})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "err_call_not_allowed")
+	})
+
+	t.Run("transfer and call", func(t *testing.T) {
+		_, err := vote(t, balances, signers[0], Vote{
+			ProposalID: "p5",
+			Transfer:   *state.NewTransfer(w.ClientID, testCallAddress, 1),
+			Call:       call,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "err_vote_invalid_action")
+	})
+
+	t.Run("incompatible votes", func(t *testing.T) {
+		other := *call
+		other.Nonce = 1
+		_, err := vote(t, balances, signers[0], Vote{ProposalID: "p6", Call: &other})
+		require.NoError(t, err)
+		other.Value = 20
+		_, err = vote(t, balances, signers[1], Vote{ProposalID: "p6", Call: &other})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "err_vote_not_compatible")
+	})
+}
+
+func TestVoteUpdate(t *testing.T) {
+	balances := newTestBalances()
+	w, groupKey, signers := setupWallet(t, balances)
+
+	// new shares of the same wallet key
+	rotated := newSigners(t, groupKey, 3, 4)
+	ids, keys := signerIDsAndKeys(rotated)
+	update := &WalletUpdate{
+		ClientID:           w.ClientID,
+		SignerThresholdIDs: ids,
+		SignerPublicKeys:   keys,
+		NumRequired:        3,
+	}
+
+	_, err := vote(t, balances, signers[0], Vote{ProposalID: "rotate", Update: update})
+	require.NoError(t, err)
+	resp, err := vote(t, balances, signers[1], Vote{ProposalID: "rotate", Update: update})
+	require.NoError(t, err)
+	require.Equal(t, "success 0: wallet updated, 3 of 4 signers required", resp)
+
+	w, err = MultiSigSmartContract{}.getWallet(w.ClientID, balances)
+	require.NoError(t, err)
+	require.Equal(t, ids, w.SignerThresholdIDs)
+	require.Equal(t, keys, w.SignerPublicKeys)
+	require.Equal(t, 3, w.NumRequired)
+	require.EqualValues(t, 1, w.Nonce)
+
+	transfer := *state.NewTransfer(w.ClientID, testCallAddress, 5)
+
+	// the previous signers can't vote anymore
+	_, err = vote(t, balances, signers[0], Vote{ProposalID: "send", Transfer: transfer})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "err_vote_auth")
+
+	// the new signers sign with the wallet key
+	for i, signer := range rotated[:3] {
+		resp, err = vote(t, balances, signer, Vote{ProposalID: "send", Transfer: transfer})
+		require.NoError(t, err, i)
+	}
+	require.Contains(t, resp, "success 0: transfer executed with signature ")
+	require.Equal(t, []*state.Transfer{&transfer}, balances.transfers)
+
+	p, err := MultiSigSmartContract{}.getProposal(proposalRef{ClientID: w.ClientID, ProposalID: "send"}, balances)
+	require.NoError(t, err)
+	st := w.makeSignedTransferForProposal(p)
+	require.NoError(t, st.VerifySignature(true))
+
+	t.Run("invalid update", func(t *testing.T) {
+		invalid := &WalletUpdate{
+			ClientID:           w.ClientID,
+			SignerThresholdIDs: ids,
+			SignerPublicKeys:   keys,
+			NumRequired:        5,
+			Nonce:              1,
+		}
+		for _, signer := range rotated[:2] {
+			_, err := vote(t, balances, signer, Vote{ProposalID: "invalid", Update: invalid})
+			require.NoError(t, err)
+		}
+		_, err := vote(t, balances, rotated[2], Vote{ProposalID: "invalid", Update: invalid})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "too_many_signers_required")
+	})
+
+	t.Run("shares of another key", func(t *testing.T) {
+		otherKey := encryption.GetSignatureScheme(encryption.SignatureSchemeBls0chain)
+		require.NoError(t, otherKey.GenerateKeys())
+		ids, keys := signerIDsAndKeys(newSigners(t, otherKey, 2, 3))
+		invalid := &WalletUpdate{
+			ClientID:           w.ClientID,
+			SignerThresholdIDs: ids,
+			SignerPublicKeys:   keys,
+			NumRequired:        2,
+			Nonce:              1,
+		}
+		for _, signer := range rotated[:2] {
+			_, err := vote(t, balances, signer, Vote{ProposalID: "foreign", Update: invalid})
+			require.NoError(t, err)
+		}
+		_, err := vote(t, balances, rotated[2], Vote{ProposalID: "foreign", Update: invalid})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "err_update_invalid_signers")
+
+		w, err := MultiSigSmartContract{}.getWallet(w.ClientID, balances)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, w.Nonce)
+	})
+}
+
+func TestVoteCallCost(t *testing.T) {
+	smartcontract.ContractMap[testCallAddress] = &testContract{}
+	smartcontract.ContractMap[Address] = NewMultiSigSmartContract()
+	defer func() {
+		delete(smartcontract.ContractMap, testCallAddress)
+		delete(smartcontract.ContractMap, Address)
+	}()
+
+	voteData := func(v Vote) sci.SmartContractTransactionData {
+		input, err := json.Marshal(&v)
+		require.NoError(t, err)
+		return sci.SmartContractTransactionData{FunctionName: VoteFuncName, InputData: input}
+	}
+	call := &SmartContractCall{Address: testCallAddress, FunctionName: "stake_pool_lock"}
+	txn := &transaction.Transaction{ToClientID: Address}
+	balances := newTestBalances()
+	balances.block = &block.Block{}
+	balances.block.Round = 10
+
+	// a vote is charged the vote before the hermes hardfork
+	address, name, err := smartcontract.CostFunction(Address, voteData(Vote{Call: call}), balances)
+	require.NoError(t, err)
+	require.Equal(t, Address, address)
+	require.Equal(t, VoteFuncName, name)
+
+	_, err = balances.InsertTrieNode(cstate.NewHardFork("hermes", 10).GetKey(), cstate.NewHardFork("hermes", 10))
+	require.NoError(t, err)
+
+	// a vote with a call is charged the cost of the called function
+	address, name, err = smartcontract.CostFunction(Address, voteData(Vote{Call: call}), balances)
+	require.NoError(t, err)
+	require.Equal(t, testCallAddress, address)
+	require.Equal(t, "stake_pool_lock", name)
+	cost, err := smartcontract.EstimateTransactionCost(txn, voteData(Vote{Call: call}), balances)
+	require.NoError(t, err)
+	require.Equal(t, 300, cost)
+
+	// a transfer vote is charged the vote
+	address, name, err = smartcontract.CostFunction(Address, voteData(Vote{}), balances)
+	require.NoError(t, err)
+	require.Equal(t, Address, address)
+	require.Equal(t, VoteFuncName, name)
+}
@@ -7,6 +7,7 @@ import (
 	sci "0chain.net/chaincore/smartcontractinterface"
 	"0chain.net/core/viper"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/govsc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/storagesc"
@@ -23,6 +24,7 @@ const (
 	Miner
 	Vesting
 	Zcn
+	Governance
 )
 
 var (
@@ -33,15 +35,17 @@ var (
 		"miner",
 		"vesting",
 		"zcn",
+		"governance",
 	}
 
 	SCCode = map[string]SCName{
-		"faucet":   Faucet,
-		"storage":  Storage,
-		"multisig": Multisig,
-		"miner":    Miner,
-		"vesting":  Vesting,
-		"zcn":      Zcn,
+		"faucet":     Faucet,
+		"storage":    Storage,
+		"multisig":   Multisig,
+		"miner":      Miner,
+		"vesting":    Vesting,
+		"zcn":        Zcn,
+		"governance": Governance,
 	}
 )
 
@@ -73,6 +77,8 @@ func newSmartContract(name string) sci.SmartContractInterface {
 		return vestingsc.NewVestingSmartContract()
 	case Zcn:
 		return zcnsc.NewZCNSmartContract()
+	case Governance:
+		return govsc.NewGovernanceSmartContract()
 	default:
 		return nil
 	}
@@ -117,7 +117,6 @@ func (tb *testBalances) GetState() util.MerklePatriciaTrieI          { return ni
 func (tb *testBalances) Validate() error                             { return nil }
 func (tb *testBalances) GetMints() []*state.Mint                     { return nil }
 func (tb *testBalances) SetStateContext(*state.State) error          { return nil }
-func (tb *testBalances) AddMint(*state.Mint) error                   { return nil }
 func (tb *testBalances) GetTransfers() []*state.Transfer             { return nil }
 func (tb *testBalances) GetMagicBlock(round int64) *block.MagicBlock { return nil }
 func (tb *testBalances) SetMagicBlock(block *block.MagicBlock)       {}
@@ -252,6 +251,10 @@ func (tb *testBalances) AddTransfer(t *state.Transfer) error {
 	return nil
 }
 
+func (tb *testBalances) AddMint(m *state.Mint) error {
+	return tb.AddTransfer(state.NewTransfer(m.Minter, m.ToClientID, m.Amount))
+}
+
 func (tb *testBalances) GetInvalidStateErrors() []error { return nil }
 
 func (tb *testBalances) Cache() *statecache.TransactionCache {
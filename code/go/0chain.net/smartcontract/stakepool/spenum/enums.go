@@ -78,6 +78,7 @@ const (
 	ChallengePassReward
 	ChallengeSlashPenalty
 	CancellationChargeReward
+	AuthorizerSlashPenalty
 	NumOfRewards
 )
 
@@ -93,6 +94,7 @@ func initRewardString() {
 	rewardString[ChallengePassReward] = "challenge_pass_reward"
 	rewardString[ChallengeSlashPenalty] = "challenge_slash"
 	rewardString[CancellationChargeReward] = "cancellation_charge"
+	rewardString[AuthorizerSlashPenalty] = "authorizer_slash"
 	rewardString[NumOfRewards] = "invalid"
 }
 
@@ -249,11 +249,7 @@ func (sp *StakePool) MintServiceCharge(balances cstate.StateContextI) (currency.
 		return 0, err
 	}
 
-	if err := balances.AddTransfer(&state.Transfer{
-		ClientID:   minter,
-		ToClientID: sp.Settings.DelegateWallet,
-		Amount:     sp.Reward,
-	}); err != nil {
+	if err := balances.AddMint(state.NewMint(minter, sp.Settings.DelegateWallet, sp.Reward)); err != nil {
 		return 0, fmt.Errorf("could not transfer rewards: %v", err)
 	}
 
@@ -293,11 +289,7 @@ func (sp *StakePool) MintRewards(
 		if err != nil {
 			return 0, err
 		}
-		if err := balances.AddTransfer(&state.Transfer{
-			ClientID:   minter,
-			ToClientID: clientId,
-			Amount:     dPool.Reward,
-		}); err != nil {
+		if err := balances.AddMint(state.NewMint(minter, clientId, dPool.Reward)); err != nil {
 			return 0, fmt.Errorf("could not transfer rewards: %v", err)
 		}
 		balances.EmitEvent(event.TypeStats, event.TagMintReward, clientId, event.RewardMint{
@@ -0,0 +1,162 @@
+package storagesc
+
+import (
+	"context"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/util"
+)
+
+// auditBatchSize is the number of rows read from the event db at once
+const auditBatchSize = 100
+
+// AuditEventDb compares the blobbers, their stake pools and delegate pools
+// and the allocations of the event db with the objects of the MPT of the
+// state context, the differences are added to the report.
+//
+// Blobbers and allocations are read from the event db, an object of the MPT
+// without a row is not reported.
+func AuditEventDb(ctx context.Context, edb *event.EventDb,
+	balances cstate.CommonStateContextI, report *event.AuditReport) error {
+
+	if err := auditBlobbers(ctx, edb, balances, report); err != nil {
+		return fmt.Errorf("audit blobbers: %v", err)
+	}
+	if err := auditAllocations(ctx, edb, balances, report); err != nil {
+		return fmt.Errorf("audit allocations: %v", err)
+	}
+	return nil
+}
+
+func auditBlobbers(ctx context.Context, edb *event.EventDb,
+	balances cstate.CommonStateContextI, report *event.AuditReport) error {
+
+	var last string
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		blobbers, err := edb.GetAuditBlobbers(last, auditBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range blobbers {
+			if err := auditBlobber(edb, &blobbers[i], balances, report); err != nil {
+				return err
+			}
+		}
+		if len(blobbers) < auditBatchSize {
+			return nil
+		}
+		last = blobbers[len(blobbers)-1].ID
+	}
+}
+
+func auditBlobber(edb *event.EventDb, row *event.Blobber,
+	balances cstate.CommonStateContextI, report *event.AuditReport) error {
+
+	sn, err := getBlobber(row.ID, balances)
+	if err == util.ErrValueNotPresent {
+		report.Missing("blobbers", row.ID, "missing in MPT")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	sp, err := getStakePool(spenum.Blobber, row.ID, balances)
+	if err == util.ErrValueNotPresent {
+		report.Missing("stake_pools", row.ID, "missing in MPT")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	mpt, err := storageNodeToBlobberTable(sn, sp)
+	if err != nil {
+		return err
+	}
+	if err := report.CompareBlobber(row, mpt); err != nil {
+		return err
+	}
+
+	return auditDelegatePools(edb, spenum.Blobber, row.ID, sp, report)
+}
+
+func auditDelegatePools(edb *event.EventDb, pType spenum.Provider, pID string,
+	sp *stakePool, report *event.AuditReport) error {
+
+	dps, err := edb.GetProviderDelegatePools(pType, pID)
+	if err != nil {
+		return err
+	}
+	rows := make(map[string]*event.DelegatePool, len(dps))
+	for i := range dps {
+		rows[dps[i].PoolID] = &dps[i]
+	}
+
+	for _, poolID := range sp.OrderedPoolIds() {
+		dp := sp.Pools[poolID]
+		err := report.CompareDelegatePool(rows[poolID], &event.DelegatePool{
+			PoolID:       poolID,
+			ProviderType: pType,
+			ProviderID:   pID,
+			DelegateID:   dp.DelegateID,
+			Balance:      dp.Balance,
+			Reward:       dp.Reward,
+			Status:       dp.Status,
+			RoundCreated: dp.RoundCreated,
+			StakedAt:     dp.StakedAt,
+		})
+		if err != nil {
+			return err
+		}
+		delete(rows, poolID)
+	}
+
+	for poolID := range rows {
+		report.Missing("delegate_pools",
+			fmt.Sprintf("%s:%s:%s", pType, pID, poolID), "missing in MPT")
+	}
+	return nil
+}
+
+func auditAllocations(ctx context.Context, edb *event.EventDb,
+	balances cstate.CommonStateContextI, report *event.AuditReport) error {
+
+	var last string
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		allocs, err := edb.GetAuditAllocations(last, auditBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range allocs {
+			row := &allocs[i]
+			sa := &StorageAllocation{ID: row.AllocationID}
+			err := balances.GetTrieNode(sa.GetKey(ADDRESS), sa)
+			if err == util.ErrValueNotPresent {
+				report.Missing("allocations", row.AllocationID, "missing in MPT")
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := report.CompareAllocation(row, storageAllocationToAllocationTable(sa)); err != nil {
+				return err
+			}
+		}
+		if len(allocs) < auditBatchSize {
+			return nil
+		}
+		last = allocs[len(allocs)-1].AllocationID
+	}
+}
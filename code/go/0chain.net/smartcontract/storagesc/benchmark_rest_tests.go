@@ -243,6 +243,22 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getCollectedReward,
 			},
+			{
+				FuncName: "provider-history",
+				Params: map[string]string{
+					"id":     getMockBlobberId(0),
+					"type":   spenum.Blobber.String(),
+					"bucket": "1h",
+				},
+				Endpoint: srh.getProviderHistory,
+			},
+			{
+				FuncName: "network-history",
+				Params: map[string]string{
+					"bucket": "1h",
+				},
+				Endpoint: srh.getNetworkHistory,
+			},
 			{
 				FuncName: "alloc-blobbers",
 				Params: map[string]string{
@@ -7,10 +7,21 @@ import (
 )
 
 func emitUpdateBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateContextI) error {
-	staked, err := sp.stake()
+	data, err := storageNodeToBlobberTable(sn, sp)
 	if err != nil {
 		return err
 	}
+
+	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, data.ID, data)
+	return nil
+}
+
+// storageNodeToBlobberTable returns the blobber row of the blobber updates
+func storageNodeToBlobberTable(sn *StorageNode, sp *stakePool) (*event.Blobber, error) {
+	staked, err := sp.stake()
+	if err != nil {
+		return nil, err
+	}
 	b := sn.mustBase()
 	data := &event.Blobber{
 		BaseURL:    b.BaseURL,
@@ -36,9 +47,7 @@ func emitUpdateBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateCont
 	if v2, ok := sn.Entity().(*storageNodeV2); ok && v2.IsRestricted != nil {
 		data.IsRestricted = *v2.IsRestricted
 	}
-
-	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobber, b.ID, data)
-	return nil
+	return data, nil
 }
 
 func emitAddBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateContextI) error {
@@ -742,9 +742,8 @@ func (ssc *StorageSmartContract) updateSettings(
 			"can't get config: "+err.Error())
 	}
 
-	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
-		return conf.OwnerId == t.ClientID
-	}); err != nil {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_settings",
+		conf.OwnerId, t.ClientID); err != nil {
 		return "", err
 	}
 
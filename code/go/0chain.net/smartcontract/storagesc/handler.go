@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -82,6 +83,8 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/getChallengePoolStat", common.UserRateLimit(srh.getChallengePoolStat)),
 		rest.MakeEndpoint(storage+"/alloc_write_marker_count", common.UserRateLimit(srh.getWriteMarkerCount)),
 		rest.MakeEndpoint(storage+"/collected_reward", common.UserRateLimit(srh.getCollectedReward)),
+		rest.MakeEndpoint(storage+"/provider-history", common.UserRateLimit(srh.getProviderHistory)),
+		rest.MakeEndpoint(storage+"/network-history", common.UserRateLimit(srh.getNetworkHistory)),
 		rest.MakeEndpoint(storage+"/blobber_ids", common.UserRateLimit(srh.getBlobberIdsByUrls)),
 		rest.MakeEndpoint(storage+"/alloc_blobbers", common.UserRateLimit(srh.getAllocationBlobbers)),
 		rest.MakeEndpoint(storage+"/free_alloc_blobbers", common.UserRateLimit(srh.getFreeAllocationBlobbers)),
@@ -3012,3 +3015,157 @@ func (srh StorageRestHandler) getSearchHandler(w http.ResponseWriter, r *http.Re
 
 	common.Respond(w, r, nil, common.NewErrInternal("Request failed, searchString isn't a (wallet address)/(block hash)/(txn hash)/(round num)/(content hash)/(file name)"))
 }
+
+// defaultHistoryRange is the time range of a history query without from
+const defaultHistoryRange = 30 * 24 * time.Hour
+
+// getHistoryQuery parses the time range and the bucket duration of a history
+// query, the bucket is a duration like 1h or a number of seconds
+func getHistoryQuery(values url.Values) (event.HistoryQuery, error) {
+	q := event.HistoryQuery{
+		To:     time.Now().Unix(),
+		Bucket: int64((24 * time.Hour).Seconds()),
+	}
+
+	var err error
+	if to := values.Get("to"); to != "" {
+		if q.To, err = strconv.ParseInt(to, 10, 64); err != nil {
+			return q, common.NewErrBadRequest("to is not valid")
+		}
+	}
+	q.From = q.To - int64(defaultHistoryRange.Seconds())
+	if from := values.Get("from"); from != "" {
+		if q.From, err = strconv.ParseInt(from, 10, 64); err != nil {
+			return q, common.NewErrBadRequest("from is not valid")
+		}
+	}
+	if bucket := values.Get("bucket"); bucket != "" {
+		if q.Bucket, err = strconv.ParseInt(bucket, 10, 64); err != nil {
+			d, err := time.ParseDuration(bucket)
+			if err != nil {
+				return q, common.NewErrBadRequest("bucket is not valid")
+			}
+			q.Bucket = int64(d.Seconds())
+		}
+	}
+
+	if err := q.Validate(); err != nil {
+		return q, common.NewErrBadRequest(err.Error())
+	}
+	return q, nil
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/provider-history storage-sc GetProviderHistory
+// Get the history of a provider.
+//
+// Gets the stake, rewards, capacity, challenges, fees and mint totals of a provider in time buckets, recorded every history period.
+// Each bucket has the last state of the provider in the bucket and its change since the previous bucket.
+//
+// parameters:
+//
+//	+name: id
+//	 description: ID of the provider
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: type
+//	 description: type of the provider, blobber, validator, miner, sharder or authorizer
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: from
+//	 description: start of the time range in unix seconds, 30 days before to by default
+//	 in: query
+//	 type: string
+//	+name: to
+//	 description: end of the time range in unix seconds excluded, now by default
+//	 in: query
+//	 type: string
+//	+name: bucket
+//	 description: duration of the time buckets, like 1h or a number of seconds, 24h by default
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []ProviderHistoryBucket
+//	400:
+//	500:
+func (srh *StorageRestHandler) getProviderHistory(w http.ResponseWriter, r *http.Request) {
+	var (
+		id    = r.URL.Query().Get("id")
+		pType = spenum.ToProviderType(r.URL.Query().Get("type"))
+	)
+	if id == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("no provider id"))
+		return
+	}
+	if pType == 0 {
+		common.Respond(w, r, nil, common.NewErrBadRequest("invalid provider type"))
+		return
+	}
+
+	q, err := getHistoryQuery(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	history, err := edb.GetProviderHistory(pType, id, q)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("getting provider history "+err.Error()))
+		return
+	}
+	common.Respond(w, r, history, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/network-history storage-sc GetNetworkHistory
+// Get the history of the network.
+//
+// Gets the totals of the providers, the transaction fees and the rewards minted in time buckets, recorded every history period.
+// Each bucket has the last totals in the bucket and their change since the previous bucket, the fees and the rewards minted are summed over the bucket.
+//
+// parameters:
+//
+//	+name: from
+//	 description: start of the time range in unix seconds, 30 days before to by default
+//	 in: query
+//	 type: string
+//	+name: to
+//	 description: end of the time range in unix seconds excluded, now by default
+//	 in: query
+//	 type: string
+//	+name: bucket
+//	 description: duration of the time buckets, like 1h or a number of seconds, 24h by default
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []NetworkHistoryBucket
+//	400:
+//	500:
+func (srh *StorageRestHandler) getNetworkHistory(w http.ResponseWriter, r *http.Request) {
+	q, err := getHistoryQuery(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	history, err := edb.GetNetworkHistory(q)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("getting network history "+err.Error()))
+		return
+	}
+	common.Respond(w, r, history, nil)
+}
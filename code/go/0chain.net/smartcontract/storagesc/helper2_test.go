@@ -93,6 +93,10 @@ func (sc *mockStateContext) AddTransfer(t *state.Transfer) error {
 	return nil
 }
 
+func (sc *mockStateContext) AddMint(m *state.Mint) error {
+	return sc.AddTransfer(state.NewTransfer(m.Minter, m.ToClientID, m.Amount))
+}
+
 func (sc *mockStateContext) GetTransfers() []*state.Transfer {
 	return sc.transfers
 }
@@ -312,6 +312,16 @@ func getStakePool(providerType spenum.Provider, providerID datastore.Key, balanc
 	return sp, nil
 }
 
+// GetStakePool of the blobber or the validator
+func GetStakePool(providerType spenum.Provider, providerID string, balances chainstate.CommonStateContextI) (
+	*stakepool.StakePool, error) {
+	sp, err := getStakePool(providerType, providerID, balances)
+	if err != nil {
+		return nil, err
+	}
+	return sp.StakePool, nil
+}
+
 // initial or successive method should be used by add_blobber/add_validator
 // SC functions
 
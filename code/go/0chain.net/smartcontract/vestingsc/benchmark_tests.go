@@ -133,6 +133,21 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "vesting.transfer_destination",
+			endpoint: vsc.transferDestination,
+			txn: &transaction.Transaction{
+				ClientID:     getMockDestinationId(0, 0),
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&transferRequest{
+					PoolID:      geMockVestingPoolId(0),
+					Destination: data.Clients[1],
+				})
+				return bytes
+			}(),
+		},
 		{
 			name:     "vesting.stop",
 			endpoint: vsc.stop,
@@ -56,6 +56,7 @@ var (
 		"stop",
 		"trigger",
 		"unlock",
+		"transfer_destination",
 		"vestingsc-update-settings",
 	}
 )
@@ -217,9 +218,8 @@ func (vsc *VestingSmartContract) updateConfig(
 			"can't get config: "+err.Error())
 	}
 
-	if err := smartcontractinterface.AuthorizeWithOwner("update_config", func() bool {
-		return conf.OwnerId == txn.ClientID
-	}); err != nil {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance("update_config",
+		conf.OwnerId, txn.ClientID); err != nil {
 		return "", err
 	}
 
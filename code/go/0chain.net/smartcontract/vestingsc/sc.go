@@ -78,6 +78,10 @@ func (vsc *VestingSmartContract) setSC(sc *smartcontractinterface.SmartContract,
 	vsc.SmartContractExecutionStats["unlock"] = metrics.GetOrRegisterTimer(
 		fmt.Sprintf("sc:%v:func:%v", vsc.ID, "unlock"), nil)
 
+	// transfer vesting of a destination to another client
+	vsc.SmartContractExecutionStats["transfer_destination"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", vsc.ID, "transfer_destination"), nil)
+
 	// move vested tokens to destinations by pool owner
 	vsc.SmartContractExecutionStats["trigger"] = metrics.GetOrRegisterTimer(
 		fmt.Sprintf("sc:%v:func:%v", vsc.ID, "trigger"), nil)
@@ -96,6 +100,8 @@ func (vsc *VestingSmartContract) Execute(t *transaction.Transaction,
 		resp, err = vsc.trigger(t, input, balances)
 	case "unlock":
 		resp, err = vsc.unlock(t, input, balances)
+	case "transfer_destination":
+		resp, err = vsc.transferDestination(t, input, balances)
 
 	case "add":
 		resp, err = vsc.add(t, input, balances)
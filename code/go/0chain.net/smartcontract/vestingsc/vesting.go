@@ -21,10 +21,11 @@ import (
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
 	"github.com/0chain/common/core/util"
 )
 
-//msgp:ignore info destInfo addRequest
+//msgp:ignore info destInfo addRequest transferRequest
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
 // internal errors
@@ -56,6 +57,19 @@ func (sr *stopRequest) decode(b []byte) error {
 	return json.Unmarshal(b, sr)
 }
 
+//
+// transfer vesting of a destination to another client
+//
+
+type transferRequest struct {
+	PoolID      string `json:"pool_id"`
+	Destination string `json:"destination"` // new destination
+}
+
+func (tr *transferRequest) decode(b []byte) error {
+	return json.Unmarshal(b, tr)
+}
+
 //
 // a destination
 //
@@ -166,6 +180,12 @@ type addRequest struct {
 	StartTime    common.Timestamp `json:"start_time"`            //
 	Duration     time.Duration    `json:"duration"`              //
 	Destinations destinations     `json:"destinations"`          //
+	// Cliff is time from start before which nothing vests.
+	Cliff time.Duration `json:"cliff,omitempty"`
+	// Step is period of tranches releases, zero for linear vesting.
+	Step time.Duration `json:"step,omitempty"`
+	// Irrevocable pool can't be stopped or deleted by its owner.
+	Irrevocable bool `json:"irrevocable,omitempty"`
 }
 
 func (ar *addRequest) decode(b []byte) error {
@@ -194,6 +214,16 @@ func (ar *addRequest) validate(now common.Timestamp, conf *config) (err error) {
 		return errors.New("no destinations")
 	case len(ar.Destinations) > conf.MaxDestinations:
 		return errors.New("too many destinations")
+	case ar.Cliff < 0:
+		return errors.New("negative vesting cliff")
+	case ar.Cliff > ar.Duration:
+		return errors.New("vesting cliff is longer than duration")
+	case ar.Step < 0:
+		return errors.New("negative vesting step")
+	case ar.Step > 0 && toSeconds(ar.Step) < 1:
+		return errors.New("vesting step is too short")
+	case ar.Step > ar.Duration:
+		return errors.New("vesting step is longer than duration")
 	}
 	return
 }
@@ -214,6 +244,9 @@ type vestingPool struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    //
 	Destinations destinations     `json:"destinations"` //
 	ClientID     string           `json:"client_id"`    // the pool owner
+	CliffAt      common.Timestamp `json:"cliff_at"`     // nothing vests before
+	Step         common.Timestamp `json:"step"`         // tranches period
+	Irrevocable  bool             `json:"irrevocable"`  // can't stop, delete
 }
 
 // newVestingPool returns new empty uninitialized vesting pool.
@@ -235,6 +268,58 @@ func newVestingPoolFromReqeust(clientID datastore.Key, ar *addRequest) (
 	vp.ExpireAt = ar.StartTime + toSeconds(ar.Duration)
 	vp.Destinations = ar.Destinations
 	vp.Destinations.start(vp.StartTime)
+	if ar.Cliff > 0 {
+		vp.CliffAt = ar.StartTime + toSeconds(ar.Cliff)
+	}
+	vp.Step = toSeconds(ar.Step)
+	vp.Irrevocable = ar.Irrevocable
+	return
+}
+
+// scheduled pool has a cliff or tranches. Such pool vests by its schedule
+// from the start, instead of linearly from last vesting of a destination.
+func (vp *vestingPool) scheduled() bool {
+	return vp.CliffAt != 0 || vp.Step != 0
+}
+
+// vestedRatio is part of the destinations amounts vested by the schedule
+// of the pool at given time
+func (vp *vestingPool) vestedRatio(now common.Timestamp) float64 {
+	switch {
+	case now < vp.CliffAt, now <= vp.StartTime:
+		return 0
+	case now >= vp.ExpireAt:
+		return 1
+	}
+	var elapsed = now - vp.StartTime
+	if vp.Step > 0 {
+		elapsed -= elapsed % vp.Step // last tranche
+	}
+	return float64(elapsed) / float64(vp.ExpireAt-vp.StartTime)
+}
+
+// unlock returns amount of tokens to vest for the destination for current
+// period. The now must be within the pool time range. See destination.unlock
+// for the dry argument.
+func (vp *vestingPool) unlock(d *destination, now common.Timestamp,
+	dry bool) (amount currency.Coin, err error) {
+
+	if !vp.scheduled() {
+		return d.unlock(now, vp.ExpireAt, dry)
+	}
+
+	var vested currency.Coin
+	if vested, err = currency.MultFloat64(d.Amount, vp.vestedRatio(now)); err != nil {
+		return 0, err
+	}
+	if vested > d.Vested {
+		amount = vested - d.Vested
+	}
+
+	if !dry {
+		err = d.move(now, amount)
+	}
+
 	return
 }
 
@@ -348,7 +433,7 @@ func (vp *vestingPool) trigger(t *transaction.Transaction,
 	)
 	sb.WriteByte('[')
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, false)
+		value, err := vp.unlock(d, now, false)
 		if err != nil {
 			return "", err
 		}
@@ -438,7 +523,7 @@ func (vp *vestingPool) vest(vscID, destID datastore.Key, now common.Timestamp,
 		return
 	}
 
-	value, err := d.unlock(now, end, false)
+	value, err := vp.unlock(d, now, false)
 	if err != nil {
 		return "", err
 	}
@@ -502,6 +587,9 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 	i.Description = vp.Description
 	i.StartTime = vp.StartTime
 	i.ExpireAt = vp.ExpireAt
+	i.CliffAt = vp.CliffAt
+	i.Step = vp.Step
+	i.Irrevocable = vp.Irrevocable
 
 	var end = i.ExpireAt
 
@@ -515,7 +603,7 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 
 	var dinfos = make([]*destInfo, 0, len(vp.Destinations))
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, true)
+		value, err := vp.unlock(d, now, true)
 		if err != nil {
 			return nil, err
 		}
@@ -551,6 +639,9 @@ type info struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    // until
 	Destinations []*destInfo      `json:"destinations"` // receivers
 	ClientID     datastore.Key    `json:"client_id"`    // owner
+	CliffAt      common.Timestamp `json:"cliff_at"`     // nothing vests before
+	Step         common.Timestamp `json:"step"`         // tranches period
+	Irrevocable  bool             `json:"irrevocable"`  // can't stop, delete
 }
 
 //
@@ -677,6 +768,10 @@ func (vsc *VestingSmartContract) stop(t *transaction.Transaction,
 			"only owner can stop a vesting")
 	}
 
+	if vp.Irrevocable {
+		return "", common.NewError("stop_vesting_failed", "irrevocable pool")
+	}
+
 	if t.CreationDate > vp.ExpireAt {
 		return "", common.NewError("stop_vesting_failed", "expired pool")
 	}
@@ -729,6 +824,11 @@ func (vsc *VestingSmartContract) delete(t *transaction.Transaction,
 			"only pool owner can delete the pool")
 	}
 
+	if vp.Irrevocable && t.CreationDate < vp.ExpireAt {
+		return "", common.NewError("delete_vesting_pool_failed",
+			"irrevocable pool can't be deleted before it expires")
+	}
+
 	// move tokens to destinations
 	if vp.Balance > 0 {
 		if _, err = vp.trigger(t, balances); err != nil {
@@ -822,6 +922,66 @@ func (vsc *VestingSmartContract) unlock(t *transaction.Transaction,
 	return
 }
 
+// transfer vesting of a destination (the transaction client) to another
+// client, vesting all released tokens to the destination before
+func (vsc *VestingSmartContract) transferDestination(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (resp string, err error) {
+
+	var tr transferRequest
+	if err = tr.decode(input); err != nil {
+		return "", common.NewError("transfer_destination_failed",
+			"malformed request: "+err.Error())
+	}
+
+	if tr.PoolID == "" {
+		return "", common.NewError("transfer_destination_failed",
+			"invalid request: missing pool id")
+	}
+
+	if tr.Destination == "" {
+		return "", common.NewError("transfer_destination_failed",
+			"invalid request: missing destination")
+	}
+
+	// an invalid destination would fail the transfers of the whole pool
+	if !encryption.IsHash(tr.Destination) {
+		return "", common.NewError("transfer_destination_failed",
+			"invalid request: invalid destination "+tr.Destination)
+	}
+
+	var vp *vestingPool
+	if vp, err = vsc.getPool(tr.PoolID, balances); err != nil {
+		return "", common.NewError("transfer_destination_failed",
+			"can't get pool: "+err.Error())
+	}
+
+	var d *destination
+	if d, err = vp.find(t.ClientID); err != nil {
+		return "", common.NewError("transfer_destination_failed",
+			"only a destination can transfer its vesting")
+	}
+
+	if _, err = vp.find(tr.Destination); err == nil {
+		return "", common.NewError("transfer_destination_failed",
+			fmt.Sprintf("destination %s already in the pool", tr.Destination))
+	}
+
+	_, err = vp.vest(t.ToClientID, d.ID, t.CreationDate, balances)
+	if err != nil && err != errZeroVesting {
+		return "", common.NewError("transfer_destination_failed",
+			"vesting pool: "+err.Error())
+	}
+
+	d.ID = tr.Destination
+
+	if err = vp.save(balances); err != nil {
+		return "", common.NewError("transfer_destination_failed",
+			"saving pool: "+err.Error())
+	}
+
+	return t.ClientID + " has transferred vesting to " + tr.Destination, nil
+}
+
 //
 // function triggered by server
 //
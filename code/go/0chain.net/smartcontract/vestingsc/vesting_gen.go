@@ -292,9 +292,9 @@ func (z stopRequest) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *vestingPool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 9
 	// string "ZcnPool"
-	o = append(o, 0x86, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
+	o = append(o, 0x89, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
 	o, err = z.ZcnPool.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "ZcnPool")
@@ -334,6 +334,23 @@ func (z *vestingPool) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "ClientID"
 	o = append(o, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ClientID)
+	// string "CliffAt"
+	o = append(o, 0xa7, 0x43, 0x6c, 0x69, 0x66, 0x66, 0x41, 0x74)
+	o, err = z.CliffAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "CliffAt")
+		return
+	}
+	// string "Step"
+	o = append(o, 0xa4, 0x53, 0x74, 0x65, 0x70)
+	o, err = z.Step.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Step")
+		return
+	}
+	// string "Irrevocable"
+	o = append(o, 0xab, 0x49, 0x72, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x6c, 0x65)
+	o = msgp.AppendBool(o, z.Irrevocable)
 	return
 }
 
@@ -415,6 +432,24 @@ func (z *vestingPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ClientID")
 				return
 			}
+		case "CliffAt":
+			bts, err = z.CliffAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CliffAt")
+				return
+			}
+		case "Step":
+			bts, err = z.Step.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Step")
+				return
+			}
+		case "Irrevocable":
+			z.Irrevocable, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Irrevocable")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -437,6 +472,6 @@ func (z *vestingPool) Msgsize() (s int) {
 			s += z.Destinations[za0001].Msgsize()
 		}
 	}
-	s += 9 + msgp.StringPrefixSize + len(z.ClientID)
+	s += 9 + msgp.StringPrefixSize + len(z.ClientID) + 8 + z.CliffAt.Msgsize() + 5 + z.Step.Msgsize() + 12 + msgp.BoolSize
 	return
 }
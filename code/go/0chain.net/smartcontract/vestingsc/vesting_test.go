@@ -11,6 +11,7 @@ import (
 	"github.com/0chain/common/core/currency"
 
 	"0chain.net/core/common"
+	"0chain.net/core/encryption"
 	"github.com/0chain/common/core/util"
 	"github.com/stretchr/testify/mock"
 
@@ -96,11 +97,209 @@ func Test_addRequest_validate(t *testing.T) {
 		&destination{ID: "two", Amount: 20},
 	}
 
+	ar.Cliff = -1
+	requireErrMsg(t, ar.validate(10, conf), "negative vesting cliff")
+	ar.Cliff = 2 * time.Minute
+	requireErrMsg(t, ar.validate(10, conf), "vesting cliff is longer than duration")
+	ar.Cliff = 10 * time.Second
+
+	ar.Step = -1
+	requireErrMsg(t, ar.validate(10, conf), "negative vesting step")
+	ar.Step = time.Millisecond
+	requireErrMsg(t, ar.validate(10, conf), "vesting step is too short")
+	ar.Step = 2 * time.Minute
+	requireErrMsg(t, ar.validate(10, conf), "vesting step is longer than duration")
+	ar.Step = 20 * time.Second
+
 	assert.NoError(t, ar.validate(10, conf))
 	ar.StartTime = 0
 	assert.NoError(t, ar.validate(10, conf))
 }
 
+func Test_vestingPool_schedule(t *testing.T) {
+	var vp = newVestingPoolFromReqeust("client_hex", &addRequest{
+		StartTime: 100,
+		Duration:  1000 * time.Second,
+		Cliff:     250 * time.Second,
+		Step:      100 * time.Second,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 1000},
+		},
+	})
+	require.True(t, vp.scheduled())
+	assert.Equal(t, common.Timestamp(350), vp.CliffAt)
+	assert.Equal(t, common.Timestamp(100), vp.Step)
+
+	var d = vp.Destinations[0]
+	for _, tt := range []struct {
+		now    common.Timestamp
+		amount currency.Coin
+	}{
+		{now: 100, amount: 0},
+		{now: 349, amount: 0}, // cliff
+		{now: 350, amount: 200},
+		{now: 399, amount: 0}, // same tranche
+		{now: 400, amount: 100},
+		{now: 1099, amount: 600},
+		{now: 1100, amount: 100}, // expired, rest of the amount
+		{now: 1100, amount: 0},
+	} {
+		amount, err := vp.unlock(d, tt.now, false)
+		require.NoError(t, err)
+		assert.Equal(t, tt.amount, amount, tt.now)
+	}
+	assert.Equal(t, currency.Coin(1000), d.Vested)
+
+	// linear pools vest from the last vesting
+	vp = newVestingPoolFromReqeust("client_hex", &addRequest{
+		StartTime: 100,
+		Duration:  1000 * time.Second,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 1000},
+		},
+	})
+	require.False(t, vp.scheduled())
+	amount, err := vp.unlock(vp.Destinations[0], 350, true)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(250), amount)
+}
+
+func TestVestingSmartContract_irrevocable(t *testing.T) {
+	var (
+		vsc      = newTestVestingSC()
+		balances = newTestBalances()
+		client   = newClient(1200e10, balances)
+		tp       = common.Timestamp(0)
+		tx       = newTransaction(client.id, vsc.ID, 0, tp)
+		err      = InitConfig(balances)
+	)
+	require.NoError(t, err)
+	configureConfig()
+
+	var resp string
+	resp, err = client.add(t, vsc, &addRequest{
+		Description: "for something",
+		StartTime:   10,
+		Duration:    10 * time.Second,
+		Cliff:       5 * time.Second,
+		Irrevocable: true,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 10},
+			&destination{ID: "two", Amount: 20},
+		},
+	}, 800e10, tp, balances)
+	require.NoError(t, err)
+	var set vestingPool
+	require.NoError(t, set.Decode([]byte(resp)))
+	assert.True(t, set.Irrevocable)
+	assert.Equal(t, common.Timestamp(15), set.CliffAt)
+
+	balances.txn = tx
+	_, err = vsc.stop(tx, mustEncode(t, &stopRequest{
+		PoolID:      set.ID,
+		Destination: "one",
+	}), balances)
+	requireErrMsg(t, err, "stop_vesting_failed: irrevocable pool")
+
+	var dr = poolRequest{PoolID: set.ID}
+	_, err = vsc.delete(tx, mustEncode(t, &dr), balances)
+	requireErrMsg(t, err, "delete_vesting_pool_failed: "+
+		"irrevocable pool can't be deleted before it expires")
+
+	tx.CreationDate = 20
+	_, err = vsc.delete(tx, mustEncode(t, &dr), balances)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(10), balances.balances["one"])
+	assert.Equal(t, currency.Coin(20), balances.balances["two"])
+}
+
+func TestVestingSmartContract_transferDestination(t *testing.T) {
+	var (
+		vsc      = newTestVestingSC()
+		balances = newTestBalances()
+		client   = newClient(1200e10, balances)
+		tp       = common.Timestamp(0)
+		tx       = newTransaction("one", vsc.ID, 0, tp)
+		tr       transferRequest
+		two      = encryption.Hash("two")
+		three    = encryption.Hash("three")
+		err      = InitConfig(balances)
+	)
+	require.NoError(t, err)
+	configureConfig()
+
+	var resp string
+	resp, err = client.add(t, vsc, &addRequest{
+		Description: "for something",
+		StartTime:   10,
+		Duration:    10 * time.Second,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 10},
+			&destination{ID: two, Amount: 20},
+		},
+	}, 800e10, tp, balances)
+	require.NoError(t, err)
+	var set vestingPool
+	require.NoError(t, set.Decode([]byte(resp)))
+	balances.txn = tx
+
+	// 1. malformed
+	_, err = vsc.transferDestination(tx, []byte("} malformed {"), balances)
+	requireErrMsg(t, err, "transfer_destination_failed: malformed request:"+
+		" invalid character '}' looking for beginning of value")
+
+	// 2. missing destination
+	tr.PoolID = set.ID
+	_, err = vsc.transferDestination(tx, mustEncode(t, &tr), balances)
+	requireErrMsg(t, err, "transfer_destination_failed: "+
+		"invalid request: missing destination")
+
+	// 3. invalid destination
+	tr.Destination = "three"
+	_, err = vsc.transferDestination(tx, mustEncode(t, &tr), balances)
+	requireErrMsg(t, err, "transfer_destination_failed: "+
+		"invalid request: invalid destination three")
+
+	// 4. not a destination
+	tr.Destination = three
+	tx.ClientID = client.id
+	_, err = vsc.transferDestination(tx, mustEncode(t, &tr), balances)
+	requireErrMsg(t, err, "transfer_destination_failed: "+
+		"only a destination can transfer its vesting")
+
+	// 5. already a destination
+	tx.ClientID = "one"
+	tr.Destination = two
+	_, err = vsc.transferDestination(tx, mustEncode(t, &tr), balances)
+	requireErrMsg(t, err, "transfer_destination_failed: "+
+		"destination "+two+" already in the pool")
+
+	// 6. transfer, vesting the released tokens to the destination
+	tx.CreationDate = 15
+	tr.Destination = three
+	resp, err = vsc.transferDestination(tx, mustEncode(t, &tr), balances)
+	require.NoError(t, err)
+	assert.Equal(t, "one has transferred vesting to "+three, resp)
+	assert.Equal(t, currency.Coin(5), balances.balances["one"])
+
+	var got *vestingPool
+	got, err = vsc.getPool(set.ID, balances)
+	require.NoError(t, err)
+	_, err = got.find("one")
+	require.Error(t, err)
+	var d *destination
+	d, err = got.find(three)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(5), d.Vested)
+
+	// 7. the new destination unlocks the rest
+	tx.ClientID = three
+	tx.CreationDate = 20
+	_, err = vsc.unlock(tx, mustEncode(t, &poolRequest{PoolID: set.ID}), balances)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(5), balances.balances[three])
+}
+
 func Test_vestingPool(t *testing.T) {
 	const poolID, clientID = "pool_hex", "client_hex"
 	require.NotZero(t, poolKey(ADDRESS, poolID))
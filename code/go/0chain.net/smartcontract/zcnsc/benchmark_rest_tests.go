@@ -1,6 +1,8 @@
 package zcnsc
 
 import (
+	"strconv"
+
 	"0chain.net/core/common"
 	"0chain.net/smartcontract/benchmark"
 	"0chain.net/smartcontract/rest"
@@ -41,6 +43,21 @@ func BenchmarkRestTests(data benchmark.BenchData, _ benchmark.SignatureScheme) b
 				},
 				Endpoint: zrh.NotProcessedBurnTicketsHandler,
 			},
+			{
+				FuncName: "getBridgeStatus",
+				Endpoint: zrh.getBridgeStatus,
+			},
+			{
+				FuncName: "getPendingMint",
+				Params: map[string]string{
+					"nonce": strconv.FormatInt(pendingMintNonce, 10),
+				},
+				Endpoint: zrh.getPendingMint,
+			},
+			{
+				FuncName: "getBridgeChains",
+				Endpoint: zrh.getBridgeChains,
+			},
 		},
 		ADDRESS,
 		zrh,
@@ -22,7 +22,9 @@ import (
 )
 
 var (
-	mintNonce = int64(0)
+	mintNonce        = int64(0)
+	pendingMintNonce = int64(100)
+	benchmarkChainID = "5"
 )
 
 func Setup(eventDb *event.EventDb, clients, publicKeys []string, balances cstate.StateContextI) {
@@ -31,6 +33,8 @@ func Setup(eventDb *event.EventDb, clients, publicKeys []string, balances cstate
 	addMockUserNodes(clients, balances)
 	addMockAuthorizers(eventDb, clients, publicKeys, balances)
 	addMockStakePools(clients, balances)
+	addMockPendingMint(clients, balances)
+	addMockChains(clients, balances)
 }
 
 func addMockGlobalNode(balances cstate.StateContextI) {
@@ -65,6 +69,8 @@ func addMockGlobalNode(balances cstate.StateContextI) {
 	gn.PercentAuthorizers = config.SmartContractConfig.GetFloat64(benchmark.ZcnPercentAuthorizers)
 	gn.MaxDelegates = viper.GetInt(benchmark.ZcnMaxDelegates)
 	gn.HealthCheckPeriod = viper.GetDuration(benchmark.HealthCheckPeriod)
+	gn.SlashFraction = config.SmartContractConfig.GetFloat64(benchmark.ZcnSlashFraction)
+	gn.ChallengeRewardFraction = config.SmartContractConfig.GetFloat64(benchmark.ZcnChallengeRewardFraction)
 	_, err = balances.InsertTrieNode(gn.GetKey(), gn)
 	if err != nil {
 		log.Fatal(err)
@@ -127,6 +133,30 @@ func addMockStakePools(clients []string, ctx cstate.StateContextI) {
 	}
 }
 
+func addMockPendingMint(clients []string, balances cstate.StateContextI) {
+	pm := &PendingMint{
+		Nonce:    pendingMintNonce,
+		ClientID: clients[0],
+		Amount:   1e10,
+	}
+	if err := pm.Save(balances); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func addMockChains(clients []string, balances cstate.StateContextI) {
+	cn := &ChainsNode{}
+	cn.put(&BridgeChain{
+		ID:                 benchmarkChainID,
+		Authorizers:        clients[:viper.GetInt(benchmark.NumAuthorizers)],
+		PercentAuthorizers: 0.7,
+		MaxFee:             100,
+	})
+	if err := cn.Save(balances); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func addMockUserNodes(clients []string, balances cstate.StateContextI) {
 	for _, clientId := range clients {
 		un := NewUserNode(clientId)
@@ -107,6 +107,51 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 				txn:      createRandomTransaction(data.Clients[0], data.PublicKeys[0]),
 				input:    createMintPayloadForZCNSCMint(scheme, data),
 			},
+			{
+				name:     benchmark.ZcnSc + ClaimMintFunc,
+				endpoint: sc.ClaimMint,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0], 0),
+				input:    (&PendingMintPayload{Nonce: pendingMintNonce}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + CancelPendingMintFunc,
+				endpoint: sc.CancelPendingMint,
+				txn:      createTransaction(owner, "", 0),
+				input:    (&PendingMintPayload{Nonce: pendingMintNonce}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + SlashAuthorizerFunc,
+				endpoint: sc.SlashAuthorizer,
+				txn:      createTransaction(data.Clients[1], data.PublicKeys[1], 0),
+				input:    createSlashAuthorizerPayload(scheme, data),
+			},
+			{
+				name:     benchmark.ZcnSc + PauseBridgeFunc,
+				endpoint: sc.PauseBridge,
+				txn:      createTransaction(owner, "", 0),
+			},
+			{
+				name:     benchmark.ZcnSc + UnpauseBridgeFunc,
+				endpoint: sc.UnpauseBridge,
+				txn:      createTransaction(owner, "", 0),
+			},
+			{
+				name:     benchmark.ZcnSc + RegisterChainFunc,
+				endpoint: sc.RegisterChain,
+				txn:      createTransaction(owner, "", 0),
+				input: (&BridgeChain{
+					ID:                 "10",
+					Authorizers:        data.Clients[:viper.GetInt(benchmark.NumAuthorizers)],
+					PercentAuthorizers: 0.7,
+					MaxFee:             100,
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + RemoveChainFunc,
+				endpoint: sc.RemoveChain,
+				txn:      createTransaction(owner, "", 0),
+				input:    (&RemoveChainPayload{ID: benchmarkChainID}).Encode(),
+			},
 			{
 				name:     benchmark.ZcnSc + UpdateGlobalConfigFunc,
 				endpoint: sc.UpdateGlobalConfig,
@@ -185,49 +230,67 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 }
 
 func createMintPayloadForZCNSCMint(scheme benchmark.SignatureScheme, data benchmark.BenchData) []byte {
-	var sigs []*AuthorizerSignature
-
-	client := data.Clients[0]
-
-	for i := 0; i < viper.GetInt(benchmark.NumAuthorizers); i++ {
-		pb := &proofOfBurn{
-			TxnID:             encryption.Hash(strconv.Itoa(i)),
-			Amount:            100,
-			ReceivingClientID: client,
-			Nonce:             0,
-			Scheme:            scheme,
-		}
-
-		err := pb.sign(data.PrivateKeys[i])
-		if err != nil {
-			panic(err)
-		}
-
-		sig := &AuthorizerSignature{
-			ID:        data.Clients[i],
-			Signature: pb.Signature,
-		}
-
-		err = pb.verifySignature(data.PublicKeys[i])
-		if err != nil {
-			panic(err)
-		}
-
-		sigs = append(sigs, sig)
-	}
-
 	// mintNonce = mintNonce + 1
 	payload := &MintPayload{
 		EthereumTxnID:     "0xc8285f5304b1B7aAB09a7d26721D6F585448D0ed",
 		Nonce:             mintNonce + 1,
-		Signatures:        sigs,
-		ReceivingClientID: client,
+		ReceivingClientID: data.Clients[0],
 	}
 	payload.Amount, _ = currency.ParseZCN(1000)
 
+	for i := 0; i < viper.GetInt(benchmark.NumAuthorizers); i++ {
+		payload.Signatures = append(payload.Signatures, signMintPayload(scheme, data, payload, i))
+	}
+
 	return payload.Encode()
 }
 
+// signMintPayload returns the signature of the payload by the i-th authorizer
+func signMintPayload(scheme benchmark.SignatureScheme, data benchmark.BenchData, payload *MintPayload, i int) *AuthorizerSignature {
+	pb := &proofOfBurn{
+		TxnID:             payload.EthereumTxnID,
+		Amount:            int64(payload.Amount),
+		ReceivingClientID: payload.ReceivingClientID,
+		Nonce:             payload.Nonce,
+		Scheme:            scheme,
+	}
+
+	err := pb.sign(data.PrivateKeys[i])
+	if err != nil {
+		panic(err)
+	}
+
+	err = pb.verifySignature(data.PublicKeys[i])
+	if err != nil {
+		panic(err)
+	}
+
+	return &AuthorizerSignature{
+		ID:        data.Clients[i],
+		Signature: pb.Signature,
+	}
+}
+
+func createSlashAuthorizerPayload(scheme benchmark.SignatureScheme, data benchmark.BenchData) []byte {
+	mint := &MintPayload{
+		EthereumTxnID:     "0xc8285f5304b1B7aAB09a7d26721D6F585448D0ed",
+		Amount:            100,
+		Nonce:             mintNonce,
+		ReceivingClientID: data.Clients[1],
+	}
+	mint.Signatures = []*AuthorizerSignature{signMintPayload(scheme, data, mint, 0)}
+
+	conflicting := *mint
+	conflicting.Amount = 200
+	conflicting.Signatures = []*AuthorizerSignature{signMintPayload(scheme, data, &conflicting, 0)}
+
+	return (&SlashAuthorizerPayload{
+		AuthorizerID:    data.Clients[0],
+		Mint:            mint,
+		ConflictingMint: &conflicting,
+	}).Encode()
+}
+
 func createBurnPayloadForZCNSCBurn() []byte {
 	payload := &BurnPayload{
 		EthereumAddress: "0xc8285f5304b1B7aAB09a7d26721D6F585448D0ed",
@@ -14,6 +14,7 @@ func TestZcnBenchmarkTests(t *testing.T) {
 	mockSigScheme.On("SetPublicKey", mock.Anything).Return(nil)
 	mockSigScheme.On("SetPrivateKey", mock.Anything).Return()
 	mockSigScheme.On("Sign", mock.Anything).Return("", nil)
+	mockSigScheme.On("Verify", mock.Anything, mock.Anything).Return(true, nil)
 
 	zsc := &ZCNSmartContract{
 		smartcontractinterface.NewSC(ADDRESS),
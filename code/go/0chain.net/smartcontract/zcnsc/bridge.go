@@ -0,0 +1,488 @@
+package zcnsc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/pkg/errors"
+)
+
+//msgp:ignore PendingMintPayload
+//go:generate msgp -v -io=false -tests=false -unexported
+
+// rateWindowBuckets is the number of buckets of a rate limit window
+const rateWindowBuckets = 10
+
+// ------------- RateWindow ------------------------
+
+// RateWindow is the amount of tokens minted or burned over the last rounds of
+// the rate limit window. The amounts are summed in buckets of a tenth of the
+// window to bound the size of the node, a bucket leaves the window once all
+// its rounds are out of it.
+type RateWindow struct {
+	Buckets []*RateBucket `json:"buckets"`
+}
+
+type RateBucket struct {
+	Round  int64         `json:"round"` // first round of the bucket
+	Amount currency.Coin `json:"amount"`
+}
+
+func bucketRounds(window int64) int64 {
+	if window < rateWindowBuckets {
+		return 1
+	}
+	return window / rateWindowBuckets
+}
+
+func inWindow(b *RateBucket, round, window int64) bool {
+	return b.Round+bucketRounds(window) > round-window+1
+}
+
+// Total is the amount of the window ending at the round.
+func (rw *RateWindow) Total(round, window int64) (total currency.Coin, err error) {
+	for _, b := range rw.Buckets {
+		if !inWindow(b, round, window) {
+			continue
+		}
+		if total, err = currency.AddCoin(total, b.Amount); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// take adds the amount to the window ending at the round, it fails if the
+// total of the window would be more than max.
+func (rw *RateWindow) take(round, window int64, amount, max currency.Coin) error {
+	i := 0
+	for i < len(rw.Buckets) && !inWindow(rw.Buckets[i], round, window) {
+		i++
+	}
+	rw.Buckets = rw.Buckets[i:]
+
+	total, err := rw.Total(round, window)
+	if err != nil {
+		return err
+	}
+	if total, err = currency.AddCoin(total, amount); err != nil {
+		return err
+	}
+	if total > max {
+		return fmt.Errorf("amount %v exceeds the cap %v of the last %d rounds, %v left",
+			amount, max, window, max-(total-amount))
+	}
+
+	start := round - round%bucketRounds(window)
+	if n := len(rw.Buckets); n > 0 && rw.Buckets[n-1].Round == start {
+		rw.Buckets[n-1].Amount, err = currency.AddCoin(rw.Buckets[n-1].Amount, amount)
+		return err
+	}
+	rw.Buckets = append(rw.Buckets, &RateBucket{Round: start, Amount: amount})
+	return nil
+}
+
+// ------------- BridgeNode ------------------------
+
+// BridgeNode is the state of the bridge shared by all the clients: the pause
+// switch and the tokens minted and burned in the rate limit window.
+type BridgeNode struct {
+	Paused     bool       `json:"paused"`
+	PauseVotes []string   `json:"pause_votes"` // authorizers voting to pause
+	Mints      RateWindow `json:"mints"`
+	Burns      RateWindow `json:"burns"`
+}
+
+func (bn *BridgeNode) GetKey() datastore.Key {
+	return fmt.Sprintf("%s:%s", ADDRESS, BridgeNodeType)
+}
+
+func (bn *BridgeNode) Encode() []byte {
+	buff, _ := json.Marshal(bn)
+	return buff
+}
+
+func (bn *BridgeNode) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(bn.GetKey(), bn)
+	return
+}
+
+// GetBridgeNode returns the bridge node, an empty one if not saved yet
+func GetBridgeNode(ctx cstate.CommonStateContextI) (*BridgeNode, error) {
+	node := &BridgeNode{}
+	err := ctx.GetTrieNode(node.GetKey(), node)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return node, nil
+	default:
+		return nil, err
+	}
+}
+
+// takeMint records the amount minted to the client, it fails if the amount
+// exceeds the bridge or the client cap of the rate limit window.
+func (bn *BridgeNode) takeMint(ctx cstate.StateContextI, conf *ZCNSConfig, clientID string, round int64, amount currency.Coin) error {
+	return bn.take(ctx, conf.RateLimitWindow, clientID, round, amount,
+		&bn.Mints, conf.MaxWindowMint,
+		func(cn *ClientBridgeNode) *RateWindow { return &cn.Mints }, conf.MaxClientWindowMint)
+}
+
+// takeBurn records the amount burned by the client, it fails if the amount
+// exceeds the bridge or the client cap of the rate limit window.
+func (bn *BridgeNode) takeBurn(ctx cstate.StateContextI, conf *ZCNSConfig, clientID string, round int64, amount currency.Coin) error {
+	return bn.take(ctx, conf.RateLimitWindow, clientID, round, amount,
+		&bn.Burns, conf.MaxWindowBurn,
+		func(cn *ClientBridgeNode) *RateWindow { return &cn.Burns }, conf.MaxClientWindowBurn)
+}
+
+// take records the amount in the windows with a cap, a zero cap is no cap
+func (bn *BridgeNode) take(
+	ctx cstate.StateContextI,
+	window int64,
+	clientID string,
+	round int64,
+	amount currency.Coin,
+	bridgeWindow *RateWindow,
+	maxBridge currency.Coin,
+	clientWindow func(*ClientBridgeNode) *RateWindow,
+	maxClient currency.Coin,
+) error {
+	if maxBridge > 0 {
+		if err := bridgeWindow.take(round, window, amount, maxBridge); err != nil {
+			return fmt.Errorf("bridge rate limit: %v", err)
+		}
+		if err := bn.Save(ctx); err != nil {
+			return fmt.Errorf("saving bridge node: %v", err)
+		}
+	}
+
+	if maxClient > 0 {
+		cn, err := GetClientBridgeNode(clientID, ctx)
+		if err != nil {
+			return fmt.Errorf("get client bridge node: %v", err)
+		}
+		if err := clientWindow(cn).take(round, window, amount, maxClient); err != nil {
+			return fmt.Errorf("client rate limit: %v", err)
+		}
+		if err := cn.Save(ctx); err != nil {
+			return fmt.Errorf("saving client bridge node: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// pauseVotesRequired is the number of authorizers voting to pause the bridge
+// required to pause it
+func pauseVotesRequired(quorum float64, numAuth int) int {
+	required := int(math.Ceil(quorum * float64(numAuth)))
+	if required < 1 {
+		return 1
+	}
+	return required
+}
+
+// ------------- ClientBridgeNode ------------------------
+
+// ClientBridgeNode is the tokens minted and burned by a client in the rate
+// limit window.
+type ClientBridgeNode struct {
+	ID    string     `json:"id"`
+	Mints RateWindow `json:"mints"`
+	Burns RateWindow `json:"burns"`
+}
+
+func (cn *ClientBridgeNode) GetKey() datastore.Key {
+	return fmt.Sprintf("%s:%s:%s", ADDRESS, ClientBridgeNodeType, cn.ID)
+}
+
+func (cn *ClientBridgeNode) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(cn.GetKey(), cn)
+	return
+}
+
+// GetClientBridgeNode returns the client bridge node, an empty one if not saved yet
+func GetClientBridgeNode(id string, ctx cstate.CommonStateContextI) (*ClientBridgeNode, error) {
+	node := &ClientBridgeNode{ID: id}
+	err := ctx.GetTrieNode(node.GetKey(), node)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return node, nil
+	default:
+		return nil, err
+	}
+}
+
+// ------------- PendingMint ------------------------
+
+// PendingMint is a mint of at least large_mint_amount tokens, the tokens are
+// claimable by the client once the large_mint_delay rounds are over. The
+// owner can cancel the mint until then.
+type PendingMint struct {
+	ChainID        string        `json:"chain_id"`
+	Nonce          int64         `json:"nonce"`
+	ClientID       string        `json:"client_id"`
+	Amount         currency.Coin `json:"amount"`
+	ClaimableRound int64         `json:"claimable_round"`
+}
+
+func pendingMintKey(chainID string, nonce int64) datastore.Key {
+	if chainID == "" {
+		return fmt.Sprintf("%s:%s:%d", ADDRESS, PendingMintNodeType, nonce)
+	}
+	return fmt.Sprintf("%s:%s:%s:%d", ADDRESS, PendingMintNodeType, chainID, nonce)
+}
+
+func (pm *PendingMint) GetKey() datastore.Key {
+	return pendingMintKey(pm.ChainID, pm.Nonce)
+}
+
+func (pm *PendingMint) Encode() []byte {
+	buff, _ := json.Marshal(pm)
+	return buff
+}
+
+func (pm *PendingMint) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(pm.GetKey(), pm)
+	return
+}
+
+// GetPendingMint returns error if the pending mint is not found
+func GetPendingMint(chainID string, nonce int64, ctx cstate.CommonStateContextI) (*PendingMint, error) {
+	node := &PendingMint{}
+	if err := ctx.GetTrieNode(pendingMintKey(chainID, nonce), node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// PendingMintPayload is the input of claim-mint and cancel-pending-mint
+type PendingMintPayload struct {
+	ChainID string `json:"chain_id"`
+	Nonce   int64  `json:"nonce"`
+}
+
+func (pp *PendingMintPayload) Encode() []byte {
+	buff, _ := json.Marshal(pp)
+	return buff
+}
+
+func (pp *PendingMintPayload) Decode(input []byte) error {
+	return json.Unmarshal(input, pp)
+}
+
+func getPendingMintOf(input []byte, ctx cstate.StateContextI) (*PendingMint, error) {
+	payload := &PendingMintPayload{}
+	if err := payload.Decode(input); err != nil {
+		return nil, fmt.Errorf("payload decode error: %v", err)
+	}
+
+	pm, err := GetPendingMint(payload.ChainID, payload.Nonce, ctx)
+	if err == util.ErrValueNotPresent {
+		return nil, fmt.Errorf("no pending mint for nonce %d", payload.Nonce)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get pending mint: %v", err)
+	}
+	return pm, nil
+}
+
+// ClaimMint transfers the tokens of a pending mint to its client once the
+// delay is over. The claims are blocked while the bridge is paused.
+func (zcn *ZCNSmartContract) ClaimMint(
+	trans *transaction.Transaction,
+	inputData []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const code = "failed to claim mint"
+
+	bn, err := GetBridgeNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get bridge node: "+err.Error())
+	}
+	if bn.Paused {
+		return "", common.NewError(code, "bridge is paused")
+	}
+
+	pm, err := getPendingMintOf(inputData, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if pm.ClientID != trans.ClientID {
+		return "", common.NewError(code, "pending mint of another client "+pm.ClientID)
+	}
+
+	if round := ctx.GetBlock().Round; round < pm.ClaimableRound {
+		return "", common.NewErrorf(code, "pending mint claimable from round %d, current round %d",
+			pm.ClaimableRound, round)
+	}
+
+	if _, err := ctx.DeleteTrieNode(pm.GetKey()); err != nil {
+		return "", common.NewError(code, "deleting pending mint: "+err.Error())
+	}
+
+	err = ctx.AddMint(state.NewMint(ADDRESS, pm.ClientID, pm.Amount))
+	if err != nil {
+		return "", errors.Wrap(err, code+", add mint operation")
+	}
+
+	return string(pm.Encode()), nil
+}
+
+// CancelPendingMint drops a pending mint, the owner cancels the large mints
+// of compromised authorizers before they are claimed. The nonce of the mint
+// stays used.
+func (zcn *ZCNSmartContract) CancelPendingMint(
+	trans *transaction.Transaction,
+	inputData []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const (
+		code     = "failed to cancel pending mint"
+		funcName = "CancelPendingMint"
+	)
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get global node: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance(funcName,
+		gn.OwnerId, trans.ClientID); err != nil {
+		return "", errors.Wrap(err, code)
+	}
+
+	pm, err := getPendingMintOf(inputData, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if _, err := ctx.DeleteTrieNode(pm.GetKey()); err != nil {
+		return "", common.NewError(code, "deleting pending mint: "+err.Error())
+	}
+
+	return string(pm.Encode()), nil
+}
+
+// PauseBridge blocks the mints and the burns of the bridge, the stake
+// operations keep working. The owner pauses the bridge at once, an authorizer
+// votes to pause it and the bridge is paused once the pause_quorum of the
+// authorizers voted.
+func (zcn *ZCNSmartContract) PauseBridge(
+	trans *transaction.Transaction,
+	_ []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const (
+		code     = "failed to pause bridge"
+		funcName = "PauseBridge"
+	)
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get global node: "+err.Error())
+	}
+
+	bn, err := GetBridgeNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get bridge node: "+err.Error())
+	}
+	if bn.Paused {
+		return "", common.NewError(code, "bridge is already paused")
+	}
+
+	if smartcontractinterface.AuthorizeWithOwnerOrGovernance(funcName, gn.OwnerId, trans.ClientID) == nil {
+		bn.Paused = true
+	} else if err := bn.votePause(gn, trans.ClientID, ctx); err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if err := bn.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving bridge node: "+err.Error())
+	}
+
+	return string(bn.Encode()), nil
+}
+
+// votePause adds the vote of the authorizer, the votes of the authorizers
+// deleted since they voted are not counted.
+func (bn *BridgeNode) votePause(gn *GlobalNode, id string, ctx cstate.StateContextI) error {
+	if _, err := GetAuthorizerNode(id, ctx); err != nil {
+		if err == util.ErrValueNotPresent {
+			return errors.New("only the owner or an authorizer can pause the bridge")
+		}
+		return fmt.Errorf("get authorizer node: %v", err)
+	}
+
+	if gn.PauseQuorum <= 0 {
+		return errors.New("authorizers are not allowed to pause the bridge")
+	}
+
+	votes := make([]string, 0, len(bn.PauseVotes)+1)
+	for _, voter := range bn.PauseVotes {
+		if voter == id {
+			return errors.New("authorizer already voted to pause the bridge")
+		}
+		if _, err := GetAuthorizerNode(voter, ctx); err == nil {
+			votes = append(votes, voter)
+		} else if err != util.ErrValueNotPresent {
+			return fmt.Errorf("get authorizer node: %v", err)
+		}
+	}
+	bn.PauseVotes = append(votes, id)
+
+	numAuth, err := getAuthorizerCount(ctx)
+	if err != nil {
+		return fmt.Errorf("get number of authorizers: %v", err)
+	}
+
+	bn.Paused = len(bn.PauseVotes) >= pauseVotesRequired(gn.PauseQuorum, numAuth)
+	return nil
+}
+
+// UnpauseBridge resumes the mints and the burns, only the owner unpauses
+// the bridge. The pause votes of the authorizers are cleared.
+func (zcn *ZCNSmartContract) UnpauseBridge(
+	trans *transaction.Transaction,
+	_ []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const (
+		code     = "failed to unpause bridge"
+		funcName = "UnpauseBridge"
+	)
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get global node: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance(funcName,
+		gn.OwnerId, trans.ClientID); err != nil {
+		return "", errors.Wrap(err, code)
+	}
+
+	bn, err := GetBridgeNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get bridge node: "+err.Error())
+	}
+
+	bn.Paused = false
+	bn.PauseVotes = nil
+
+	if err := bn.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving bridge node: "+err.Error())
+	}
+
+	return string(bn.Encode()), nil
+}
@@ -0,0 +1,677 @@
+package zcnsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *BridgeNode) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Paused"
+	o = append(o, 0x84, 0xa6, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Paused)
+	// string "PauseVotes"
+	o = append(o, 0xaa, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x6f, 0x74, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.PauseVotes)))
+	for za0001 := range z.PauseVotes {
+		o = msgp.AppendString(o, z.PauseVotes[za0001])
+	}
+	// string "Mints"
+	o = append(o, 0xa5, 0x4d, 0x69, 0x6e, 0x74, 0x73)
+	o, err = z.Mints.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Mints")
+		return
+	}
+	// string "Burns"
+	o = append(o, 0xa5, 0x42, 0x75, 0x72, 0x6e, 0x73)
+	o, err = z.Burns.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Burns")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *BridgeNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Paused":
+			z.Paused, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Paused")
+				return
+			}
+		case "PauseVotes":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PauseVotes")
+				return
+			}
+			if cap(z.PauseVotes) >= int(zb0002) {
+				z.PauseVotes = (z.PauseVotes)[:zb0002]
+			} else {
+				z.PauseVotes = make([]string, zb0002)
+			}
+			for za0001 := range z.PauseVotes {
+				z.PauseVotes[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "PauseVotes", za0001)
+					return
+				}
+			}
+		case "Mints":
+			bts, err = z.Mints.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Mints")
+				return
+			}
+		case "Burns":
+			bts, err = z.Burns.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Burns")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *BridgeNode) Msgsize() (s int) {
+	s = 1 + 7 + msgp.BoolSize + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.PauseVotes {
+		s += msgp.StringPrefixSize + len(z.PauseVotes[za0001])
+	}
+	s += 6 + z.Mints.Msgsize() + 6 + z.Burns.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ClientBridgeNode) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "ID"
+	o = append(o, 0x83, 0xa2, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ID)
+	// string "Mints"
+	o = append(o, 0xa5, 0x4d, 0x69, 0x6e, 0x74, 0x73)
+	// map header, size 1
+	// string "Buckets"
+	o = append(o, 0x81, 0xa7, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Mints.Buckets)))
+	for za0001 := range z.Mints.Buckets {
+		if z.Mints.Buckets[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Round"
+			o = append(o, 0x82, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+			o = msgp.AppendInt64(o, z.Mints.Buckets[za0001].Round)
+			// string "Amount"
+			o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			o, err = z.Mints.Buckets[za0001].Amount.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Mints", "Buckets", za0001, "Amount")
+				return
+			}
+		}
+	}
+	// string "Burns"
+	o = append(o, 0xa5, 0x42, 0x75, 0x72, 0x6e, 0x73)
+	// map header, size 1
+	// string "Buckets"
+	o = append(o, 0x81, 0xa7, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Burns.Buckets)))
+	for za0002 := range z.Burns.Buckets {
+		if z.Burns.Buckets[za0002] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Round"
+			o = append(o, 0x82, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+			o = msgp.AppendInt64(o, z.Burns.Buckets[za0002].Round)
+			// string "Amount"
+			o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			o, err = z.Burns.Buckets[za0002].Amount.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Burns", "Buckets", za0002, "Amount")
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ClientBridgeNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "Mints":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Mints")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Mints")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Buckets":
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Mints", "Buckets")
+						return
+					}
+					if cap(z.Mints.Buckets) >= int(zb0003) {
+						z.Mints.Buckets = (z.Mints.Buckets)[:zb0003]
+					} else {
+						z.Mints.Buckets = make([]*RateBucket, zb0003)
+					}
+					for za0001 := range z.Mints.Buckets {
+						if msgp.IsNil(bts) {
+							bts, err = msgp.ReadNilBytes(bts)
+							if err != nil {
+								return
+							}
+							z.Mints.Buckets[za0001] = nil
+						} else {
+							if z.Mints.Buckets[za0001] == nil {
+								z.Mints.Buckets[za0001] = new(RateBucket)
+							}
+							var zb0004 uint32
+							zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Mints", "Buckets", za0001)
+								return
+							}
+							for zb0004 > 0 {
+								zb0004--
+								field, bts, err = msgp.ReadMapKeyZC(bts)
+								if err != nil {
+									err = msgp.WrapError(err, "Mints", "Buckets", za0001)
+									return
+								}
+								switch msgp.UnsafeString(field) {
+								case "Round":
+									z.Mints.Buckets[za0001].Round, bts, err = msgp.ReadInt64Bytes(bts)
+									if err != nil {
+										err = msgp.WrapError(err, "Mints", "Buckets", za0001, "Round")
+										return
+									}
+								case "Amount":
+									bts, err = z.Mints.Buckets[za0001].Amount.UnmarshalMsg(bts)
+									if err != nil {
+										err = msgp.WrapError(err, "Mints", "Buckets", za0001, "Amount")
+										return
+									}
+								default:
+									bts, err = msgp.Skip(bts)
+									if err != nil {
+										err = msgp.WrapError(err, "Mints", "Buckets", za0001)
+										return
+									}
+								}
+							}
+						}
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Mints")
+						return
+					}
+				}
+			}
+		case "Burns":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Burns")
+				return
+			}
+			for zb0005 > 0 {
+				zb0005--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Burns")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Buckets":
+					var zb0006 uint32
+					zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Burns", "Buckets")
+						return
+					}
+					if cap(z.Burns.Buckets) >= int(zb0006) {
+						z.Burns.Buckets = (z.Burns.Buckets)[:zb0006]
+					} else {
+						z.Burns.Buckets = make([]*RateBucket, zb0006)
+					}
+					for za0002 := range z.Burns.Buckets {
+						if msgp.IsNil(bts) {
+							bts, err = msgp.ReadNilBytes(bts)
+							if err != nil {
+								return
+							}
+							z.Burns.Buckets[za0002] = nil
+						} else {
+							if z.Burns.Buckets[za0002] == nil {
+								z.Burns.Buckets[za0002] = new(RateBucket)
+							}
+							var zb0007 uint32
+							zb0007, bts, err = msgp.ReadMapHeaderBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Burns", "Buckets", za0002)
+								return
+							}
+							for zb0007 > 0 {
+								zb0007--
+								field, bts, err = msgp.ReadMapKeyZC(bts)
+								if err != nil {
+									err = msgp.WrapError(err, "Burns", "Buckets", za0002)
+									return
+								}
+								switch msgp.UnsafeString(field) {
+								case "Round":
+									z.Burns.Buckets[za0002].Round, bts, err = msgp.ReadInt64Bytes(bts)
+									if err != nil {
+										err = msgp.WrapError(err, "Burns", "Buckets", za0002, "Round")
+										return
+									}
+								case "Amount":
+									bts, err = z.Burns.Buckets[za0002].Amount.UnmarshalMsg(bts)
+									if err != nil {
+										err = msgp.WrapError(err, "Burns", "Buckets", za0002, "Amount")
+										return
+									}
+								default:
+									bts, err = msgp.Skip(bts)
+									if err != nil {
+										err = msgp.WrapError(err, "Burns", "Buckets", za0002)
+										return
+									}
+								}
+							}
+						}
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Burns")
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ClientBridgeNode) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 6 + 1 + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Mints.Buckets {
+		if z.Mints.Buckets[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 6 + msgp.Int64Size + 7 + z.Mints.Buckets[za0001].Amount.Msgsize()
+		}
+	}
+	s += 6 + 1 + 8 + msgp.ArrayHeaderSize
+	for za0002 := range z.Burns.Buckets {
+		if z.Burns.Buckets[za0002] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 6 + msgp.Int64Size + 7 + z.Burns.Buckets[za0002].Amount.Msgsize()
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PendingMint) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "ChainID"
+	o = append(o, 0x85, 0xa7, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ChainID)
+	// string "Nonce"
+	o = append(o, 0xa5, 0x4e, 0x6f, 0x6e, 0x63, 0x65)
+	o = msgp.AppendInt64(o, z.Nonce)
+	// string "ClientID"
+	o = append(o, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// string "ClaimableRound"
+	o = append(o, 0xae, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.ClaimableRound)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PendingMint) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ChainID":
+			z.ChainID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChainID")
+				return
+			}
+		case "Nonce":
+			z.Nonce, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nonce")
+				return
+			}
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "ClaimableRound":
+			z.ClaimableRound, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimableRound")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PendingMint) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.ChainID) + 6 + msgp.Int64Size + 9 + msgp.StringPrefixSize + len(z.ClientID) + 7 + z.Amount.Msgsize() + 15 + msgp.Int64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *RateBucket) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Round"
+	o = append(o, 0x82, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RateBucket) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *RateBucket) Msgsize() (s int) {
+	s = 1 + 6 + msgp.Int64Size + 7 + z.Amount.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *RateWindow) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Buckets"
+	o = append(o, 0x81, 0xa7, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Buckets)))
+	for za0001 := range z.Buckets {
+		if z.Buckets[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "Round"
+			o = append(o, 0x82, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+			o = msgp.AppendInt64(o, z.Buckets[za0001].Round)
+			// string "Amount"
+			o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			o, err = z.Buckets[za0001].Amount.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets", za0001, "Amount")
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RateWindow) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Buckets":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Buckets")
+				return
+			}
+			if cap(z.Buckets) >= int(zb0002) {
+				z.Buckets = (z.Buckets)[:zb0002]
+			} else {
+				z.Buckets = make([]*RateBucket, zb0002)
+			}
+			for za0001 := range z.Buckets {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Buckets[za0001] = nil
+				} else {
+					if z.Buckets[za0001] == nil {
+						z.Buckets[za0001] = new(RateBucket)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Buckets", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Buckets", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "Round":
+							z.Buckets[za0001].Round, bts, err = msgp.ReadInt64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Buckets", za0001, "Round")
+								return
+							}
+						case "Amount":
+							bts, err = z.Buckets[za0001].Amount.UnmarshalMsg(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Buckets", za0001, "Amount")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Buckets", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *RateWindow) Msgsize() (s int) {
+	s = 1 + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Buckets {
+		if z.Buckets[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 6 + msgp.Int64Size + 7 + z.Buckets[za0001].Amount.Msgsize()
+		}
+	}
+	return
+}
@@ -0,0 +1,212 @@
+package zcnsc_test
+
+import (
+	"testing"
+
+	"0chain.net/core/config"
+	"0chain.net/smartcontract/dbs/event"
+	. "0chain.net/smartcontract/zcnsc"
+	"github.com/stretchr/testify/require"
+)
+
+func makeBridgeStateContext(t *testing.T) *mockStateContext {
+	ctx := MakeMockStateContext()
+
+	eventDb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		Debug:                 true,
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = eventDb.Drop()
+		require.NoError(t, err)
+
+		eventDb.Close()
+	})
+
+	ctx.SetEventDb(eventDb)
+	return ctx
+}
+
+func mint(t *testing.T, ctx *mockStateContext, nonce int64) error {
+	payload, err := CreateMintPayloadWithNonce(ctx, defaultClient, nonce)
+	require.NoError(t, err)
+
+	transaction, err := CreateTransaction(defaultClient, MintFunc, payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	_, err = CreateZCNSmartContract().Mint(transaction, payload.Encode(), ctx)
+	return err
+}
+
+func callBridge(ctx *mockStateContext, clientID, method string, input []byte) error {
+	transaction, err := CreateTransaction(clientID, method, input, ctx)
+	if err != nil {
+		return err
+	}
+
+	contract := CreateZCNSmartContract()
+	switch method {
+	case ClaimMintFunc:
+		_, err = contract.ClaimMint(transaction, input, ctx)
+	case CancelPendingMintFunc:
+		_, err = contract.CancelPendingMint(transaction, input, ctx)
+	case PauseBridgeFunc:
+		_, err = contract.PauseBridge(transaction, input, ctx)
+	case UnpauseBridgeFunc:
+		_, err = contract.UnpauseBridge(transaction, input, ctx)
+	}
+	return err
+}
+
+func Test_MintRateLimit(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	ctx.globalNode.RateLimitWindow = 100
+	ctx.globalNode.MaxWindowMint = 300
+
+	require.NoError(t, mint(t, ctx, 1))
+
+	err := mint(t, ctx, 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bridge rate limit")
+
+	// the bucket of the first mint, rounds 0 to 9, is in the window until
+	// round 109
+	ctx.block.Round = 100
+	require.Error(t, mint(t, ctx, 3))
+
+	ctx.block.Round = 109
+	require.NoError(t, mint(t, ctx, 4))
+	require.Len(t, ctx.GetTransfers(), 2)
+}
+
+func Test_ClientBurnRateLimit(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	contract := CreateZCNSmartContract()
+	tr := CreateDefaultTransactionToZcnsc()
+
+	ctx.globalNode.RateLimitWindow = 100
+	ctx.globalNode.MaxClientWindowBurn = tr.Value * 3 / 2
+
+	_, err := contract.Burn(tr, createBurnPayload().Encode(), ctx)
+	require.NoError(t, err)
+
+	_, err = contract.Burn(tr, createBurnPayload().Encode(), ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "client rate limit")
+
+	// the cap is per client
+	other := CreateDefaultTransactionToZcnsc()
+	other.ClientID = clients[1]
+	_, err = contract.Burn(other, createBurnPayload().Encode(), ctx)
+	require.NoError(t, err)
+}
+
+func Test_LargeMintClaimedAfterDelay(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	ctx.globalNode.LargeMintAmount = 100
+	ctx.globalNode.LargeMintDelay = 10
+
+	require.NoError(t, mint(t, ctx, 1))
+	require.Empty(t, ctx.GetTransfers())
+
+	pm, err := GetPendingMint("", 1, ctx)
+	require.NoError(t, err)
+	require.Equal(t, defaultClient, pm.ClientID)
+	require.Equal(t, int64(10), pm.ClaimableRound)
+
+	input := (&PendingMintPayload{Nonce: 1}).Encode()
+
+	err = callBridge(ctx, defaultClient, ClaimMintFunc, input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "claimable from round 10")
+
+	ctx.block.Round = 10
+	err = callBridge(ctx, clients[1], ClaimMintFunc, input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "pending mint of another client")
+
+	require.NoError(t, callBridge(ctx, defaultClient, ClaimMintFunc, input))
+	transfers := ctx.GetTransfers()
+	require.Len(t, transfers, 1)
+	require.Equal(t, defaultClient, transfers[0].ToClientID)
+	require.Equal(t, pm.Amount, transfers[0].Amount)
+
+	err = callBridge(ctx, defaultClient, ClaimMintFunc, input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no pending mint")
+}
+
+func Test_CancelPendingMint(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	ctx.globalNode.LargeMintAmount = 100
+	ctx.globalNode.LargeMintDelay = 10
+
+	require.NoError(t, mint(t, ctx, 1))
+
+	input := (&PendingMintPayload{Nonce: 1}).Encode()
+	require.Error(t, callBridge(ctx, defaultClient, CancelPendingMintFunc, input))
+	require.NoError(t, callBridge(ctx, ctx.globalNode.OwnerId, CancelPendingMintFunc, input))
+
+	ctx.block.Round = 10
+	require.Error(t, callBridge(ctx, defaultClient, ClaimMintFunc, input))
+	require.Empty(t, ctx.GetTransfers())
+
+	// the nonce of a cancelled mint stays used
+	require.Error(t, mint(t, ctx, 1))
+}
+
+func Test_OwnerPausesBridge(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	contract := CreateZCNSmartContract()
+
+	require.Error(t, callBridge(ctx, defaultClient, PauseBridgeFunc, nil))
+	require.NoError(t, callBridge(ctx, ctx.globalNode.OwnerId, PauseBridgeFunc, nil))
+
+	err := mint(t, ctx, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bridge is paused")
+
+	_, err = contract.Burn(CreateDefaultTransactionToZcnsc(), createBurnPayload().Encode(), ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bridge is paused")
+
+	require.Error(t, callBridge(ctx, defaultAuthorizer, UnpauseBridgeFunc, nil))
+	require.NoError(t, callBridge(ctx, ctx.globalNode.OwnerId, UnpauseBridgeFunc, nil))
+	require.NoError(t, mint(t, ctx, 1))
+}
+
+func Test_AuthorizersPauseBridge(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+
+	err := callBridge(ctx, authorizersID[0], PauseBridgeFunc, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not allowed")
+
+	// 2 of the 3 authorizers
+	ctx.globalNode.PauseQuorum = 0.6
+
+	require.NoError(t, callBridge(ctx, authorizersID[0], PauseBridgeFunc, nil))
+	bn, err := GetBridgeNode(ctx)
+	require.NoError(t, err)
+	require.False(t, bn.Paused)
+
+	err = callBridge(ctx, authorizersID[0], PauseBridgeFunc, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already voted")
+
+	require.Error(t, callBridge(ctx, defaultClient, PauseBridgeFunc, nil))
+
+	require.NoError(t, callBridge(ctx, authorizersID[1], PauseBridgeFunc, nil))
+	bn, err = GetBridgeNode(ctx)
+	require.NoError(t, err)
+	require.True(t, bn.Paused)
+	require.Error(t, mint(t, ctx, 1))
+
+	require.NoError(t, callBridge(ctx, ctx.globalNode.OwnerId, UnpauseBridgeFunc, nil))
+	bn, err = GetBridgeNode(ctx)
+	require.NoError(t, err)
+	require.False(t, bn.Paused)
+	require.Empty(t, bn.PauseVotes)
+}
@@ -42,6 +42,17 @@ func (zcn *ZCNSmartContract) Burn(
 		return "", common.NewError(code, msg)
 	}
 
+	bn, err := GetBridgeNode(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get bridge node error: %v, %s", err, info)
+		logging.Logger.Error(msg, zap.Error(err))
+		return "", common.NewError(code, msg)
+	}
+
+	if bn.Paused {
+		return "", common.NewError(code, "bridge is paused, "+info)
+	}
+
 	// check burn amount
 	if trans.Value < gn.MinBurnAmount {
 		msg := fmt.Sprintf(
@@ -70,8 +81,21 @@ func (zcn *ZCNSmartContract) Burn(
 		return
 	}
 
-	// get user node
-	un, err := GetUserNode(payload.EthereumAddress, ctx)
+	if _, err = getBridgeChain(ctx, gn, payload.ChainID); err != nil {
+		err = common.NewError(code, fmt.Sprintf("get chain error (%v), %s", err, info))
+		logging.Logger.Error(err.Error(), zap.Error(err))
+		return
+	}
+
+	err = bn.takeBurn(ctx, gn.ZCNSConfig, trans.ClientID, ctx.GetBlock().Round, trans.Value)
+	if err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
+		logging.Logger.Error(err.Error(), zap.Error(err))
+		return
+	}
+
+	// get user node, the burn nonces of each chain are separate
+	un, err := GetUserNode(chainAddressID(payload.ChainID, payload.EthereumAddress), ctx)
 	if err != nil {
 		err = common.NewError(code, fmt.Sprintf("get user node error (%v), %s", err, info))
 		logging.Logger.Error(err.Error(), zap.Error(err))
@@ -96,6 +120,7 @@ func (zcn *ZCNSmartContract) Burn(
 
 	response := &BurnPayloadResponse{
 		TxnID:           trans.Hash,
+		ChainID:         payload.ChainID,
 		Amount:          trans.Value,
 		Nonce:           un.BurnNonce, // it can be just the nonce of this transaction
 		EthereumAddress: payload.EthereumAddress,
@@ -106,7 +131,8 @@ func (zcn *ZCNSmartContract) Burn(
 		Amount: trans.Value,
 	})
 
-	ctx.EmitEvent(event.TypeStats, event.TagAddBurnTicket, payload.EthereumAddress, &event.BurnTicket{
+	ctx.EmitEvent(event.TypeStats, event.TagAddBurnTicket, chainAddressID(payload.ChainID, payload.EthereumAddress), &event.BurnTicket{
+		ChainID:         payload.ChainID,
 		EthereumAddress: payload.EthereumAddress,
 		Hash:            trans.Hash,
 		Amount:          trans.Value,
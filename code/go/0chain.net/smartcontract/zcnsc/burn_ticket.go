@@ -8,14 +8,16 @@ import (
 
 // swagger:model BurnTicket
 type BurnTicket struct {
+	ChainID         string        `json:"chain_id"`
 	EthereumAddress string        `json:"ethereum_address"`
 	Hash            string        `json:"hash"`
 	Amount          currency.Coin `json:"amount"`
 	Nonce           int64         `json:"nonce"`
 }
 
-func NewBurnTicket(ethereumAddress, hash string, amount currency.Coin, nonce int64) *BurnTicket {
+func NewBurnTicket(chainID, ethereumAddress, hash string, amount currency.Coin, nonce int64) *BurnTicket {
 	m := &BurnTicket{
+		ChainID:         chainID,
 		EthereumAddress: ethereumAddress,
 		Hash:            hash,
 		Amount:          amount,
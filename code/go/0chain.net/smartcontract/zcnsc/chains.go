@@ -0,0 +1,291 @@
+package zcnsc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/pkg/errors"
+)
+
+//msgp:ignore RemoveChainPayload
+//go:generate msgp -v -io=false -tests=false -unexported
+
+// ------------- BridgeChain ------------------------
+
+// BridgeChain is an external chain registered to the bridge. The mints and
+// the burns of a chain have their own nonces, signers, threshold and fee.
+// The default chain, with an empty id, is not registered: its mints are
+// signed by all the authorizers with the threshold and the fee of the
+// global config.
+type BridgeChain struct {
+	ID                 string        `json:"id"`
+	Authorizers        []string      `json:"authorizers"` // authorizers signing the mints of the chain
+	PercentAuthorizers float64       `json:"percent_authorizers"`
+	MaxFee             currency.Coin `json:"max_fee"`
+}
+
+func (bc *BridgeChain) Encode() []byte {
+	buff, _ := json.Marshal(bc)
+	return buff
+}
+
+func (bc *BridgeChain) Decode(input []byte) error {
+	return json.Unmarshal(input, bc)
+}
+
+// authorizerCount is the number of authorizers signing the mints of the chain
+func (bc *BridgeChain) authorizerCount(ctx cstate.StateContextI) (int, error) {
+	if bc.ID == "" {
+		return getAuthorizerCount(ctx)
+	}
+	return len(bc.Authorizers), nil
+}
+
+// isSigner reports if the authorizer signs the mints of the chain
+func (bc *BridgeChain) isSigner(id string) bool {
+	if bc.ID == "" {
+		return true
+	}
+	for _, a := range bc.Authorizers {
+		if a == id {
+			return true
+		}
+	}
+	return false
+}
+
+// threshold is the number of signatures required by a mint
+func (bc *BridgeChain) threshold(numAuth int) int {
+	return int(math.RoundToEven(bc.PercentAuthorizers * float64(numAuth)))
+}
+
+func (bc *BridgeChain) validate(ctx cstate.StateContextI) error {
+	switch {
+	case bc.ID == "":
+		return errors.New("chain id is required")
+	case len(bc.Authorizers) == 0:
+		return errors.New("chain authorizers are required")
+	case bc.PercentAuthorizers <= 0 || bc.PercentAuthorizers > 1:
+		return fmt.Errorf("percent of authorizers (%v) is not in (0, 1]", bc.PercentAuthorizers)
+	}
+
+	seen := make(map[string]struct{}, len(bc.Authorizers))
+	for _, id := range bc.Authorizers {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("duplicate authorizer %s", id)
+		}
+		seen[id] = struct{}{}
+
+		if _, err := GetAuthorizerNode(id, ctx); err != nil {
+			return fmt.Errorf("get authorizer %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// ------------- ChainsNode ------------------------
+
+// ChainsNode is the external chains registered to the bridge, sorted by id.
+type ChainsNode struct {
+	Chains []*BridgeChain `json:"chains"`
+}
+
+func (cn *ChainsNode) GetKey() datastore.Key {
+	return fmt.Sprintf("%s:%s", ADDRESS, ChainsNodeType)
+}
+
+func (cn *ChainsNode) Encode() []byte {
+	buff, _ := json.Marshal(cn)
+	return buff
+}
+
+func (cn *ChainsNode) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(cn.GetKey(), cn)
+	return
+}
+
+func (cn *ChainsNode) find(id string) (int, bool) {
+	i := sort.Search(len(cn.Chains), func(i int) bool {
+		return cn.Chains[i].ID >= id
+	})
+	return i, i < len(cn.Chains) && cn.Chains[i].ID == id
+}
+
+func (cn *ChainsNode) get(id string) *BridgeChain {
+	if i, ok := cn.find(id); ok {
+		return cn.Chains[i]
+	}
+	return nil
+}
+
+// put registers the chain or updates the registered one
+func (cn *ChainsNode) put(bc *BridgeChain) {
+	i, ok := cn.find(bc.ID)
+	if ok {
+		cn.Chains[i] = bc
+		return
+	}
+	cn.Chains = append(cn.Chains, nil)
+	copy(cn.Chains[i+1:], cn.Chains[i:])
+	cn.Chains[i] = bc
+}
+
+func (cn *ChainsNode) remove(id string) bool {
+	i, ok := cn.find(id)
+	if ok {
+		cn.Chains = append(cn.Chains[:i], cn.Chains[i+1:]...)
+	}
+	return ok
+}
+
+// GetChainsNode returns the registered chains, an empty node if none
+func GetChainsNode(ctx cstate.CommonStateContextI) (*ChainsNode, error) {
+	node := &ChainsNode{}
+	err := ctx.GetTrieNode(node.GetKey(), node)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return node, nil
+	default:
+		return nil, err
+	}
+}
+
+// getBridgeChain returns the chain of a mint or a burn, the empty id is the
+// default chain configured by the global node.
+func getBridgeChain(ctx cstate.CommonStateContextI, gn *GlobalNode, id string) (*BridgeChain, error) {
+	if id == "" {
+		return &BridgeChain{
+			PercentAuthorizers: gn.PercentAuthorizers,
+			MaxFee:             gn.MaxFee,
+		}, nil
+	}
+
+	cn, err := GetChainsNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bc := cn.get(id)
+	if bc == nil {
+		return nil, fmt.Errorf("chain %s is not registered", id)
+	}
+	return bc, nil
+}
+
+// chainAddressID is the id of the user node of an address on a chain, the
+// address is the id on the default chain.
+func chainAddressID(chainID, address string) string {
+	if chainID == "" {
+		return address
+	}
+	return chainID + ":" + address
+}
+
+// RegisterChain registers an external chain or updates the authorizers, the
+// threshold and the fee of a registered one.
+func (zcn *ZCNSmartContract) RegisterChain(
+	trans *transaction.Transaction,
+	inputData []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const (
+		code     = "failed to register chain"
+		funcName = "RegisterChain"
+	)
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get global node: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance(funcName,
+		gn.OwnerId, trans.ClientID); err != nil {
+		return "", errors.Wrap(err, code)
+	}
+
+	bc := &BridgeChain{}
+	if err := bc.Decode(inputData); err != nil {
+		return "", common.NewError(code, "payload decode error: "+err.Error())
+	}
+
+	if err := bc.validate(ctx); err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	cn, err := GetChainsNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get chains node: "+err.Error())
+	}
+
+	cn.put(bc)
+	if err := cn.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving chains node: "+err.Error())
+	}
+
+	return string(bc.Encode()), nil
+}
+
+// RemoveChainPayload is the input of remove-chain
+type RemoveChainPayload struct {
+	ID string `json:"id"`
+}
+
+func (rp *RemoveChainPayload) Encode() []byte {
+	buff, _ := json.Marshal(rp)
+	return buff
+}
+
+func (rp *RemoveChainPayload) Decode(input []byte) error {
+	return json.Unmarshal(input, rp)
+}
+
+// RemoveChain stops the mints and the burns of a registered chain, the
+// nonces of the chain stay used if the chain is registered again.
+func (zcn *ZCNSmartContract) RemoveChain(
+	trans *transaction.Transaction,
+	inputData []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const (
+		code     = "failed to remove chain"
+		funcName = "RemoveChain"
+	)
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get global node: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance(funcName,
+		gn.OwnerId, trans.ClientID); err != nil {
+		return "", errors.Wrap(err, code)
+	}
+
+	payload := &RemoveChainPayload{}
+	if err := payload.Decode(inputData); err != nil {
+		return "", common.NewError(code, "payload decode error: "+err.Error())
+	}
+
+	cn, err := GetChainsNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get chains node: "+err.Error())
+	}
+
+	if !cn.remove(payload.ID) {
+		return "", common.NewError(code, fmt.Sprintf("chain %s is not registered", payload.ID))
+	}
+
+	if err := cn.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving chains node: "+err.Error())
+	}
+
+	return string(payload.Encode()), nil
+}
@@ -0,0 +1,204 @@
+package zcnsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *BridgeChain) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "ID"
+	o = append(o, 0x84, 0xa2, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ID)
+	// string "Authorizers"
+	o = append(o, 0xab, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Authorizers)))
+	for za0001 := range z.Authorizers {
+		o = msgp.AppendString(o, z.Authorizers[za0001])
+	}
+	// string "PercentAuthorizers"
+	o = append(o, 0xb2, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x72, 0x73)
+	o = msgp.AppendFloat64(o, z.PercentAuthorizers)
+	// string "MaxFee"
+	o = append(o, 0xa6, 0x4d, 0x61, 0x78, 0x46, 0x65, 0x65)
+	o, err = z.MaxFee.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxFee")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *BridgeChain) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "Authorizers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Authorizers")
+				return
+			}
+			if cap(z.Authorizers) >= int(zb0002) {
+				z.Authorizers = (z.Authorizers)[:zb0002]
+			} else {
+				z.Authorizers = make([]string, zb0002)
+			}
+			for za0001 := range z.Authorizers {
+				z.Authorizers[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Authorizers", za0001)
+					return
+				}
+			}
+		case "PercentAuthorizers":
+			z.PercentAuthorizers, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PercentAuthorizers")
+				return
+			}
+		case "MaxFee":
+			bts, err = z.MaxFee.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxFee")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *BridgeChain) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 12 + msgp.ArrayHeaderSize
+	for za0001 := range z.Authorizers {
+		s += msgp.StringPrefixSize + len(z.Authorizers[za0001])
+	}
+	s += 19 + msgp.Float64Size + 7 + z.MaxFee.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ChainsNode) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Chains"
+	o = append(o, 0x81, 0xa6, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Chains)))
+	for za0001 := range z.Chains {
+		if z.Chains[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Chains[za0001].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Chains", za0001)
+				return
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ChainsNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Chains":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Chains")
+				return
+			}
+			if cap(z.Chains) >= int(zb0002) {
+				z.Chains = (z.Chains)[:zb0002]
+			} else {
+				z.Chains = make([]*BridgeChain, zb0002)
+			}
+			for za0001 := range z.Chains {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Chains[za0001] = nil
+				} else {
+					if z.Chains[za0001] == nil {
+						z.Chains[za0001] = new(BridgeChain)
+					}
+					bts, err = z.Chains[za0001].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Chains", za0001)
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ChainsNode) Msgsize() (s int) {
+	s = 1 + 7 + msgp.ArrayHeaderSize
+	for za0001 := range z.Chains {
+		if z.Chains[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Chains[za0001].Msgsize()
+		}
+	}
+	return
+}
@@ -0,0 +1,180 @@
+package zcnsc_test
+
+import (
+	"testing"
+
+	. "0chain.net/smartcontract/zcnsc"
+	"github.com/stretchr/testify/require"
+)
+
+const l2ChainID = "10"
+
+func registerChain(ctx *mockStateContext, clientID string, chain *BridgeChain) error {
+	transaction, err := CreateTransaction(clientID, RegisterChainFunc, chain.Encode(), ctx)
+	if err != nil {
+		return err
+	}
+	_, err = CreateZCNSmartContract().RegisterChain(transaction, chain.Encode(), ctx)
+	return err
+}
+
+func newL2Chain() *BridgeChain {
+	return &BridgeChain{
+		ID:                 l2ChainID,
+		Authorizers:        authorizersID[:2],
+		PercentAuthorizers: 1,
+		MaxFee:             10,
+	}
+}
+
+// signMint signs the payload with the given authorizers
+func signMint(t *testing.T, ctx *mockStateContext, payload *MintPayload, ids ...string) {
+	payload.Signatures = nil
+	for _, authorizer := range ctx.authorizers {
+		for _, id := range ids {
+			if authorizer.Node.ID != id {
+				continue
+			}
+			signature, err := authorizer.Sign(payload.GetStringToSign())
+			require.NoError(t, err)
+			payload.Signatures = append(payload.Signatures, &AuthorizerSignature{
+				ID:        id,
+				Signature: signature,
+			})
+		}
+	}
+}
+
+func mintPayload(t *testing.T, ctx *mockStateContext, payload *MintPayload) error {
+	transaction, err := CreateTransaction(defaultClient, MintFunc, payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	_, err = CreateZCNSmartContract().Mint(transaction, payload.Encode(), ctx)
+	return err
+}
+
+func Test_RegisterChain(t *testing.T) {
+	ctx := MakeMockStateContext()
+
+	err := registerChain(ctx, defaultClient, newL2Chain())
+	require.Error(t, err)
+
+	unknown := newL2Chain()
+	unknown.Authorizers = []string{"unknown"}
+	err = registerChain(ctx, ctx.globalNode.OwnerId, unknown)
+	require.Error(t, err)
+
+	noThreshold := newL2Chain()
+	noThreshold.PercentAuthorizers = 0
+	require.Error(t, registerChain(ctx, ctx.globalNode.OwnerId, noThreshold))
+
+	require.NoError(t, registerChain(ctx, ctx.globalNode.OwnerId, newL2Chain()))
+	require.NoError(t, registerChain(ctx, ctx.globalNode.OwnerId, &BridgeChain{
+		ID:                 "1",
+		Authorizers:        authorizersID,
+		PercentAuthorizers: 0.5,
+	}))
+
+	cn, err := GetChainsNode(ctx)
+	require.NoError(t, err)
+	require.Len(t, cn.Chains, 2)
+	require.Equal(t, "1", cn.Chains[0].ID)
+	require.Equal(t, l2ChainID, cn.Chains[1].ID)
+	require.Equal(t, authorizersID[:2], cn.Chains[1].Authorizers)
+
+	input := (&RemoveChainPayload{ID: "1"}).Encode()
+	transaction, err := CreateTransaction(ctx.globalNode.OwnerId, RemoveChainFunc, input, ctx)
+	require.NoError(t, err)
+	_, err = CreateZCNSmartContract().RemoveChain(transaction, input, ctx)
+	require.NoError(t, err)
+	_, err = CreateZCNSmartContract().RemoveChain(transaction, input, ctx)
+	require.Error(t, err)
+
+	cn, err = GetChainsNode(ctx)
+	require.NoError(t, err)
+	require.Len(t, cn.Chains, 1)
+}
+
+func Test_MintNoncesPerChain(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	require.NoError(t, registerChain(ctx, ctx.globalNode.OwnerId, newL2Chain()))
+
+	require.NoError(t, mint(t, ctx, 1))
+
+	payload := &MintPayload{
+		ChainID:           l2ChainID,
+		EthereumTxnID:     txHash,
+		Amount:            200,
+		Nonce:             1,
+		ReceivingClientID: defaultClient,
+	}
+
+	// the signatures of the default chain are not valid on another chain,
+	// the nonce is used once the payload is checked, use a new one after
+	payload.Nonce = 4
+	defaultPayload := *payload
+	defaultPayload.ChainID = ""
+	signMint(t, ctx, &defaultPayload, authorizersID[:2]...)
+	payload.Signatures = defaultPayload.Signatures
+	require.Error(t, mintPayload(t, ctx, payload))
+
+	payload.Nonce = 2
+	signMint(t, ctx, payload, authorizersID[0])
+	err := mintPayload(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "lesser than threshold")
+
+	payload.Nonce = 3
+	signMint(t, ctx, payload, authorizersID[1], authorizersID[2])
+	err = mintPayload(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not sign the mints of chain")
+
+	payload.Nonce = 1
+	signMint(t, ctx, payload, authorizersID[:2]...)
+	require.NoError(t, mintPayload(t, ctx, payload))
+
+	transfers := ctx.GetTransfers()
+	require.Len(t, transfers, 2)
+	// the fee of the chain is shared by the 2 signers
+	require.Equal(t, payload.Amount-5, transfers[1].Amount)
+
+	err = mintPayload(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "has already been minted")
+
+	payload.ChainID = "unknown"
+	signMint(t, ctx, payload, authorizersID[:2]...)
+	err = mintPayload(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not registered")
+}
+
+func Test_BurnNoncesPerChain(t *testing.T) {
+	ctx := makeBridgeStateContext(t)
+	require.NoError(t, registerChain(ctx, ctx.globalNode.OwnerId, newL2Chain()))
+	contract := CreateZCNSmartContract()
+
+	payload := createBurnPayload()
+	_, err := contract.Burn(CreateDefaultTransactionToZcnsc(), payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	payload.ChainID = l2ChainID
+	resp, err := contract.Burn(CreateDefaultTransactionToZcnsc(), payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	response := &BurnPayloadResponse{}
+	require.NoError(t, response.Decode([]byte(resp)))
+	require.Equal(t, l2ChainID, response.ChainID)
+	require.Equal(t, int64(1), response.Nonce)
+
+	burnTickets := burnTicketEvents[l2ChainID+":"+payload.EthereumAddress]
+	require.Len(t, burnTickets, 1)
+	require.Equal(t, l2ChainID, burnTickets[0].ChainID)
+	require.Equal(t, int64(1), burnTickets[0].Nonce)
+
+	payload.ChainID = "unknown"
+	_, err = contract.Burn(CreateDefaultTransactionToZcnsc(), payload.Encode(), ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not registered")
+}
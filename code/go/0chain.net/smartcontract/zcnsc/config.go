@@ -35,6 +35,17 @@ const (
 	Cost                = "cost"
 	MaxDelegates        = "max_delegates"
 	HealthCheckPeriod   = "health_check_period"
+	RateLimitWindow     = "rate_limit_window"
+	MaxWindowMint       = "max_window_mint"
+	MaxClientWindowMint = "max_client_window_mint"
+	MaxWindowBurn       = "max_window_burn"
+	MaxClientWindowBurn = "max_client_window_burn"
+	LargeMintAmount     = "large_mint_amount"
+	LargeMintDelay      = "large_mint_delay"
+	PauseQuorum         = "pause_quorum"
+	SlashFraction       = "slash_fraction"
+
+	ChallengeRewardFraction = "challenge_reward_fraction"
 )
 
 var CostFunctions = []string{
@@ -42,6 +53,13 @@ var CostFunctions = []string{
 	BurnFunc,
 	DeleteAuthorizerFunc,
 	AddAuthorizerFunc,
+	ClaimMintFunc,
+	CancelPendingMintFunc,
+	PauseBridgeFunc,
+	UnpauseBridgeFunc,
+	RegisterChainFunc,
+	RemoveChainFunc,
+	SlashAuthorizerFunc,
 }
 
 // InitConfig initializes global node config to MPT
@@ -74,9 +92,8 @@ func (zcn *ZCNSmartContract) UpdateGlobalConfig(t *transaction.Transaction, inpu
 		return "", errors.Wrap(err, Code)
 	}
 
-	if err := smartcontractinterface.AuthorizeWithOwner(FuncName, func() bool {
-		return gn.OwnerId == t.ClientID
-	}); err != nil {
+	if err := smartcontractinterface.AuthorizeWithOwnerOrGovernance(FuncName,
+		gn.OwnerId, t.ClientID); err != nil {
 		return "", errors.Wrap(err, Code)
 	}
 
@@ -116,6 +133,17 @@ func (gn *GlobalNode) ToStringMap() config.StringMap {
 		OwnerID:             fmt.Sprintf("%v", gn.OwnerId),
 		MaxDelegates:        fmt.Sprintf("%v", gn.MaxDelegates),
 		HealthCheckPeriod:   fmt.Sprintf("%v", gn.HealthCheckPeriod),
+		RateLimitWindow:     fmt.Sprintf("%v", gn.RateLimitWindow),
+		MaxWindowMint:       fmt.Sprintf("%v", gn.MaxWindowMint),
+		MaxClientWindowMint: fmt.Sprintf("%v", gn.MaxClientWindowMint),
+		MaxWindowBurn:       fmt.Sprintf("%v", gn.MaxWindowBurn),
+		MaxClientWindowBurn: fmt.Sprintf("%v", gn.MaxClientWindowBurn),
+		LargeMintAmount:     fmt.Sprintf("%v", gn.LargeMintAmount),
+		LargeMintDelay:      fmt.Sprintf("%v", gn.LargeMintDelay),
+		PauseQuorum:         fmt.Sprintf("%v", gn.PauseQuorum),
+		SlashFraction:       fmt.Sprintf("%v", gn.SlashFraction),
+
+		ChallengeRewardFraction: fmt.Sprintf("%v", gn.ChallengeRewardFraction),
 	}
 
 	for _, key := range CostFunctions {
@@ -164,6 +192,17 @@ func getConfig() (conf *ZCNSConfig, err error) {
 	conf.Cost = cfg.GetStringMapInt(postfix(Cost))
 	conf.MaxDelegates = cfg.GetInt(postfix(MaxDelegates))
 	conf.HealthCheckPeriod = cfg.GetDuration(postfix(HealthCheckPeriod))
+	conf.RateLimitWindow = cfg.GetInt64(postfix(RateLimitWindow))
+	for _, key := range []string{MaxWindowMint, MaxClientWindowMint, MaxWindowBurn, MaxClientWindowBurn, LargeMintAmount} {
+		*conf.coinSetting(key), err = currency.ParseZCN(cfg.GetFloat64(postfix(key)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	conf.LargeMintDelay = cfg.GetInt64(postfix(LargeMintDelay))
+	conf.PauseQuorum = cfg.GetFloat64(postfix(PauseQuorum))
+	conf.SlashFraction = cfg.GetFloat64(postfix(SlashFraction))
+	conf.ChallengeRewardFraction = cfg.GetFloat64(postfix(ChallengeRewardFraction))
 
 	return conf, nil
 }
@@ -32,7 +32,7 @@ func TestConfigMap_Get(t *testing.T) {
 
 	stringMap := cfg.ToStringMap()
 
-	require.Equal(t, 16, len(stringMap.Fields))
+	require.Equal(t, 33, len(stringMap.Fields))
 	require.Contains(t, stringMap.Fields, OwnerID)
 	require.Contains(t, stringMap.Fields, MinBurnAmount)
 	require.Contains(t, stringMap.Fields, MinMintAmount)
@@ -45,6 +45,16 @@ func TestConfigMap_Get(t *testing.T) {
 	require.Contains(t, stringMap.Fields, PercentAuthorizers)
 	require.Contains(t, stringMap.Fields, MaxDelegates)
 	require.Contains(t, stringMap.Fields, HealthCheckPeriod)
+	require.Contains(t, stringMap.Fields, RateLimitWindow)
+	require.Contains(t, stringMap.Fields, MaxWindowMint)
+	require.Contains(t, stringMap.Fields, MaxClientWindowMint)
+	require.Contains(t, stringMap.Fields, MaxWindowBurn)
+	require.Contains(t, stringMap.Fields, MaxClientWindowBurn)
+	require.Contains(t, stringMap.Fields, LargeMintAmount)
+	require.Contains(t, stringMap.Fields, LargeMintDelay)
+	require.Contains(t, stringMap.Fields, PauseQuorum)
+	require.Contains(t, stringMap.Fields, SlashFraction)
+	require.Contains(t, stringMap.Fields, ChallengeRewardFraction)
 
 	for _, costFunction := range CostFunctions {
 		require.Contains(t, stringMap.Fields, fmt.Sprintf("%s.%s", Cost, costFunction))
@@ -152,6 +152,7 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 				delete(ctx.authorizers, key)
 				return key
 			}
+			delete(ctx.data, key)
 			return ""
 		},
 		func(_ datastore.Key) error {
@@ -236,6 +237,7 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 
 	ctx.On("AddMint", mock.AnythingOfType("*state.Mint")).Return(func(m *state.Mint) error {
 		mints = append(mints, m)
+		transfers = append(transfers, state.NewTransfer(m.Minter, m.ToClientID, m.Amount))
 		return nil
 	})
 	ctx.On("GetMints").Return(func() []*state.Mint {
@@ -297,9 +299,16 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 				UserID:    bm.UserID,
 				MintNonce: bm.MintNonce,
 			}
-			if user.UserID != userId {
+			index := user.UserID
+			if bm.ChainID != "" {
+				index = bm.ChainID + ":" + index
+			}
+			if index != userId {
 				panic("user id must be equal to the id given as a param")
 			}
+			if bm.ChainID != "" {
+				return
+			}
 
 			err := ctx.eventDb.Get().Clauses(clause.OnConflict{
 				Columns:   []clause.Column{{Name: "user_id"}},
@@ -326,7 +335,11 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 			if !ok {
 				panic("failed to convert to get user")
 			}
-			if burnTicket.EthereumAddress != ethereumAdress {
+			index := burnTicket.EthereumAddress
+			if burnTicket.ChainID != "" {
+				index = burnTicket.ChainID + ":" + index
+			}
+			if index != ethereumAdress {
 				panic("given ethereum address as index should be equal to the one given as a payload")
 			}
 			burnTicketEvents[ethereumAdress] = append(burnTicketEvents[ethereumAdress], burnTicket)
@@ -39,6 +39,9 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		{URI: zcn + "/getAuthorizer", Handler: common.UserRateLimit(zrh.getAuthorizer)},
 		{URI: zcn + "/v1/mint_nonce", Handler: common.UserRateLimit(zrh.MintNonceHandler)},
 		{URI: zcn + "/v1/not_processed_burn_tickets", Handler: common.UserRateLimit(zrh.NotProcessedBurnTicketsHandler)},
+		{URI: zcn + "/getBridgeStatus", Handler: common.UserRateLimit(zrh.getBridgeStatus)},
+		{URI: zcn + "/getPendingMint", Handler: common.UserRateLimit(zrh.getPendingMint)},
+		{URI: zcn + "/getBridgeChains", Handler: common.UserRateLimit(zrh.getBridgeChains)},
 	}
 }
 
@@ -163,6 +166,10 @@ func (zrh *ZcnRestHandler) getAuthorizer(w http.ResponseWriter, r *http.Request)
 //	 type: string
 //	 description: "Client ID"
 //	 required: true
+//	+name: chain_id
+//	 in: query
+//	 type: string
+//	 description: "Source chain of the mints, the default chain if empty"
 //
 // responses:
 //
@@ -180,13 +187,13 @@ func (zrh *ZcnRestHandler) MintNonceHandler(w http.ResponseWriter, r *http.Reque
 
 	clientID := r.FormValue("client_id")
 
-	user, err := edb.GetUser(clientID)
+	mintNonce, err := edb.GetUserMintNonce(clientID, r.FormValue("chain_id"))
 	if err != nil {
 		common.Respond(w, r, nil, errors.Wrap(err, "GetUser DB error, ID = "+clientID))
 		return
 	}
 
-	common.Respond(w, r, user.MintNonce, nil)
+	common.Respond(w, r, mintNonce, nil)
 }
 
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0/v1/not_processed_burn_tickets zcn-sc GetNotProcessedBurnTickets
@@ -201,6 +208,10 @@ func (zrh *ZcnRestHandler) MintNonceHandler(w http.ResponseWriter, r *http.Reque
 //	 type: string
 //	 description: "Ethereum address"
 //	 required: true
+//	+name: chain_id
+//	 in: query
+//	 type: string
+//	 description: "Target chain of the burns, the default chain if empty"
 //	+name: nonce
 //	 in: query
 //	 type: string
@@ -238,7 +249,9 @@ func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter,
 		}
 	}
 
-	burnTickets, err := edb.GetBurnTickets(ethereumAddress)
+	chainID := r.FormValue("chain_id")
+
+	burnTickets, err := edb.GetBurnTickets(chainID, ethereumAddress)
 	if err != nil {
 		common.Respond(w, r, nil, errors.Wrap(err, "Failed to retrieve burn tickets"))
 		return
@@ -251,6 +264,7 @@ func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter,
 			response = append(
 				response,
 				NewBurnTicket(
+					burnTicket.ChainID,
 					burnTicket.EthereumAddress,
 					burnTicket.Hash,
 					burnTicket.Amount,
@@ -266,6 +280,78 @@ func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter,
 	common.Respond(w, r, response, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0/getBridgeStatus zcn-sc GetBridgeStatus
+// Get bridge status.
+// Retrieve the pause switch, the pause votes of the authorizers and the tokens minted and burned in the rate limit window.
+//
+// responses:
+//
+//	200: BridgeNode
+//	404:
+func (zrh *ZcnRestHandler) getBridgeStatus(w http.ResponseWriter, r *http.Request) {
+	bn, err := GetBridgeNode(zrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewError("get bridge status handler", err.Error()))
+		return
+	}
+
+	common.Respond(w, r, bn, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0/getPendingMint zcn-sc GetPendingMint
+// Get pending mint.
+// Retrieve the large mint of the given nonce waiting for its delay to be claimed.
+//
+// parameters:
+//	+name: nonce
+//	 in: query
+//	 type: string
+//	 description: "Mint nonce"
+//	 required: true
+//	+name: chain_id
+//	 in: query
+//	 type: string
+//	 description: "Source chain of the mint, the default chain if empty"
+//
+// responses:
+//
+//	200: PendingMint
+//  400:
+//	404:
+func (zrh *ZcnRestHandler) getPendingMint(w http.ResponseWriter, r *http.Request) {
+	nonce, err := strconv.ParseInt(r.URL.Query().Get("nonce"), 10, 64)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrBadRequest("bad nonce format"))
+		return
+	}
+
+	pm, err := GetPendingMint(r.URL.Query().Get("chain_id"), nonce, zrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrNoResource("no pending mint for nonce "+strconv.FormatInt(nonce, 10)))
+		return
+	}
+
+	common.Respond(w, r, pm, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0/getBridgeChains zcn-sc GetBridgeChains
+// Get bridge chains.
+// Retrieve the external chains registered to the bridge with their authorizers, threshold and fee.
+//
+// responses:
+//
+//	200: ChainsNode
+//	404:
+func (zrh *ZcnRestHandler) getBridgeChains(w http.ResponseWriter, r *http.Request) {
+	cn, err := GetChainsNode(zrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewError("get bridge chains handler", err.Error()))
+		return
+	}
+
+	common.Respond(w, r, cn, nil)
+}
+
 // swagger:model authorizerResponse
 type authorizerResponse struct {
 	AuthorizerID string `json:"id"`
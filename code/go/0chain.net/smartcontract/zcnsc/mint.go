@@ -2,7 +2,6 @@ package zcnsc
 
 import (
 	"fmt"
-	"math"
 	"math/rand"
 	"sort"
 
@@ -38,6 +37,16 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 		return "", common.NewError(code, msg)
 	}
 
+	bn, err := GetBridgeNode(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get bridge node error: %v, %s", err, info)
+		return "", common.NewError(code, msg)
+	}
+
+	if bn.Paused {
+		return "", common.NewError(code, "bridge is paused, "+info)
+	}
+
 	payload := &MintPayload{}
 	err = payload.Decode(inputData)
 	if err != nil {
@@ -46,13 +55,20 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 		return
 	}
 
+	chain, err := getBridgeChain(ctx, gn, payload.ChainID)
+	if err != nil {
+		msg := fmt.Sprintf("get chain error: %v, %s", err, info)
+		err = common.NewError(code, msg)
+		return
+	}
+
 	if len(payload.Signatures) == 0 {
 		msg := fmt.Sprintf("payload doesn't contain signatures: %v, %s", err, info)
 		err = common.NewError(code, msg)
 		return
 	}
 
-	numAuth, err := getAuthorizerCount(ctx)
+	numAuth, err := chain.authorizerCount(ctx)
 	if err != nil {
 		msg := fmt.Sprintf("error while retriving number of authorizers: %v, %s", err, info)
 		err = common.NewError(code, msg)
@@ -63,7 +79,7 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 		return "", common.NewError(code, "no authorizers found")
 	}
 
-	threshold := int(math.RoundToEven(gn.PercentAuthorizers * float64(numAuth)))
+	threshold := chain.threshold(numAuth)
 
 	// if number of slices exceeds limits the check only withing required range
 	if len(payload.Signatures) < threshold {
@@ -99,18 +115,18 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 	}
 
 	// check mint amount to be higher than min mint amount
-	if payload.Amount < gn.ZCNSConfig.MaxFee {
+	if payload.Amount < chain.MaxFee {
 		msg := fmt.Sprintf(
 			"amount requested (%v) is lower than zcn max fee (%v), %s",
 			payload.Amount,
-			gn.ZCNSConfig.MaxFee,
+			chain.MaxFee,
 			info,
 		)
 		err = common.NewError(code, msg)
 		return
 	}
 
-	if err = PartitionWZCNMintedNonceAdd(ctx, payload.Nonce); err != nil {
+	if err = PartitionWZCNMintedNonceAdd(ctx, payload.ChainID, payload.Nonce); err != nil {
 		if partitions.ErrItemExist(err) {
 			err = common.NewError(
 				code,
@@ -126,6 +142,14 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 
 	uniqueSignatures := payload.getUniqueSignatures()
 
+	for _, sig := range uniqueSignatures {
+		if !chain.isSigner(sig.ID) {
+			msg := fmt.Sprintf("authorizer %s does not sign the mints of chain %s, %s", sig.ID, chain.ID, info)
+			err = common.NewError(code, msg)
+			return
+		}
+	}
+
 	// verify signatures of authorizers
 	err = payload.verifySignatures(uniqueSignatures, ctx)
 	if err != nil {
@@ -142,12 +166,20 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 		return
 	}
 
+	round := ctx.GetBlock().Round
+	if err = bn.takeMint(ctx, gn.ZCNSConfig, trans.ClientID, round, payload.Amount); err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
+		return
+	}
+
+	delayed := gn.LargeMintAmount > 0 && payload.Amount >= gn.LargeMintAmount
+
 	var (
 		amount currency.Coin
 		share  currency.Coin
 	)
 
-	share, _, err = currency.DistributeCoin(gn.ZCNSConfig.MaxFee, int64(len(payload.Signatures)))
+	share, _, err = currency.DistributeCoin(chain.MaxFee, int64(len(payload.Signatures)))
 	if err != nil {
 		err = errors.Wrap(err, fmt.Sprintf("%s, DistributeCoin operation, %s", code, info))
 		return
@@ -166,7 +198,8 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 		signers = append(signers, sig.ID)
 	}
 
-	ctx.EmitEvent(event.TypeStats, event.TagAddBridgeMint, trans.ClientID, &event.BridgeMint{
+	ctx.EmitEvent(event.TypeStats, event.TagAddBridgeMint, chainAddressID(payload.ChainID, trans.ClientID), &event.BridgeMint{
+		ChainID:   payload.ChainID,
 		UserID:    trans.ClientID,
 		MintNonce: payload.Nonce,
 		Amount:    payload.Amount,
@@ -196,15 +229,26 @@ func (zcn *ZCNSmartContract) mint(trans *transaction.Transaction, inputData []by
 		return
 	}
 
-	// mint the tokens
-	err = ctx.AddTransfer(&state.Transfer{
-		ClientID:   ADDRESS,
-		ToClientID: trans.ClientID,
-		Amount:     payload.Amount,
-	})
-	if err != nil {
-		err = errors.Wrap(err, fmt.Sprintf("%s, Add mint operation, %s", code, info))
-		return
+	if delayed {
+		// the large mints are claimed once the delay is over
+		pm := &PendingMint{
+			ChainID:        payload.ChainID,
+			Nonce:          payload.Nonce,
+			ClientID:       trans.ClientID,
+			Amount:         payload.Amount,
+			ClaimableRound: round + gn.LargeMintDelay,
+		}
+		if err = pm.Save(ctx); err != nil {
+			err = errors.Wrap(err, fmt.Sprintf("%s, save pending mint, %s", code, info))
+			return
+		}
+	} else {
+		// mint the tokens
+		err = ctx.AddMint(state.NewMint(ADDRESS, trans.ClientID, payload.Amount))
+		if err != nil {
+			err = errors.Wrap(err, fmt.Sprintf("%s, Add mint operation, %s", code, info))
+			return
+		}
 	}
 
 	if err = sp.save("", sig.ID, ctx); err != nil {
@@ -40,6 +40,7 @@ func Test_MintPayload_Encode_Decode(t *testing.T) {
 	ctx := MakeMockStateContext()
 	expected, err := CreateMintPayload(ctx, defaultClient)
 	require.NoError(t, err)
+	expected.BurnBlockHeight = 10
 	actual := &MintPayload{}
 	err = actual.Decode(expected.Encode())
 	require.NoError(t, err)
@@ -47,6 +48,7 @@ func Test_MintPayload_Encode_Decode(t *testing.T) {
 	require.Equal(t, expected.Amount, actual.Amount)
 	require.Equal(t, expected.EthereumTxnID, actual.EthereumTxnID)
 	require.Equal(t, expected.ReceivingClientID, actual.ReceivingClientID)
+	require.Equal(t, expected.BurnBlockHeight, actual.BurnBlockHeight)
 	require.Equal(t, len(expected.Signatures), len(actual.Signatures))
 	for i := range actual.Signatures {
 		require.Equal(t, expected.Signatures[i].ID, actual.Signatures[i].ID)
@@ -387,7 +389,7 @@ func TestZCNSmartContractMintNonce(t *testing.T) {
 		{
 			name: "add new nonce - ok",
 			initFunc: func(state cstate.StateContextI) {
-				err := PartitionWZCNMintedNonceAdd(state, 1)
+				err := PartitionWZCNMintedNonceAdd(state, "", 1)
 				require.NoError(t, err)
 			},
 			nonce:        2,
@@ -396,7 +398,7 @@ func TestZCNSmartContractMintNonce(t *testing.T) {
 		{
 			name: "add duplicate nonce - fail",
 			initFunc: func(state cstate.StateContextI) {
-				err := PartitionWZCNMintedNonceAdd(state, 1)
+				err := PartitionWZCNMintedNonceAdd(state, "", 1)
 				require.NoError(t, err)
 			},
 			nonce:        1,
@@ -453,7 +455,7 @@ func TestZCNSmartContractMintNonce(t *testing.T) {
 
 			// check that the nonce is saved to the partition by calling the Add and got
 			// error of item already exists
-			err = PartitionWZCNMintedNonceAdd(ctx, tc.nonce)
+			err = PartitionWZCNMintedNonceAdd(ctx, "", tc.nonce)
 			require.True(t, partitions.ErrItemExist(err))
 		})
 	}
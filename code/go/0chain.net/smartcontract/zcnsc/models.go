@@ -28,6 +28,11 @@ const (
 	StakePoolNodeType     = "stakepool"
 	UserNodeType          = "usernode"
 	Porvider              = "provider"
+	BridgeNodeType        = "bridgenode"
+	ClientBridgeNodeType  = "clientbridgenode"
+	PendingMintNodeType   = "pendingmint"
+	ChainsNodeType        = "chainsnode"
+	SlashNodeType         = "authorizerslash"
 )
 
 type (
@@ -44,11 +49,15 @@ type AuthorizerSignature struct {
 // -----------  MintPayload -------------------
 
 type MintPayload struct {
+	ChainID           string                 `json:"chain_id"` // source chain, empty for the default one
 	EthereumTxnID     string                 `json:"ethereum_txn_id"`
 	Amount            currency.Coin          `json:"amount"`
 	Nonce             int64                  `json:"nonce"`
 	Signatures        []*AuthorizerSignature `json:"signatures"`
 	ReceivingClientID string                 `json:"receiving_client_id"`
+	// BurnBlockHeight is the source chain block of the burn, it's optional
+	// and binds the mint to the no burn attestations of the authorizers
+	BurnBlockHeight int64 `json:"burn_block_height,omitempty"`
 }
 
 func (mp *MintPayload) Encode() []byte {
@@ -58,10 +67,12 @@ func (mp *MintPayload) Encode() []byte {
 
 func (mp *MintPayload) Decode(input []byte) error {
 	const (
+		fieldChainId           = "chain_id"
 		fieldEthereumTxnId     = "ethereum_txn_id"
 		fieldNonce             = "nonce"
 		fieldAmount            = "amount"
 		fieldReceivingClientId = "receiving_client_id"
+		fieldBurnBlockHeight   = "burn_block_height"
 		fieldSignatures        = "signatures"
 	)
 
@@ -71,7 +82,17 @@ func (mp *MintPayload) Decode(input []byte) error {
 		return err
 	}
 
-	id, ok := objMap[fieldEthereumTxnId]
+	id, ok := objMap[fieldChainId]
+	if ok && id != nil {
+		var value string
+		err = json.Unmarshal(*id, &value)
+		if err != nil {
+			return err
+		}
+		mp.ChainID = value
+	}
+
+	id, ok = objMap[fieldEthereumTxnId]
 	if ok {
 		if id == nil {
 			return errors.New("ethereum_txn_id is missing in the payload")
@@ -123,6 +144,16 @@ func (mp *MintPayload) Decode(input []byte) error {
 		mp.ReceivingClientID = *value
 	}
 
+	id, ok = objMap[fieldBurnBlockHeight]
+	if ok && id != nil {
+		var value int64
+		err = json.Unmarshal(*id, &value)
+		if err != nil {
+			return err
+		}
+		mp.BurnBlockHeight = value
+	}
+
 	id, ok = objMap[fieldSignatures]
 	if ok {
 		if id == nil {
@@ -147,8 +178,18 @@ func (mp *MintPayload) Decode(input []byte) error {
 	return err
 }
 
+// GetStringToSign is the hash signed by the authorizers, the chain id is
+// signed for the chains other than the default one and the burn block height
+// when it's given.
 func (mp *MintPayload) GetStringToSign() string {
-	return encryption.Hash(fmt.Sprintf("%v:%v:%v:%v", mp.EthereumTxnID, mp.Amount, mp.Nonce, mp.ReceivingClientID))
+	toSign := fmt.Sprintf("%v:%v:%v:%v", mp.EthereumTxnID, mp.Amount, mp.Nonce, mp.ReceivingClientID)
+	if mp.ChainID != "" {
+		toSign = mp.ChainID + ":" + toSign
+	}
+	if mp.BurnBlockHeight > 0 {
+		toSign = fmt.Sprintf("%v:%v", toSign, mp.BurnBlockHeight)
+	}
+	return encryption.Hash(toSign)
 }
 
 func (mp *MintPayload) verifySignatures(signatures []*AuthorizerSignature, state cstate.StateContextI) error {
@@ -158,32 +199,54 @@ func (mp *MintPayload) verifySignatures(signatures []*AuthorizerSignature, state
 	}
 
 	for _, v := range signatures {
-		authorizerID := v.ID
-		if authorizerID == "" {
-			return errors.New("authorizer ID is empty in a signature")
+		if err := verifyAuthorizerSignature(v.ID, v.Signature, toSign, state); err != nil {
+			return err
 		}
+	}
 
-		node, err := GetAuthorizerNode(authorizerID, state)
-		if err != nil {
-			return errors.Wrapf(err, "failed to find authorizer by ID: %s", authorizerID)
-		}
+	return nil
+}
 
-		if node.PublicKey == "" {
-			return errors.New("authorizer public key is empty")
-		}
+// verifyAuthorizerSignature verifies the signature of the hash with the public
+// key of the authorizer
+func verifyAuthorizerSignature(authorizerID, signature, hash string, state cstate.StateContextI) error {
+	if authorizerID == "" {
+		return errors.New("authorizer ID is empty in a signature")
+	}
 
-		signatureScheme := state.GetSignatureScheme()
-		err = signatureScheme.SetPublicKey(node.PublicKey)
-		if err != nil {
-			return errors.Wrap(err, "failed to set public key")
-		}
+	node, err := GetAuthorizerNode(authorizerID, state)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find authorizer by ID: %s", authorizerID)
+	}
 
-		ok, err := signatureScheme.Verify(v.Signature, toSign)
-		if !ok || err != nil {
-			return errors.Wrap(err, "failed to verify signature")
-		}
+	if node.PublicKey == "" {
+		return errors.New("authorizer public key is empty")
+	}
+
+	signatureScheme := state.GetSignatureScheme()
+	err = signatureScheme.SetPublicKey(node.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to set public key")
 	}
 
+	ok, err := signatureScheme.Verify(signature, hash)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify signature")
+	}
+	if !ok {
+		return errors.Errorf("invalid signature of authorizer %s", authorizerID)
+	}
+
+	return nil
+}
+
+// signatureOf returns the signature of the authorizer, nil if it didn't sign
+func (mp *MintPayload) signatureOf(authorizerID string) *AuthorizerSignature {
+	for _, sig := range mp.Signatures {
+		if sig != nil && sig.ID == authorizerID {
+			return sig
+		}
+	}
 	return nil
 }
 
@@ -200,6 +263,7 @@ func (mp *MintPayload) getUniqueSignatures() []*AuthorizerSignature {
 
 type BurnPayloadResponse struct {
 	TxnID           string        `json:"0chain_txn_id"`
+	ChainID         string        `json:"chain_id,omitempty"`
 	Nonce           int64         `json:"nonce"`
 	Amount          currency.Coin `json:"amount"`
 	EthereumAddress string        `json:"ethereum_address"`
@@ -218,6 +282,7 @@ func (bp *BurnPayloadResponse) Decode(input []byte) error {
 // ------ BurnPayload ----------------
 
 type BurnPayload struct {
+	ChainID         string `json:"chain_id"` // target chain, empty for the default one
 	EthereumAddress string `json:"ethereum_address"`
 }
 
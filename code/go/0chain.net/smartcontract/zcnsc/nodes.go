@@ -42,6 +42,17 @@ type ZCNSConfig struct {
 	Cost                map[string]int `json:"cost"`
 	MaxDelegates        int            `json:"max_delegates"`       // MaxDelegates per stake pool
 	HealthCheckPeriod   time.Duration  `json:"health_check_period"` // MaxDelegates per stake pool
+	RateLimitWindow     int64          `json:"rate_limit_window"`   // rounds of the mint and burn caps
+	MaxWindowMint       currency.Coin  `json:"max_window_mint"`
+	MaxClientWindowMint currency.Coin  `json:"max_client_window_mint"`
+	MaxWindowBurn       currency.Coin  `json:"max_window_burn"`
+	MaxClientWindowBurn currency.Coin  `json:"max_client_window_burn"`
+	LargeMintAmount     currency.Coin  `json:"large_mint_amount"`
+	LargeMintDelay      int64          `json:"large_mint_delay"` // rounds before a large mint is claimable
+	PauseQuorum         float64        `json:"pause_quorum"`     // part of the authorizers pausing the bridge
+
+	SlashFraction           float64 `json:"slash_fraction"`            // part of the stake slashed for an invalid mint
+	ChallengeRewardFraction float64 `json:"challenge_reward_fraction"` // part of the slashed stake paid to the challenger
 }
 
 type GlobalNode struct {
@@ -137,6 +148,41 @@ func (gn *GlobalNode) UpdateConfig(cfg *config.StringMap) (err error) {
 				return fmt.Errorf("cannot convert key %s value %v to duration: %v", key, value, err)
 			}
 			gn.HealthCheckPeriod = v
+		case RateLimitWindow:
+			gn.RateLimitWindow, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
+			}
+		case MaxWindowMint, MaxClientWindowMint, MaxWindowBurn, MaxClientWindowBurn, LargeMintAmount:
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
+			}
+			coins, err := currency.ParseZCN(amount)
+			if err != nil {
+				return err
+			}
+			*gn.coinSetting(key) = coins
+		case LargeMintDelay:
+			gn.LargeMintDelay, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
+			}
+		case PauseQuorum:
+			gn.PauseQuorum, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to float64", key, value)
+			}
+		case SlashFraction:
+			gn.SlashFraction, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to float64", key, value)
+			}
+		case ChallengeRewardFraction:
+			gn.ChallengeRewardFraction, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to float64", key, value)
+			}
 		default:
 			return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
 		}
@@ -145,6 +191,22 @@ func (gn *GlobalNode) UpdateConfig(cfg *config.StringMap) (err error) {
 	return nil
 }
 
+// coinSetting is the field of the bridge limit setting in tokens
+func (c *ZCNSConfig) coinSetting(key string) *currency.Coin {
+	switch key {
+	case MaxWindowMint:
+		return &c.MaxWindowMint
+	case MaxClientWindowMint:
+		return &c.MaxClientWindowMint
+	case MaxWindowBurn:
+		return &c.MaxWindowBurn
+	case MaxClientWindowBurn:
+		return &c.MaxClientWindowBurn
+	default:
+		return &c.LargeMintAmount
+	}
+}
+
 func (gn *GlobalNode) setCostValue(key, value string) error {
 	if !strings.HasPrefix(key, fmt.Sprintf("%s.", Cost)) {
 		return fmt.Errorf("key %s not recognised as setting", key)
@@ -198,6 +260,18 @@ func (gn *GlobalNode) Validate() error {
 		return common.NewError(Code, fmt.Sprintf("max delegate count (%v) is less than 0", gn.MaxDelegates))
 	case gn.HealthCheckPeriod <= 0:
 		return common.NewError(Code, fmt.Sprintf("health check period (%v) is less than 0", gn.HealthCheckPeriod))
+	case gn.RateLimitWindow < 0:
+		return common.NewError(Code, fmt.Sprintf("rate limit window (%v) is less than 0", gn.RateLimitWindow))
+	case gn.RateLimitWindow == 0 && gn.MaxWindowMint+gn.MaxClientWindowMint+gn.MaxWindowBurn+gn.MaxClientWindowBurn > 0:
+		return common.NewError(Code, "rate limit window is required by the mint and burn caps")
+	case gn.LargeMintDelay < 0:
+		return common.NewError(Code, fmt.Sprintf("large mint delay (%v) is less than 0", gn.LargeMintDelay))
+	case gn.PauseQuorum < 0 || gn.PauseQuorum > 1:
+		return common.NewError(Code, fmt.Sprintf("pause quorum (%v) is not in [0, 1]", gn.PauseQuorum))
+	case gn.SlashFraction < 0 || gn.SlashFraction > 1:
+		return common.NewError(Code, fmt.Sprintf("slash fraction (%v) is not in [0, 1]", gn.SlashFraction))
+	case gn.ChallengeRewardFraction < 0 || gn.ChallengeRewardFraction > 1:
+		return common.NewError(Code, fmt.Sprintf("challenge reward fraction (%v) is not in [0, 1]", gn.ChallengeRewardFraction))
 		// case gn.MinLockAmount == 0:
 		// 	return common.NewError(Code, fmt.Sprintf("min lock amount (%v) is equal to 0", gn.MinLockAmount))
 	}
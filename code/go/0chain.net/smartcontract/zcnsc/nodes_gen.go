@@ -359,9 +359,9 @@ func (z UserNode) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *ZCNSConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 13
+	// map header, size 23
 	// string "MinMintAmount"
-	o = append(o, 0x8d, 0xad, 0x4d, 0x69, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = append(o, 0xde, 0x0, 0x17, 0xad, 0x4d, 0x69, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	o, err = z.MinMintAmount.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "MinMintAmount")
@@ -437,6 +437,56 @@ func (z *ZCNSConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "HealthCheckPeriod"
 	o = append(o, 0xb1, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
 	o = msgp.AppendDuration(o, z.HealthCheckPeriod)
+	// string "RateLimitWindow"
+	o = append(o, 0xaf, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77)
+	o = msgp.AppendInt64(o, z.RateLimitWindow)
+	// string "MaxWindowMint"
+	o = append(o, 0xad, 0x4d, 0x61, 0x78, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x74)
+	o, err = z.MaxWindowMint.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxWindowMint")
+		return
+	}
+	// string "MaxClientWindowMint"
+	o = append(o, 0xb3, 0x4d, 0x61, 0x78, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x74)
+	o, err = z.MaxClientWindowMint.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxClientWindowMint")
+		return
+	}
+	// string "MaxWindowBurn"
+	o = append(o, 0xad, 0x4d, 0x61, 0x78, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x75, 0x72, 0x6e)
+	o, err = z.MaxWindowBurn.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxWindowBurn")
+		return
+	}
+	// string "MaxClientWindowBurn"
+	o = append(o, 0xb3, 0x4d, 0x61, 0x78, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x75, 0x72, 0x6e)
+	o, err = z.MaxClientWindowBurn.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxClientWindowBurn")
+		return
+	}
+	// string "LargeMintAmount"
+	o = append(o, 0xaf, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.LargeMintAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "LargeMintAmount")
+		return
+	}
+	// string "LargeMintDelay"
+	o = append(o, 0xae, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x4d, 0x69, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79)
+	o = msgp.AppendInt64(o, z.LargeMintDelay)
+	// string "PauseQuorum"
+	o = append(o, 0xab, 0x50, 0x61, 0x75, 0x73, 0x65, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d)
+	o = msgp.AppendFloat64(o, z.PauseQuorum)
+	// string "SlashFraction"
+	o = append(o, 0xad, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendFloat64(o, z.SlashFraction)
+	// string "ChallengeRewardFraction"
+	o = append(o, 0xb7, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendFloat64(o, z.ChallengeRewardFraction)
 	return
 }
 
@@ -560,6 +610,66 @@ func (z *ZCNSConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "HealthCheckPeriod")
 				return
 			}
+		case "RateLimitWindow":
+			z.RateLimitWindow, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RateLimitWindow")
+				return
+			}
+		case "MaxWindowMint":
+			bts, err = z.MaxWindowMint.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxWindowMint")
+				return
+			}
+		case "MaxClientWindowMint":
+			bts, err = z.MaxClientWindowMint.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxClientWindowMint")
+				return
+			}
+		case "MaxWindowBurn":
+			bts, err = z.MaxWindowBurn.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxWindowBurn")
+				return
+			}
+		case "MaxClientWindowBurn":
+			bts, err = z.MaxClientWindowBurn.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxClientWindowBurn")
+				return
+			}
+		case "LargeMintAmount":
+			bts, err = z.LargeMintAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LargeMintAmount")
+				return
+			}
+		case "LargeMintDelay":
+			z.LargeMintDelay, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LargeMintDelay")
+				return
+			}
+		case "PauseQuorum":
+			z.PauseQuorum, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PauseQuorum")
+				return
+			}
+		case "SlashFraction":
+			z.SlashFraction, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SlashFraction")
+				return
+			}
+		case "ChallengeRewardFraction":
+			z.ChallengeRewardFraction, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengeRewardFraction")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -574,13 +684,13 @@ func (z *ZCNSConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ZCNSConfig) Msgsize() (s int) {
-	s = 1 + 14 + z.MinMintAmount.Msgsize() + 14 + z.MinBurnAmount.Msgsize() + 15 + z.MinStakeAmount.Msgsize() + 20 + z.MinStakePerDelegate.Msgsize() + 15 + z.MaxStakeAmount.Msgsize() + 14 + z.MinLockAmount.Msgsize() + 15 + msgp.Int64Size + 19 + msgp.Float64Size + 7 + z.MaxFee.Msgsize() + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
+	s = 3 + 14 + z.MinMintAmount.Msgsize() + 14 + z.MinBurnAmount.Msgsize() + 15 + z.MinStakeAmount.Msgsize() + 20 + z.MinStakePerDelegate.Msgsize() + 15 + z.MaxStakeAmount.Msgsize() + 14 + z.MinLockAmount.Msgsize() + 15 + msgp.Int64Size + 19 + msgp.Float64Size + 7 + z.MaxFee.Msgsize() + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
 			_ = za0002
 			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
 		}
 	}
-	s += 13 + msgp.IntSize + 18 + msgp.DurationSize
+	s += 13 + msgp.IntSize + 18 + msgp.DurationSize + 16 + msgp.Int64Size + 14 + z.MaxWindowMint.Msgsize() + 20 + z.MaxClientWindowMint.Msgsize() + 14 + z.MaxWindowBurn.Msgsize() + 20 + z.MaxClientWindowBurn.Msgsize() + 16 + z.LargeMintAmount.Msgsize() + 15 + msgp.Int64Size + 12 + msgp.Float64Size + 14 + msgp.Float64Size + 24 + msgp.Float64Size
 	return
 }
@@ -28,6 +28,13 @@ const (
 	DeleteFromDelegatePoolFunc    = "delete-from-delegate-pool"
 	UpdateAuthorizerStakePoolFunc = "update-authorizer-stake-pool"
 	CollectRewardsFunc            = "collect-rewards"
+	ClaimMintFunc                 = "claim-mint"
+	CancelPendingMintFunc         = "cancel-pending-mint"
+	PauseBridgeFunc               = "pause-bridge"
+	UnpauseBridgeFunc             = "unpause-bridge"
+	RegisterChainFunc             = "register-chain"
+	RemoveChainFunc               = "remove-chain"
+	SlashAuthorizerFunc           = "slash-authorizer"
 )
 
 // ZCNSmartContract ...
@@ -55,6 +62,13 @@ func (zcn *ZCNSmartContract) InitSC() {
 	// Bridge related
 	zcn.smartContractFunctions[MintFunc] = zcn.Mint
 	zcn.smartContractFunctions[BurnFunc] = zcn.Burn
+	zcn.smartContractFunctions[ClaimMintFunc] = zcn.ClaimMint
+	zcn.smartContractFunctions[CancelPendingMintFunc] = zcn.CancelPendingMint
+	zcn.smartContractFunctions[PauseBridgeFunc] = zcn.PauseBridge
+	zcn.smartContractFunctions[UnpauseBridgeFunc] = zcn.UnpauseBridge
+	zcn.smartContractFunctions[RegisterChainFunc] = zcn.RegisterChain
+	zcn.smartContractFunctions[RemoveChainFunc] = zcn.RemoveChain
+	zcn.smartContractFunctions[SlashAuthorizerFunc] = zcn.SlashAuthorizer
 	// Authorizer
 	zcn.smartContractFunctions[AddAuthorizerFunc] = zcn.AddAuthorizer
 	zcn.smartContractFunctions[DeleteAuthorizerFunc] = zcn.DeleteAuthorizer
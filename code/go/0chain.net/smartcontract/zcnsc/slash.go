@@ -0,0 +1,244 @@
+package zcnsc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/pkg/errors"
+)
+
+//msgp:ignore NoBurnAttestation SlashAuthorizerPayload
+//go:generate msgp -v -io=false -tests=false -unexported
+
+// ------------- NoBurnAttestation ------------------------
+
+// NoBurnAttestation is the statement of an authorizer that no burn was made
+// for the nonce on the chain up to the block height, signed when it refuses to
+// sign a mint. A burn landing after the block height doesn't contradict it.
+type NoBurnAttestation struct {
+	ChainID     string `json:"chain_id"`
+	Nonce       int64  `json:"nonce"`
+	BlockHeight int64  `json:"block_height"`
+	Signature   string `json:"signature"`
+}
+
+// GetStringToSign is the hash signed by the authorizer
+func (na *NoBurnAttestation) GetStringToSign() string {
+	return encryption.Hash(fmt.Sprintf("no burn:%v:%v:%v", na.ChainID, na.Nonce, na.BlockHeight))
+}
+
+// ------------- SlashAuthorizerPayload ------------------------
+
+// SlashAuthorizerPayload is the proof of an authorizer signing a mint which
+// doesn't match a burn: the mint and either a conflicting mint of the same
+// nonce or an attestation of the authorizer that the nonce wasn't burned up to
+// the block of the burn of the mint, both signed by the authorizer.
+type SlashAuthorizerPayload struct {
+	AuthorizerID    string             `json:"authorizer_id"`
+	Mint            *MintPayload       `json:"mint"`
+	ConflictingMint *MintPayload       `json:"conflicting_mint,omitempty"`
+	NoBurn          *NoBurnAttestation `json:"no_burn,omitempty"`
+}
+
+func (sp *SlashAuthorizerPayload) Encode() []byte {
+	buff, _ := json.Marshal(sp)
+	return buff
+}
+
+func (sp *SlashAuthorizerPayload) Decode(input []byte) error {
+	return json.Unmarshal(input, sp)
+}
+
+// verifyMint verifies the signature of the authorizer on the mint
+func (sp *SlashAuthorizerPayload) verifyMint(mint *MintPayload, ctx cstate.StateContextI) error {
+	sig := mint.signatureOf(sp.AuthorizerID)
+	if sig == nil {
+		return fmt.Errorf("mint of nonce %d is not signed by the authorizer", mint.Nonce)
+	}
+	return verifyAuthorizerSignature(sp.AuthorizerID, sig.Signature, mint.GetStringToSign(), ctx)
+}
+
+// verify verifies the authorizer signed the mint and a statement conflicting
+// with it
+func (sp *SlashAuthorizerPayload) verify(ctx cstate.StateContextI) error {
+	switch {
+	case sp.AuthorizerID == "":
+		return errors.New("authorizer id is required")
+	case sp.Mint == nil:
+		return errors.New("signed mint is required")
+	case (sp.ConflictingMint == nil) == (sp.NoBurn == nil):
+		return errors.New("either a conflicting mint or a no burn attestation is required")
+	}
+
+	if err := sp.verifyMint(sp.Mint, ctx); err != nil {
+		return err
+	}
+
+	if cm := sp.ConflictingMint; cm != nil {
+		if cm.ChainID != sp.Mint.ChainID || cm.Nonce != sp.Mint.Nonce {
+			return errors.New("mints are not of the same chain and nonce")
+		}
+		if cm.EthereumTxnID == sp.Mint.EthereumTxnID &&
+			cm.Amount == sp.Mint.Amount &&
+			cm.ReceivingClientID == sp.Mint.ReceivingClientID {
+			return errors.New("mints do not conflict")
+		}
+		return sp.verifyMint(cm, ctx)
+	}
+
+	if sp.NoBurn.ChainID != sp.Mint.ChainID || sp.NoBurn.Nonce != sp.Mint.Nonce {
+		return errors.New("attestation is not of the chain and the nonce of the mint")
+	}
+	if sp.Mint.BurnBlockHeight <= 0 {
+		return errors.New("mint has no burn block height")
+	}
+	if sp.Mint.BurnBlockHeight > sp.NoBurn.BlockHeight {
+		return fmt.Errorf("burn of the mint at block %d is after the attestation at block %d",
+			sp.Mint.BurnBlockHeight, sp.NoBurn.BlockHeight)
+	}
+	return verifyAuthorizerSignature(sp.AuthorizerID, sp.NoBurn.Signature,
+		sp.NoBurn.GetStringToSign(), ctx)
+}
+
+// ------------- AuthorizerSlash ------------------------
+
+// AuthorizerSlash is the slash of an authorizer for the mint of a nonce, an
+// authorizer is slashed once for a nonce.
+type AuthorizerSlash struct {
+	AuthorizerID string        `json:"authorizer_id"`
+	ChainID      string        `json:"chain_id"`
+	Nonce        int64         `json:"nonce"`
+	ChallengerID string        `json:"challenger_id"`
+	Slashed      currency.Coin `json:"slashed"`
+	Reward       currency.Coin `json:"reward"`
+	Burned       currency.Coin `json:"burned"`
+	Round        int64         `json:"round"`
+}
+
+func authorizerSlashKey(authorizerID, chainID string, nonce int64) datastore.Key {
+	return fmt.Sprintf("%s:%s:%s:%s:%d", ADDRESS, SlashNodeType, authorizerID, chainID, nonce)
+}
+
+func (as *AuthorizerSlash) GetKey() datastore.Key {
+	return authorizerSlashKey(as.AuthorizerID, as.ChainID, as.Nonce)
+}
+
+func (as *AuthorizerSlash) Encode() []byte {
+	buff, _ := json.Marshal(as)
+	return buff
+}
+
+func (as *AuthorizerSlash) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(as.GetKey(), as)
+	return
+}
+
+// GetAuthorizerSlash returns the slash of the authorizer for the nonce
+func GetAuthorizerSlash(authorizerID, chainID string, nonce int64, ctx cstate.CommonStateContextI) (*AuthorizerSlash, error) {
+	as := &AuthorizerSlash{}
+	if err := ctx.GetTrieNode(authorizerSlashKey(authorizerID, chainID, nonce), as); err != nil {
+		return nil, err
+	}
+	return as, nil
+}
+
+// SlashAuthorizer slashes the stake pool of an authorizer given the proof it
+// signed a mint which doesn't match a burn. The challenger is paid the
+// challenge reward fraction of the slashed tokens and the rest is burned, it
+// doesn't stay in the mint liquidity of the contract.
+func (zcn *ZCNSmartContract) SlashAuthorizer(
+	trans *transaction.Transaction,
+	inputData []byte,
+	ctx cstate.StateContextI,
+) (string, error) {
+	const code = "failed to slash authorizer"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, "get global node: "+err.Error())
+	}
+
+	if gn.SlashFraction <= 0 {
+		return "", common.NewError(code, "authorizer slashing is disabled")
+	}
+
+	payload := &SlashAuthorizerPayload{}
+	if err := payload.Decode(inputData); err != nil {
+		return "", common.NewError(code, "payload decode error: "+err.Error())
+	}
+
+	if err := payload.verify(ctx); err != nil {
+		return "", common.NewError(code, "invalid proof: "+err.Error())
+	}
+
+	chainID, nonce := payload.Mint.ChainID, payload.Mint.Nonce
+	switch _, err := GetAuthorizerSlash(payload.AuthorizerID, chainID, nonce, ctx); err {
+	case nil:
+		return "", common.NewError(code, fmt.Sprintf(
+			"authorizer %s is already slashed for the nonce %d", payload.AuthorizerID, nonce))
+	case util.ErrValueNotPresent:
+	default:
+		return "", common.NewError(code, "get authorizer slash: "+err.Error())
+	}
+
+	sp, err := zcn.getStakePool(payload.AuthorizerID, ctx)
+	if err != nil {
+		return "", common.NewError(code, "get stake pool: "+err.Error())
+	}
+
+	slashed, err := sp.slash(payload.AuthorizerID, gn.SlashFraction, ctx)
+	if err != nil {
+		return "", common.NewError(code, "slash stake pool: "+err.Error())
+	}
+	if slashed == 0 {
+		return "", common.NewError(code, "no stake to slash")
+	}
+
+	reward, err := currency.MultFloat64(slashed, gn.ChallengeRewardFraction)
+	if err != nil {
+		return "", common.NewError(code, "challenge reward: "+err.Error())
+	}
+	if reward > 0 {
+		if err := ctx.AddTransfer(state.NewTransfer(ADDRESS, trans.ClientID, reward)); err != nil {
+			return "", common.NewError(code, "transfer challenge reward: "+err.Error())
+		}
+	}
+
+	burned, err := currency.MinusCoin(slashed, reward)
+	if err != nil {
+		return "", common.NewError(code, "slashed tokens to burn: "+err.Error())
+	}
+	if burned > 0 {
+		if err := ctx.AddTransfer(state.NewBurnTransfer(ADDRESS, burned)); err != nil {
+			return "", common.NewError(code, "burn slashed tokens: "+err.Error())
+		}
+	}
+
+	if err := sp.save(ADDRESS, payload.AuthorizerID, ctx); err != nil {
+		return "", common.NewError(code, "saving stake pool: "+err.Error())
+	}
+
+	as := &AuthorizerSlash{
+		AuthorizerID: payload.AuthorizerID,
+		ChainID:      chainID,
+		Nonce:        nonce,
+		ChallengerID: trans.ClientID,
+		Slashed:      slashed,
+		Reward:       reward,
+		Burned:       burned,
+		Round:        ctx.GetBlock().Round,
+	}
+	if err := as.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving authorizer slash: "+err.Error())
+	}
+
+	return string(as.Encode()), nil
+}
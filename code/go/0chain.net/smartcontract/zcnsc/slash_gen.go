@@ -0,0 +1,134 @@
+package zcnsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *AuthorizerSlash) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 8
+	// string "AuthorizerID"
+	o = append(o, 0x88, 0xac, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AuthorizerID)
+	// string "ChainID"
+	o = append(o, 0xa7, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ChainID)
+	// string "Nonce"
+	o = append(o, 0xa5, 0x4e, 0x6f, 0x6e, 0x63, 0x65)
+	o = msgp.AppendInt64(o, z.Nonce)
+	// string "ChallengerID"
+	o = append(o, 0xac, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ChallengerID)
+	// string "Slashed"
+	o = append(o, 0xa7, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64)
+	o, err = z.Slashed.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Slashed")
+		return
+	}
+	// string "Reward"
+	o = append(o, 0xa6, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o, err = z.Reward.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Reward")
+		return
+	}
+	// string "Burned"
+	o = append(o, 0xa6, 0x42, 0x75, 0x72, 0x6e, 0x65, 0x64)
+	o, err = z.Burned.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Burned")
+		return
+	}
+	// string "Round"
+	o = append(o, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AuthorizerSlash) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AuthorizerID":
+			z.AuthorizerID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AuthorizerID")
+				return
+			}
+		case "ChainID":
+			z.ChainID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChainID")
+				return
+			}
+		case "Nonce":
+			z.Nonce, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nonce")
+				return
+			}
+		case "ChallengerID":
+			z.ChallengerID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengerID")
+				return
+			}
+		case "Slashed":
+			bts, err = z.Slashed.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Slashed")
+				return
+			}
+		case "Reward":
+			bts, err = z.Reward.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reward")
+				return
+			}
+		case "Burned":
+			bts, err = z.Burned.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Burned")
+				return
+			}
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AuthorizerSlash) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AuthorizerID) + 8 + msgp.StringPrefixSize + len(z.ChainID) + 6 + msgp.Int64Size + 13 + msgp.StringPrefixSize + len(z.ChallengerID) + 8 + z.Slashed.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Burned.Msgsize() + 6 + msgp.Int64Size
+	return
+}
@@ -0,0 +1,236 @@
+package zcnsc_test
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/state"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	. "0chain.net/smartcontract/zcnsc"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+const challenger = "challenger"
+
+func makeSlashStateContext() *mockStateContext {
+	ctx := MakeMockStateContext()
+	ctx.globalNode.SlashFraction = 0.5
+	ctx.globalNode.ChallengeRewardFraction = 0.5
+
+	for _, id := range authorizersID {
+		sp := ctx.stakingPools[stakepool.StakePoolKey(spenum.Authorizer, id)]
+		sp.Pools["delegate_0"] = &stakepool.DelegatePool{Balance: 100, DelegateID: "delegate_0"}
+		sp.Pools["delegate_1"] = &stakepool.DelegatePool{Balance: 200, DelegateID: "delegate_1"}
+	}
+	return ctx
+}
+
+func signedMint(t *testing.T, ctx *mockStateContext, amount currency.Coin, ids ...string) *MintPayload {
+	payload := &MintPayload{
+		EthereumTxnID:     txHash,
+		Amount:            amount,
+		Nonce:             1,
+		ReceivingClientID: defaultClient,
+	}
+	signMint(t, ctx, payload, ids...)
+	return payload
+}
+
+func signNoBurn(t *testing.T, ctx *mockStateContext, na *NoBurnAttestation, id string) {
+	for _, authorizer := range ctx.authorizers {
+		if authorizer.Node.ID != id {
+			continue
+		}
+		signature, err := authorizer.Sign(na.GetStringToSign())
+		require.NoError(t, err)
+		na.Signature = signature
+	}
+}
+
+func slashAuthorizer(t *testing.T, ctx *mockStateContext, payload *SlashAuthorizerPayload) error {
+	input := payload.Encode()
+	transaction, err := CreateTransaction(challenger, SlashAuthorizerFunc, input, ctx)
+	require.NoError(t, err)
+
+	_, err = CreateZCNSmartContract().SlashAuthorizer(transaction, input, ctx)
+	return err
+}
+
+func Test_SlashAuthorizerConflictingMints(t *testing.T) {
+	ctx := makeSlashStateContext()
+	authorizer := authorizersID[0]
+
+	payload := &SlashAuthorizerPayload{
+		AuthorizerID:    authorizer,
+		Mint:            signedMint(t, ctx, 200, authorizersID...),
+		ConflictingMint: signedMint(t, ctx, 300, authorizer),
+	}
+	require.NoError(t, slashAuthorizer(t, ctx, payload))
+
+	sp := ctx.stakingPools[stakepool.StakePoolKey(spenum.Authorizer, authorizer)]
+	require.Equal(t, currency.Coin(50), sp.Pools["delegate_0"].Balance)
+	require.Equal(t, currency.Coin(100), sp.Pools["delegate_1"].Balance)
+
+	// the challenger is paid half of the slashed tokens and the rest is burned
+	transfers := ctx.GetTransfers()
+	require.Len(t, transfers, 2)
+	require.Equal(t, challenger, transfers[0].ToClientID)
+	require.Equal(t, currency.Coin(75), transfers[0].Amount)
+	require.Equal(t, state.BurnAddress, transfers[1].ToClientID)
+	require.Equal(t, currency.Coin(75), transfers[1].Amount)
+
+	slash, err := GetAuthorizerSlash(authorizer, "", 1, ctx)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(150), slash.Slashed)
+	require.Equal(t, currency.Coin(75), slash.Burned)
+	require.Equal(t, challenger, slash.ChallengerID)
+
+	err = slashAuthorizer(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already slashed")
+
+	// the other signers of the mint did not sign the conflicting one
+	payload.AuthorizerID = authorizersID[1]
+	err = slashAuthorizer(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not signed by the authorizer")
+}
+
+func Test_SlashAuthorizerInvalidProofs(t *testing.T) {
+	ctx := makeSlashStateContext()
+	authorizer := authorizersID[0]
+	mint := signedMint(t, ctx, 200, authorizer)
+
+	tests := []struct {
+		name    string
+		payload *SlashAuthorizerPayload
+		err     string
+	}{
+		{
+			name:    "no proof",
+			payload: &SlashAuthorizerPayload{AuthorizerID: authorizer, Mint: mint},
+			err:     "either a conflicting mint or a no burn attestation is required",
+		},
+		{
+			name: "same mint",
+			payload: &SlashAuthorizerPayload{
+				AuthorizerID:    authorizer,
+				Mint:            mint,
+				ConflictingMint: signedMint(t, ctx, 200, authorizer),
+			},
+			err: "mints do not conflict",
+		},
+		{
+			name: "other nonce",
+			payload: &SlashAuthorizerPayload{
+				AuthorizerID: authorizer,
+				Mint:         mint,
+				ConflictingMint: func() *MintPayload {
+					m := signedMint(t, ctx, 300)
+					m.Nonce = 2
+					signMint(t, ctx, m, authorizer)
+					return m
+				}(),
+			},
+			err: "mints are not of the same chain and nonce",
+		},
+		{
+			name: "forged signature",
+			payload: &SlashAuthorizerPayload{
+				AuthorizerID: authorizer,
+				Mint:         mint,
+				ConflictingMint: func() *MintPayload {
+					m := signedMint(t, ctx, 300, authorizersID[1])
+					m.Signatures[0].ID = authorizer
+					return m
+				}(),
+			},
+			err: "invalid signature of authorizer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := slashAuthorizer(t, ctx, tt.payload)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	require.Empty(t, ctx.GetTransfers())
+}
+
+func signedBurnMint(t *testing.T, ctx *mockStateContext, burnBlockHeight int64, id string) *MintPayload {
+	mint := signedMint(t, ctx, 200)
+	mint.BurnBlockHeight = burnBlockHeight
+	signMint(t, ctx, mint, id)
+	return mint
+}
+
+func Test_SlashAuthorizerNoBurn(t *testing.T) {
+	ctx := makeSlashStateContext()
+	authorizer := authorizersID[1]
+
+	noBurn := &NoBurnAttestation{Nonce: 1, BlockHeight: 100}
+	signNoBurn(t, ctx, noBurn, authorizer)
+
+	// an honest authorizer attests no burn and signs the mint of the burn
+	// landing afterwards
+	for _, tt := range []struct {
+		name string
+		mint *MintPayload
+		err  string
+	}{
+		{
+			name: "burn after the attestation",
+			mint: signedBurnMint(t, ctx, 101, authorizer),
+			err:  "burn of the mint at block 101 is after the attestation at block 100",
+		},
+		{
+			name: "no burn block height",
+			mint: signedMint(t, ctx, 200, authorizer),
+			err:  "mint has no burn block height",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := slashAuthorizer(t, ctx, &SlashAuthorizerPayload{
+				AuthorizerID: authorizer,
+				Mint:         tt.mint,
+				NoBurn:       noBurn,
+			})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	payload := &SlashAuthorizerPayload{
+		AuthorizerID: authorizer,
+		Mint:         signedBurnMint(t, ctx, 100, authorizer),
+		NoBurn:       noBurn,
+	}
+
+	ctx.globalNode.SlashFraction = 0
+	err := slashAuthorizer(t, ctx, payload)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "slashing is disabled")
+
+	ctx.globalNode.SlashFraction = 0.1
+	require.NoError(t, slashAuthorizer(t, ctx, payload))
+
+	sp := ctx.stakingPools[stakepool.StakePoolKey(spenum.Authorizer, authorizer)]
+	require.Equal(t, currency.Coin(90), sp.Pools["delegate_0"].Balance)
+	require.Equal(t, currency.Coin(180), sp.Pools["delegate_1"].Balance)
+
+	// the attestation of another nonce doesn't prove anything
+	other := &NoBurnAttestation{Nonce: 2, BlockHeight: 100}
+	signNoBurn(t, ctx, other, authorizersID[2])
+	mint := signedBurnMint(t, ctx, 50, authorizersID[2])
+	err = slashAuthorizer(t, ctx, &SlashAuthorizerPayload{
+		AuthorizerID: authorizersID[2],
+		Mint:         mint,
+		NoBurn:       other,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "attestation is not of the chain and the nonce of the mint")
+}
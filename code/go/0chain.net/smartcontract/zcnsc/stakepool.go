@@ -9,8 +9,10 @@ import (
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
 	"github.com/0chain/common/core/util"
 )
 
@@ -66,6 +68,55 @@ func (sp *StakePool) empty(sscID, clientID string, balances cstate.StateContextI
 	return true, nil
 }
 
+// slash takes the fraction of the stake of the delegate pools of the
+// authorizer, it returns the tokens taken from the delegate pools
+func (sp *StakePool) slash(
+	authorizerID string,
+	fraction float64,
+	balances cstate.StateContextI,
+) (slashed currency.Coin, err error) {
+	if fraction <= 0 {
+		return // nothing to slash
+	}
+	if fraction > 1 {
+		return 0, fmt.Errorf("slash fraction %v is not in [0, 1]", fraction)
+	}
+
+	edbSlash := stakepool.NewStakePoolReward(authorizerID, spenum.Authorizer,
+		spenum.AuthorizerSlashPenalty, sp.Settings.DelegateWallet)
+	for _, dp := range sp.GetOrderedPools() {
+		dpSlash, err := currency.MultFloat64(dp.Balance, fraction)
+		if err != nil {
+			return 0, err
+		}
+
+		if dpSlash == 0 {
+			continue
+		}
+
+		if dpSlash > dp.Balance {
+			dpSlash = dp.Balance // can not exceed the dp balance
+		}
+
+		if dp.Balance, err = currency.MinusCoin(dp.Balance, dpSlash); err != nil {
+			return 0, err
+		}
+		if slashed, err = currency.AddCoin(slashed, dpSlash); err != nil {
+			return 0, err
+		}
+		edbSlash.DelegatePenalties[dp.DelegateID] = dpSlash
+	}
+
+	if slashed == 0 {
+		return
+	}
+
+	if err := edbSlash.Emit(event.TagStakePoolPenalty, balances); err != nil {
+		return 0, err
+	}
+	return
+}
+
 //
 // smart contract methods
 //